@@ -62,6 +62,22 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.DNSServers = restored.Spec.DNSServers
 	}
 
+	if restored.Spec.DedicatedHost != nil {
+		dst.Spec.DedicatedHost = restored.Spec.DedicatedHost
+	}
+
+	if restored.Spec.Diagnostics != nil {
+		dst.Spec.Diagnostics = restored.Spec.Diagnostics
+	}
+
+	if restored.Status.BootDiagnostics != nil {
+		dst.Status.BootDiagnostics = restored.Status.BootDiagnostics
+	}
+
+	if restored.Spec.SpotVMOptions != nil && dst.Spec.SpotVMOptions != nil {
+		dst.Spec.SpotVMOptions.EvictionPolicy = restored.Spec.SpotVMOptions.EvictionPolicy
+	}
+
 	dst.Spec.SubnetName = restored.Spec.SubnetName
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
@@ -180,3 +196,8 @@ func Convert_v1alpha3_AzureMarketplaceImage_To_v1beta1_AzureMarketplaceImage(in
 func Convert_v1beta1_Image_To_v1alpha3_Image(in *infrav1.Image, out *Image, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_Image_To_v1alpha3_Image(in, out, s)
 }
+
+// Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions converts a SpotVMOptions from v1beta1 to v1alpha3.
+func Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in *infrav1.SpotVMOptions, out *SpotVMOptions, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in, out, s)
+}
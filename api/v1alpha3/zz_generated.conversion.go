@@ -126,11 +126,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.AzureMachineTemplate)(nil), (*AzureMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AzureMachineTemplate_To_v1alpha3_AzureMachineTemplate(a.(*v1beta1.AzureMachineTemplate), b.(*AzureMachineTemplate), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AzureMachineTemplateList)(nil), (*v1beta1.AzureMachineTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AzureMachineTemplateList_To_v1beta1_AzureMachineTemplateList(a.(*AzureMachineTemplateList), b.(*v1beta1.AzureMachineTemplateList), scope)
 	}); err != nil {
@@ -236,11 +231,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.SpotVMOptions)(nil), (*SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(a.(*v1beta1.SpotVMOptions), b.(*SpotVMOptions), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*UserAssignedIdentity)(nil), (*v1beta1.UserAssignedIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_UserAssignedIdentity_To_v1beta1_UserAssignedIdentity(a.(*UserAssignedIdentity), b.(*v1beta1.UserAssignedIdentity), scope)
 	}); err != nil {
@@ -361,6 +351,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.AzureMachineTemplate)(nil), (*AzureMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AzureMachineTemplate_To_v1alpha3_AzureMachineTemplate(a.(*v1beta1.AzureMachineTemplate), b.(*AzureMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.AzureMarketplaceImage)(nil), (*AzureMarketplaceImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AzureMarketplaceImage_To_v1alpha3_AzureMarketplaceImage(a.(*v1beta1.AzureMarketplaceImage), b.(*AzureMarketplaceImage), scope)
 	}); err != nil {
@@ -421,6 +416,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.SpotVMOptions)(nil), (*SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(a.(*v1beta1.SpotVMOptions), b.(*SpotVMOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SubnetSpec)(nil), (*SubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SubnetSpec_To_v1alpha3_SubnetSpec(a.(*v1beta1.SubnetSpec), b.(*SubnetSpec), scope)
 	}); err != nil {
@@ -862,7 +862,15 @@ func autoConvert_v1alpha3_AzureMachineSpec_To_v1beta1_AzureMachineSpec(in *Azure
 	out.AllocatePublicIP = in.AllocatePublicIP
 	out.EnableIPForwarding = in.EnableIPForwarding
 	out.AcceleratedNetworking = (*bool)(unsafe.Pointer(in.AcceleratedNetworking))
-	out.SpotVMOptions = (*v1beta1.SpotVMOptions)(unsafe.Pointer(in.SpotVMOptions))
+	if in.SpotVMOptions != nil {
+		in, out := &in.SpotVMOptions, &out.SpotVMOptions
+		*out = new(v1beta1.SpotVMOptions)
+		if err := Convert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SpotVMOptions = nil
+	}
 	out.SecurityProfile = (*v1beta1.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
 	return nil
 }
@@ -903,9 +911,19 @@ func autoConvert_v1beta1_AzureMachineSpec_To_v1alpha3_AzureMachineSpec(in *v1bet
 	out.AllocatePublicIP = in.AllocatePublicIP
 	out.EnableIPForwarding = in.EnableIPForwarding
 	out.AcceleratedNetworking = (*bool)(unsafe.Pointer(in.AcceleratedNetworking))
-	out.SpotVMOptions = (*SpotVMOptions)(unsafe.Pointer(in.SpotVMOptions))
+	if in.SpotVMOptions != nil {
+		in, out := &in.SpotVMOptions, &out.SpotVMOptions
+		*out = new(SpotVMOptions)
+		if err := Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SpotVMOptions = nil
+	}
 	out.SecurityProfile = (*SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	// WARNING: in.DedicatedHost requires manual conversion: does not exist in peer-type
 	// WARNING: in.SubnetName requires manual conversion: does not exist in peer-type
+	// WARNING: in.Diagnostics requires manual conversion: does not exist in peer-type
 	// WARNING: in.DNSServers requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -948,6 +966,7 @@ func autoConvert_v1beta1_AzureMachineStatus_To_v1alpha3_AzureMachineStatus(in *v
 		out.Conditions = nil
 	}
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootDiagnostics requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -969,14 +988,10 @@ func autoConvert_v1beta1_AzureMachineTemplate_To_v1alpha3_AzureMachineTemplate(i
 	if err := Convert_v1beta1_AzureMachineTemplateSpec_To_v1alpha3_AzureMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
+	// WARNING: in.Status requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_AzureMachineTemplate_To_v1alpha3_AzureMachineTemplate is an autogenerated conversion function.
-func Convert_v1beta1_AzureMachineTemplate_To_v1alpha3_AzureMachineTemplate(in *v1beta1.AzureMachineTemplate, out *AzureMachineTemplate, s conversion.Scope) error {
-	return autoConvert_v1beta1_AzureMachineTemplate_To_v1alpha3_AzureMachineTemplate(in, out, s)
-}
-
 func autoConvert_v1alpha3_AzureMachineTemplateList_To_v1beta1_AzureMachineTemplateList(in *AzureMachineTemplateList, out *v1beta1.AzureMachineTemplateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1488,14 +1503,10 @@ func Convert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(in *SpotVMOptions,
 
 func autoConvert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in *v1beta1.SpotVMOptions, out *SpotVMOptions, s conversion.Scope) error {
 	out.MaxPrice = (*resource.Quantity)(unsafe.Pointer(in.MaxPrice))
+	// WARNING: in.EvictionPolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions is an autogenerated conversion function.
-func Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in *v1beta1.SpotVMOptions, out *SpotVMOptions, s conversion.Scope) error {
-	return autoConvert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in, out, s)
-}
-
 func autoConvert_v1alpha3_SubnetSpec_To_v1beta1_SubnetSpec(in *SubnetSpec, out *v1beta1.SubnetSpec, s conversion.Scope) error {
 	// WARNING: in.Role requires manual conversion: does not exist in peer-type
 	out.ID = in.ID
@@ -48,6 +48,22 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.DNSServers = restored.Spec.DNSServers
 	}
 
+	if restored.Spec.DedicatedHost != nil {
+		dst.Spec.DedicatedHost = restored.Spec.DedicatedHost
+	}
+
+	if restored.Spec.Diagnostics != nil {
+		dst.Spec.Diagnostics = restored.Spec.Diagnostics
+	}
+
+	if restored.Status.BootDiagnostics != nil {
+		dst.Status.BootDiagnostics = restored.Status.BootDiagnostics
+	}
+
+	if restored.Spec.SpotVMOptions != nil && dst.Spec.SpotVMOptions != nil {
+		dst.Spec.SpotVMOptions.EvictionPolicy = restored.Spec.SpotVMOptions.EvictionPolicy
+	}
+
 	return nil
 }
 
@@ -101,3 +117,13 @@ func Convert_v1beta1_Image_To_v1alpha4_Image(in *infrav1.Image, out *Image, s ap
 func Convert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in *infrav1.AzureMachineSpec, out *AzureMachineSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in, out, s)
 }
+
+// Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus is an autogenerated conversion function.
+func Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in *infrav1.AzureMachineStatus, out *AzureMachineStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in, out, s)
+}
+
+// Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions converts a SpotVMOptions from v1beta1 to v1alpha4.
+func Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in *infrav1.SpotVMOptions, out *SpotVMOptions, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in, out, s)
+}
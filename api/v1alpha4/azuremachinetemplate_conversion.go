@@ -50,6 +50,20 @@ func (src *AzureMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.DNSServers = restored.Spec.Template.Spec.DNSServers
 	}
 
+	if restored.Spec.Template.Spec.DedicatedHost != nil {
+		dst.Spec.Template.Spec.DedicatedHost = restored.Spec.Template.Spec.DedicatedHost
+	}
+
+	if restored.Spec.Template.Spec.Diagnostics != nil {
+		dst.Spec.Template.Spec.Diagnostics = restored.Spec.Template.Spec.Diagnostics
+	}
+
+	if restored.Spec.Template.Spec.SpotVMOptions != nil && dst.Spec.Template.Spec.SpotVMOptions != nil {
+		dst.Spec.Template.Spec.SpotVMOptions.EvictionPolicy = restored.Spec.Template.Spec.SpotVMOptions.EvictionPolicy
+	}
+
+	dst.Status = restored.Status
+
 	return nil
 }
 
@@ -80,3 +94,8 @@ func (dst *AzureMachineTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
 func Convert_v1beta1_AzureMachineTemplateResource_To_v1alpha4_AzureMachineTemplateResource(in *infrav1.AzureMachineTemplateResource, out *AzureMachineTemplateResource, s apimachineryconversion.Scope) error {
 	return autoConvert_v1beta1_AzureMachineTemplateResource_To_v1alpha4_AzureMachineTemplateResource(in, out, s)
 }
+
+// Convert_v1beta1_AzureMachineTemplate_To_v1alpha4_AzureMachineTemplate converts an Azure Machine Template from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureMachineTemplate_To_v1alpha4_AzureMachineTemplate(in *infrav1.AzureMachineTemplate, out *AzureMachineTemplate, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachineTemplate_To_v1alpha4_AzureMachineTemplate(in, out, s)
+}
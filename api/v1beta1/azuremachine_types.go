@@ -115,20 +115,45 @@ type AzureMachineSpec struct {
 	// +optional
 	SecurityProfile *SecurityProfile `json:"securityProfile,omitempty"`
 
+	// DedicatedHost places the VM on an Azure Dedicated Host, either through automatic placement in a
+	// dedicated host group or on a specific host.
+	// +optional
+	DedicatedHost *DedicatedHost `json:"dedicatedHost,omitempty"`
+
 	// SubnetName selects the Subnet where the VM will be placed
 	// +optional
 	SubnetName string `json:"subnetName,omitempty"`
 
+	// Diagnostics specifies the diagnostic settings of the virtual machine.
+	// +optional
+	Diagnostics *Diagnostics `json:"diagnostics,omitempty"`
+
 	// DNSServers adds a list of DNS Server IP addresses to the VM NICs.
 	// +optional
 	DNSServers []string `json:"dnsServers,omitempty"`
 }
 
+// SpotEvictionPolicy defines the eviction policy for spot virtual machines.
+// +kubebuilder:validation:Enum=Deallocate;Delete
+type SpotEvictionPolicy string
+
+const (
+	// SpotEvictionPolicyDeallocate is the default eviction policy and will deallocate the VM when the node is marked for eviction.
+	SpotEvictionPolicyDeallocate SpotEvictionPolicy = "Deallocate"
+	// SpotEvictionPolicyDelete will delete the VM when the node is marked for eviction.
+	SpotEvictionPolicyDelete SpotEvictionPolicy = "Delete"
+)
+
 // SpotVMOptions defines the options relevant to running the Machine on Spot VMs.
 type SpotVMOptions struct {
 	// MaxPrice defines the maximum price the user is willing to pay for Spot VM instances
 	// +optional
 	MaxPrice *resource.Quantity `json:"maxPrice,omitempty"`
+
+	// EvictionPolicy defines the behavior of the virtual machine when it is evicted. It can be either Delete or Deallocate.
+	// Defaults to Deallocate, unless the OS disk is ephemeral, in which case it defaults to Delete.
+	// +optional
+	EvictionPolicy *SpotEvictionPolicy `json:"evictionPolicy,omitempty"`
 }
 
 // AzureMachineStatus defines the observed state of AzureMachine.
@@ -191,6 +216,21 @@ type AzureMachineStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// BootDiagnostics describes the serial console log captured when bootstrapping the virtual machine failed.
+	// +optional
+	BootDiagnostics *BootDiagnosticsStatus `json:"bootDiagnostics,omitempty"`
+}
+
+// BootDiagnosticsStatus describes the serial console log captured when bootstrapping a virtual machine failed.
+type BootDiagnosticsStatus struct {
+	// CaptureTime is the time at which the serial console log was captured.
+	CaptureTime metav1.Time `json:"captureTime"`
+
+	// SerialConsoleLogRef references the ConfigMap holding the tail of the serial console log, when the controller
+	// is configured to store it in a ConfigMap.
+	// +optional
+	SerialConsoleLogRef *corev1.LocalObjectReference `json:"serialConsoleLogRef,omitempty"`
 }
 
 // AdditionalCapabilities enables or disables a capability on the virtual machine.
@@ -19,6 +19,8 @@ package v1beta1
 import (
 	"encoding/base64"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/google/uuid"
@@ -54,6 +56,99 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateDedicatedHost(spec.DedicatedHost, spec.SpotVMOptions, field.NewPath("dedicatedHost")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateSpotVMOptions(spec.SpotVMOptions, spec.OSDisk, field.NewPath("spotVMOptions")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateDiagnostics(spec.Diagnostics, field.NewPath("diagnostics")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	return allErrs
+}
+
+var (
+	hostGroupIDRE = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/hostGroups/[^/]+$`)
+	hostIDRE      = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/hostGroups/[^/]+/hosts/[^/]+$`)
+)
+
+// ValidateDedicatedHost validates the dedicated host placement of a virtual machine.
+func ValidateDedicatedHost(dedicatedHost *DedicatedHost, spotVMOptions *SpotVMOptions, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if dedicatedHost == nil {
+		return allErrs
+	}
+
+	if spotVMOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "dedicated hosts cannot be used with Spot VMs"))
+	}
+
+	if dedicatedHost.HostGroupID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("hostGroupID"), "the host group ID cannot be empty"))
+		return allErrs
+	}
+
+	if !hostGroupIDRE.MatchString(dedicatedHost.HostGroupID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostGroupID"), dedicatedHost.HostGroupID, "must be a valid dedicated host group resource ID"))
+		return allErrs
+	}
+
+	if dedicatedHost.HostID != "" {
+		if !hostIDRE.MatchString(dedicatedHost.HostID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostID"), dedicatedHost.HostID, "must be a valid dedicated host resource ID"))
+		} else if !strings.HasPrefix(strings.ToLower(dedicatedHost.HostID), strings.ToLower(dedicatedHost.HostGroupID)+"/hosts/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostID"), dedicatedHost.HostID, "the dedicated host must belong to the host group specified in hostGroupID"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateSpotVMOptions validates the Spot VM options against the OS disk settings.
+func ValidateSpotVMOptions(spotVMOptions *SpotVMOptions, osDisk OSDisk, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spotVMOptions == nil || spotVMOptions.EvictionPolicy == nil {
+		return allErrs
+	}
+
+	if *spotVMOptions.EvictionPolicy == SpotEvictionPolicyDeallocate && osDisk.DiffDiskSettings != nil && osDisk.DiffDiskSettings.Option == "Local" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("evictionPolicy"), *spotVMOptions.EvictionPolicy, "Deallocate eviction policy is not supported for Spot VMs with ephemeral OS disks, use Delete instead"))
+	}
+
+	return allErrs
+}
+
+// ValidateDiagnostics validates the diagnostic settings of a virtual machine.
+func ValidateDiagnostics(diagnostics *Diagnostics, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if diagnostics == nil || diagnostics.Boot == nil {
+		return allErrs
+	}
+
+	bootPath := fldPath.Child("boot")
+	switch diagnostics.Boot.StorageAccountType {
+	case UserManagedDiagnosticsStorage:
+		if diagnostics.Boot.UserManaged == nil || diagnostics.Boot.UserManaged.StorageAccountURI == "" {
+			allErrs = append(allErrs, field.Required(bootPath.Child("userManaged", "storageAccountURI"),
+				fmt.Sprintf("the storage account URI is required when the storage account type is %s", UserManagedDiagnosticsStorage)))
+		}
+	case ManagedDiagnosticsStorage, DisabledDiagnosticsStorage:
+		if diagnostics.Boot.UserManaged != nil {
+			allErrs = append(allErrs, field.Forbidden(bootPath.Child("userManaged"),
+				fmt.Sprintf("userManaged can only be set when the storage account type is %s", UserManagedDiagnosticsStorage)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(bootPath.Child("storageAccountType"), diagnostics.Boot.StorageAccountType,
+			[]string{string(ManagedDiagnosticsStorage), string(UserManagedDiagnosticsStorage), string(DisabledDiagnosticsStorage)}))
+	}
+
 	return allErrs
 }
 
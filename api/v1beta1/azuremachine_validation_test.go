@@ -499,6 +499,129 @@ func TestAzureMachine_ValidateSystemAssignedIdentity(t *testing.T) {
 	}
 }
 
+func TestAzureMachine_ValidateDedicatedHost(t *testing.T) {
+	g := NewWithT(t)
+
+	hostGroupID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+
+	tests := []struct {
+		name          string
+		dedicatedHost *DedicatedHost
+		spotVMOptions *SpotVMOptions
+		wantErr       bool
+	}{
+		{
+			name:          "no dedicated host",
+			dedicatedHost: nil,
+			wantErr:       false,
+		},
+		{
+			name:          "host group with automatic placement",
+			dedicatedHost: &DedicatedHost{HostGroupID: hostGroupID},
+			wantErr:       false,
+		},
+		{
+			name:          "specific host in the host group",
+			dedicatedHost: &DedicatedHost{HostGroupID: hostGroupID, HostID: hostGroupID + "/hosts/my-host"},
+			wantErr:       false,
+		},
+		{
+			name:          "empty host group ID",
+			dedicatedHost: &DedicatedHost{HostID: hostGroupID + "/hosts/my-host"},
+			wantErr:       true,
+		},
+		{
+			name:          "invalid host group ID",
+			dedicatedHost: &DedicatedHost{HostGroupID: "my-host-group"},
+			wantErr:       true,
+		},
+		{
+			name:          "invalid host ID",
+			dedicatedHost: &DedicatedHost{HostGroupID: hostGroupID, HostID: "my-host"},
+			wantErr:       true,
+		},
+		{
+			name: "host in a different host group",
+			dedicatedHost: &DedicatedHost{
+				HostGroupID: hostGroupID,
+				HostID:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/other-host-group/hosts/my-host",
+			},
+			wantErr: true,
+		},
+		{
+			name:          "spot VM on a dedicated host",
+			dedicatedHost: &DedicatedHost{HostGroupID: hostGroupID},
+			spotVMOptions: &SpotVMOptions{},
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDedicatedHost(tc.dedicatedHost, tc.spotVMOptions, field.NewPath("dedicatedHost"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateSpotVMOptions(t *testing.T) {
+	g := NewWithT(t)
+
+	deallocate := SpotEvictionPolicyDeallocate
+	deletePolicy := SpotEvictionPolicyDelete
+
+	tests := []struct {
+		name          string
+		spotVMOptions *SpotVMOptions
+		osDisk        OSDisk
+		wantErr       bool
+	}{
+		{
+			name:          "no spot vm options",
+			spotVMOptions: nil,
+			wantErr:       false,
+		},
+		{
+			name:          "default eviction policy with ephemeral os disk",
+			spotVMOptions: &SpotVMOptions{},
+			osDisk:        OSDisk{DiffDiskSettings: &DiffDiskSettings{Option: "Local"}},
+			wantErr:       false,
+		},
+		{
+			name:          "deallocate eviction policy with managed os disk",
+			spotVMOptions: &SpotVMOptions{EvictionPolicy: &deallocate},
+			wantErr:       false,
+		},
+		{
+			name:          "delete eviction policy with ephemeral os disk",
+			spotVMOptions: &SpotVMOptions{EvictionPolicy: &deletePolicy},
+			osDisk:        OSDisk{DiffDiskSettings: &DiffDiskSettings{Option: "Local"}},
+			wantErr:       false,
+		},
+		{
+			name:          "deallocate eviction policy with ephemeral os disk",
+			spotVMOptions: &SpotVMOptions{EvictionPolicy: &deallocate},
+			osDisk:        OSDisk{DiffDiskSettings: &DiffDiskSettings{Option: "Local"}},
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSpotVMOptions(tc.spotVMOptions, tc.osDisk, field.NewPath("spotVMOptions"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 	g := NewWithT(t)
 
@@ -694,3 +817,66 @@ func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureMachine_ValidateDiagnostics(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		diagnostics *Diagnostics
+		wantErr     bool
+	}{
+		{
+			name:        "no diagnostics",
+			diagnostics: nil,
+			wantErr:     false,
+		},
+		{
+			name:        "managed storage",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: ManagedDiagnosticsStorage}},
+			wantErr:     false,
+		},
+		{
+			name:        "disabled",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: DisabledDiagnosticsStorage}},
+			wantErr:     false,
+		},
+		{
+			name: "user managed storage",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{
+				StorageAccountType: UserManagedDiagnosticsStorage,
+				UserManaged:        &UserManagedBootDiagnostics{StorageAccountURI: "https://fake.blob.core.windows.net/"},
+			}},
+			wantErr: false,
+		},
+		{
+			name:        "user managed storage without storage account URI",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: UserManagedDiagnosticsStorage}},
+			wantErr:     true,
+		},
+		{
+			name: "managed storage with a user managed storage account",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{
+				StorageAccountType: ManagedDiagnosticsStorage,
+				UserManaged:        &UserManagedBootDiagnostics{StorageAccountURI: "https://fake.blob.core.windows.net/"},
+			}},
+			wantErr: true,
+		},
+		{
+			name:        "unknown storage account type",
+			diagnostics: &Diagnostics{Boot: &BootDiagnostics{StorageAccountType: "Unknown"}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDiagnostics(tc.diagnostics, field.NewPath("diagnostics"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
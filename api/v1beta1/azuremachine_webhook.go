@@ -136,6 +136,20 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object) error {
 		)
 	}
 
+	if !reflect.DeepEqual(m.Spec.DedicatedHost, old.Spec.DedicatedHost) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "dedicatedHost"),
+				m.Spec.DedicatedHost, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(m.Spec.Diagnostics, old.Spec.Diagnostics) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "diagnostics"),
+				m.Spec.Diagnostics, "field is immutable"),
+		)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -38,6 +38,8 @@ const (
 	VMDeletingReason = "VMDeleting"
 	// VMProvisionFailedReason used for failures during vm provisioning.
 	VMProvisionFailedReason = "VMProvisionFailed"
+	// SpotVMEvictedReason used when a Spot VM has been evicted by Azure.
+	SpotVMEvictedReason = "SpotVMEvicted"
 	// WaitingForClusterInfrastructureReason used when machine is waiting for cluster infrastructure to be ready before proceeding.
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
@@ -74,14 +76,45 @@ const (
 	ScaleSetModelUpdatedCondition clusterv1.ConditionType = "ScaleSetModelUpdated"
 	// ScaleSetModelOutOfDateReason describes the machine pool model being out of date.
 	ScaleSetModelOutOfDateReason = "ScaleSetModelOutOfDate"
+
+	// RolloutPausedCondition reports that the rollout of the latest model of the pool is paused because the machines
+	// based on it are unhealthy.
+	RolloutPausedCondition clusterv1.ConditionType = "RolloutPaused"
+	// NodeReadyTimeoutReason describes the node of a machine based on the latest model not becoming ready in time.
+	NodeReadyTimeoutReason = "NodeReadyTimeout"
+	// TooManyUnhealthyMachinesReason describes too many machines based on the latest model being unhealthy.
+	TooManyUnhealthyMachinesReason = "TooManyUnhealthyMachines"
 )
 
 // AzureManagedCluster Conditions and Reasons.
 const (
 	// ManagedClusterRunningCondition means the AKS cluster exists and is in a running state.
 	ManagedClusterRunningCondition clusterv1.ConditionType = "ManagedClusterRunning"
+	// OutboundTypePrerequisitesPendingReason means the managed cluster waits for its subnet to provide the egress path
+	// required by its outbound type before being created.
+	OutboundTypePrerequisitesPendingReason = "OutboundTypePrerequisitesPending"
 	// AgentPoolsReadyCondition means the AKS agent pools exist and are ready to be used.
 	AgentPoolsReadyCondition clusterv1.ConditionType = "AgentPoolsReady"
+	// MaintenanceConfigurationsReadyCondition means the AKS planned maintenance configurations exist and match the spec.
+	MaintenanceConfigurationsReadyCondition clusterv1.ConditionType = "MaintenanceConfigurationsReady"
+	// ManagedClusterImportedCondition means an existing AKS cluster has been adopted by the AzureManagedControlPlane.
+	ManagedClusterImportedCondition clusterv1.ConditionType = "ManagedClusterImported"
+	// ManagedClusterImportPendingReason means the existing AKS cluster matches the spec and the import awaits confirmation.
+	ManagedClusterImportPendingReason = "ImportPending"
+	// ManagedClusterImportConflictReason means the existing AKS cluster differs from the spec in ways that cannot be reconciled.
+	ManagedClusterImportConflictReason = "ImportConflict"
+	// AgentPoolsUpgradedCondition means all the AKS agent pools run their desired Kubernetes version.
+	AgentPoolsUpgradedCondition clusterv1.ConditionType = "AgentPoolsUpgraded"
+	// AgentPoolUpgradedCondition means the AKS agent pool runs its desired Kubernetes version.
+	AgentPoolUpgradedCondition clusterv1.ConditionType = "AgentPoolUpgraded"
+	// AgentPoolUpgradePendingReason means the agent pool waits for the control plane or for its turn to be upgraded.
+	AgentPoolUpgradePendingReason = "UpgradePending"
+	// AgentPoolUpgradingReason means agent pools are being upgraded.
+	AgentPoolUpgradingReason = "Upgrading"
+	// AgentPoolUpgradeFailedReason means the upgrade of the agent pool failed.
+	AgentPoolUpgradeFailedReason = "UpgradeFailed"
+	// AgentPoolUpgradePausedReason means agent pool upgrades are paused because the upgrade of an agent pool failed.
+	AgentPoolUpgradePausedReason = "UpgradePaused"
 )
 
 // Azure Services Conditions and Reasons.
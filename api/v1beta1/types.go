@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -455,6 +456,17 @@ const (
 	VMIdentityUserAssigned VMIdentity = "UserAssigned"
 )
 
+// OrchestrationModeType represents the orchestration mode for a Virtual Machine Scale Set backing an AzureMachinePool.
+// +kubebuilder:validation:Enum=Flexible;Uniform
+type OrchestrationModeType string
+
+const (
+	// FlexibleOrchestrationMode treats VMs as individual resources accessible by standard VM APIs.
+	FlexibleOrchestrationMode OrchestrationModeType = "Flexible"
+	// UniformOrchestrationMode treats VMs as identical instances accessible by the VMSS VM API.
+	UniformOrchestrationMode OrchestrationModeType = "Uniform"
+)
+
 // UserAssignedIdentity defines the user-assigned identities provided
 // by the user to be assigned to Azure resources.
 type UserAssignedIdentity struct {
@@ -635,6 +647,60 @@ type SecurityProfile struct {
 	EncryptionAtHost *bool `json:"encryptionAtHost,omitempty"`
 }
 
+// DedicatedHost specifies the Azure Dedicated Host placement of a virtual machine or virtual machine scale set.
+// See https://docs.microsoft.com/en-us/azure/virtual-machines/dedicated-hosts
+type DedicatedHost struct {
+	// HostGroupID is the resource ID of the dedicated host group to place the virtual machine in.
+	// When HostID is not set, the host group must support automatic placement and Azure selects the host.
+	HostGroupID string `json:"hostGroupID"`
+
+	// HostID is the resource ID of a specific dedicated host within the host group to place the virtual machine on.
+	// It is not supported for virtual machine scale sets.
+	// +optional
+	HostID string `json:"hostID,omitempty"`
+}
+
+// BootDiagnosticsStorageAccountType defines where the boot diagnostics data of a virtual machine is stored.
+// +kubebuilder:validation:Enum=Managed;UserManaged;Disabled
+type BootDiagnosticsStorageAccountType string
+
+const (
+	// ManagedDiagnosticsStorage stores the boot diagnostics data in a storage account managed by Azure.
+	ManagedDiagnosticsStorage BootDiagnosticsStorageAccountType = "Managed"
+	// UserManagedDiagnosticsStorage stores the boot diagnostics data in a storage account provided by the user.
+	UserManagedDiagnosticsStorage BootDiagnosticsStorageAccountType = "UserManaged"
+	// DisabledDiagnosticsStorage disables boot diagnostics.
+	DisabledDiagnosticsStorage BootDiagnosticsStorageAccountType = "Disabled"
+)
+
+// Diagnostics configures the diagnostic settings of a virtual machine or virtual machine scale set.
+type Diagnostics struct {
+	// Boot configures the boot diagnostics, which capture the serial console output and a screenshot of the
+	// virtual machine on boot. Boot diagnostics are enabled with managed storage when not specified.
+	// +optional
+	Boot *BootDiagnostics `json:"boot,omitempty"`
+}
+
+// BootDiagnostics configures the boot diagnostics of a virtual machine.
+type BootDiagnostics struct {
+	// StorageAccountType determines whether boot diagnostics are disabled (Disabled), stored in a storage
+	// account managed by Azure (Managed) or stored in a storage account provided by the user (UserManaged).
+	StorageAccountType BootDiagnosticsStorageAccountType `json:"storageAccountType"`
+
+	// UserManaged references the storage account provided by the user. It must be set when StorageAccountType
+	// is UserManaged.
+	// +optional
+	UserManaged *UserManagedBootDiagnostics `json:"userManaged,omitempty"`
+}
+
+// UserManagedBootDiagnostics references a storage account provided by the user for boot diagnostics.
+type UserManagedBootDiagnostics struct {
+	// StorageAccountURI is the blob endpoint of the storage account, e.g. https://mystorageaccount.blob.core.windows.net/.
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +kubebuilder:validation:MaxLength=1024
+	StorageAccountURI string `json:"storageAccountURI"`
+}
+
 // AddressRecord specifies a DNS record mapping a hostname to an IPV4 or IPv6 address.
 type AddressRecord struct {
 	Hostname string
@@ -740,6 +806,30 @@ type AzureBastion struct {
 	PublicIP PublicIPSpec `json:"publicIP,omitempty"`
 }
 
+// NodeInfo describes the nodes of a group of machines for the cluster autoscaler to build the labels of a node group
+// scaled from zero.
+type NodeInfo struct {
+	// Architecture is the CPU architecture of the nodes.
+	// +kubebuilder:validation:Enum=amd64;arm64
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+
+	// OperatingSystem is the operating system of the nodes.
+	// +kubebuilder:validation:Enum=linux;windows
+	// +optional
+	OperatingSystem string `json:"operatingSystem,omitempty"`
+
+	// Labels are the labels of the nodes known to CAPZ: the instance type of their VM size, and the labels set with
+	// the node labels annotation. They are hints for the labels of the node group.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are the taints the nodes are registered with, set with the node taints annotation. They are hints for the
+	// taints of the node group.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
+}
+
 // IsTerminalProvisioningState returns true if the ProvisioningState is a terminal state for an Azure resource.
 func IsTerminalProvisioningState(state ProvisioningState) bool {
 	return state == Failed || state == Succeeded
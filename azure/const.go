@@ -32,4 +32,48 @@ const (
 	// ReplicasManagedByAutoscalerAnnotation is set to true in the corresponding capi machine pool
 	// when an external autoscaler manages the node count of the associated machine pool.
 	ReplicasManagedByAutoscalerAnnotation = "cluster.x-k8s.io/replicas-managed-by-autoscaler"
+
+	// ManagedClusterImportAnnotation is set on an AzureManagedControlPlane to adopt an existing AKS cluster.
+	// While its value is ManagedClusterImportPending, the existing cluster is only read and compared with the spec.
+	// Setting it to ManagedClusterImportConfirmed lets CAPZ start managing the cluster.
+	ManagedClusterImportAnnotation = "sigs.k8s.io/cluster-api-provider-azure-import"
+
+	// ManagedClusterImportPending is the value of ManagedClusterImportAnnotation before the import is confirmed.
+	ManagedClusterImportPending = "pending"
+
+	// ManagedClusterImportConfirmed is the value of ManagedClusterImportAnnotation once the import is confirmed.
+	ManagedClusterImportConfirmed = "confirmed"
+
+	// VMPowerStateDeallocated is the power state reported in the instance view of a VM which has been deallocated,
+	// for example a Spot VM evicted with the Deallocate eviction policy.
+	VMPowerStateDeallocated = "deallocated"
+
+	// DeletePriorityAnnotation is set on an AzureMachinePoolMachine to the priority of the deletion of its instance when
+	// the pool scales in or rolls out a new model. Machines with a higher priority are deleted first. Defaults to 0.
+	DeletePriorityAnnotation = "sigs.k8s.io/cluster-api-provider-azure-delete-priority"
+
+	// ScheduledEventsAnnotation is set on a Node by a node agent to the "Events" array of the Scheduled Events
+	// reported by the Azure Instance Metadata Service for the VM of the Node, as JSON.
+	ScheduledEventsAnnotation = "sigs.k8s.io/cluster-api-provider-azure-scheduled-events"
+
+	// ApprovedScheduledEventsAnnotation is set on a Node once it has been drained ahead of the Scheduled Events in
+	// ScheduledEventsAnnotation, to the comma separated IDs of the events the node agent can approve.
+	ApprovedScheduledEventsAnnotation = "sigs.k8s.io/cluster-api-provider-azure-approved-scheduled-events"
+
+	// CordonedForScheduledEventsAnnotation is set on a Node cordoned ahead of Scheduled Events, so that the Node is
+	// uncordoned once the events are over.
+	CordonedForScheduledEventsAnnotation = "sigs.k8s.io/cluster-api-provider-azure-cordoned-for-scheduled-events"
+
+	// MaxPodsAnnotation is set on an AzureMachineTemplate or an AzureMachinePool to the maximum number of pods of the
+	// kubelet of its nodes, when the bootstrap configuration sets one other than the kubelet default of 110.
+	MaxPodsAnnotation = "sigs.k8s.io/cluster-api-provider-azure-max-pods"
+
+	// NodeLabelsAnnotation is set on an AzureMachineTemplate or an AzureMachinePool to the comma separated key=value
+	// labels the kubelet of its nodes registers them with, as in the --node-labels flag of the kubelet.
+	NodeLabelsAnnotation = "sigs.k8s.io/cluster-api-provider-azure-node-labels"
+
+	// NodeTaintsAnnotation is set on an AzureMachineTemplate or an AzureMachinePool to the comma separated
+	// key=value:Effect taints the kubelet of its nodes registers them with, as in the --register-with-taints flag of
+	// the kubelet.
+	NodeTaintsAnnotation = "sigs.k8s.io/cluster-api-provider-azure-node-taints"
 )
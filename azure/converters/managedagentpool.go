@@ -17,30 +17,117 @@ limitations under the License.
 package converters
 
 import (
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 )
 
 // AgentPoolToManagedClusterAgentPoolProfile converts a AgentPoolSpec to an Azure SDK ManagedClusterAgentPoolProfile used in managedcluster reconcile.
 func AgentPoolToManagedClusterAgentPoolProfile(pool containerservice.AgentPool) containerservice.ManagedClusterAgentPoolProfile {
 	properties := pool.ManagedClusterAgentPoolProfileProperties
 	return containerservice.ManagedClusterAgentPoolProfile{
-		Name:                pool.Name, // Note: if converting from agentPoolSpec.Parameters(), this field will not be set
-		VMSize:              properties.VMSize,
-		OsType:              properties.OsType,
-		OsDiskSizeGB:        properties.OsDiskSizeGB,
-		Count:               properties.Count,
-		Type:                properties.Type,
-		OrchestratorVersion: properties.OrchestratorVersion,
-		VnetSubnetID:        properties.VnetSubnetID,
-		Mode:                properties.Mode,
-		EnableAutoScaling:   properties.EnableAutoScaling,
-		MaxCount:            properties.MaxCount,
-		MinCount:            properties.MinCount,
-		NodeTaints:          properties.NodeTaints,
-		AvailabilityZones:   properties.AvailabilityZones,
-		MaxPods:             properties.MaxPods,
-		OsDiskType:          properties.OsDiskType,
-		NodeLabels:          properties.NodeLabels,
-		EnableUltraSSD:      properties.EnableUltraSSD,
+		Name:                 pool.Name, // Note: if converting from agentPoolSpec.Parameters(), this field will not be set
+		VMSize:               properties.VMSize,
+		OsType:               properties.OsType,
+		OsDiskSizeGB:         properties.OsDiskSizeGB,
+		Count:                properties.Count,
+		Type:                 properties.Type,
+		OrchestratorVersion:  properties.OrchestratorVersion,
+		VnetSubnetID:         properties.VnetSubnetID,
+		Mode:                 properties.Mode,
+		EnableAutoScaling:    properties.EnableAutoScaling,
+		MaxCount:             properties.MaxCount,
+		MinCount:             properties.MinCount,
+		NodeTaints:           properties.NodeTaints,
+		AvailabilityZones:    properties.AvailabilityZones,
+		MaxPods:              properties.MaxPods,
+		OsDiskType:           properties.OsDiskType,
+		NodeLabels:           properties.NodeLabels,
+		EnableUltraSSD:       properties.EnableUltraSSD,
+		OsSKU:                properties.OsSKU,
+		EnableNodePublicIP:   properties.EnableNodePublicIP,
+		NodePublicIPPrefixID: properties.NodePublicIPPrefixID,
+		ScaleSetPriority:     properties.ScaleSetPriority,
+		ScaleDownMode:        properties.ScaleDownMode,
+		UpgradeSettings:      properties.UpgradeSettings,
+		KubeletConfig:        properties.KubeletConfig,
+		LinuxOSConfig:        properties.LinuxOSConfig,
+		PodSubnetID:          properties.PodSubnetID,
+	}
+}
+
+// KubeletConfigToSDK converts a KubeletConfig to an Azure SDK KubeletConfig.
+func KubeletConfigToSDK(kubeletConfig *infrav1exp.KubeletConfig) *containerservice.KubeletConfig {
+	if kubeletConfig == nil {
+		return nil
+	}
+
+	var allowedUnsafeSysctls *[]string
+	if len(kubeletConfig.AllowedUnsafeSysctls) > 0 {
+		allowedUnsafeSysctls = &kubeletConfig.AllowedUnsafeSysctls
+	}
+
+	return &containerservice.KubeletConfig{
+		CPUManagerPolicy:      kubeletConfig.CPUManagerPolicy,
+		CPUCfsQuota:           kubeletConfig.CPUCfsQuota,
+		CPUCfsQuotaPeriod:     kubeletConfig.CPUCfsQuotaPeriod,
+		ImageGcHighThreshold:  kubeletConfig.ImageGcHighThreshold,
+		ImageGcLowThreshold:   kubeletConfig.ImageGcLowThreshold,
+		TopologyManagerPolicy: kubeletConfig.TopologyManagerPolicy,
+		AllowedUnsafeSysctls:  allowedUnsafeSysctls,
+		FailSwapOn:            kubeletConfig.FailSwapOn,
+		ContainerLogMaxSizeMB: kubeletConfig.ContainerLogMaxSizeMB,
+		ContainerLogMaxFiles:  kubeletConfig.ContainerLogMaxFiles,
+		PodMaxPids:            kubeletConfig.PodMaxPids,
+	}
+}
+
+// LinuxOSConfigToSDK converts a LinuxOSConfig to an Azure SDK LinuxOSConfig.
+func LinuxOSConfigToSDK(linuxOSConfig *infrav1exp.LinuxOSConfig) *containerservice.LinuxOSConfig {
+	if linuxOSConfig == nil {
+		return nil
+	}
+
+	return &containerservice.LinuxOSConfig{
+		SwapFileSizeMB:             linuxOSConfig.SwapFileSizeMB,
+		Sysctls:                    sysctlConfigToSDK(linuxOSConfig.Sysctls),
+		TransparentHugePageDefrag:  linuxOSConfig.TransparentHugePageDefrag,
+		TransparentHugePageEnabled: linuxOSConfig.TransparentHugePageEnabled,
+	}
+}
+
+func sysctlConfigToSDK(sysctls *infrav1exp.SysctlConfig) *containerservice.SysctlConfig {
+	if sysctls == nil {
+		return nil
+	}
+
+	return &containerservice.SysctlConfig{
+		FsAioMaxNr:                     sysctls.FsAioMaxNr,
+		FsFileMax:                      sysctls.FsFileMax,
+		FsInotifyMaxUserWatches:        sysctls.FsInotifyMaxUserWatches,
+		FsNrOpen:                       sysctls.FsNrOpen,
+		KernelThreadsMax:               sysctls.KernelThreadsMax,
+		NetCoreNetdevMaxBacklog:        sysctls.NetCoreNetdevMaxBacklog,
+		NetCoreOptmemMax:               sysctls.NetCoreOptmemMax,
+		NetCoreRmemDefault:             sysctls.NetCoreRmemDefault,
+		NetCoreRmemMax:                 sysctls.NetCoreRmemMax,
+		NetCoreSomaxconn:               sysctls.NetCoreSomaxconn,
+		NetCoreWmemDefault:             sysctls.NetCoreWmemDefault,
+		NetCoreWmemMax:                 sysctls.NetCoreWmemMax,
+		NetIpv4IPLocalPortRange:        sysctls.NetIpv4IPLocalPortRange,
+		NetIpv4NeighDefaultGcThresh1:   sysctls.NetIpv4NeighDefaultGcThresh1,
+		NetIpv4NeighDefaultGcThresh2:   sysctls.NetIpv4NeighDefaultGcThresh2,
+		NetIpv4NeighDefaultGcThresh3:   sysctls.NetIpv4NeighDefaultGcThresh3,
+		NetIpv4TCPFinTimeout:           sysctls.NetIpv4TCPFinTimeout,
+		NetIpv4TCPKeepaliveProbes:      sysctls.NetIpv4TCPKeepaliveProbes,
+		NetIpv4TCPKeepaliveTime:        sysctls.NetIpv4TCPKeepaliveTime,
+		NetIpv4TCPMaxSynBacklog:        sysctls.NetIpv4TCPMaxSynBacklog,
+		NetIpv4TCPMaxTwBuckets:         sysctls.NetIpv4TCPMaxTwBuckets,
+		NetIpv4TCPTwReuse:              sysctls.NetIpv4TCPTwReuse,
+		NetIpv4TcpkeepaliveIntvl:       sysctls.NetIpv4TCPkeepaliveIntvl,
+		NetNetfilterNfConntrackBuckets: sysctls.NetNetfilterNfConntrackBuckets,
+		NetNetfilterNfConntrackMax:     sysctls.NetNetfilterNfConntrackMax,
+		VMMaxMapCount:                  sysctls.VMMaxMapCount,
+		VMSwappiness:                   sysctls.VMSwappiness,
+		VMVfsCachePressure:             sysctls.VMVfsCachePressure,
 	}
 }
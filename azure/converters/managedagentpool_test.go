@@ -19,10 +19,11 @@ package converters
 import (
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 )
 
 func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
@@ -54,6 +55,12 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 					NodeLabels: map[string]*string{
 						"custom": to.StringPtr("default"),
 					},
+					OsSKU:            containerservice.OSSKUCBLMariner,
+					ScaleSetPriority: containerservice.ScaleSetPrioritySpot,
+					ScaleDownMode:    containerservice.ScaleDownModeDeallocate,
+					UpgradeSettings:  &containerservice.AgentPoolUpgradeSettings{MaxSurge: to.StringPtr("33%")},
+					KubeletConfig:    &containerservice.KubeletConfig{CPUManagerPolicy: to.StringPtr("static")},
+					PodSubnetID:      to.StringPtr("pod-subnet-id"),
 				},
 			},
 
@@ -78,6 +85,12 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 					NodeLabels: map[string]*string{
 						"custom": to.StringPtr("default"),
 					},
+					OsSKU:            containerservice.OSSKUCBLMariner,
+					ScaleSetPriority: containerservice.ScaleSetPrioritySpot,
+					ScaleDownMode:    containerservice.ScaleDownModeDeallocate,
+					UpgradeSettings:  &containerservice.AgentPoolUpgradeSettings{MaxSurge: to.StringPtr("33%")},
+					KubeletConfig:    &containerservice.KubeletConfig{CPUManagerPolicy: to.StringPtr("static")},
+					PodSubnetID:      to.StringPtr("pod-subnet-id"),
 				}))
 			},
 		},
@@ -93,3 +106,43 @@ func Test_AgentPoolToManagedClusterAgentPoolProfile(t *testing.T) {
 		})
 	}
 }
+
+func Test_KubeletConfigToSDK(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(KubeletConfigToSDK(nil)).To(BeNil())
+	g.Expect(KubeletConfigToSDK(&infrav1exp.KubeletConfig{
+		CPUManagerPolicy:     to.StringPtr("static"),
+		ImageGcHighThreshold: to.Int32Ptr(90),
+		AllowedUnsafeSysctls: []string{"net.core.*"},
+		FailSwapOn:           to.BoolPtr(false),
+	})).To(Equal(&containerservice.KubeletConfig{
+		CPUManagerPolicy:     to.StringPtr("static"),
+		ImageGcHighThreshold: to.Int32Ptr(90),
+		AllowedUnsafeSysctls: to.StringSlicePtr([]string{"net.core.*"}),
+		FailSwapOn:           to.BoolPtr(false),
+	}))
+}
+
+func Test_LinuxOSConfigToSDK(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(LinuxOSConfigToSDK(nil)).To(BeNil())
+	g.Expect(LinuxOSConfigToSDK(&infrav1exp.LinuxOSConfig{
+		SwapFileSizeMB:             to.Int32Ptr(1500),
+		TransparentHugePageEnabled: to.StringPtr("madvise"),
+		Sysctls: &infrav1exp.SysctlConfig{
+			NetCoreSomaxconn:         to.Int32Ptr(16384),
+			NetIpv4IPLocalPortRange:  to.StringPtr("32768 60999"),
+			NetIpv4TCPkeepaliveIntvl: to.Int32Ptr(30),
+		},
+	})).To(Equal(&containerservice.LinuxOSConfig{
+		SwapFileSizeMB:             to.Int32Ptr(1500),
+		TransparentHugePageEnabled: to.StringPtr("madvise"),
+		Sysctls: &containerservice.SysctlConfig{
+			NetCoreSomaxconn:         to.Int32Ptr(16384),
+			NetIpv4IPLocalPortRange:  to.StringPtr("32768 60999"),
+			NetIpv4TcpkeepaliveIntvl: to.Int32Ptr(30),
+		},
+	}))
+}
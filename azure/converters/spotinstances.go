@@ -20,6 +20,7 @@ import (
 	"strconv"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
@@ -42,7 +43,18 @@ func GetSpotVMOptions(spotVMOptions *infrav1.SpotVMOptions, diffDiskSettings *in
 	}
 	evictionPolicy := compute.VirtualMachineEvictionPolicyTypesDeallocate
 	if diffDiskSettings != nil && diffDiskSettings.Option == "Local" {
+		// VMs with ephemeral OS disks cannot be deallocated
 		evictionPolicy = compute.VirtualMachineEvictionPolicyTypesDelete
 	}
+	if spotVMOptions.EvictionPolicy != nil {
+		switch *spotVMOptions.EvictionPolicy {
+		case infrav1.SpotEvictionPolicyDeallocate:
+			evictionPolicy = compute.VirtualMachineEvictionPolicyTypesDeallocate
+		case infrav1.SpotEvictionPolicyDelete:
+			evictionPolicy = compute.VirtualMachineEvictionPolicyTypesDelete
+		default:
+			return "", "", nil, errors.Errorf("unknown spot eviction policy: %s", *spotVMOptions.EvictionPolicy)
+		}
+	}
 	return compute.VirtualMachinePriorityTypesSpot, evictionPolicy, billingProfile, nil
 }
@@ -92,6 +92,34 @@ func TestGetSpotVMOptions(t *testing.T) {
 				billingProfile:        nil,
 			},
 		},
+		{
+			name: "spot with delete eviction policy",
+			spot: &infrav1.SpotVMOptions{
+				EvictionPolicy: func(policy infrav1.SpotEvictionPolicy) *infrav1.SpotEvictionPolicy {
+					return &policy
+				}(infrav1.SpotEvictionPolicyDelete),
+			},
+			diffDiskSettings: nil,
+			want: resultParams{
+				vmPriorityTypes:       compute.VirtualMachinePriorityTypesSpot,
+				vmEvictionPolicyTypes: compute.VirtualMachineEvictionPolicyTypesDelete,
+				billingProfile:        nil,
+			},
+		},
+		{
+			name: "spot with deallocate eviction policy",
+			spot: &infrav1.SpotVMOptions{
+				EvictionPolicy: func(policy infrav1.SpotEvictionPolicy) *infrav1.SpotEvictionPolicy {
+					return &policy
+				}(infrav1.SpotEvictionPolicyDeallocate),
+			},
+			diffDiskSettings: nil,
+			want: resultParams{
+				vmPriorityTypes:       compute.VirtualMachinePriorityTypesSpot,
+				vmEvictionPolicyTypes: compute.VirtualMachineEvictionPolicyTypesDeallocate,
+				billingProfile:        nil,
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -17,12 +17,17 @@ limitations under the License.
 package converters
 
 import (
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
 	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
+// powerStatePrefix is the prefix of the instance view status code describing the power state of a VM.
+const powerStatePrefix = "PowerState/"
+
 // VM describes an Azure virtual machine.
 type VM struct {
 	ID               string `json:"id,omitempty"`
@@ -35,9 +40,11 @@ type VM struct {
 	OSDisk        infrav1.OSDisk `json:"osDisk,omitempty"`
 	StartupScript string         `json:"startupScript,omitempty"`
 	// State - The provisioning state, which only appears in the response.
-	State    infrav1.ProvisioningState `json:"vmState,omitempty"`
-	Identity infrav1.VMIdentity        `json:"identity,omitempty"`
-	Tags     infrav1.Tags              `json:"tags,omitempty"`
+	State infrav1.ProvisioningState `json:"vmState,omitempty"`
+	// PowerState - The power state of the VM, which only appears when the instance view is requested.
+	PowerState string             `json:"powerState,omitempty"`
+	Identity   infrav1.VMIdentity `json:"identity,omitempty"`
+	Tags       infrav1.Tags       `json:"tags,omitempty"`
 
 	// Addresses contains the addresses associated with the Azure VM.
 	Addresses []corev1.NodeAddress `json:"addresses,omitempty"`
@@ -55,6 +62,10 @@ func SDKToVM(v compute.VirtualMachine) *VM {
 		vm.VMSize = string(v.VirtualMachineProperties.HardwareProfile.VMSize)
 	}
 
+	if v.VirtualMachineProperties != nil && v.VirtualMachineProperties.InstanceView != nil {
+		vm.PowerState = SDKToPowerState(v.VirtualMachineProperties.InstanceView.Statuses)
+	}
+
 	if v.Zones != nil && len(*v.Zones) > 0 {
 		vm.AvailabilityZone = to.StringSlice(v.Zones)[0]
 	}
@@ -65,3 +76,16 @@ func SDKToVM(v compute.VirtualMachine) *VM {
 
 	return vm
 }
+
+// SDKToPowerState returns the power state of a VM, e.g. "running" or "deallocated", from the statuses of its instance view.
+func SDKToPowerState(statuses *[]compute.InstanceViewStatus) string {
+	if statuses == nil {
+		return ""
+	}
+	for _, status := range *statuses {
+		if code := to.String(status.Code); strings.HasPrefix(code, powerStatePrefix) {
+			return strings.TrimPrefix(code, powerStatePrefix)
+		}
+	}
+	return ""
+}
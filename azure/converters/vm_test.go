@@ -100,6 +100,28 @@ func TestSDKToVM(t *testing.T) {
 				Tags:  infrav1.Tags{"foo": "bar"},
 			},
 		},
+		{
+			name: "Should convert and populate with power state",
+			sdk: compute.VirtualMachine{
+				ID:   to.StringPtr("test-vm-id"),
+				Name: to.StringPtr("test-vm-name"),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					ProvisioningState: to.StringPtr("Succeeded"),
+					InstanceView: &compute.VirtualMachineInstanceView{
+						Statuses: &[]compute.InstanceViewStatus{
+							{Code: to.StringPtr("ProvisioningState/succeeded")},
+							{Code: to.StringPtr("PowerState/deallocated")},
+						},
+					},
+				},
+			},
+			want: &VM{
+				ID:         "test-vm-id",
+				Name:       "test-vm-name",
+				State:      infrav1.ProvisioningState(compute.ProvisioningStateSucceeded),
+				PowerState: "deallocated",
+			},
+		},
 		{
 			name: "Should convert and populate with all fields",
 			sdk: compute.VirtualMachine{
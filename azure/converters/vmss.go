@@ -17,6 +17,8 @@ limitations under the License.
 package converters
 
 import (
+	"sort"
+
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -58,6 +60,35 @@ func SDKToVMSS(sdkvmss compute.VirtualMachineScaleSet, sdkinstances []compute.Vi
 		vmss.Image = SDKImageToImage(imageRef, sdkvmss.Plan != nil)
 	}
 
+	if sdkvmss.VirtualMachineScaleSetProperties != nil && sdkvmss.AutomaticRepairsPolicy != nil &&
+		to.Bool(sdkvmss.AutomaticRepairsPolicy.Enabled) {
+		vmss.AutomaticRepairsGracePeriod = to.String(sdkvmss.AutomaticRepairsPolicy.GracePeriod)
+	}
+
+	if sdkvmss.VirtualMachineProfile != nil &&
+		sdkvmss.VirtualMachineProfile.ExtensionProfile != nil &&
+		sdkvmss.VirtualMachineProfile.ExtensionProfile.Extensions != nil {
+		for _, extension := range *sdkvmss.VirtualMachineProfile.ExtensionProfile.Extensions {
+			vmss.Extensions = append(vmss.Extensions, to.String(extension.Name))
+		}
+		sort.Strings(vmss.Extensions)
+	}
+
+	return vmss
+}
+
+// SDKToFlexVMSS converts an Azure SDK VirtualMachineScaleSet in Flexible orchestration mode, along with the virtual
+// machines it orchestrates, to the AzureMachinePool type.
+func SDKToFlexVMSS(sdkvmss compute.VirtualMachineScaleSet, sdkvms []compute.VirtualMachine) *azure.VMSS {
+	vmss := SDKToVMSS(sdkvmss, nil)
+
+	if len(sdkvms) > 0 {
+		vmss.Instances = make([]azure.VMSSVM, len(sdkvms))
+		for i, vm := range sdkvms {
+			vmss.Instances[i] = *SDKVMToVMSSVM(vm)
+		}
+	}
+
 	return vmss
 }
 
@@ -91,6 +122,17 @@ func SDKToVMSSVM(sdkInstance compute.VirtualMachineScaleSetVM) *azure.VMSSVM {
 		instance.AvailabilityZone = to.StringSlice(sdkInstance.Zones)[0]
 	}
 
+	if sdkInstance.InstanceView != nil {
+		instance.PowerState = SDKToPowerState(sdkInstance.InstanceView.Statuses)
+	}
+
+	if sdkInstance.ProtectionPolicy != nil {
+		instance.ProtectionPolicy = azure.VMSSVMProtectionPolicy{
+			ProtectFromScaleIn:         to.Bool(sdkInstance.ProtectionPolicy.ProtectFromScaleIn),
+			ProtectFromScaleSetActions: to.Bool(sdkInstance.ProtectionPolicy.ProtectFromScaleSetActions),
+		}
+	}
+
 	return &instance
 }
 
@@ -109,3 +151,41 @@ func SDKImageToImage(sdkImageRef *compute.ImageReference, isThirdPartyImage bool
 		},
 	}
 }
+
+// SDKVMToVMSSVM converts an Azure SDK VirtualMachine orchestrated by a Flexible scale set into an azure.VMSSVM. The
+// name of the virtual machine is used as its instance ID, as Flexible scale sets do not assign instance IDs.
+func SDKVMToVMSSVM(sdkVM compute.VirtualMachine) *azure.VMSSVM {
+	instance := azure.VMSSVM{
+		ID:         to.String(sdkVM.ID),
+		InstanceID: to.String(sdkVM.Name),
+	}
+
+	if sdkVM.VirtualMachineProperties == nil {
+		return &instance
+	}
+
+	instance.State = infrav1.Creating
+	if sdkVM.ProvisioningState != nil {
+		instance.State = infrav1.ProvisioningState(to.String(sdkVM.ProvisioningState))
+	}
+
+	if sdkVM.OsProfile != nil && sdkVM.OsProfile.ComputerName != nil {
+		instance.Name = *sdkVM.OsProfile.ComputerName
+	}
+
+	if sdkVM.StorageProfile != nil && sdkVM.StorageProfile.ImageReference != nil {
+		imageRef := sdkVM.StorageProfile.ImageReference
+		instance.Image = SDKImageToImage(imageRef, sdkVM.Plan != nil)
+	}
+
+	if sdkVM.Zones != nil && len(*sdkVM.Zones) > 0 {
+		// a virtual machine should only have 1 zone, so we select the first item of the slice
+		instance.AvailabilityZone = to.StringSlice(sdkVM.Zones)[0]
+	}
+
+	if sdkVM.InstanceView != nil {
+		instance.PowerState = SDKToPowerState(sdkVM.InstanceView.Statuses)
+	}
+
+	return &instance
+}
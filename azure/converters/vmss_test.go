@@ -109,6 +109,39 @@ func Test_SDKToVMSS(t *testing.T) {
 				g.Expect(actual).To(gomega.Equal(&expected))
 			},
 		},
+		{
+			Name: "ShouldPopulateAutomaticRepairsAndSortedExtensions",
+			SubjectFactory: func(g *gomega.GomegaWithT) (compute.VirtualMachineScaleSet, []compute.VirtualMachineScaleSetVM) {
+				return compute.VirtualMachineScaleSet{
+					ID:   to.StringPtr("vmssID"),
+					Name: to.StringPtr("vmssName"),
+					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+						ProvisioningState: to.StringPtr(string(compute.ProvisioningState1Succeeded)),
+						AutomaticRepairsPolicy: &compute.AutomaticRepairsPolicy{
+							Enabled:     to.BoolPtr(true),
+							GracePeriod: to.StringPtr("PT30M"),
+						},
+						VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
+							ExtensionProfile: &compute.VirtualMachineScaleSetExtensionProfile{
+								Extensions: &[]compute.VirtualMachineScaleSetExtension{
+									{Name: to.StringPtr("CAPZ.Linux.Bootstrapping")},
+									{Name: to.StringPtr("ApplicationHealthLinux")},
+								},
+							},
+						},
+					},
+				}, nil
+			},
+			Expect: func(g *gomega.GomegaWithT, actual *azure.VMSS) {
+				g.Expect(actual).To(gomega.Equal(&azure.VMSS{
+					ID:                          "vmssID",
+					Name:                        "vmssName",
+					State:                       "Succeeded",
+					AutomaticRepairsGracePeriod: "PT30M",
+					Extensions:                  []string{"ApplicationHealthLinux", "CAPZ.Linux.Bootstrapping"},
+				}))
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -122,3 +155,61 @@ func Test_SDKToVMSS(t *testing.T) {
 		})
 	}
 }
+
+func Test_SDKToFlexVMSS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	vmss := compute.VirtualMachineScaleSet{
+		Sku: &compute.Sku{
+			Name:     to.StringPtr("skuName"),
+			Capacity: to.Int64Ptr(2),
+		},
+		ID:   to.StringPtr("vmssID"),
+		Name: to.StringPtr("vmssName"),
+		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+			OrchestrationMode: compute.OrchestrationModeFlexible,
+			ProvisioningState: to.StringPtr(string(compute.ProvisioningState1Succeeded)),
+		},
+	}
+
+	vms := make([]compute.VirtualMachine, 2)
+	for i := 0; i < 2; i++ {
+		vms[i] = compute.VirtualMachine{
+			ID:    to.StringPtr(fmt.Sprintf("vm/vmssName_%d", i)),
+			Name:  to.StringPtr(fmt.Sprintf("vmssName_%d", i)),
+			Zones: to.StringSlicePtr([]string{fmt.Sprintf("zone%d", i)}),
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				ProvisioningState: to.StringPtr(string(compute.ProvisioningState1Succeeded)),
+				OsProfile: &compute.OSProfile{
+					ComputerName: to.StringPtr(fmt.Sprintf("vmssName00000%d", i)),
+				},
+				InstanceView: &compute.VirtualMachineInstanceView{
+					Statuses: &[]compute.InstanceViewStatus{
+						{Code: to.StringPtr("PowerState/running")},
+					},
+				},
+			},
+		}
+	}
+
+	expected := azure.VMSS{
+		ID:        "vmssID",
+		Name:      "vmssName",
+		Sku:       "skuName",
+		Capacity:  2,
+		State:     "Succeeded",
+		Instances: make([]azure.VMSSVM, 2),
+	}
+	for i := 0; i < 2; i++ {
+		expected.Instances[i] = azure.VMSSVM{
+			ID:               fmt.Sprintf("vm/vmssName_%d", i),
+			InstanceID:       fmt.Sprintf("vmssName_%d", i),
+			Name:             fmt.Sprintf("vmssName00000%d", i),
+			AvailabilityZone: fmt.Sprintf("zone%d", i),
+			State:            "Succeeded",
+			PowerState:       "running",
+		}
+	}
+
+	g.Expect(converters.SDKToFlexVMSS(vmss, vms)).To(gomega.Equal(&expected))
+}
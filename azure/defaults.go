@@ -90,6 +90,8 @@ const (
 	// azureBuiltInContributorID the ID of the Contributor role in Azure
 	// Ref: https://docs.microsoft.com/en-us/azure/role-based-access-control/built-in-roles
 	azureBuiltInContributorID = "b24988ac-6180-42a0-ab88-20f7382dd24c"
+	// PrivateDNSZoneContributorRoleID is the ID of the Private DNS Zone Contributor role in Azure.
+	PrivateDNSZoneContributorRoleID = "b12aa53e-6015-4669-85d0-8515ebb3ae7f"
 )
 
 const (
@@ -120,7 +122,12 @@ func GenerateSubscriptionScope(subscriptionID string) string {
 
 // GenerateContributorRoleDefinitionID generates the contributor role definition ID.
 func GenerateContributorRoleDefinitionID(subscriptionID string) string {
-	return fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Authorization/roleDefinitions/%s", subscriptionID, azureBuiltInContributorID)
+	return GenerateRoleDefinitionID(subscriptionID, azureBuiltInContributorID)
+}
+
+// GenerateRoleDefinitionID generates the ID of the role definition with the given name.
+func GenerateRoleDefinitionID(subscriptionID, roleDefinitionName string) string {
+	return fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Authorization/roleDefinitions/%s", subscriptionID, roleDefinitionName)
 }
 
 // GenerateOutboundBackendAddressPoolName generates a load balancer outbound backend address pool name.
@@ -231,6 +238,11 @@ func SubnetID(subscriptionID, resourceGroup, vnetName, subnetName string) string
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s/subnets/%s", subscriptionID, resourceGroup, vnetName, subnetName)
 }
 
+// PrivateDNSZoneID returns the azure resource ID for a given private DNS zone.
+func PrivateDNSZoneID(subscriptionID, resourceGroup, zoneName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateDnsZones/%s", subscriptionID, resourceGroup, zoneName)
+}
+
 // PublicIPID returns the azure resource ID for a given public IP.
 func PublicIPID(subscriptionID, resourceGroup, ipName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPAddresses/%s", subscriptionID, resourceGroup, ipName)
@@ -316,6 +328,35 @@ func GetBootstrappingVMExtension(osType string, cloud string, vmName string) *Ex
 	return nil
 }
 
+// GetApplicationHealthExtension returns the application health extension, which reports the health of the instances
+// of a scale set by probing the given endpoint of the instances.
+func GetApplicationHealthExtension(osType string, vmName string, protocol string, port int32, requestPath string) *ExtensionSpec {
+	name := "ApplicationHealthLinux"
+	if osType == WindowsOS {
+		name = "ApplicationHealthWindows"
+	}
+
+	if protocol == "" {
+		protocol = "http"
+	}
+
+	settings := map[string]interface{}{
+		"protocol": protocol,
+		"port":     port,
+	}
+	if requestPath != "" {
+		settings["requestPath"] = requestPath
+	}
+
+	return &ExtensionSpec{
+		Name:      name,
+		VMName:    vmName,
+		Publisher: "Microsoft.ManagedServices",
+		Version:   "1.0",
+		Settings:  settings,
+	}
+}
+
 // UserAgent specifies a string to append to the agent identifier.
 func UserAgent() string {
 	return fmt.Sprintf("cluster-api-provider-azure/%s", version.Get().String())
@@ -28,6 +28,16 @@ import (
 
 const codeResourceGroupNotFound = "ResourceGroupNotFound"
 
+// capacityErrorCodes are the error codes returned by Azure when there is not enough capacity
+// to allocate the requested VM size in a region or zone.
+var capacityErrorCodes = map[string]struct{}{
+	"AllocationFailed":                      {},
+	"ZonalAllocationFailed":                 {},
+	"OverconstrainedAllocationRequest":      {},
+	"OverconstrainedZonalAllocationRequest": {},
+	"SkuNotAvailable":                       {},
+}
+
 // ResourceGroupNotFound parses the error to check if it's a resource group not found error.
 func ResourceGroupNotFound(err error) bool {
 	derr := autorest.DetailedError{}
@@ -47,6 +57,39 @@ func ResourceConflict(err error) bool {
 	return errors.As(err, &derr) && derr.StatusCode == 409
 }
 
+// IsCapacityError parses the error to check if Azure could not allocate the requested capacity,
+// either when starting an operation or as the result of a long running operation.
+func IsCapacityError(err error) bool {
+	serr := serviceError(err)
+	if serr == nil {
+		return false
+	}
+	if _, ok := capacityErrorCodes[serr.Code]; ok {
+		return true
+	}
+	for _, detail := range serr.Details {
+		if code, ok := detail["code"].(string); ok {
+			if _, ok := capacityErrorCodes[code]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serviceError returns the Azure service error wrapped in err, if any.
+func serviceError(err error) *azure.ServiceError {
+	serr := &azure.ServiceError{}
+	if errors.As(err, &serr) {
+		return serr
+	}
+	rerr := &azure.RequestError{}
+	if errors.As(err, &rerr) {
+		return rerr.ServiceError
+	}
+	return nil
+}
+
 // VMDeletedError is returned when a virtual machine is deleted outside of capz.
 type VMDeletedError struct {
 	ProviderID string
@@ -57,6 +100,16 @@ func (vde VMDeletedError) Error() string {
 	return fmt.Sprintf("VM with provider id %q has been deleted", vde.ProviderID)
 }
 
+// VMEvictedError is returned when a Spot virtual machine has been evicted by Azure.
+type VMEvictedError struct {
+	ProviderID string
+}
+
+// Error returns the error string.
+func (vee VMEvictedError) Error() string {
+	return fmt.Sprintf("Spot VM with provider id %q has been evicted", vee.ProviderID)
+}
+
 // ReconcileError represents an error that is not automatically recoverable
 // errorType indicates what type of action is required to recover. It can take two values:
 // 1. `Transient` - Can be recovered through manual intervention, will be requeued after.
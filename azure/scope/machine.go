@@ -27,6 +27,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
@@ -164,6 +165,8 @@ func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 		UserAssignedIdentities: m.AzureMachine.Spec.UserAssignedIdentities,
 		SpotVMOptions:          m.AzureMachine.Spec.SpotVMOptions,
 		SecurityProfile:        m.AzureMachine.Spec.SecurityProfile,
+		DedicatedHost:          m.AzureMachine.Spec.DedicatedHost,
+		Diagnostics:            m.AzureMachine.Spec.Diagnostics,
 		AdditionalTags:         m.AdditionalTags(),
 		AdditionalCapabilities: m.AzureMachine.Spec.AdditionalCapabilities,
 		ProviderID:             m.ProviderID(),
@@ -451,6 +454,11 @@ func (m *MachineScope) AvailabilitySet() (string, bool) {
 		return "", false
 	}
 
+	// VMs placed on dedicated hosts cannot be part of an availability set.
+	if m.AzureMachine != nil && m.AzureMachine.Spec.DedicatedHost != nil {
+		return "", false
+	}
+
 	if m.IsControlPlane() {
 		return azure.GenerateAvailabilitySetName(m.ClusterName(), azure.ControlPlaneNodeGroup), true
 	}
@@ -731,3 +739,37 @@ func (m *MachineScope) UpdatePatchStatus(condition clusterv1.ConditionType, serv
 		conditions.MarkFalse(m.AzureMachine, condition, infrav1.FailedReason, clusterv1.ConditionSeverityError, "%s failed to update. err: %s", service, err.Error())
 	}
 }
+
+// ReconcileScheduledEvents drains the node of the Machine ahead of the disruptive Azure Scheduled Events reported in
+// its annotations, and uncordons it once they are over. It returns the IDs of the events approved once the node was
+// drained, if any.
+func (m *MachineScope) ReconcileScheduledEvents(ctx context.Context) ([]string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachineScope.ReconcileScheduledEvents")
+	defer done()
+
+	nodeRef := m.Machine.Status.NodeRef
+	if nodeRef == nil || nodeRef.Name == "" {
+		return nil, nil
+	}
+
+	cluster := client.ObjectKey{
+		Name:      m.ClusterName(),
+		Namespace: m.Namespace(),
+	}
+	workloadClient, err := getWorkloadClient(ctx, m.client, cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the workload cluster client")
+	}
+
+	node := &corev1.Node{}
+	if err := workloadClient.Get(ctx, client.ObjectKey{Name: nodeRef.Name}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get node %s", nodeRef.Name)
+	}
+
+	return reconcileScheduledEvents(ctx, workloadClient, node, func(ctx context.Context, node *corev1.Node) error {
+		return drainWorkloadNode(ctx, m.client, cluster, node, nil)
+	})
+}
@@ -1158,6 +1158,37 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 			wantAvailabilitySetName:      "cluster_foo-machine-deployment-as",
 			wantAvailabilitySetExistence: true,
 		},
+		{
+			name: "returns empty and false if AvailabilitySet is enabled but machine is placed on a dedicated host",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Status: infrav1.AzureClusterStatus{},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineDeploymentLabelName: "foo-machine-deployment",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						DedicatedHost: &infrav1.DedicatedHost{
+							HostGroupID: "fake-host-group-id",
+						},
+					},
+				},
+			},
+			wantAvailabilitySetName:      "",
+			wantAvailabilitySetExistence: false,
+		},
 		{
 			name: "returns empty and false if AvailabilitySet is enabled but worker machine is not part of machine deployment or machine set",
 			machineScope: MachineScope{
@@ -19,6 +19,8 @@ package scope
 import (
 	"context"
 	"encoding/base64"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
@@ -108,9 +110,17 @@ func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, erro
 
 // ScaleSetSpec returns the scale set spec.
 func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
+	spotVMOptions := m.AzureMachinePool.Spec.Template.SpotVMOptions
+	if m.AzureMachinePool.Status.SpotFallbackActive {
+		// Spot capacity could not be allocated while the scale set had no allocated instances, so it was recreated with
+		// on-demand VMs. It keeps using on-demand VMs until it is scaled to zero and recreated with Spot VMs.
+		spotVMOptions = nil
+	}
+
 	return azure.ScaleSetSpec{
 		Name:                         m.Name(),
-		Size:                         m.AzureMachinePool.Spec.Template.VMSize,
+		Size:                         m.VMSize(),
+		FallbackSizes:                m.fallbackVMSizes(),
 		Capacity:                     int64(to.Int32(m.MachinePool.Spec.Replicas)),
 		SSHKeyData:                   m.AzureMachinePool.Spec.Template.SSHPublicKey,
 		OSDisk:                       m.AzureMachinePool.Spec.Template.OSDisk,
@@ -124,10 +134,79 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		Identity:                     m.AzureMachinePool.Spec.Identity,
 		UserAssignedIdentities:       m.AzureMachinePool.Spec.UserAssignedIdentities,
 		SecurityProfile:              m.AzureMachinePool.Spec.Template.SecurityProfile,
-		SpotVMOptions:                m.AzureMachinePool.Spec.Template.SpotVMOptions,
+		SpotVMOptions:                spotVMOptions,
+		SpotFallbackToOnDemand:       m.AzureMachinePool.Spec.SpotFallbackPolicy == infrav1exp.OnDemandSpotFallbackPolicyType,
+		SpotFallbackActive:           m.AzureMachinePool.Status.SpotFallbackActive,
 		FailureDomains:               m.MachinePool.Spec.FailureDomains,
 		TerminateNotificationTimeout: m.AzureMachinePool.Spec.Template.TerminateNotificationTimeout,
+		DedicatedHost:                m.AzureMachinePool.Spec.Template.DedicatedHost,
+		Diagnostics:                  m.AzureMachinePool.Spec.Template.Diagnostics,
+		OrchestrationMode:            m.AzureMachinePool.Spec.OrchestrationMode,
+		AutomaticRepairsGracePeriod:  m.automaticRepairsGracePeriod(),
+		ZoneBalance:                  m.AzureMachinePool.Spec.ZoneBalance,
+	}
+}
+
+// VMSize returns the VM size of the scale set: the fallback VM size recorded in the status as long as the fallback is
+// active, or the VM size of the template.
+func (m *MachinePoolScope) VMSize() string {
+	if fallback := m.vmSizeFallback(); fallback != nil {
+		return fallback.VMSize
+	}
+	return m.AzureMachinePool.Spec.Template.VMSize
+}
+
+// vmSizeFallback returns the fallback VM size recorded in the status, or nil if there is none or if it no longer applies:
+// the VM size of the template changed, or the fallback VM size was removed from the fallback VM sizes of the template.
+func (m *MachinePoolScope) vmSizeFallback() *infrav1exp.AzureMachinePoolVMSizeFallback {
+	template := m.AzureMachinePool.Spec.Template
+	fallback := m.AzureMachinePool.Status.VMSizeFallback
+	if fallback == nil || fallback.PrimaryVMSize != template.VMSize {
+		return nil
+	}
+	for _, size := range template.FallbackVMSizes {
+		if size == fallback.VMSize {
+			return fallback
+		}
+	}
+	return nil
+}
+
+// fallbackVMSizes returns the fallback VM sizes of the template which come after the VM size of the scale set.
+func (m *MachinePoolScope) fallbackVMSizes() []string {
+	template := m.AzureMachinePool.Spec.Template
+	size := m.VMSize()
+	for i, fallback := range template.FallbackVMSizes {
+		if fallback == size {
+			return template.FallbackVMSizes[i+1:]
+		}
+	}
+	return template.FallbackVMSizes
+}
+
+// SetVMSizeFallback records the VM size the scale set falls back to because Azure could not allocate enough capacity.
+func (m *MachinePoolScope) SetVMSizeFallback(size string) {
+	m.AzureMachinePool.Status.VMSizeFallback = &infrav1exp.AzureMachinePoolVMSizeFallback{
+		VMSize:        size,
+		PrimaryVMSize: m.AzureMachinePool.Spec.Template.VMSize,
+		FallbackTime:  metav1.Now(),
+	}
+}
+
+// automaticRepairsGracePeriod returns the grace period of the automatic repairs of the scale set, or nil if automatic
+// repairs are not enabled.
+func (m *MachinePoolScope) automaticRepairsGracePeriod() *time.Duration {
+	repairPolicy := m.AzureMachinePool.Spec.RepairPolicy
+	if repairPolicy == nil || repairPolicy.AutomaticRepairs == nil {
+		return nil
+	}
+
+	gracePeriod := 30 * time.Minute
+	if repairPolicy.AutomaticRepairs.GracePeriod != nil {
+		gracePeriod = repairPolicy.AutomaticRepairs.GracePeriod.Duration
 	}
+
+	return &gracePeriod
 }
 
 // Name returns the Azure Machine Pool Name.
@@ -164,6 +243,32 @@ func (m *MachinePoolScope) ProvisioningState() infrav1.ProvisioningState {
 // SetVMSSState updates the machine pool scope with the current state of the VMSS.
 func (m *MachinePoolScope) SetVMSSState(vmssState *azure.VMSS) {
 	m.vmssState = vmssState
+
+	if vmssState == nil || m.AzureMachinePool.Status.VMSizeFallback == nil {
+		return
+	}
+
+	switch {
+	case m.DesiredReplicas() == 0 && len(vmssState.Instances) == 0:
+		// The VM size of the template is only tried again once the MachinePool is scaled to zero, so that returning to
+		// it does not replace any instance.
+		m.AzureMachinePool.Status.VMSizeFallback = nil
+	case m.vmSizeFallback() == nil && vmssState.Sku == m.AzureMachinePool.Spec.Template.VMSize:
+		// Once the fallback no longer applies and the scale set is back to the VM size of the template, the fallback is
+		// cleared from the status.
+		m.AzureMachinePool.Status.VMSizeFallback = nil
+	}
+}
+
+// SetSpotFallbackActive records whether the scale set falls back to on-demand VMs because Spot capacity could not be allocated.
+func (m *MachinePoolScope) SetSpotFallbackActive(active bool) {
+	m.AzureMachinePool.Status.SpotFallbackActive = active
+}
+
+// SetNodeCapacity sets the capacity and node info the cluster autoscaler uses to scale the MachinePool from zero.
+func (m *MachinePoolScope) SetNodeCapacity(capacity corev1.ResourceList, nodeInfo *infrav1.NodeInfo) {
+	m.AzureMachinePool.Status.Capacity = capacity
+	m.AzureMachinePool.Status.NodeInfo = nodeInfo
 }
 
 // NeedsRequeue return true if any machines are not on the latest model or the VMSS is not in a terminal provisioning
@@ -222,10 +327,47 @@ func (m *MachinePoolScope) updateReplicasAndProviderIDs(ctx context.Context) err
 	}
 
 	m.AzureMachinePool.Status.Replicas = readyReplicas
+	m.AzureMachinePool.Status.Zones = zoneStatuses(machines)
 	m.AzureMachinePool.Spec.ProviderIDList = providerIDs
 	return nil
 }
 
+// zoneStatuses counts the machines of each availability zone, sorted by zone. Machines without a zone are not counted.
+func zoneStatuses(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolZoneStatus {
+	statusesByZone := make(map[string]*infrav1exp.AzureMachinePoolZoneStatus)
+	for _, machine := range machines {
+		zone := machine.Status.AvailabilityZone
+		if zone == "" {
+			continue
+		}
+
+		status, ok := statusesByZone[zone]
+		if !ok {
+			status = &infrav1exp.AzureMachinePoolZoneStatus{Zone: zone}
+			statusesByZone[zone] = status
+		}
+
+		status.Replicas++
+		if machine.Status.Ready {
+			status.ReadyReplicas++
+		}
+	}
+
+	if len(statusesByZone) == 0 {
+		return nil
+	}
+
+	statuses := make([]infrav1exp.AzureMachinePoolZoneStatus, 0, len(statusesByZone))
+	for _, status := range statusesByZone {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Zone < statuses[j].Zone
+	})
+
+	return statuses
+}
+
 func (m *MachinePoolScope) getMachinePoolMachines(ctx context.Context) ([]infrav1exp.AzureMachinePoolMachine, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.MachinePoolScope.getMachinePoolMachines")
 	defer done()
@@ -291,6 +433,14 @@ func (m *MachinePoolScope) applyAzureMachinePoolMachines(ctx context.Context) er
 		}
 	}
 
+	if err := m.updateDeploymentProgress(existingMachinesByProviderID); err != nil {
+		return errors.Wrap(err, "failed to update the progress of the deployment")
+	}
+
+	if err := m.updateRolloutPausedCondition(existingMachinesByProviderID); err != nil {
+		return errors.Wrap(err, "failed to update the rollout paused condition")
+	}
+
 	if deleted {
 		log.V(4).Info("exiting early due to finding AzureMachinePoolMachine(s) that were deleted because they no longer exist in the VMSS")
 		// exit early to be less greedy about delete
@@ -327,6 +477,50 @@ func (m *MachinePoolScope) applyAzureMachinePoolMachines(ctx context.Context) er
 	return nil
 }
 
+// updateDeploymentProgress records the progress of the rollout of the latest model in the AzureMachinePool status.
+func (m *MachinePoolScope) updateDeploymentProgress(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) error {
+	reporter, ok := m.getDeploymentStrategy().(machinepool.ProgressReporter)
+	if !ok {
+		return nil
+	}
+
+	progress, err := reporter.Progress(m.DesiredReplicas(), machinesByProviderID)
+	if err != nil {
+		return err
+	}
+
+	m.AzureMachinePool.Status.Deployment = progress
+	return nil
+}
+
+// updateRolloutPausedCondition records whether the rollout of the latest model is paused because the machines based
+// on it are unhealthy.
+func (m *MachinePoolScope) updateRolloutPausedCondition(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) error {
+	checker, ok := m.getDeploymentStrategy().(machinepool.RolloutHealthChecker)
+	if !ok {
+		conditions.Delete(m.AzureMachinePool, infrav1.RolloutPausedCondition)
+		return nil
+	}
+
+	health, err := checker.RolloutHealth(machinesByProviderID)
+	if err != nil {
+		return err
+	}
+
+	if !health.Paused {
+		conditions.Delete(m.AzureMachinePool, infrav1.RolloutPausedCondition)
+		return nil
+	}
+
+	conditions.Set(m.AzureMachinePool, &clusterv1.Condition{
+		Type:    infrav1.RolloutPausedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  health.Reason,
+		Message: health.Message,
+	})
+	return nil
+}
+
 func (m *MachinePoolScope) createMachine(ctx context.Context, machine azure.VMSSVM) error {
 	if machine.InstanceID == "" {
 		return errors.New("machine.InstanceID must not be empty")
@@ -336,9 +530,13 @@ func (m *MachinePoolScope) createMachine(ctx context.Context, machine azure.VMSS
 		return errors.New("machine.Name must not be empty")
 	}
 
+	// The instance ID of a VM in a Flexible scale set is the VM name, e.g. "my-vmss_1a2b3c4d", which must be turned into
+	// a valid object name.
+	name := strings.ToLower(strings.ReplaceAll(m.AzureMachinePool.Name+"-"+machine.InstanceID, "_", "-"))
+
 	ampm := infrav1exp.AzureMachinePoolMachine{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      m.AzureMachinePool.Name + "-" + machine.InstanceID,
+			Name:      name,
 			Namespace: m.AzureMachinePool.Namespace,
 			OwnerReferences: []metav1.OwnerReference{
 				{
@@ -501,6 +699,7 @@ func (m *MachinePoolScope) PatchObject(ctx context.Context) error {
 			infrav1.ScaleSetDesiredReplicasCondition,
 			infrav1.ScaleSetModelUpdatedCondition,
 			infrav1.ScaleSetRunningCondition,
+			infrav1.RolloutPausedCondition,
 		}})
 }
 
@@ -622,6 +821,15 @@ func (m *MachinePoolScope) VMSSExtensionSpecs() []azure.ResourceSpecGetter {
 		})
 	}
 
+	if repairPolicy := m.AzureMachinePool.Spec.RepairPolicy; repairPolicy != nil && repairPolicy.AutomaticRepairs != nil {
+		// automatic repairs replace the instances the application health extension reports as unhealthy
+		probe := repairPolicy.AutomaticRepairs.HealthProbe
+		extensionSpecs = append(extensionSpecs, &scalesets.VMSSExtensionSpec{
+			ExtensionSpec: *azure.GetApplicationHealthExtension(m.AzureMachinePool.Spec.Template.OSDisk.OSType, m.Name(), probe.Protocol, probe.Port, probe.RequestPath),
+			ResourceGroup: m.ResourceGroup(),
+		})
+	}
+
 	return extensionSpecs
 }
 
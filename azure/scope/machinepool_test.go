@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	autorestazure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
@@ -39,6 +40,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -98,6 +100,156 @@ func TestMachinePoolScope_Name(t *testing.T) {
 	}
 }
 
+func TestMachinePoolScope_VMSize(t *testing.T) {
+	fallback := func(size, primarySize string, age time.Duration) *infrav1exp.AzureMachinePoolVMSizeFallback {
+		return &infrav1exp.AzureMachinePoolVMSizeFallback{
+			VMSize:        size,
+			PrimaryVMSize: primarySize,
+			FallbackTime:  metav1.NewTime(time.Now().Add(-age)),
+		}
+	}
+
+	tests := []struct {
+		name                string
+		vmSizeFallback      *infrav1exp.AzureMachinePoolVMSizeFallback
+		wantVMSize          string
+		wantFallbackVMSizes []string
+	}{
+		{
+			name:                "without fallback",
+			wantVMSize:          "Standard_D2s_v3",
+			wantFallbackVMSizes: []string{"Standard_D2s_v4", "Standard_D2as_v4"},
+		},
+		{
+			name:                "with a fallback VM size",
+			vmSizeFallback:      fallback("Standard_D2s_v4", "Standard_D2s_v3", time.Minute),
+			wantVMSize:          "Standard_D2s_v4",
+			wantFallbackVMSizes: []string{"Standard_D2as_v4"},
+		},
+		{
+			name:                "with the last fallback VM size",
+			vmSizeFallback:      fallback("Standard_D2as_v4", "Standard_D2s_v3", time.Minute),
+			wantVMSize:          "Standard_D2as_v4",
+			wantFallbackVMSizes: []string{},
+		},
+		{
+			name:                "with a fallback VM size which was removed from the template",
+			vmSizeFallback:      fallback("Standard_D4s_v3", "Standard_D2s_v3", time.Minute),
+			wantVMSize:          "Standard_D2s_v3",
+			wantFallbackVMSizes: []string{"Standard_D2s_v4", "Standard_D2as_v4"},
+		},
+		{
+			name:                "with a fallback from a VM size which is no longer the VM size of the template",
+			vmSizeFallback:      fallback("Standard_D2s_v4", "Standard_D4s_v3", time.Minute),
+			wantVMSize:          "Standard_D2s_v3",
+			wantFallbackVMSizes: []string{"Standard_D2s_v4", "Standard_D2as_v4"},
+		},
+		{
+			name:                "with an old fallback VM size",
+			vmSizeFallback:      fallback("Standard_D2s_v4", "Standard_D2s_v3", 24*time.Hour),
+			wantVMSize:          "Standard_D2s_v4",
+			wantFallbackVMSizes: []string{"Standard_D2as_v4"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &MachinePoolScope{
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							VMSize:          "Standard_D2s_v3",
+							FallbackVMSizes: []string{"Standard_D2s_v4", "Standard_D2as_v4"},
+						},
+					},
+					Status: infrav1exp.AzureMachinePoolStatus{
+						VMSizeFallback: tt.vmSizeFallback,
+					},
+				},
+			}
+			g.Expect(s.VMSize()).To(Equal(tt.wantVMSize))
+			g.Expect(s.fallbackVMSizes()).To(Equal(tt.wantFallbackVMSizes))
+		})
+	}
+}
+
+func TestMachinePoolScope_SetVMSSState(t *testing.T) {
+	tests := []struct {
+		name               string
+		templateVMSize     string
+		replicas           int32
+		vmss               azure.VMSS
+		wantVMSizeFallback bool
+	}{
+		{
+			name:               "keeps the fallback while the MachinePool has replicas",
+			templateVMSize:     "Standard_D2s_v3",
+			replicas:           2,
+			vmss:               azure.VMSS{Sku: "Standard_D2s_v4", Instances: []azure.VMSSVM{{ID: "vm/0"}, {ID: "vm/1"}}},
+			wantVMSizeFallback: true,
+		},
+		{
+			name:               "keeps the fallback while the scale set still has instances",
+			templateVMSize:     "Standard_D2s_v3",
+			vmss:               azure.VMSS{Sku: "Standard_D2s_v4", Instances: []azure.VMSSVM{{ID: "vm/0"}}},
+			wantVMSizeFallback: true,
+		},
+		{
+			name:               "clears the fallback once the MachinePool is scaled to zero",
+			templateVMSize:     "Standard_D2s_v3",
+			vmss:               azure.VMSS{Sku: "Standard_D2s_v4"},
+			wantVMSizeFallback: false,
+		},
+		{
+			name:               "keeps a discarded fallback until the scale set returns to the VM size of the template",
+			templateVMSize:     "Standard_D4s_v3",
+			replicas:           1,
+			vmss:               azure.VMSS{Sku: "Standard_D2s_v4", Instances: []azure.VMSSVM{{ID: "vm/0"}}},
+			wantVMSizeFallback: true,
+		},
+		{
+			name:               "clears a discarded fallback once the scale set returned to the VM size of the template",
+			templateVMSize:     "Standard_D4s_v3",
+			replicas:           1,
+			vmss:               azure.VMSS{Sku: "Standard_D4s_v3", Instances: []azure.VMSSVM{{ID: "vm/0"}}},
+			wantVMSizeFallback: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &MachinePoolScope{
+				MachinePool: &expv1.MachinePool{
+					Spec: expv1.MachinePoolSpec{
+						Replicas: to.Int32Ptr(tt.replicas),
+					},
+				},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							VMSize:          tt.templateVMSize,
+							FallbackVMSizes: []string{"Standard_D2s_v4"},
+						},
+					},
+					Status: infrav1exp.AzureMachinePoolStatus{
+						VMSizeFallback: &infrav1exp.AzureMachinePoolVMSizeFallback{
+							VMSize:        "Standard_D2s_v4",
+							PrimaryVMSize: "Standard_D2s_v3",
+							FallbackTime:  metav1.NewTime(time.Now().Add(-time.Minute)),
+						},
+					},
+				},
+			}
+			s.SetVMSSState(&tt.vmss)
+			g.Expect(s.AzureMachinePool.Status.VMSizeFallback != nil).To(Equal(tt.wantVMSizeFallback))
+		})
+	}
+}
+
 func TestMachinePoolScope_SetBootstrapConditions(t *testing.T) {
 	cases := []struct {
 		Name   string
@@ -219,6 +371,19 @@ func TestMachinePoolScope_MaxSurge(t *testing.T) {
 				g.Expect(err).NotTo(HaveOccurred())
 			},
 		},
+		{
+			Name: "blue/green surge should be the desired replicas",
+			Setup: func(mp *expv1.MachinePool, amp *infrav1exp.AzureMachinePool) {
+				mp.Spec.Replicas = to.Int32Ptr(3)
+				amp.Spec.Strategy = infrav1exp.AzureMachinePoolDeploymentStrategy{
+					Type: infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType,
+				}
+			},
+			Verify: func(g *WithT, surge int, err error) {
+				g.Expect(surge).To(Equal(3))
+				g.Expect(err).NotTo(HaveOccurred())
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -558,6 +723,42 @@ func TestMachinePoolScope_updateReplicasAndProviderIDs(t *testing.T) {
 				g.Expect(amp.Spec.ProviderIDList).To(ConsistOf("/foo/ampm0", "/foo/ampm1", "/foo/ampm2"))
 			},
 		},
+		{
+			Name: "should count the machines of each zone",
+			Setup: func(cb *fake.ClientBuilder) {
+				machines := getReadyAzureMachinePoolMachines(4)
+				machines[0].Status.AvailabilityZone = "2"
+				machines[1].Status.AvailabilityZone = "1"
+				machines[2].Status.AvailabilityZone = "2"
+				machines[2].Status.Ready = false
+				machines[3].Status.AvailabilityZone = "2"
+				for _, machine := range machines {
+					obj := machine
+					cb.WithObjects(&obj)
+				}
+			},
+			Verify: func(g *WithT, amp *infrav1exp.AzureMachinePool, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(amp.Status.Replicas).To(BeEquivalentTo(3))
+				g.Expect(amp.Status.Zones).To(Equal([]infrav1exp.AzureMachinePoolZoneStatus{
+					{Zone: "1", Replicas: 1, ReadyReplicas: 1},
+					{Zone: "2", Replicas: 3, ReadyReplicas: 2},
+				}))
+			},
+		},
+		{
+			Name: "should not report zones for machines without a zone",
+			Setup: func(cb *fake.ClientBuilder) {
+				for _, machine := range getReadyAzureMachinePoolMachines(2) {
+					obj := machine
+					cb.WithObjects(&obj)
+				}
+			},
+			Verify: func(g *WithT, amp *infrav1exp.AzureMachinePool, err error) {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(amp.Status.Zones).To(BeNil())
+			},
+		},
 		{
 			Name: "should only count machines with matching machine pool label",
 			Setup: func(cb *fake.ClientBuilder) {
@@ -640,6 +841,72 @@ func TestMachinePoolScope_updateReplicasAndProviderIDs(t *testing.T) {
 	}
 }
 
+func TestMachinePoolScope_createMachine(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	cases := []struct {
+		Name         string
+		Instance     azure.VMSSVM
+		ExpectedName string
+	}{
+		{
+			Name: "should name the machine after the instance ID of a uniform scale set instance",
+			Instance: azure.VMSSVM{
+				ID:         "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachineScaleSets/amp1/virtualMachines/3",
+				InstanceID: "3",
+				Name:       "amp1000003",
+			},
+			ExpectedName: "amp1-3",
+		},
+		{
+			Name: "should turn the name of a flexible scale set VM into a valid object name",
+			Instance: azure.VMSSVM{
+				ID:         "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/amp1_1A2B3C4D",
+				InstanceID: "amp1_1A2B3C4D",
+				Name:       "amp11A2B3C",
+			},
+			ExpectedName: "amp1-amp1-1a2b3c4d",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var (
+				g       = NewWithT(t)
+				cluster = &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster1",
+						Namespace: "default",
+					},
+				}
+				amp = &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "amp1",
+						Namespace: "default",
+					},
+				}
+				c8s = fake.NewClientBuilder().WithScheme(scheme).Build()
+			)
+
+			s := &MachinePoolScope{
+				client: c8s,
+				ClusterScoper: &ClusterScope{
+					Cluster: cluster,
+				},
+				AzureMachinePool: amp,
+			}
+			g.Expect(s.createMachine(context.TODO(), c.Instance)).To(Succeed())
+
+			ampm := &infrav1exp.AzureMachinePoolMachine{}
+			g.Expect(c8s.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: c.ExpectedName}, ampm)).To(Succeed())
+			g.Expect(ampm.Spec.InstanceID).To(Equal(c.Instance.InstanceID))
+			g.Expect(ampm.Spec.ProviderID).To(Equal(c.Instance.ProviderID()))
+		})
+	}
+}
+
 func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -839,6 +1106,62 @@ func TestMachinePoolScope_VMSSExtensionSpecs(t *testing.T) {
 			},
 			want: []azure.ResourceSpecGetter{},
 		},
+		{
+			name: "If automatic repairs are enabled, it returns the application health ExtensionSpec",
+			machinePoolScope: MachinePoolScope{
+				MachinePool: &expv1.MachinePool{},
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machinepool-name",
+					},
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							OSDisk: infrav1.OSDisk{
+								OSType: "Linux",
+							},
+						},
+						RepairPolicy: &infrav1exp.AzureMachinePoolRepairPolicy{
+							AutomaticRepairs: &infrav1exp.AutomaticRepairs{
+								HealthProbe: infrav1exp.ApplicationHealthProbe{
+									Port:        10256,
+									RequestPath: "/healthz",
+								},
+							},
+						},
+					},
+				},
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Environment: autorestazure.Environment{
+								Name: autorestazure.USGovernmentCloud.Name,
+							},
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&scalesets.VMSSExtensionSpec{
+					ExtensionSpec: azure.ExtensionSpec{
+						Name:      "ApplicationHealthLinux",
+						VMName:    "machinepool-name",
+						Publisher: "Microsoft.ManagedServices",
+						Version:   "1.0",
+						Settings: map[string]interface{}{
+							"protocol":    "http",
+							"port":        int32(10256),
+							"requestPath": "/healthz",
+						},
+					},
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
 		{
 			name: "If OS type is not Windows or Linux and cloud is not AzurePublicCloud, it returns empty",
 			machinePoolScope: MachinePoolScope{
@@ -25,6 +25,9 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	kubedrain "k8s.io/kubectl/pkg/drain"
@@ -39,6 +42,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,8 +51,27 @@ import (
 const (
 	// MachinePoolMachineScopeName is the sourceName, or more specifically the UserAgent, of client used in cordon and drain.
 	MachinePoolMachineScopeName = "azuremachinepoolmachine-scope"
+
+	// defaultRepairGracePeriod is the time a new instance has for its node to join the cluster when the repair policy
+	// does not specify a grace period.
+	defaultRepairGracePeriod = 10 * time.Minute
 )
 
+// defaultUnhealthyConditions are the node conditions which make an instance unhealthy when the repair policy does not
+// specify any.
+var defaultUnhealthyConditions = []clusterv1.UnhealthyCondition{
+	{
+		Type:    corev1.NodeReady,
+		Status:  corev1.ConditionFalse,
+		Timeout: metav1.Duration{Duration: 5 * time.Minute},
+	},
+	{
+		Type:    corev1.NodeReady,
+		Status:  corev1.ConditionUnknown,
+		Timeout: metav1.Duration{Duration: 5 * time.Minute},
+	},
+}
+
 type (
 	nodeGetter interface {
 		GetNodeByProviderID(ctx context.Context, providerID string) (*corev1.Node, error)
@@ -158,11 +181,29 @@ func (s *MachinePoolMachineScope) InstanceID() string {
 	return s.AzureMachinePoolMachine.Spec.InstanceID
 }
 
+// OrchestrationMode is the orchestration mode of the VMSS.
+func (s *MachinePoolMachineScope) OrchestrationMode() infrav1.OrchestrationModeType {
+	return s.AzureMachinePool.Spec.OrchestrationMode
+}
+
 // ScaleSetName is the name of the VMSS.
 func (s *MachinePoolMachineScope) ScaleSetName() string {
 	return s.MachinePoolScope.Name()
 }
 
+// ProtectionPolicy returns the desired protection policy of the instance.
+func (s *MachinePoolMachineScope) ProtectionPolicy() azure.VMSSVMProtectionPolicy {
+	protectionPolicy := s.AzureMachinePoolMachine.Spec.ProtectionPolicy
+	if protectionPolicy == nil {
+		return azure.VMSSVMProtectionPolicy{}
+	}
+
+	return azure.VMSSVMProtectionPolicy{
+		ProtectFromScaleIn:         protectionPolicy.ProtectFromScaleIn,
+		ProtectFromScaleSetActions: protectionPolicy.ProtectFromScaleSetActions,
+	}
+}
+
 // SetLongRunningOperationState will set the future on the AzureMachinePoolMachine status to allow the resource to continue
 // in the next reconciliation.
 func (s *MachinePoolMachineScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -259,6 +300,7 @@ func (s *MachinePoolMachineScope) PatchObject(ctx context.Context) error {
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
 			clusterv1.MachineNodeHealthyCondition,
+			infrav1.VMRunningCondition,
 		}})
 }
 
@@ -343,11 +385,29 @@ func (s *MachinePoolMachineScope) UpdateInstanceStatus(ctx context.Context) erro
 		}
 
 		s.AzureMachinePoolMachine.Status.LatestModelApplied = hasLatestModel
+		s.AzureMachinePoolMachine.Status.AvailabilityZone = s.instance.AvailabilityZone
+		s.updateSpotEvictionStatus()
 	}
 
 	return nil
 }
 
+// updateSpotEvictionStatus reflects the eviction of a Spot VMSS instance in the VMRunning condition.
+func (s *MachinePoolMachineScope) updateSpotEvictionStatus() {
+	if s.AzureMachinePool.Spec.Template.SpotVMOptions == nil || s.AzureMachinePool.Status.SpotFallbackActive {
+		return
+	}
+
+	if s.instance.PowerState == azure.VMPowerStateDeallocated {
+		conditions.MarkFalse(s.AzureMachinePoolMachine, infrav1.VMRunningCondition, infrav1.SpotVMEvictedReason, clusterv1.ConditionSeverityWarning, "Spot VM instance %s has been evicted", s.instance.InstanceID)
+		return
+	}
+
+	if conditions.GetReason(s.AzureMachinePoolMachine, infrav1.VMRunningCondition) == infrav1.SpotVMEvictedReason {
+		conditions.MarkTrue(s.AzureMachinePoolMachine, infrav1.VMRunningCondition)
+	}
+}
+
 // CordonAndDrain will cordon and drain the Kubernetes node associated with this AzureMachinePoolMachine.
 func (s *MachinePoolMachineScope) CordonAndDrain(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(
@@ -405,6 +465,8 @@ func (s *MachinePoolMachineScope) CordonAndDrain(ctx context.Context) error {
 	return nil
 }
 
+// drainNode cordons and drains the node before the AzureMachinePoolMachine is deleted. The deletion goes ahead without
+// draining the node when no client can be created for the workload cluster.
 func (s *MachinePoolMachineScope) drainNode(ctx context.Context, node *corev1.Node) error {
 	ctx, log, done := tele.StartSpanWithLogger(
 		ctx,
@@ -412,22 +474,90 @@ func (s *MachinePoolMachineScope) drainNode(ctx context.Context, node *corev1.No
 	)
 	defer done()
 
-	restConfig, err := remote.RESTConfig(ctx, MachinePoolMachineScopeName, s.client, client.ObjectKey{
+	kubeClient, err := getWorkloadKubeClient(ctx, s.client, client.ObjectKey{
 		Name:      s.ClusterName(),
 		Namespace: s.AzureMachinePoolMachine.Namespace,
 	})
-
 	if err != nil {
 		log.Error(err, "Error creating a remote client while deleting Machine, won't retry")
 		return nil
 	}
 
+	return drainNode(ctx, kubeClient, node, s.configureDrainer)
+}
+
+// configureDrainer applies the node drain options of the AzureMachinePool to the drain helper.
+func (s *MachinePoolMachineScope) configureDrainer(drainer *kubedrain.Helper) error {
+	if s.AzureMachinePool == nil || s.AzureMachinePool.Spec.NodeDrainOptions == nil {
+		return nil
+	}
+
+	options := s.AzureMachinePool.Spec.NodeDrainOptions
+	if options.DeleteEmptyDirData != nil {
+		drainer.DeleteEmptyDirData = *options.DeleteEmptyDirData
+	}
+	if options.IgnoreDaemonSets != nil {
+		drainer.IgnoreAllDaemonSets = *options.IgnoreDaemonSets
+	}
+	if options.GracePeriodSeconds != nil {
+		drainer.GracePeriodSeconds = int(*options.GracePeriodSeconds)
+	}
+
+	if options.ExcludePodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(options.ExcludePodSelector)
+		if err != nil {
+			return azure.WithTerminalError(errors.Wrap(err, "invalid exclude pod selector"))
+		}
+		drainer.AdditionalFilters = append(drainer.AdditionalFilters, func(pod corev1.Pod) kubedrain.PodDeleteStatus {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				return kubedrain.MakePodDeleteStatusSkip()
+			}
+			return kubedrain.MakePodDeleteStatusOkay()
+		})
+	}
+
+	// Deleting the pods instead of evicting them bypasses their PodDisruptionBudgets.
+	if s.podDisruptionBudgetTimeoutExceeded() {
+		drainer.DisableEviction = true
+	}
+
+	return nil
+}
+
+// drainWorkloadNode cordons and drains a node of a workload cluster, failing when no client can be created for it. The
+// drain helper is customized by configure, if not nil.
+func drainWorkloadNode(ctx context.Context, c client.Client, cluster client.ObjectKey, node *corev1.Node, configure func(*kubedrain.Helper) error) error {
+	kubeClient, err := getWorkloadKubeClient(ctx, c, cluster)
+	if err != nil {
+		return err
+	}
+
+	return drainNode(ctx, kubeClient, node, configure)
+}
+
+// getWorkloadKubeClient creates a Kubernetes clientset for a workload cluster.
+func getWorkloadKubeClient(ctx context.Context, c client.Client, cluster client.ObjectKey) (kubernetes.Interface, error) {
+	restConfig, err := remote.RESTConfig(ctx, MachinePoolMachineScopeName, c, cluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the workload cluster REST config")
+	}
+
 	kubeClient, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		log.Error(err, "Error creating a remote client while deleting Machine, won't retry")
-		return nil
+		return nil, errors.Wrap(err, "failed to create the workload cluster client")
 	}
 
+	return kubeClient, nil
+}
+
+// drainNode cordons and drains a node of a workload cluster. The drain helper is customized by configure, if not nil.
+func drainNode(ctx context.Context, kubeClient kubernetes.Interface, node *corev1.Node, configure func(*kubedrain.Helper) error) error {
+	ctx, log, done := tele.StartSpanWithLogger(
+		ctx,
+		"scope.drainNode",
+	)
+	defer done()
+
 	drainer := &kubedrain.Helper{
 		Client:              kubeClient,
 		Ctx:                 ctx,
@@ -450,6 +580,12 @@ func (s *MachinePoolMachineScope) drainNode(ctx context.Context, node *corev1.No
 		ErrOut: writer{klog.Error},
 	}
 
+	if configure != nil {
+		if err := configure(drainer); err != nil {
+			return err
+		}
+	}
+
 	if noderefutil.IsNodeUnreachable(node) {
 		// When the node is unreachable and some pods are not evicted for as long as this timeout, we ignore them.
 		drainer.SkipWaitForDeleteTimeoutSeconds = 60 * 5 // 5 minutes
@@ -469,6 +605,42 @@ func (s *MachinePoolMachineScope) drainNode(ctx context.Context, node *corev1.No
 	return nil
 }
 
+// ReconcileScheduledEvents drains the node of the AzureMachinePoolMachine ahead of the disruptive Azure Scheduled Events
+// reported in its annotations, and uncordons it once they are over. It returns the IDs of the events approved once the
+// node was drained, if any.
+func (s *MachinePoolMachineScope) ReconcileScheduledEvents(ctx context.Context) ([]string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(
+		ctx,
+		"scope.MachinePoolMachineScope.ReconcileScheduledEvents",
+	)
+	defer done()
+
+	node, found, err := s.GetNode(ctx)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get node")
+	} else if !found {
+		return nil, nil
+	}
+
+	workloadClient, err := getWorkloadClient(ctx, s.client, client.ObjectKey{
+		Name:      s.ClusterName(),
+		Namespace: s.AzureMachinePoolMachine.Namespace,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the workload cluster client")
+	}
+
+	return reconcileScheduledEvents(ctx, workloadClient, node, func(ctx context.Context, node *corev1.Node) error {
+		return drainWorkloadNode(ctx, s.client, client.ObjectKey{
+			Name:      s.ClusterName(),
+			Namespace: s.AzureMachinePoolMachine.Namespace,
+		}, node, s.configureDrainer)
+	})
+}
+
 // isNodeDrainAllowed checks to see the node is excluded from draining or if the NodeDrainTimeout has expired.
 func (s *MachinePoolMachineScope) isNodeDrainAllowed() bool {
 	if _, exists := s.AzureMachinePoolMachine.ObjectMeta.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]; exists {
@@ -502,6 +674,141 @@ func (s *MachinePoolMachineScope) nodeDrainTimeoutExceeded() bool {
 	return diff.Seconds() >= s.AzureMachinePool.Spec.NodeDrainTimeout.Seconds()
 }
 
+// podDisruptionBudgetTimeoutExceeded checks if the PodDisruptionBudgetTimeout of the AzureMachinePool's node drain
+// options is exceeded for the AzureMachinePoolMachine.
+func (s *MachinePoolMachineScope) podDisruptionBudgetTimeoutExceeded() bool {
+	pool := s.AzureMachinePool
+	if pool == nil || pool.Spec.NodeDrainOptions == nil || pool.Spec.NodeDrainOptions.PodDisruptionBudgetTimeout == nil {
+		return false
+	}
+
+	// the DrainingSucceededCondition is only set once the node is being drained before deletion
+	if conditions.Get(s.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition) == nil {
+		return false
+	}
+
+	firstTimeDrain := conditions.GetLastTransitionTime(s.AzureMachinePoolMachine, clusterv1.DrainingSucceededCondition)
+	return time.Since(firstTimeDrain.Time) >= pool.Spec.NodeDrainOptions.PodDisruptionBudgetTimeout.Duration
+}
+
+// WaitForDeleteHook tells whether the deletion of the AzureMachinePoolMachine waits for the hooks annotated on it with
+// the given prefix to be removed, and reflects it in the given condition.
+func (s *MachinePoolMachineScope) WaitForDeleteHook(prefix string, condition clusterv1.ConditionType) bool {
+	if annotations.HasWithPrefix(prefix, s.AzureMachinePoolMachine.ObjectMeta.Annotations) {
+		conditions.MarkFalse(s.AzureMachinePoolMachine, condition, clusterv1.WaitingExternalHookReason, clusterv1.ConditionSeverityInfo, "")
+		return true
+	}
+
+	conditions.MarkTrue(s.AzureMachinePoolMachine, condition)
+	return false
+}
+
+// NeedsRepair tells whether the instance needs to be repaired according to the repair policy of the pool, and why. An
+// instance needs to be repaired when its node did not join the cluster within the grace period, or when its node has
+// one of the unhealthy conditions for longer than its timeout, unless the pool is already repairing as many instances
+// as it can at the same time.
+func (s *MachinePoolMachineScope) NeedsRepair(ctx context.Context) (bool, string, error) {
+	ctx, log, done := tele.StartSpanWithLogger(
+		ctx,
+		"scope.MachinePoolMachineScope.NeedsRepair",
+	)
+	defer done()
+
+	repairPolicy := s.AzureMachinePool.Spec.RepairPolicy
+	if repairPolicy == nil || !s.AzureMachinePoolMachine.DeletionTimestamp.IsZero() {
+		return false, "", nil
+	}
+
+	node, _, err := s.GetNode(ctx)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, "", errors.Wrap(err, "failed to get node")
+	}
+
+	reason := unhealthyReason(*repairPolicy, s.AzureMachinePoolMachine, node, time.Now())
+	if reason == "" {
+		return false, "", nil
+	}
+
+	machines, err := s.MachinePoolScope.getMachinePoolMachines(ctx)
+	if err != nil {
+		return false, "", err
+	}
+
+	repairing := 0
+	for _, machine := range machines {
+		if !machine.DeletionTimestamp.IsZero() {
+			repairing++
+		}
+	}
+
+	maxRepairs, err := maxConcurrentRepairs(*repairPolicy, int(s.MachinePoolScope.DesiredReplicas()))
+	if err != nil {
+		return false, "", err
+	}
+
+	if repairing >= maxRepairs {
+		log.V(4).Info("not repairing the unhealthy instance since too many instances are being repaired", "reason", reason, "repairing", repairing, "maxConcurrentRepairs", maxRepairs)
+		return false, "", nil
+	}
+
+	return true, reason, nil
+}
+
+// unhealthyReason describes why the instance of the machine is unhealthy according to the repair policy, or is empty
+// if the instance is healthy.
+func unhealthyReason(repairPolicy infrav1exp.AzureMachinePoolRepairPolicy, machine *infrav1exp.AzureMachinePoolMachine, node *corev1.Node, now time.Time) string {
+	if node == nil {
+		if machine.Status.NodeRef != nil {
+			return fmt.Sprintf("node %s no longer exists", machine.Status.NodeRef.Name)
+		}
+
+		gracePeriod := defaultRepairGracePeriod
+		if repairPolicy.GracePeriod != nil {
+			gracePeriod = repairPolicy.GracePeriod.Duration
+		}
+
+		if now.Sub(machine.CreationTimestamp.Time) > gracePeriod {
+			return fmt.Sprintf("node did not join the cluster within %s", gracePeriod)
+		}
+
+		return ""
+	}
+
+	unhealthyConditions := repairPolicy.UnhealthyConditions
+	if len(unhealthyConditions) == 0 {
+		unhealthyConditions = defaultUnhealthyConditions
+	}
+
+	for _, unhealthyCondition := range unhealthyConditions {
+		for _, nodeCondition := range node.Status.Conditions {
+			if nodeCondition.Type == unhealthyCondition.Type && nodeCondition.Status == unhealthyCondition.Status &&
+				now.Sub(nodeCondition.LastTransitionTime.Time) > unhealthyCondition.Timeout.Duration {
+				return fmt.Sprintf("node %s has condition %s %s for more than %s", node.Name, nodeCondition.Type, nodeCondition.Status, unhealthyCondition.Timeout.Duration)
+			}
+		}
+	}
+
+	return ""
+}
+
+// maxConcurrentRepairs calculates the number of instances of the pool which can be repaired at the same time.
+func maxConcurrentRepairs(repairPolicy infrav1exp.AzureMachinePoolRepairPolicy, desiredReplicaCount int) (int, error) {
+	if repairPolicy.MaxConcurrentRepairs == nil {
+		return 1, nil
+	}
+
+	maxRepairs, err := intstr.GetScaledValueFromIntOrPercent(repairPolicy.MaxConcurrentRepairs, desiredReplicaCount, false)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get scaled value or int from maxConcurrentRepairs")
+	}
+
+	if maxRepairs < 1 {
+		return 1, nil
+	}
+
+	return maxRepairs, nil
+}
+
 func (s *MachinePoolMachineScope) hasLatestModelApplied(ctx context.Context) (bool, error) {
 	ctx, _, done := tele.StartSpanWithLogger(
 		ctx,
@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
@@ -27,6 +28,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubedrain "k8s.io/kubectl/pkg/drain"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	mock_scope "sigs.k8s.io/cluster-api-provider-azure/azure/scope/mocks"
@@ -274,6 +278,81 @@ func TestMachineScope_UpdateNodeStatus(t *testing.T) {
 	}
 }
 
+func TestMachinePoolMachineScope_UpdateSpotEvictionStatus(t *testing.T) {
+	cases := []struct {
+		Name              string
+		SpotVMOptions     *infrav1.SpotVMOptions
+		FallbackActive    bool
+		PowerState        string
+		ExistingReason    string
+		ExpectCondition   *clusterv1.Condition
+		ExpectNoCondition bool
+	}{
+		{
+			Name:              "does nothing if the pool does not use spot VMs",
+			PowerState:        azure.VMPowerStateDeallocated,
+			ExpectNoCondition: true,
+		},
+		{
+			Name:              "does nothing if the pool fell back to on-demand VMs",
+			SpotVMOptions:     &infrav1.SpotVMOptions{},
+			FallbackActive:    true,
+			PowerState:        azure.VMPowerStateDeallocated,
+			ExpectNoCondition: true,
+		},
+		{
+			Name:            "marks an evicted spot instance as not running",
+			SpotVMOptions:   &infrav1.SpotVMOptions{},
+			PowerState:      azure.VMPowerStateDeallocated,
+			ExpectCondition: conditions.FalseCondition(infrav1.VMRunningCondition, infrav1.SpotVMEvictedReason, clusterv1.ConditionSeverityWarning, "Spot VM instance 0 has been evicted"),
+		},
+		{
+			Name:            "marks a previously evicted spot instance as running",
+			SpotVMOptions:   &infrav1.SpotVMOptions{},
+			PowerState:      "running",
+			ExistingReason:  infrav1.SpotVMEvictedReason,
+			ExpectCondition: conditions.TrueCondition(infrav1.VMRunningCondition),
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			ampm := &infrav1exp.AzureMachinePoolMachine{}
+			if c.ExistingReason != "" {
+				conditions.MarkFalse(ampm, infrav1.VMRunningCondition, c.ExistingReason, clusterv1.ConditionSeverityWarning, "")
+			}
+			s := &MachinePoolMachineScope{
+				AzureMachinePoolMachine: ampm,
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						Template: infrav1exp.AzureMachinePoolMachineTemplate{
+							SpotVMOptions: c.SpotVMOptions,
+						},
+					},
+					Status: infrav1exp.AzureMachinePoolStatus{
+						SpotFallbackActive: c.FallbackActive,
+					},
+				},
+				instance: &azure.VMSSVM{
+					InstanceID: "0",
+					PowerState: c.PowerState,
+				},
+			}
+
+			s.updateSpotEvictionStatus()
+
+			if c.ExpectNoCondition {
+				g.Expect(conditions.Has(ampm, infrav1.VMRunningCondition)).To(BeFalse())
+				return
+			}
+			assertCondition(t, ampm, c.ExpectCondition)
+			g.Expect(conditions.Get(ampm, infrav1.VMRunningCondition).Status).To(Equal(c.ExpectCondition.Status))
+		})
+	}
+}
+
 func TestMachinePoolMachineScope_CordonAndDrain(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = expv1.AddToScheme(scheme)
@@ -367,6 +446,310 @@ func TestMachinePoolMachineScope_CordonAndDrain(t *testing.T) {
 	}
 }
 
+func TestMachinePoolMachineScope_ConfigureDrainer(t *testing.T) {
+	defaultDrainer := kubedrain.Helper{
+		Force:               true,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+	}
+
+	cases := []struct {
+		Name                    string
+		Options                 *infrav1exp.NodeDrainOptions
+		DrainingFor             time.Duration
+		ExpectDrainer           kubedrain.Helper
+		ExpectSkippedPods       []string
+		ExpectNotSkippedPods    []string
+		ExpectErr               string
+		ExpectDisabledEvictions bool
+	}{
+		{
+			Name:          "keeps the default drain settings without options",
+			ExpectDrainer: defaultDrainer,
+		},
+		{
+			Name: "overrides the default drain settings",
+			Options: &infrav1exp.NodeDrainOptions{
+				DeleteEmptyDirData: to.BoolPtr(false),
+				IgnoreDaemonSets:   to.BoolPtr(false),
+				GracePeriodSeconds: to.Int32Ptr(30),
+			},
+			ExpectDrainer: kubedrain.Helper{
+				Force:              true,
+				GracePeriodSeconds: 30,
+			},
+		},
+		{
+			Name: "skips the pods matching the exclude pod selector",
+			Options: &infrav1exp.NodeDrainOptions{
+				ExcludePodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "stateful"}},
+			},
+			ExpectDrainer:        defaultDrainer,
+			ExpectSkippedPods:    []string{"stateful"},
+			ExpectNotSkippedPods: []string{"stateless"},
+		},
+		{
+			Name: "fails with an invalid exclude pod selector",
+			Options: &infrav1exp.NodeDrainOptions{
+				ExcludePodSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+				},
+			},
+			ExpectErr: "invalid exclude pod selector",
+		},
+		{
+			Name: "respects pod disruption budgets before the node is drained",
+			Options: &infrav1exp.NodeDrainOptions{
+				PodDisruptionBudgetTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			ExpectDrainer: defaultDrainer,
+		},
+		{
+			Name: "respects pod disruption budgets until the timeout",
+			Options: &infrav1exp.NodeDrainOptions{
+				PodDisruptionBudgetTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			DrainingFor:   5 * time.Minute,
+			ExpectDrainer: defaultDrainer,
+		},
+		{
+			Name: "disables evictions after the pod disruption budget timeout",
+			Options: &infrav1exp.NodeDrainOptions{
+				PodDisruptionBudgetTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			DrainingFor:             15 * time.Minute,
+			ExpectDrainer:           defaultDrainer,
+			ExpectDisabledEvictions: true,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			ampm := &infrav1exp.AzureMachinePoolMachine{}
+			if c.DrainingFor != 0 {
+				ampm.Status.Conditions = clusterv1.Conditions{
+					{
+						Type:               clusterv1.DrainingSucceededCondition,
+						Status:             corev1.ConditionFalse,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-c.DrainingFor)),
+					},
+				}
+			}
+			s := &MachinePoolMachineScope{
+				AzureMachinePoolMachine: ampm,
+				AzureMachinePool: &infrav1exp.AzureMachinePool{
+					Spec: infrav1exp.AzureMachinePoolSpec{
+						NodeDrainOptions: c.Options,
+					},
+				},
+			}
+
+			drainer := defaultDrainer
+			err := s.configureDrainer(&drainer)
+			if c.ExpectErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(c.ExpectErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(drainer.DisableEviction).To(Equal(c.ExpectDisabledEvictions))
+			g.Expect(drainer.DeleteEmptyDirData).To(Equal(c.ExpectDrainer.DeleteEmptyDirData))
+			g.Expect(drainer.IgnoreAllDaemonSets).To(Equal(c.ExpectDrainer.IgnoreAllDaemonSets))
+			g.Expect(drainer.GracePeriodSeconds).To(Equal(c.ExpectDrainer.GracePeriodSeconds))
+
+			skipped := func(app string) bool {
+				pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}}}
+				for _, filter := range drainer.AdditionalFilters {
+					if !filter(pod).Delete {
+						return true
+					}
+				}
+				return false
+			}
+			for _, app := range c.ExpectSkippedPods {
+				g.Expect(skipped(app)).To(BeTrue(), "pod %s should be skipped", app)
+			}
+			for _, app := range c.ExpectNotSkippedPods {
+				g.Expect(skipped(app)).To(BeFalse(), "pod %s should not be skipped", app)
+			}
+		})
+	}
+}
+
+func TestMachinePoolMachineScope_WaitForDeleteHook(t *testing.T) {
+	cases := []struct {
+		Name            string
+		Annotations     map[string]string
+		ExpectWait      bool
+		ExpectCondition *clusterv1.Condition
+	}{
+		{
+			Name:            "does not wait without hooks",
+			ExpectCondition: conditions.TrueCondition(clusterv1.PreDrainDeleteHookSucceededCondition),
+		},
+		{
+			Name: "does not wait for other hooks",
+			Annotations: map[string]string{
+				clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/volumes": "",
+			},
+			ExpectCondition: conditions.TrueCondition(clusterv1.PreDrainDeleteHookSucceededCondition),
+		},
+		{
+			Name: "waits for hooks with the prefix",
+			Annotations: map[string]string{
+				clusterv1.PreDrainDeleteHookAnnotationPrefix + "/backup": "",
+			},
+			ExpectWait:      true,
+			ExpectCondition: conditions.FalseCondition(clusterv1.PreDrainDeleteHookSucceededCondition, clusterv1.WaitingExternalHookReason, clusterv1.ConditionSeverityInfo, ""),
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			ampm := &infrav1exp.AzureMachinePoolMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: c.Annotations,
+				},
+			}
+			s := &MachinePoolMachineScope{
+				AzureMachinePoolMachine: ampm,
+			}
+
+			g.Expect(s.WaitForDeleteHook(clusterv1.PreDrainDeleteHookAnnotationPrefix, clusterv1.PreDrainDeleteHookSucceededCondition)).To(Equal(c.ExpectWait))
+			assertCondition(t, ampm, c.ExpectCondition)
+			g.Expect(conditions.Get(ampm, clusterv1.PreDrainDeleteHookSucceededCondition).Status).To(Equal(c.ExpectCondition.Status))
+		})
+	}
+}
+
+func TestMachinePoolMachineScope_unhealthyReason(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		Name         string
+		RepairPolicy infrav1exp.AzureMachinePoolRepairPolicy
+		Machine      *infrav1exp.AzureMachinePoolMachine
+		Node         *corev1.Node
+		Expect       string
+	}{
+		{
+			Name:    "a ready node is healthy",
+			Machine: &infrav1exp.AzureMachinePoolMachine{},
+			Node:    getReadyNode(),
+		},
+		{
+			Name:    "a node which is not ready for longer than the default timeout is unhealthy",
+			Machine: &infrav1exp.AzureMachinePoolMachine{},
+			Node:    getNotReadyNode(),
+			Expect:  "node node1 has condition Ready False for more than 5m0s",
+		},
+		{
+			Name:    "a node which just became not ready is healthy",
+			Machine: &infrav1exp.AzureMachinePoolMachine{},
+			Node: func() *corev1.Node {
+				node := getNotReadyNode()
+				node.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-time.Minute))
+				return node
+			}(),
+		},
+		{
+			Name: "a node with a custom unhealthy condition is unhealthy",
+			RepairPolicy: infrav1exp.AzureMachinePoolRepairPolicy{
+				UnhealthyConditions: []clusterv1.UnhealthyCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Timeout: metav1.Duration{}},
+				},
+			},
+			Machine: &infrav1exp.AzureMachinePoolMachine{},
+			Node:    getReadyNode(),
+			Expect:  "node node1 has condition Ready True for more than 0s",
+		},
+		{
+			Name: "a new instance without a node is healthy during the grace period",
+			Machine: &infrav1exp.AzureMachinePoolMachine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute))},
+			},
+		},
+		{
+			Name: "an instance without a node is unhealthy after the grace period",
+			RepairPolicy: infrav1exp.AzureMachinePoolRepairPolicy{
+				GracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+			Machine: &infrav1exp.AzureMachinePoolMachine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute))},
+			},
+			Expect: "node did not join the cluster within 1m0s",
+		},
+		{
+			Name: "an instance whose node was deleted is unhealthy",
+			Machine: &infrav1exp.AzureMachinePoolMachine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now)},
+				Status: infrav1exp.AzureMachinePoolMachineStatus{
+					NodeRef: &corev1.ObjectReference{Name: "node1"},
+				},
+			},
+			Expect: "node node1 no longer exists",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(unhealthyReason(c.RepairPolicy, c.Machine, c.Node, now)).To(Equal(c.Expect))
+		})
+	}
+}
+
+func TestMachinePoolMachineScope_maxConcurrentRepairs(t *testing.T) {
+	var (
+		two        = intstr.FromInt(2)
+		tenPercent = intstr.FromString("10%")
+	)
+	cases := []struct {
+		Name                 string
+		MaxConcurrentRepairs *intstr.IntOrString
+		DesiredReplicas      int
+		Expect               int
+	}{
+		{
+			Name:            "defaults to 1",
+			DesiredReplicas: 20,
+			Expect:          1,
+		},
+		{
+			Name:                 "uses an absolute number",
+			MaxConcurrentRepairs: &two,
+			DesiredReplicas:      20,
+			Expect:               2,
+		},
+		{
+			Name:                 "scales a percentage against the desired replicas",
+			MaxConcurrentRepairs: &tenPercent,
+			DesiredReplicas:      30,
+			Expect:               3,
+		},
+		{
+			Name:                 "repairs at least 1 instance",
+			MaxConcurrentRepairs: &tenPercent,
+			DesiredReplicas:      5,
+			Expect:               1,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := maxConcurrentRepairs(infrav1exp.AzureMachinePoolRepairPolicy{MaxConcurrentRepairs: c.MaxConcurrentRepairs}, c.DesiredReplicas)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(c.Expect))
+		})
+	}
+}
+
 func getReadyNode() *corev1.Node {
 	return &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
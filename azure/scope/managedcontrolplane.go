@@ -19,10 +19,16 @@ package scope
 import (
 	"context"
 	"encoding/json"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
 	corev1 "k8s.io/api/core/v1"
@@ -30,7 +36,10 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
@@ -44,6 +53,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// kubeconfigClientIDKey and kubeconfigClientSecretKey are the keys of the service principal secret of the kubeconfig.
+	kubeconfigClientIDKey     = "clientID"
+	kubeconfigClientSecretKey = "clientSecret"
+)
+
 // ManagedControlPlaneScopeParams defines the input parameters used to create a new managed
 // control plane.
 type ManagedControlPlaneScopeParams struct {
@@ -111,6 +126,8 @@ type ManagedControlPlaneScope struct {
 	kubeConfigData []byte
 	cache          *ManagedControlPlaneCache
 
+	kubeConfigTokenExpiry time.Time
+
 	AzureClients
 	Cluster             *clusterv1.Cluster
 	ControlPlane        *infrav1exp.AzureManagedControlPlane
@@ -195,8 +212,14 @@ func (s *ManagedControlPlaneScope) PatchObject(ctx context.Context) error {
 			infrav1.ResourceGroupReadyCondition,
 			infrav1.VNetReadyCondition,
 			infrav1.SubnetsReadyCondition,
+			infrav1.PrivateDNSZoneReadyCondition,
+			infrav1.PrivateDNSLinkReadyCondition,
+			infrav1.PrivateDNSRecordReadyCondition,
 			infrav1.ManagedClusterRunningCondition,
 			infrav1.AgentPoolsReadyCondition,
+			infrav1.MaintenanceConfigurationsReadyCondition,
+			infrav1.ManagedClusterImportedCondition,
+			infrav1.AgentPoolsUpgradedCondition,
 		}})
 }
 
@@ -211,7 +234,7 @@ func (s *ManagedControlPlaneScope) Close(ctx context.Context) error {
 // Vnet returns the cluster Vnet.
 func (s *ManagedControlPlaneScope) Vnet() *infrav1.VnetSpec {
 	return &infrav1.VnetSpec{
-		ResourceGroup: s.ControlPlane.Spec.ResourceGroupName,
+		ResourceGroup: virtualNetworkResourceGroup(s.ControlPlane),
 		Name:          s.ControlPlane.Spec.VirtualNetwork.Name,
 		VnetClassSpec: infrav1.VnetClassSpec{
 			CIDRBlocks: []string{s.ControlPlane.Spec.VirtualNetwork.CIDRBlock},
@@ -219,6 +242,15 @@ func (s *ManagedControlPlaneScope) Vnet() *infrav1.VnetSpec {
 	}
 }
 
+// virtualNetworkResourceGroup returns the resource group of the virtual network of the managed control plane,
+// which defaults to the resource group of the managed control plane.
+func virtualNetworkResourceGroup(controlPlane *infrav1exp.AzureManagedControlPlane) string {
+	if controlPlane.Spec.VirtualNetwork.ResourceGroup != "" {
+		return controlPlane.Spec.VirtualNetwork.ResourceGroup
+	}
+	return controlPlane.Spec.ResourceGroupName
+}
+
 // GroupSpec returns the resource group spec.
 func (s *ManagedControlPlaneScope) GroupSpec() azure.ResourceSpecGetter {
 	return &groups.GroupSpec{
@@ -257,18 +289,41 @@ func (s *ManagedControlPlaneScope) NodeNatGateway() infrav1.NatGateway {
 }
 
 // SubnetSpecs returns the subnets specs.
+// Node and pod subnets of the managed machine pools are created alongside the subnet of the control plane.
 func (s *ManagedControlPlaneScope) SubnetSpecs() []azure.ResourceSpecGetter {
-	return []azure.ResourceSpecGetter{
-		&subnets.SubnetSpec{
-			Name:              s.NodeSubnet().Name,
-			ResourceGroup:     s.ResourceGroup(),
-			SubscriptionID:    s.SubscriptionID(),
-			CIDRs:             s.NodeSubnet().CIDRBlocks,
-			VNetName:          s.Vnet().Name,
-			VNetResourceGroup: s.Vnet().ResourceGroup,
-			IsVNetManaged:     s.IsVnetManaged(),
-			Role:              infrav1.SubnetNode,
-		},
+	subnetSpecs := []azure.ResourceSpecGetter{
+		s.subnetSpec(s.ControlPlane.Spec.VirtualNetwork.Subnet),
+	}
+
+	names := map[string]struct{}{s.ControlPlane.Spec.VirtualNetwork.Subnet.Name: {}}
+	for _, pool := range s.ManagedMachinePools {
+		for _, subnet := range []*infrav1exp.ManagedControlPlaneSubnet{pool.InfraMachinePool.Spec.Subnet, pool.InfraMachinePool.Spec.PodSubnet} {
+			if subnet == nil {
+				continue
+			}
+			if _, ok := names[subnet.Name]; ok {
+				continue
+			}
+			names[subnet.Name] = struct{}{}
+			subnetSpecs = append(subnetSpecs, s.subnetSpec(*subnet))
+		}
+	}
+
+	return subnetSpecs
+}
+
+func (s *ManagedControlPlaneScope) subnetSpec(subnet infrav1exp.ManagedControlPlaneSubnet) azure.ResourceSpecGetter {
+	return &subnets.SubnetSpec{
+		Name:              subnet.Name,
+		ResourceGroup:     s.Vnet().ResourceGroup,
+		SubscriptionID:    s.SubscriptionID(),
+		CIDRs:             []string{subnet.CIDRBlock},
+		VNetName:          s.Vnet().Name,
+		VNetResourceGroup: s.Vnet().ResourceGroup,
+		IsVNetManaged:     s.IsVnetManaged(),
+		RouteTableName:    subnet.RouteTable,
+		Role:              infrav1.SubnetNode,
+		CreateIfMissing:   to.Bool(s.ControlPlane.Spec.VirtualNetwork.AllowSubnetCreation),
 	}
 }
 
@@ -372,10 +427,9 @@ func (s *ManagedControlPlaneScope) APIServerLBPoolName(_ string) string {
 	return "" // does not apply for AKS
 }
 
-// IsAPIServerPrivate returns true if the API Server LB is of type Internal.
-// Currently always false as managed control planes do not currently implement private clusters.
+// IsAPIServerPrivate returns true if the managed cluster is a private cluster.
 func (s *ManagedControlPlaneScope) IsAPIServerPrivate() bool {
-	return false
+	return s.ControlPlane.Spec.APIServerAccessProfile != nil && to.Bool(s.ControlPlane.Spec.APIServerAccessProfile.EnablePrivateCluster)
 }
 
 // OutboundLBName returns the name of the outbound LB.
@@ -389,12 +443,57 @@ func (s *ManagedControlPlaneScope) OutboundPoolName(_ string) string {
 	return "aksOutboundBackendPool" // hard-coded in aks
 }
 
-// GetPrivateDNSZoneName returns the Private DNS Zone from the spec or generate it from cluster name.
-// Currently always empty as managed control planes do not currently implement private clusters.
+// GetPrivateDNSZoneName returns the name of the private DNS zone managed by CAPZ, if any.
 func (s *ManagedControlPlaneScope) GetPrivateDNSZoneName() string {
+	if zone := s.managedPrivateDNSZone(); zone != nil {
+		return zone.Name
+	}
 	return ""
 }
 
+// managedPrivateDNSZone returns the private DNS zone of the private cluster managed by CAPZ, if any.
+func (s *ManagedControlPlaneScope) managedPrivateDNSZone() *infrav1exp.ManagedPrivateDNSZone {
+	if s.ControlPlane.Spec.APIServerAccessProfile == nil {
+		return nil
+	}
+	return s.ControlPlane.Spec.APIServerAccessProfile.ManagedPrivateDNSZone
+}
+
+// PrivateDNSSpec returns the spec of the private DNS zone managed by CAPZ and of its virtual network links.
+// AKS creates the records of the API server itself, so no records are returned.
+func (s *ManagedControlPlaneScope) PrivateDNSSpec() (zoneSpec azure.ResourceSpecGetter, linkSpec, recordSpec []azure.ResourceSpecGetter) {
+	zone := s.managedPrivateDNSZone()
+	if zone == nil {
+		return nil, nil, nil
+	}
+
+	links := make([]azure.ResourceSpecGetter, 0, len(zone.AdditionalVNetLinks))
+	for _, link := range zone.AdditionalVNetLinks {
+		vnet, err := autorestazure.ParseResourceID(link.VNetResourceID)
+		if err != nil {
+			// The webhook only admits valid resource IDs.
+			continue
+		}
+		links = append(links, privatedns.LinkSpec{
+			Name:              azure.GenerateVNetLinkName(vnet.ResourceName),
+			ZoneName:          zone.Name,
+			SubscriptionID:    vnet.SubscriptionID,
+			VNetResourceGroup: vnet.ResourceGroup,
+			VNetName:          vnet.ResourceName,
+			ResourceGroup:     s.ResourceGroup(),
+			ClusterName:       s.ClusterName(),
+			AdditionalTags:    s.AdditionalTags(),
+		})
+	}
+
+	return privatedns.ZoneSpec{
+		Name:           zone.Name,
+		ResourceGroup:  s.ResourceGroup(),
+		ClusterName:    s.ClusterName(),
+		AdditionalTags: s.AdditionalTags(),
+	}, links, []azure.ResourceSpecGetter{}
+}
+
 // CloudProviderConfigOverrides returns the cloud provider config overrides for the cluster.
 func (s *ManagedControlPlaneScope) CloudProviderConfigOverrides() *infrav1.CloudProviderConfigOverrides {
 	return nil
@@ -424,7 +523,7 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec(ctx context.Context) azure
 		DNSServiceIP:      s.ControlPlane.Spec.DNSServiceIP,
 		VnetSubnetID: azure.SubnetID(
 			s.ControlPlane.Spec.SubscriptionID,
-			s.ControlPlane.Spec.ResourceGroupName,
+			virtualNetworkResourceGroup(s.ControlPlane),
 			s.ControlPlane.Spec.VirtualNetwork.Name,
 			s.ControlPlane.Spec.VirtualNetwork.Subnet.Name,
 		),
@@ -440,6 +539,9 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec(ctx context.Context) azure
 	if s.ControlPlane.Spec.LoadBalancerSKU != nil {
 		managedClusterSpec.LoadBalancerSKU = *s.ControlPlane.Spec.LoadBalancerSKU
 	}
+	if s.ControlPlane.Spec.OutboundType != nil {
+		managedClusterSpec.OutboundType = string(*s.ControlPlane.Spec.OutboundType)
+	}
 
 	if clusterNetwork := s.Cluster.Spec.ClusterNetwork; clusterNetwork != nil {
 		if clusterNetwork.Services != nil && len(clusterNetwork.Services.CIDRBlocks) == 1 {
@@ -450,6 +552,13 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec(ctx context.Context) azure
 		}
 	}
 
+	if s.ControlPlane.Spec.ServiceCIDR != nil {
+		managedClusterSpec.ServiceCIDR = *s.ControlPlane.Spec.ServiceCIDR
+	}
+	if s.ControlPlane.Spec.PodCIDR != nil {
+		managedClusterSpec.PodCIDR = *s.ControlPlane.Spec.PodCIDR
+	}
+
 	if s.ControlPlane.Spec.AADProfile != nil {
 		managedClusterSpec.AADProfile = &managedclusters.AADProfile{
 			Managed:             s.ControlPlane.Spec.AADProfile.Managed,
@@ -467,6 +576,7 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec(ctx context.Context) azure
 			})
 		}
 	}
+	managedClusterSpec.AddonProfiles = append(managedClusterSpec.AddonProfiles, typedAddonProfiles(s.ControlPlane.Spec.Addons)...)
 
 	if s.ControlPlane.Spec.SKU != nil {
 		managedClusterSpec.SKU = &managedclusters.SKU{
@@ -484,6 +594,13 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec(ctx context.Context) azure
 		}
 	}
 
+	if s.ControlPlane.Spec.NatGatewayProfile != nil {
+		managedClusterSpec.NatGatewayProfile = &managedclusters.NatGatewayProfile{
+			ManagedOutboundIPs:   s.ControlPlane.Spec.NatGatewayProfile.ManagedOutboundIPs,
+			IdleTimeoutInMinutes: s.ControlPlane.Spec.NatGatewayProfile.IdleTimeoutInMinutes,
+		}
+	}
+
 	if s.ControlPlane.Spec.APIServerAccessProfile != nil {
 		managedClusterSpec.APIServerAccessProfile = &managedclusters.APIServerAccessProfile{
 			AuthorizedIPRanges:             s.ControlPlane.Spec.APIServerAccessProfile.AuthorizedIPRanges,
@@ -493,9 +610,244 @@ func (s *ManagedControlPlaneScope) ManagedClusterSpec(ctx context.Context) azure
 		}
 	}
 
+	if zoneName := s.GetPrivateDNSZoneName(); zoneName != "" {
+		managedClusterSpec.APIServerAccessProfile.PrivateDNSZone = to.StringPtr(azure.PrivateDNSZoneID(s.SubscriptionID(), s.ResourceGroup(), zoneName))
+	}
+
+	if s.ControlPlane.Spec.AutoScalerProfile != nil {
+		profile := s.ControlPlane.Spec.AutoScalerProfile
+		managedClusterSpec.AutoScalerProfile = &managedclusters.AutoScalerProfile{
+			BalanceSimilarNodeGroups:      profile.BalanceSimilarNodeGroups,
+			Expander:                      profile.Expander,
+			MaxEmptyBulkDelete:            profile.MaxEmptyBulkDelete,
+			MaxGracefulTerminationSec:     profile.MaxGracefulTerminationSec,
+			MaxNodeProvisionTime:          profile.MaxNodeProvisionTime,
+			MaxTotalUnreadyPercentage:     profile.MaxTotalUnreadyPercentage,
+			NewPodScaleUpDelay:            profile.NewPodScaleUpDelay,
+			OkTotalUnreadyCount:           profile.OkTotalUnreadyCount,
+			ScanInterval:                  profile.ScanInterval,
+			ScaleDownDelayAfterAdd:        profile.ScaleDownDelayAfterAdd,
+			ScaleDownDelayAfterDelete:     profile.ScaleDownDelayAfterDelete,
+			ScaleDownDelayAfterFailure:    profile.ScaleDownDelayAfterFailure,
+			ScaleDownUnneededTime:         profile.ScaleDownUnneededTime,
+			ScaleDownUnreadyTime:          profile.ScaleDownUnreadyTime,
+			ScaleDownUtilizationThreshold: profile.ScaleDownUtilizationThreshold,
+			SkipNodesWithLocalStorage:     profile.SkipNodesWithLocalStorage,
+			SkipNodesWithSystemPods:       profile.SkipNodesWithSystemPods,
+		}
+	}
+
+	if s.ControlPlane.Spec.OIDCIssuerProfile != nil {
+		managedClusterSpec.OIDCIssuerProfile = &managedclusters.OIDCIssuerProfile{
+			Enabled: s.ControlPlane.Spec.OIDCIssuerProfile.Enabled,
+		}
+	}
+
+	if s.ControlPlane.Spec.WorkloadIdentityProfile != nil {
+		managedClusterSpec.WorkloadIdentityProfile = &managedclusters.WorkloadIdentityProfile{
+			Enabled: s.ControlPlane.Spec.WorkloadIdentityProfile.Enabled,
+		}
+	}
+
+	if s.ControlPlane.Spec.DisableLocalAccounts != nil {
+		managedClusterSpec.DisableLocalAccounts = s.ControlPlane.Spec.DisableLocalAccounts
+	}
+
+	if s.ControlPlane.Spec.Identity != nil {
+		managedClusterSpec.Identity = &managedclusters.Identity{
+			ControlPlaneIdentityResourceID: to.String(s.ControlPlane.Spec.Identity.ControlPlaneIdentityResourceID),
+			KubeletIdentityResourceID:      to.String(s.ControlPlane.Spec.Identity.KubeletIdentityResourceID),
+		}
+	}
+
+	if s.ControlPlane.Spec.AutoUpgradeProfile != nil && s.ControlPlane.Spec.AutoUpgradeProfile.UpgradeChannel != nil {
+		managedClusterSpec.AutoUpgradeProfile = &managedclusters.AutoUpgradeProfile{
+			UpgradeChannel: string(*s.ControlPlane.Spec.AutoUpgradeProfile.UpgradeChannel),
+		}
+	}
+
 	return &managedClusterSpec
 }
 
+// typedAddonProfiles returns the profiles of the add-ons configured with typed settings.
+func typedAddonProfiles(addons *infrav1exp.ManagedControlPlaneAddons) []managedclusters.AddonProfile {
+	if addons == nil {
+		return nil
+	}
+
+	var profiles []managedclusters.AddonProfile
+	if monitoring := addons.Monitoring; monitoring != nil {
+		config := map[string]string{}
+		if monitoring.LogAnalyticsWorkspaceResourceID != "" {
+			config["logAnalyticsWorkspaceResourceID"] = monitoring.LogAnalyticsWorkspaceResourceID
+		}
+		profiles = append(profiles, addonProfile(infrav1exp.MonitoringAddonName, monitoring.Enabled, config))
+	}
+	if policy := addons.AzurePolicy; policy != nil {
+		profiles = append(profiles, addonProfile(infrav1exp.AzurePolicyAddonName, policy.Enabled, nil))
+	}
+	if secretsProvider := addons.KeyVaultSecretsProvider; secretsProvider != nil {
+		config := map[string]string{}
+		if secretsProvider.EnableSecretRotation != nil {
+			config["enableSecretRotation"] = strconv.FormatBool(*secretsProvider.EnableSecretRotation)
+		}
+		if secretsProvider.RotationPollInterval != nil {
+			config["rotationPollInterval"] = secretsProvider.RotationPollInterval.Duration.String()
+		}
+		profiles = append(profiles, addonProfile(infrav1exp.KeyVaultSecretsProviderAddonName, secretsProvider.Enabled, config))
+	}
+	if ingress := addons.IngressApplicationGateway; ingress != nil {
+		config := map[string]string{}
+		for key, value := range map[string]*string{
+			"applicationGatewayId":   ingress.ApplicationGatewayID,
+			"applicationGatewayName": ingress.ApplicationGatewayName,
+			"subnetCIDR":             ingress.SubnetCIDR,
+			"subnetId":               ingress.SubnetID,
+		} {
+			if value != nil {
+				config[key] = *value
+			}
+		}
+		profiles = append(profiles, addonProfile(infrav1exp.IngressApplicationGatewayAddonName, ingress.Enabled, config))
+	}
+	return profiles
+}
+
+// addonProfile returns the profile of an add-on, without config if it is empty.
+func addonProfile(name string, enabled bool, config map[string]string) managedclusters.AddonProfile {
+	profile := managedclusters.AddonProfile{
+		Name:    name,
+		Enabled: enabled,
+	}
+	if len(config) > 0 {
+		profile.Config = config
+	}
+	return profile
+}
+
+// SetAddonsStatus sets the observed state of the add-ons of the managed cluster.
+func (s *ManagedControlPlaneScope) SetAddonsStatus(addons []infrav1exp.AddonStatus) {
+	s.ControlPlane.Status.Addons = addons
+}
+
+// ManagedClusterName returns the name of the AKS cluster.
+func (s *ManagedControlPlaneScope) ManagedClusterName() string {
+	return s.ControlPlane.Name
+}
+
+// MaintenanceConfigurationSpecs returns the planned maintenance configuration specs of the managed cluster.
+func (s *ManagedControlPlaneScope) MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, 0, len(s.ControlPlane.Spec.MaintenanceConfigurations))
+	for _, config := range s.ControlPlane.Spec.MaintenanceConfigurations {
+		specs = append(specs, &maintenanceconfigurations.MaintenanceConfigurationSpec{
+			Name:           config.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			Cluster:        s.ManagedClusterName(),
+			TimeInWeek:     config.TimeInWeek,
+			NotAllowedTime: config.NotAllowedTime,
+		})
+	}
+	return specs
+}
+
+// OwnedMaintenanceConfigurations returns the names of the planned maintenance configurations created by CAPZ.
+func (s *ManagedControlPlaneScope) OwnedMaintenanceConfigurations() []string {
+	return s.ControlPlane.Status.MaintenanceConfigurations
+}
+
+// SetOwnedMaintenanceConfigurations sets the names of the planned maintenance configurations created by CAPZ.
+func (s *ManagedControlPlaneScope) SetOwnedMaintenanceConfigurations(names []string) {
+	s.ControlPlane.Status.MaintenanceConfigurations = names
+}
+
+// Name returns the name of the managed control plane.
+func (s *ManagedControlPlaneScope) Name() string {
+	return s.ControlPlane.Name
+}
+
+// HasSystemAssignedIdentity returns true if the control plane uses a system-assigned identity.
+func (s *ManagedControlPlaneScope) HasSystemAssignedIdentity() bool {
+	return s.ControlPlane.Spec.Identity == nil || s.ControlPlane.Spec.Identity.ControlPlaneIdentityResourceID == nil
+}
+
+// RoleAssignmentResourceType returns the role assignment resource type.
+func (s *ManagedControlPlaneScope) RoleAssignmentResourceType() string {
+	return azure.ManagedCluster
+}
+
+// RoleAssignmentSpecs returns the role assignment specs of the user-assigned identities of the managed cluster.
+// Their principal IDs are resolved by the role assignments service, so the principalID argument is ignored.
+func (s *ManagedControlPlaneScope) RoleAssignmentSpecs(_ *string) []azure.ResourceSpecGetter {
+	identity := s.ControlPlane.Spec.Identity
+	if identity == nil {
+		return []azure.ResourceSpecGetter{}
+	}
+
+	specs := make([]azure.ResourceSpecGetter, 0, len(identity.RoleAssignments))
+	for _, roleAssignment := range identity.RoleAssignments {
+		var identityID string
+		switch roleAssignment.Identity {
+		case infrav1exp.ManagedIdentityKindControlPlane:
+			identityID = to.String(identity.ControlPlaneIdentityResourceID)
+		case infrav1exp.ManagedIdentityKindKubelet:
+			identityID = to.String(identity.KubeletIdentityResourceID)
+		}
+		if identityID == "" {
+			continue
+		}
+
+		scope := roleAssignment.Scope
+		switch scope {
+		case infrav1exp.RoleAssignmentScopeVirtualNetwork:
+			scope = azure.VNetID(s.SubscriptionID(), s.Vnet().ResourceGroup, s.Vnet().Name)
+		case infrav1exp.RoleAssignmentScopeNodeResourceGroup:
+			// AKS creates the node resource group along with the cluster.
+			if !s.ControlPlane.Status.Initialized {
+				continue
+			}
+			scope = azure.ResourceGroupID(s.SubscriptionID(), s.ControlPlane.Spec.NodeResourceGroupName)
+		}
+
+		roleDefinitionID := roleAssignment.RoleDefinitionID
+		if !strings.HasPrefix(roleDefinitionID, "/") {
+			roleDefinitionID = azure.GenerateRoleDefinitionID(s.SubscriptionID(), roleDefinitionID)
+		}
+
+		specs = append(specs, s.roleAssignmentSpec(identityID, scope, roleDefinitionID))
+	}
+
+	// The control plane identity needs to manage the records of the private DNS zone managed by CAPZ.
+	if zoneName := s.GetPrivateDNSZoneName(); zoneName != "" && identity.ControlPlaneIdentityResourceID != nil {
+		specs = append(specs, s.roleAssignmentSpec(to.String(identity.ControlPlaneIdentityResourceID),
+			azure.PrivateDNSZoneID(s.SubscriptionID(), s.ResourceGroup(), zoneName),
+			azure.GenerateRoleDefinitionID(s.SubscriptionID(), azure.PrivateDNSZoneContributorRoleID)))
+	}
+	return specs
+}
+
+// OwnedRoleAssignments returns the resource IDs of the role assignments of the user-assigned identities created by CAPZ.
+func (s *ManagedControlPlaneScope) OwnedRoleAssignments() []string {
+	return s.ControlPlane.Status.RoleAssignments
+}
+
+// SetOwnedRoleAssignments sets the resource IDs of the role assignments of the user-assigned identities created by CAPZ.
+func (s *ManagedControlPlaneScope) SetOwnedRoleAssignments(ids []string) {
+	s.ControlPlane.Status.RoleAssignments = ids
+}
+
+// roleAssignmentSpec returns the spec of a role assignment of a user-assigned identity of the managed cluster.
+func (s *ManagedControlPlaneScope) roleAssignmentSpec(identityID, scope, roleDefinitionID string) *roleassignments.RoleAssignmentSpec {
+	return &roleassignments.RoleAssignmentSpec{
+		// Role assignment names are GUIDs, derived here from what the role assignment grants so that it is stable.
+		Name:                   uuid.NewSHA1(uuid.NameSpaceURL, []byte(identityID+scope+roleDefinitionID)).String(),
+		ResourceGroup:          s.ResourceGroup(),
+		ResourceType:           azure.ManagedCluster,
+		Scope:                  scope,
+		RoleDefinitionID:       roleDefinitionID,
+		UserAssignedIdentityID: identityID,
+	}
+}
+
 // GetAllAgentPoolSpecs gets a slice of azure.AgentPoolSpec for the list of agent pools.
 func (s *ManagedControlPlaneScope) GetAllAgentPoolSpecs() ([]azure.ResourceSpecGetter, error) {
 	var (
@@ -531,6 +883,11 @@ func (s *ManagedControlPlaneScope) SetControlPlaneEndpoint(endpoint clusterv1.AP
 	s.ControlPlane.Spec.ControlPlaneEndpoint = endpoint
 }
 
+// SetOIDCIssuerProfileStatus sets the OIDC issuer status of the managed cluster.
+func (s *ManagedControlPlaneScope) SetOIDCIssuerProfileStatus(oidcIssuer *infrav1exp.OIDCIssuerStatus) {
+	s.ControlPlane.Status.OIDCIssuerProfile = oidcIssuer
+}
+
 // MakeEmptyKubeConfigSecret creates an empty secret object that is used for storing kubeconfig secret data.
 func (s *ManagedControlPlaneScope) MakeEmptyKubeConfigSecret() corev1.Secret {
 	return corev1.Secret{
@@ -554,6 +911,274 @@ func (s *ManagedControlPlaneScope) SetKubeConfigData(kubeConfigData []byte) {
 	s.kubeConfigData = kubeConfigData
 }
 
+// ManagedClusterImportState returns the value of the import annotation of the managed control plane, which is empty
+// unless the managed control plane adopts an existing managed cluster.
+func (s *ManagedControlPlaneScope) ManagedClusterImportState() string {
+	return s.ControlPlane.Annotations[azure.ManagedClusterImportAnnotation]
+}
+
+// SetManagedClusterImportConflicts sets the import condition of a managed cluster whose import is pending
+// from the differences between the spec and the existing managed cluster.
+func (s *ManagedControlPlaneScope) SetManagedClusterImportConflicts(conflicts []string) {
+	if len(conflicts) > 0 {
+		conditions.MarkFalse(s.ControlPlane, infrav1.ManagedClusterImportedCondition, infrav1.ManagedClusterImportConflictReason,
+			clusterv1.ConditionSeverityWarning, "%s", strings.Join(conflicts, "; "))
+		return
+	}
+	conditions.MarkFalse(s.ControlPlane, infrav1.ManagedClusterImportedCondition, infrav1.ManagedClusterImportPendingReason,
+		clusterv1.ConditionSeverityInfo, "the existing managed cluster matches the spec, set the %s annotation to %q to import it",
+		azure.ManagedClusterImportAnnotation, azure.ManagedClusterImportConfirmed)
+}
+
+// SetOutboundTypePrerequisitesPending sets the running condition of a managed cluster which cannot be created until
+// its subnet provides the egress path required by its outbound type.
+func (s *ManagedControlPlaneScope) SetOutboundTypePrerequisitesPending(message string) {
+	conditions.MarkFalse(s.ControlPlane, infrav1.ManagedClusterRunningCondition, infrav1.OutboundTypePrerequisitesPendingReason,
+		clusterv1.ConditionSeverityWarning, "%s", message)
+}
+
+// SetImportedNetworkProfile sets the network settings of an imported managed cluster that are not set in the spec.
+// The pod and service CIDRs pinned by the cluster network of the Cluster are left unset, and so is the DNS service IP
+// when the desired service CIDR differs from the existing one, so that a conflict is reported instead of being copied
+// into a spec that the webhook would reject.
+func (s *ManagedControlPlaneScope) SetImportedNetworkProfile(podCIDR, serviceCIDR, dnsServiceIP *string) {
+	var pinnedPodCIDR, pinnedServiceCIDR string
+	if clusterNetwork := s.Cluster.Spec.ClusterNetwork; clusterNetwork != nil {
+		if clusterNetwork.Services != nil && len(clusterNetwork.Services.CIDRBlocks) == 1 {
+			pinnedServiceCIDR = clusterNetwork.Services.CIDRBlocks[0]
+		}
+		if clusterNetwork.Pods != nil && len(clusterNetwork.Pods.CIDRBlocks) == 1 {
+			pinnedPodCIDR = clusterNetwork.Pods.CIDRBlocks[0]
+		}
+	}
+
+	desiredServiceCIDR := pinnedServiceCIDR
+	if s.ControlPlane.Spec.ServiceCIDR != nil {
+		desiredServiceCIDR = *s.ControlPlane.Spec.ServiceCIDR
+	}
+
+	if s.ControlPlane.Spec.PodCIDR == nil && pinnedPodCIDR == "" {
+		s.ControlPlane.Spec.PodCIDR = podCIDR
+	}
+	if s.ControlPlane.Spec.ServiceCIDR == nil && pinnedServiceCIDR == "" {
+		s.ControlPlane.Spec.ServiceCIDR = serviceCIDR
+	}
+	if s.ControlPlane.Spec.DNSServiceIP == nil && (desiredServiceCIDR == "" || desiredServiceCIDR == to.String(serviceCIDR)) {
+		s.ControlPlane.Spec.DNSServiceIP = dnsServiceIP
+	}
+}
+
+// UpdateAgentPoolUpgrades plans the Kubernetes version upgrades of the agent pools from the versions that the control
+// plane and the agent pools run, when upgrade orchestration is enabled. Agent pools are only upgraded to versions the
+// control plane already runs, in the order of the upgrade orchestration and no more than MaxParallelAgentPools at a
+// time. No agent pool starts upgrading while the upgrade of another agent pool has failed.
+func (s *ManagedControlPlaneScope) UpdateAgentPoolUpgrades(controlPlaneVersion string, agentPools []azure.AgentPoolVersion) {
+	orchestration := s.ControlPlane.Spec.UpgradeOrchestration
+	if orchestration == nil {
+		s.ControlPlane.Status.AgentPoolUpgrades = nil
+		conditions.Delete(s.ControlPlane, infrav1.AgentPoolsUpgradedCondition)
+		return
+	}
+
+	observed := make(map[string]azure.AgentPoolVersion, len(agentPools))
+	for _, pool := range agentPools {
+		observed[pool.Name] = pool
+	}
+	previous := make(map[string]infrav1exp.AgentPoolUpgradePhase, len(s.ControlPlane.Status.AgentPoolUpgrades))
+	for _, upgrade := range s.ControlPlane.Status.AgentPoolUpgrades {
+		previous[upgrade.Name] = upgrade.Phase
+	}
+
+	var (
+		upgrades            = make([]infrav1exp.AgentPoolUpgradeStatus, 0, len(s.ManagedMachinePools))
+		upgrading, upToDate int
+		failed              []string
+	)
+	for _, pool := range s.agentPoolsInUpgradeOrder() {
+		name := to.String(pool.InfraMachinePool.Spec.Name)
+		current, ok := observed[name]
+		if !ok {
+			// The agent pool does not exist yet and is created with its desired version.
+			continue
+		}
+		target := current.Version
+		if pool.MachinePool != nil && pool.MachinePool.Spec.Template.Spec.Version != nil {
+			target = strings.TrimPrefix(*pool.MachinePool.Spec.Template.Spec.Version, "v")
+		}
+		phase := agentPoolUpgradePhase(current, target, previous[name])
+		switch phase {
+		case infrav1exp.AgentPoolUpgradePhaseUpToDate:
+			upToDate++
+		case infrav1exp.AgentPoolUpgradePhaseUpgrading:
+			upgrading++
+		case infrav1exp.AgentPoolUpgradePhaseFailed:
+			failed = append(failed, name)
+		}
+		upgrades = append(upgrades, infrav1exp.AgentPoolUpgradeStatus{
+			Name:           name,
+			CurrentVersion: current.Version,
+			TargetVersion:  target,
+			Phase:          phase,
+		})
+	}
+
+	maxParallel := 1
+	if orchestration.MaxParallelAgentPools != nil {
+		maxParallel = int(*orchestration.MaxParallelAgentPools)
+	}
+	for i := range upgrades {
+		if len(failed) > 0 || upgrading >= maxParallel {
+			break
+		}
+		// Agent pools may not run a newer version than the control plane, so they wait for it to be upgraded first.
+		if upgrades[i].Phase == infrav1exp.AgentPoolUpgradePhasePending &&
+			semver.Compare("v"+upgrades[i].TargetVersion, "v"+strings.TrimPrefix(controlPlaneVersion, "v")) <= 0 {
+			upgrades[i].Phase = infrav1exp.AgentPoolUpgradePhaseUpgrading
+			upgrading++
+		}
+	}
+	s.ControlPlane.Status.AgentPoolUpgrades = upgrades
+
+	switch {
+	case len(failed) > 0:
+		conditions.MarkFalse(s.ControlPlane, infrav1.AgentPoolsUpgradedCondition, infrav1.AgentPoolUpgradePausedReason,
+			clusterv1.ConditionSeverityWarning, "agent pool upgrades are paused because the upgrade of agent pools %s failed", strings.Join(failed, ", "))
+	case upToDate == len(upgrades):
+		conditions.MarkTrue(s.ControlPlane, infrav1.AgentPoolsUpgradedCondition)
+	default:
+		conditions.MarkFalse(s.ControlPlane, infrav1.AgentPoolsUpgradedCondition, infrav1.AgentPoolUpgradingReason,
+			clusterv1.ConditionSeverityInfo, "%d of %d agent pools upgraded", upToDate, len(upgrades))
+	}
+}
+
+// AgentPoolUpgradesInProgress returns true if an orchestrated agent pool upgrade has not completed.
+func (s *ManagedControlPlaneScope) AgentPoolUpgradesInProgress() bool {
+	for _, upgrade := range s.ControlPlane.Status.AgentPoolUpgrades {
+		if upgrade.Phase != infrav1exp.AgentPoolUpgradePhaseUpToDate {
+			return true
+		}
+	}
+	return false
+}
+
+// agentPoolsInUpgradeOrder returns the managed machine pools in the order their agent pools are upgraded: the ones
+// listed in the upgrade orchestration first, then System mode agent pools, then the others by name.
+func (s *ManagedControlPlaneScope) agentPoolsInUpgradeOrder() []ManagedMachinePool {
+	order := make(map[string]int)
+	for i, name := range s.ControlPlane.Spec.UpgradeOrchestration.AgentPoolOrder {
+		order[name] = i
+	}
+
+	pools := make([]ManagedMachinePool, 0, len(s.ManagedMachinePools))
+	for _, pool := range s.ManagedMachinePools {
+		if pool.InfraMachinePool != nil {
+			pools = append(pools, pool)
+		}
+	}
+	sort.SliceStable(pools, func(i, j int) bool {
+		a, b := pools[i].InfraMachinePool, pools[j].InfraMachinePool
+		aIndex, aListed := order[to.String(a.Spec.Name)]
+		bIndex, bListed := order[to.String(b.Spec.Name)]
+		if aListed != bListed {
+			return aListed
+		}
+		if aListed {
+			return aIndex < bIndex
+		}
+		aSystem, bSystem := a.Spec.Mode == string(infrav1exp.NodePoolModeSystem), b.Spec.Mode == string(infrav1exp.NodePoolModeSystem)
+		if aSystem != bSystem {
+			return aSystem
+		}
+		return to.String(a.Spec.Name) < to.String(b.Spec.Name)
+	})
+	return pools
+}
+
+// agentPoolUpgradePhase returns the upgrade phase of an agent pool from the version and provisioning state it has and
+// the phase of the previous reconciliation.
+func agentPoolUpgradePhase(pool azure.AgentPoolVersion, target string, previous infrav1exp.AgentPoolUpgradePhase) infrav1exp.AgentPoolUpgradePhase {
+	upgraded := pool.Version == target
+	inProgress := pool.ProvisioningState != string(infrav1.Succeeded) &&
+		pool.ProvisioningState != string(infrav1.Failed) &&
+		pool.ProvisioningState != string(infrav1.Canceled)
+	switch {
+	case pool.ProvisioningState == string(infrav1.Failed) &&
+		(!upgraded || previous == infrav1exp.AgentPoolUpgradePhaseUpgrading || previous == infrav1exp.AgentPoolUpgradePhaseFailed):
+		return infrav1exp.AgentPoolUpgradePhaseFailed
+	case upgraded && !inProgress:
+		return infrav1exp.AgentPoolUpgradePhaseUpToDate
+	case upgraded || previous == infrav1exp.AgentPoolUpgradePhaseUpgrading:
+		return infrav1exp.AgentPoolUpgradePhaseUpgrading
+	default:
+		return infrav1exp.AgentPoolUpgradePhasePending
+	}
+}
+
+// KubeconfigCredentialKind returns the credential kind of the kubeconfig of the managed cluster.
+func (s *ManagedControlPlaneScope) KubeconfigCredentialKind() infrav1exp.KubeconfigCredentialKind {
+	if s.ControlPlane.Spec.Kubeconfig == nil || s.ControlPlane.Spec.Kubeconfig.CredentialKind == nil {
+		return infrav1exp.KubeconfigCredentialKindAdmin
+	}
+	return *s.ControlPlane.Spec.Kubeconfig.CredentialKind
+}
+
+// KubeconfigServicePrincipal returns the client ID and secret of the service principal referenced by the kubeconfig.
+func (s *ManagedControlPlaneScope) KubeconfigServicePrincipal(ctx context.Context) (clientID, clientSecret string, err error) {
+	if s.ControlPlane.Spec.Kubeconfig == nil || s.ControlPlane.Spec.Kubeconfig.ServicePrincipalSecretRef == nil {
+		return "", "", errors.New("no service principal secret is referenced by the kubeconfig")
+	}
+
+	spSecret := &corev1.Secret{}
+	key := client.ObjectKey{Name: s.ControlPlane.Spec.Kubeconfig.ServicePrincipalSecretRef.Name, Namespace: s.ControlPlane.Namespace}
+	if err := s.Client.Get(ctx, key, spSecret); err != nil {
+		return "", "", errors.Wrapf(err, "failed to get service principal secret %s", key)
+	}
+
+	clientID = string(spSecret.Data[kubeconfigClientIDKey])
+	clientSecret = string(spSecret.Data[kubeconfigClientSecretKey])
+	if clientID == "" || clientSecret == "" {
+		return "", "", errors.Errorf("service principal secret %s must contain the %s and %s keys", key, kubeconfigClientIDKey, kubeconfigClientSecretKey)
+	}
+	return clientID, clientSecret, nil
+}
+
+// KubeconfigServicePrincipalToken logs in with the service principal referenced by the kubeconfig and returns an AAD
+// token of the given tenant for the AAD server application of the managed cluster. The tenant of the cluster identity
+// is used if no tenant is given.
+func (s *ManagedControlPlaneScope) KubeconfigServicePrincipalToken(ctx context.Context, tenantID, serverID string) (adal.Token, error) {
+	clientID, clientSecret, err := s.KubeconfigServicePrincipal(ctx)
+	if err != nil {
+		return adal.Token{}, err
+	}
+
+	if tenantID == "" {
+		tenantID = s.TenantID()
+	}
+	oauthConfig, err := adal.NewOAuthConfig(s.Environment.ActiveDirectoryEndpoint, tenantID)
+	if err != nil {
+		return adal.Token{}, errors.Wrap(err, "failed to create OAuth config")
+	}
+	spt, err := adal.NewServicePrincipalToken(*oauthConfig, clientID, clientSecret, serverID)
+	if err != nil {
+		return adal.Token{}, errors.Wrap(err, "failed to create service principal token")
+	}
+	if err := spt.RefreshWithContext(ctx); err != nil {
+		return adal.Token{}, errors.Wrapf(err, "failed to log in with the service principal of secret %s", s.ControlPlane.Spec.Kubeconfig.ServicePrincipalSecretRef.Name)
+	}
+	return spt.Token(), nil
+}
+
+// KubeConfigTokenExpiry returns when the earliest AAD token of the kubeconfig expires, or the zero time if the
+// kubeconfig has no AAD tokens.
+func (s *ManagedControlPlaneScope) KubeConfigTokenExpiry() time.Time {
+	return s.kubeConfigTokenExpiry
+}
+
+// SetKubeConfigTokenExpiry sets when the earliest AAD token of the kubeconfig expires.
+func (s *ManagedControlPlaneScope) SetKubeConfigTokenExpiry(expiry time.Time) {
+	s.kubeConfigTokenExpiry = expiry
+}
+
 // SetLongRunningOperationState will set the future on the AzureManagedControlPlane status to allow the resource to continue
 // in the next reconciliation.
 func (s *ManagedControlPlaneScope) SetLongRunningOperationState(future *infrav1.Future) {
@@ -19,18 +19,25 @@ package scope
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -255,6 +262,64 @@ func TestManagedControlPlaneScope_AddonProfiles(t *testing.T) {
 				{Name: "addon2", Config: map[string]string{"k1": "v1", "k2": "v2"}, Enabled: true},
 			},
 		},
+		{
+			Name: "With typed add-ons",
+			Input: ManagedControlPlaneScopeParams{
+				AzureClients: AzureClients{
+					Authorizer: autorest.NullAuthorizer{},
+				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster1",
+						Namespace: "default",
+					},
+				},
+				ControlPlane: &infrav1exp.AzureManagedControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster1",
+						Namespace: "default",
+					},
+					Spec: infrav1exp.AzureManagedControlPlaneSpec{
+						SubscriptionID: "00000000-0000-0000-0000-000000000000",
+						AddonProfiles: []infrav1exp.AddonProfile{
+							{Name: "addon1", Enabled: true},
+						},
+						Addons: &infrav1exp.ManagedControlPlaneAddons{
+							Monitoring: &infrav1exp.MonitoringAddon{
+								Enabled:                         true,
+								LogAnalyticsWorkspaceResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.OperationalInsights/workspaces/ws1",
+							},
+							AzurePolicy: &infrav1exp.AzurePolicyAddon{
+								Enabled: false,
+							},
+							KeyVaultSecretsProvider: &infrav1exp.KeyVaultSecretsProviderAddon{
+								Enabled:              true,
+								EnableSecretRotation: to.BoolPtr(true),
+								RotationPollInterval: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+							IngressApplicationGateway: &infrav1exp.IngressApplicationGatewayAddon{
+								Enabled:                true,
+								ApplicationGatewayName: to.StringPtr("appgw1"),
+								SubnetCIDR:             to.StringPtr("10.225.0.0/16"),
+							},
+						},
+					},
+				},
+				ManagedMachinePools: []ManagedMachinePool{
+					{
+						MachinePool:      getMachinePool("pool0"),
+						InfraMachinePool: getAzureMachinePool("pool0", infrav1exp.NodePoolModeSystem),
+					},
+				},
+			},
+			Expected: []managedclusters.AddonProfile{
+				{Name: "addon1", Enabled: true},
+				{Name: "omsagent", Config: map[string]string{"logAnalyticsWorkspaceResourceID": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.OperationalInsights/workspaces/ws1"}, Enabled: true},
+				{Name: "azurepolicy", Enabled: false},
+				{Name: "azureKeyvaultSecretsProvider", Config: map[string]string{"enableSecretRotation": "true", "rotationPollInterval": "5m0s"}, Enabled: true},
+				{Name: "ingressApplicationGateway", Config: map[string]string{"applicationGatewayName": "appgw1", "subnetCIDR": "10.225.0.0/16"}, Enabled: true},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -271,6 +336,476 @@ func TestManagedControlPlaneScope_AddonProfiles(t *testing.T) {
 	}
 }
 
+func TestManagedControlPlaneScope_MaintenanceConfigurationSpecs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	input := ManagedControlPlaneScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aks1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+				ResourceGroupName: "rg1",
+				MaintenanceConfigurations: []infrav1exp.MaintenanceConfiguration{
+					{
+						Name: "default",
+						TimeInWeek: []infrav1exp.TimeInWeek{
+							{Day: "Saturday", HourSlots: []int32{1, 2}},
+						},
+					},
+				},
+			},
+		},
+		ManagedMachinePools: []ManagedMachinePool{
+			{
+				MachinePool:      getMachinePool("pool0"),
+				InfraMachinePool: getAzureMachinePool("pool0", infrav1exp.NodePoolModeSystem),
+			},
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.ControlPlane).Build()
+	s, err := NewManagedControlPlaneScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+	g.Expect(s.MaintenanceConfigurationSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&maintenanceconfigurations.MaintenanceConfigurationSpec{
+			Name:          "default",
+			ResourceGroup: "rg1",
+			Cluster:       "aks1",
+			TimeInWeek: []infrav1exp.TimeInWeek{
+				{Day: "Saturday", HourSlots: []int32{1, 2}},
+			},
+		},
+	}))
+}
+
+func TestManagedControlPlaneScope_SubnetSpecs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	podSubnetPool := getLinuxAzureMachinePool("pool1")
+	podSubnetPool.Spec.Subnet = &infrav1exp.ManagedControlPlaneSubnet{
+		Name:      "pool1",
+		CIDRBlock: "10.242.0.0/16",
+	}
+	podSubnetPool.Spec.PodSubnet = &infrav1exp.ManagedControlPlaneSubnet{
+		Name:      "pool1-pods",
+		CIDRBlock: "10.241.0.0/16",
+	}
+	input := ManagedControlPlaneScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aks1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+				ResourceGroupName: "rg1",
+				VirtualNetwork: infrav1exp.ManagedControlPlaneVirtualNetwork{
+					Name:                "vnet1",
+					CIDRBlock:           "10.0.0.0/8",
+					ResourceGroup:       "network-rg",
+					AllowSubnetCreation: to.BoolPtr(true),
+					Subnet: infrav1exp.ManagedControlPlaneSubnet{
+						Name:       "nodes",
+						CIDRBlock:  "10.240.0.0/16",
+						RouteTable: "rt1",
+					},
+				},
+			},
+		},
+		ManagedMachinePools: []ManagedMachinePool{
+			{
+				MachinePool:      getMachinePool("pool0"),
+				InfraMachinePool: getAzureMachinePool("pool0", infrav1exp.NodePoolModeSystem),
+			},
+			{
+				MachinePool:      getMachinePool("pool1"),
+				InfraMachinePool: podSubnetPool,
+			},
+		},
+		Cache: &ManagedControlPlaneCache{
+			isVnetManaged: to.BoolPtr(false),
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.ControlPlane).Build()
+	s, err := NewManagedControlPlaneScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+	g.Expect(s.SubnetSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&subnets.SubnetSpec{
+			Name:              "nodes",
+			ResourceGroup:     "network-rg",
+			SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+			CIDRs:             []string{"10.240.0.0/16"},
+			VNetName:          "vnet1",
+			VNetResourceGroup: "network-rg",
+			IsVNetManaged:     false,
+			RouteTableName:    "rt1",
+			Role:              infrav1.SubnetNode,
+			CreateIfMissing:   true,
+		},
+		&subnets.SubnetSpec{
+			Name:              "pool1",
+			ResourceGroup:     "network-rg",
+			SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+			CIDRs:             []string{"10.242.0.0/16"},
+			VNetName:          "vnet1",
+			VNetResourceGroup: "network-rg",
+			IsVNetManaged:     false,
+			Role:              infrav1.SubnetNode,
+			CreateIfMissing:   true,
+		},
+		&subnets.SubnetSpec{
+			Name:              "pool1-pods",
+			ResourceGroup:     "network-rg",
+			SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+			CIDRs:             []string{"10.241.0.0/16"},
+			VNetName:          "vnet1",
+			VNetResourceGroup: "network-rg",
+			IsVNetManaged:     false,
+			Role:              infrav1.SubnetNode,
+			CreateIfMissing:   true,
+		},
+	}))
+}
+
+func TestManagedControlPlaneScope_RoleAssignmentSpecs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	controlPlaneIdentity := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/aks1-cp"
+	kubeletIdentity := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/aks1-kubelet"
+	acrID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.ContainerRegistry/registries/acr1"
+	input := ManagedControlPlaneScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aks1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID:        "00000000-0000-0000-0000-000000000000",
+				ResourceGroupName:     "rg1",
+				NodeResourceGroupName: "rg1-nodes",
+				VirtualNetwork: infrav1exp.ManagedControlPlaneVirtualNetwork{
+					Name:          "vnet1",
+					ResourceGroup: "network-rg",
+				},
+				Identity: &infrav1exp.ManagedControlPlaneIdentity{
+					ControlPlaneIdentityResourceID: to.StringPtr(controlPlaneIdentity),
+					KubeletIdentityResourceID:      to.StringPtr(kubeletIdentity),
+					RoleAssignments: []infrav1exp.ManagedIdentityRoleAssignment{
+						{
+							Identity:         infrav1exp.ManagedIdentityKindControlPlane,
+							RoleDefinitionID: "4d97b98b-1d4f-4787-a291-c67834d212e7",
+							Scope:            infrav1exp.RoleAssignmentScopeVirtualNetwork,
+						},
+						{
+							Identity:         infrav1exp.ManagedIdentityKindKubelet,
+							RoleDefinitionID: "7f951dda-4ed3-4680-a7ca-43fe172d538d",
+							Scope:            acrID,
+						},
+						{
+							Identity:         infrav1exp.ManagedIdentityKindKubelet,
+							RoleDefinitionID: "9980e02c-c2be-4d73-94e8-173b1dc7cf3c",
+							Scope:            infrav1exp.RoleAssignmentScopeNodeResourceGroup,
+						},
+					},
+				},
+			},
+		},
+		ManagedMachinePools: []ManagedMachinePool{
+			{
+				MachinePool:      getMachinePool("pool0"),
+				InfraMachinePool: getAzureMachinePool("pool0", infrav1exp.NodePoolModeSystem),
+			},
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.ControlPlane).Build()
+	s, err := NewManagedControlPlaneScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+	g.Expect(s.HasSystemAssignedIdentity()).To(BeFalse())
+
+	specs := s.RoleAssignmentSpecs(nil)
+	// The node resource group assignment waits for AKS to create the node resource group.
+	g.Expect(specs).To(HaveLen(2))
+	vnetAssignment := specs[0].(*roleassignments.RoleAssignmentSpec)
+	g.Expect(vnetAssignment.Scope).To(Equal("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/network-rg/providers/Microsoft.Network/virtualNetworks/vnet1"))
+	g.Expect(vnetAssignment.RoleDefinitionID).To(Equal("/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleDefinitions/4d97b98b-1d4f-4787-a291-c67834d212e7"))
+	g.Expect(vnetAssignment.UserAssignedIdentityID).To(Equal(controlPlaneIdentity))
+	g.Expect(vnetAssignment.ResourceType).To(Equal(azure.ManagedCluster))
+	acrAssignment := specs[1].(*roleassignments.RoleAssignmentSpec)
+	g.Expect(acrAssignment.Scope).To(Equal(acrID))
+	g.Expect(acrAssignment.UserAssignedIdentityID).To(Equal(kubeletIdentity))
+	g.Expect(acrAssignment.Name).NotTo(Equal(vnetAssignment.Name))
+	g.Expect(s.RoleAssignmentSpecs(nil)[0].ResourceName()).To(Equal(vnetAssignment.Name))
+
+	s.ControlPlane.Status.Initialized = true
+	specs = s.RoleAssignmentSpecs(nil)
+	g.Expect(specs).To(HaveLen(3))
+	g.Expect(specs[2].(*roleassignments.RoleAssignmentSpec).Scope).To(Equal("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1-nodes"))
+}
+
+func TestManagedControlPlaneScope_PrivateDNSSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	controlPlaneIdentity := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/aks1-cp"
+	input := ManagedControlPlaneScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aks1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+				ResourceGroupName: "rg1",
+				Location:          "eastus",
+				Version:           "v1.22.6",
+				Identity: &infrav1exp.ManagedControlPlaneIdentity{
+					ControlPlaneIdentityResourceID: to.StringPtr(controlPlaneIdentity),
+				},
+			},
+		},
+		ManagedMachinePools: []ManagedMachinePool{
+			{
+				MachinePool:      getMachinePool("pool0"),
+				InfraMachinePool: getAzureMachinePool("pool0", infrav1exp.NodePoolModeSystem),
+			},
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.ControlPlane).Build()
+	s, err := NewManagedControlPlaneScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+
+	zone, links, records := s.PrivateDNSSpec()
+	g.Expect(zone).To(BeNil())
+	g.Expect(links).To(BeEmpty())
+	g.Expect(records).To(BeEmpty())
+	g.Expect(s.RoleAssignmentSpecs(nil)).To(BeEmpty())
+
+	s.ControlPlane.Spec.APIServerAccessProfile = &infrav1exp.APIServerAccessProfile{
+		EnablePrivateCluster: to.BoolPtr(true),
+		ManagedPrivateDNSZone: &infrav1exp.ManagedPrivateDNSZone{
+			Name: "aks1.privatelink.eastus.azmk8s.io",
+			AdditionalVNetLinks: []infrav1exp.PrivateDNSZoneVNetLink{
+				{VNetResourceID: "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet"},
+			},
+		},
+	}
+	g.Expect(s.IsAPIServerPrivate()).To(BeTrue())
+
+	zone, links, records = s.PrivateDNSSpec()
+	g.Expect(zone).To(Equal(privatedns.ZoneSpec{
+		Name:           "aks1.privatelink.eastus.azmk8s.io",
+		ResourceGroup:  "rg1",
+		ClusterName:    "cluster1",
+		AdditionalTags: infrav1.Tags{},
+	}))
+	g.Expect(links).To(Equal([]azure.ResourceSpecGetter{
+		privatedns.LinkSpec{
+			Name:              "hub-vnet-link",
+			ZoneName:          "aks1.privatelink.eastus.azmk8s.io",
+			SubscriptionID:    "11111111-1111-1111-1111-111111111111",
+			VNetResourceGroup: "hub-rg",
+			VNetName:          "hub-vnet",
+			ResourceGroup:     "rg1",
+			ClusterName:       "cluster1",
+			AdditionalTags:    infrav1.Tags{},
+		},
+	}))
+	g.Expect(records).To(BeEmpty())
+
+	zoneID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/privateDnsZones/aks1.privatelink.eastus.azmk8s.io"
+	managedClusterSpec := s.ManagedClusterSpec(context.TODO()).(*managedclusters.ManagedClusterSpec)
+	g.Expect(managedClusterSpec.APIServerAccessProfile.PrivateDNSZone).To(Equal(to.StringPtr(zoneID)))
+
+	specs := s.RoleAssignmentSpecs(nil)
+	g.Expect(specs).To(HaveLen(1))
+	zoneAssignment := specs[0].(*roleassignments.RoleAssignmentSpec)
+	g.Expect(zoneAssignment.Scope).To(Equal(zoneID))
+	g.Expect(zoneAssignment.RoleDefinitionID).To(Equal("/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleDefinitions/b12aa53e-6015-4669-85d0-8515ebb3ae7f"))
+	g.Expect(zoneAssignment.UserAssignedIdentityID).To(Equal(controlPlaneIdentity))
+}
+
+func TestManagedControlPlaneScope_UpdateAgentPoolUpgrades(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	input := ManagedControlPlaneScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID: "00000000-0000-0000-0000-000000000000",
+				Version:        "v1.24.6",
+				UpgradeOrchestration: &infrav1exp.UpgradeOrchestration{
+					AgentPoolOrder: []string{"pool2"},
+				},
+			},
+		},
+		ManagedMachinePools: []ManagedMachinePool{
+			{
+				MachinePool:      getMachinePoolWithVersion("pool1", "v1.24.6"),
+				InfraMachinePool: getAzureMachinePool("pool1", infrav1exp.NodePoolModeUser),
+			},
+			{
+				MachinePool:      getMachinePoolWithVersion("pool0", "v1.24.6"),
+				InfraMachinePool: getAzureMachinePool("pool0", infrav1exp.NodePoolModeSystem),
+			},
+			{
+				MachinePool:      getMachinePoolWithVersion("pool2", "v1.24.6"),
+				InfraMachinePool: getAzureMachinePool("pool2", infrav1exp.NodePoolModeUser),
+			},
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.ControlPlane).Build()
+	s, err := NewManagedControlPlaneScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+
+	agentPools := func(states ...string) []azure.AgentPoolVersion {
+		var versions []azure.AgentPoolVersion
+		for i := 0; i < len(states); i += 3 {
+			versions = append(versions, azure.AgentPoolVersion{Name: states[i], Version: states[i+1], ProvisioningState: states[i+2]})
+		}
+		return versions
+	}
+	phases := func() []infrav1exp.AgentPoolUpgradePhase {
+		var phases []infrav1exp.AgentPoolUpgradePhase
+		for _, upgrade := range s.ControlPlane.Status.AgentPoolUpgrades {
+			phases = append(phases, upgrade.Phase)
+		}
+		return phases
+	}
+
+	// The agent pools wait for the control plane to be upgraded.
+	s.UpdateAgentPoolUpgrades("1.23.12", agentPools(
+		"pool0", "1.23.12", "Succeeded",
+		"pool1", "1.23.12", "Succeeded",
+		"pool2", "1.23.12", "Succeeded",
+	))
+	g.Expect(s.ControlPlane.Status.AgentPoolUpgrades).To(Equal([]infrav1exp.AgentPoolUpgradeStatus{
+		{Name: "pool2", CurrentVersion: "1.23.12", TargetVersion: "1.24.6", Phase: infrav1exp.AgentPoolUpgradePhasePending},
+		{Name: "pool0", CurrentVersion: "1.23.12", TargetVersion: "1.24.6", Phase: infrav1exp.AgentPoolUpgradePhasePending},
+		{Name: "pool1", CurrentVersion: "1.23.12", TargetVersion: "1.24.6", Phase: infrav1exp.AgentPoolUpgradePhasePending},
+	}))
+	g.Expect(conditions.GetReason(s.ControlPlane, infrav1.AgentPoolsUpgradedCondition)).To(Equal(infrav1.AgentPoolUpgradingReason))
+	g.Expect(s.AgentPoolUpgradesInProgress()).To(BeTrue())
+
+	// The listed agent pool is upgraded first.
+	s.UpdateAgentPoolUpgrades("1.24.6", agentPools(
+		"pool0", "1.23.12", "Succeeded",
+		"pool1", "1.23.12", "Succeeded",
+		"pool2", "1.23.12", "Succeeded",
+	))
+	g.Expect(phases()).To(Equal([]infrav1exp.AgentPoolUpgradePhase{
+		infrav1exp.AgentPoolUpgradePhaseUpgrading, infrav1exp.AgentPoolUpgradePhasePending, infrav1exp.AgentPoolUpgradePhasePending,
+	}))
+
+	// A failed upgrade pauses the other agent pools.
+	s.UpdateAgentPoolUpgrades("1.24.6", agentPools(
+		"pool0", "1.23.12", "Succeeded",
+		"pool1", "1.23.12", "Succeeded",
+		"pool2", "1.24.6", "Failed",
+	))
+	g.Expect(phases()).To(Equal([]infrav1exp.AgentPoolUpgradePhase{
+		infrav1exp.AgentPoolUpgradePhaseFailed, infrav1exp.AgentPoolUpgradePhasePending, infrav1exp.AgentPoolUpgradePhasePending,
+	}))
+	g.Expect(conditions.GetReason(s.ControlPlane, infrav1.AgentPoolsUpgradedCondition)).To(Equal(infrav1.AgentPoolUpgradePausedReason))
+
+	// Once it recovers, the System mode agent pool is upgraded next.
+	s.UpdateAgentPoolUpgrades("1.24.6", agentPools(
+		"pool0", "1.23.12", "Succeeded",
+		"pool1", "1.23.12", "Succeeded",
+		"pool2", "1.24.6", "Succeeded",
+	))
+	g.Expect(phases()).To(Equal([]infrav1exp.AgentPoolUpgradePhase{
+		infrav1exp.AgentPoolUpgradePhaseUpToDate, infrav1exp.AgentPoolUpgradePhaseUpgrading, infrav1exp.AgentPoolUpgradePhasePending,
+	}))
+
+	// Up to MaxParallelAgentPools agent pools are upgraded at the same time.
+	s.ControlPlane.Spec.UpgradeOrchestration.MaxParallelAgentPools = to.Int32Ptr(2)
+	s.UpdateAgentPoolUpgrades("1.24.6", agentPools(
+		"pool0", "1.24.6", "Upgrading",
+		"pool1", "1.23.12", "Succeeded",
+		"pool2", "1.24.6", "Succeeded",
+	))
+	g.Expect(phases()).To(Equal([]infrav1exp.AgentPoolUpgradePhase{
+		infrav1exp.AgentPoolUpgradePhaseUpToDate, infrav1exp.AgentPoolUpgradePhaseUpgrading, infrav1exp.AgentPoolUpgradePhaseUpgrading,
+	}))
+
+	s.UpdateAgentPoolUpgrades("1.24.6", agentPools(
+		"pool0", "1.24.6", "Succeeded",
+		"pool1", "1.24.6", "Succeeded",
+		"pool2", "1.24.6", "Succeeded",
+	))
+	g.Expect(conditions.IsTrue(s.ControlPlane, infrav1.AgentPoolsUpgradedCondition)).To(BeTrue())
+	g.Expect(s.AgentPoolUpgradesInProgress()).To(BeFalse())
+
+	s.ControlPlane.Spec.UpgradeOrchestration = nil
+	s.UpdateAgentPoolUpgrades("1.24.6", nil)
+	g.Expect(s.ControlPlane.Status.AgentPoolUpgrades).To(BeNil())
+	g.Expect(conditions.Has(s.ControlPlane, infrav1.AgentPoolsUpgradedCondition)).To(BeFalse())
+}
+
 func TestManagedControlPlaneScope_OSType(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = expv1.AddToScheme(scheme)
@@ -542,3 +1077,76 @@ func TestManagedControlPlaneScope_IsVnetManagedCache(t *testing.T) {
 		})
 	}
 }
+
+func TestManagedControlPlaneScope_SetImportedNetworkProfile(t *testing.T) {
+	cases := []struct {
+		Name                 string
+		ClusterNetwork       *clusterv1.ClusterNetwork
+		Spec                 infrav1exp.AzureManagedControlPlaneSpec
+		ExpectedPodCIDR      *string
+		ExpectedServiceCIDR  *string
+		ExpectedDNSServiceIP *string
+	}{
+		{
+			Name:                 "backfills the network settings that are not set",
+			ExpectedPodCIDR:      to.StringPtr("10.244.0.0/16"),
+			ExpectedServiceCIDR:  to.StringPtr("10.0.0.0/16"),
+			ExpectedDNSServiceIP: to.StringPtr("10.0.0.10"),
+		},
+		{
+			Name: "keeps the network settings of the spec",
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				PodCIDR:      to.StringPtr("192.168.0.0/16"),
+				ServiceCIDR:  to.StringPtr("10.0.0.0/16"),
+				DNSServiceIP: to.StringPtr("10.0.0.20"),
+			},
+			ExpectedPodCIDR:      to.StringPtr("192.168.0.0/16"),
+			ExpectedServiceCIDR:  to.StringPtr("10.0.0.0/16"),
+			ExpectedDNSServiceIP: to.StringPtr("10.0.0.20"),
+		},
+		{
+			Name: "does not backfill the CIDRs pinned by the cluster network",
+			ClusterNetwork: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.244.0.0/16"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/16"}},
+			},
+			ExpectedDNSServiceIP: to.StringPtr("10.0.0.10"),
+		},
+		{
+			Name: "does not backfill the DNS service IP when the pinned service CIDR conflicts",
+			ClusterNetwork: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.96.0.0/12"}},
+			},
+		},
+		{
+			Name: "does not backfill the DNS service IP when the service CIDR of the spec conflicts",
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				ServiceCIDR: to.StringPtr("10.96.0.0/12"),
+			},
+			ExpectedPodCIDR:     to.StringPtr("10.244.0.0/16"),
+			ExpectedServiceCIDR: to.StringPtr("10.96.0.0/12"),
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := &ManagedControlPlaneScope{
+				Cluster: &clusterv1.Cluster{
+					Spec: clusterv1.ClusterSpec{
+						ClusterNetwork: c.ClusterNetwork,
+					},
+				},
+				ControlPlane: &infrav1exp.AzureManagedControlPlane{
+					Spec: c.Spec,
+				},
+			}
+			s.SetImportedNetworkProfile(to.StringPtr("10.244.0.0/16"), to.StringPtr("10.0.0.0/16"), to.StringPtr("10.0.0.10"))
+			g.Expect(s.ControlPlane.Spec.PodCIDR).To(Equal(c.ExpectedPodCIDR))
+			g.Expect(s.ControlPlane.Spec.ServiceCIDR).To(Equal(c.ExpectedServiceCIDR))
+			g.Expect(s.ControlPlane.Spec.DNSServiceIP).To(Equal(c.ExpectedDNSServiceIP))
+		})
+	}
+}
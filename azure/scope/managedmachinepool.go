@@ -114,6 +114,7 @@ func (s *ManagedMachinePoolScope) PatchObject(ctx context.Context) error {
 		s.InfraMachinePool,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
+			infrav1.AgentPoolUpgradedCondition,
 		}})
 }
 
@@ -137,13 +138,80 @@ func (s *ManagedMachinePoolScope) Name() string {
 
 // AgentPoolSpec returns an azure.ResourceSpecGetter for currently reconciled AzureManagedMachinePool.
 func (s *ManagedMachinePoolScope) AgentPoolSpec() azure.ResourceSpecGetter {
-	return buildAgentPoolSpec(s.ControlPlane, s.MachinePool, s.InfraMachinePool, s.AgentPoolAnnotations())
+	agentPoolSpec := buildAgentPoolSpec(s.ControlPlane, s.MachinePool, s.InfraMachinePool, s.AgentPoolAnnotations())
+	if upgrade := s.agentPoolUpgrade(); upgrade != nil && !agentPoolUpgradeAllowed(upgrade, agentPoolSpec.Version) {
+		// Keep the version the agent pool runs until the managed control plane lets it upgrade.
+		currentVersion := upgrade.CurrentVersion
+		agentPoolSpec.Version = &currentVersion
+	}
+	return agentPoolSpec
+}
+
+// SetAgentPoolUpgradeCondition sets the condition reporting the progress of the orchestrated Kubernetes version upgrade
+// of the agent pool.
+func (s *ManagedMachinePoolScope) SetAgentPoolUpgradeCondition() {
+	upgrade := s.agentPoolUpgrade()
+	if upgrade == nil {
+		conditions.Delete(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)
+		return
+	}
+
+	desiredVersion := buildAgentPoolSpec(s.ControlPlane, s.MachinePool, s.InfraMachinePool, s.AgentPoolAnnotations()).Version
+	switch {
+	case !agentPoolUpgradeAllowed(upgrade, desiredVersion) && s.agentPoolUpgradesPaused():
+		conditions.MarkFalse(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition, infrav1.AgentPoolUpgradePausedReason,
+			clusterv1.ConditionSeverityWarning, "upgrade from %s is paused because the upgrade of another agent pool failed", upgrade.CurrentVersion)
+	case !agentPoolUpgradeAllowed(upgrade, desiredVersion):
+		conditions.MarkFalse(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition, infrav1.AgentPoolUpgradePendingReason,
+			clusterv1.ConditionSeverityInfo, "waiting to upgrade from %s to %s", upgrade.CurrentVersion, to.String(desiredVersion))
+	case upgrade.Phase == infrav1exp.AgentPoolUpgradePhaseFailed:
+		conditions.MarkFalse(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition, infrav1.AgentPoolUpgradeFailedReason,
+			clusterv1.ConditionSeverityError, "upgrade from %s to %s failed", upgrade.CurrentVersion, upgrade.TargetVersion)
+	case upgrade.Phase == infrav1exp.AgentPoolUpgradePhaseUpgrading:
+		conditions.MarkFalse(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition, infrav1.AgentPoolUpgradingReason,
+			clusterv1.ConditionSeverityInfo, "upgrading from %s to %s", upgrade.CurrentVersion, upgrade.TargetVersion)
+	default:
+		conditions.MarkTrue(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)
+	}
+}
+
+// agentPoolUpgrade returns the upgrade of the agent pool planned by the managed control plane, or nil if upgrades
+// are not orchestrated or the agent pool does not exist yet.
+func (s *ManagedMachinePoolScope) agentPoolUpgrade() *infrav1exp.AgentPoolUpgradeStatus {
+	if s.ControlPlane.Spec.UpgradeOrchestration == nil {
+		return nil
+	}
+	for i, upgrade := range s.ControlPlane.Status.AgentPoolUpgrades {
+		if upgrade.Name == to.String(s.InfraMachinePool.Spec.Name) {
+			return &s.ControlPlane.Status.AgentPoolUpgrades[i]
+		}
+	}
+	return nil
+}
+
+// agentPoolUpgradesPaused returns true if the managed control plane paused agent pool upgrades after a failure.
+func (s *ManagedMachinePoolScope) agentPoolUpgradesPaused() bool {
+	for _, upgrade := range s.ControlPlane.Status.AgentPoolUpgrades {
+		if upgrade.Phase == infrav1exp.AgentPoolUpgradePhaseFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// agentPoolUpgradeAllowed returns true if the agent pool may run the desired version. Until the managed control plane
+// plans the upgrade to the desired version and lets it start, the agent pool keeps the version it runs.
+func agentPoolUpgradeAllowed(upgrade *infrav1exp.AgentPoolUpgradeStatus, desiredVersion *string) bool {
+	if desiredVersion == nil || *desiredVersion == upgrade.CurrentVersion {
+		return true
+	}
+	return *desiredVersion == upgrade.TargetVersion && upgrade.Phase != infrav1exp.AgentPoolUpgradePhasePending
 }
 
 func buildAgentPoolSpec(managedControlPlane *infrav1exp.AzureManagedControlPlane,
 	machinePool *expv1.MachinePool,
 	managedMachinePool *infrav1exp.AzureManagedMachinePool,
-	agentPoolAnnotations map[string]string) azure.ResourceSpecGetter {
+	agentPoolAnnotations map[string]string) *agentpools.AgentPoolSpec {
 	var normalizedVersion *string
 	if machinePool.Spec.Template.Spec.Version != nil {
 		v := strings.TrimPrefix(*machinePool.Spec.Template.Spec.Version, "v")
@@ -165,16 +233,46 @@ func buildAgentPoolSpec(managedControlPlane *infrav1exp.AzureManagedControlPlane
 		OSType:        managedMachinePool.Spec.OSType,
 		VnetSubnetID: azure.SubnetID(
 			managedControlPlane.Spec.SubscriptionID,
-			managedControlPlane.Spec.ResourceGroupName,
+			virtualNetworkResourceGroup(managedControlPlane),
 			managedControlPlane.Spec.VirtualNetwork.Name,
 			managedControlPlane.Spec.VirtualNetwork.Subnet.Name,
 		),
-		Mode:              managedMachinePool.Spec.Mode,
-		MaxPods:           managedMachinePool.Spec.MaxPods,
-		AvailabilityZones: managedMachinePool.Spec.AvailabilityZones,
-		OsDiskType:        managedMachinePool.Spec.OsDiskType,
-		EnableUltraSSD:    managedMachinePool.Spec.EnableUltraSSD,
-		Headers:           maps.FilterByKeyPrefix(agentPoolAnnotations, azure.CustomHeaderPrefix),
+		Mode:                 managedMachinePool.Spec.Mode,
+		MaxPods:              managedMachinePool.Spec.MaxPods,
+		AvailabilityZones:    managedMachinePool.Spec.AvailabilityZones,
+		OsDiskType:           managedMachinePool.Spec.OsDiskType,
+		EnableUltraSSD:       managedMachinePool.Spec.EnableUltraSSD,
+		OSSKU:                managedMachinePool.Spec.OSSKU,
+		EnableNodePublicIP:   managedMachinePool.Spec.EnableNodePublicIP,
+		NodePublicIPPrefixID: managedMachinePool.Spec.NodePublicIPPrefixID,
+		ScaleSetPriority:     managedMachinePool.Spec.ScaleSetPriority,
+		ScaleDownMode:        managedMachinePool.Spec.ScaleDownMode,
+		KubeletConfig:        managedMachinePool.Spec.KubeletConfig,
+		LinuxOSConfig:        managedMachinePool.Spec.LinuxOSConfig,
+		PodSubnetID:          managedMachinePool.Spec.PodSubnetID,
+		Headers:              maps.FilterByKeyPrefix(agentPoolAnnotations, azure.CustomHeaderPrefix),
+	}
+
+	if managedMachinePool.Spec.Subnet != nil {
+		agentPoolSpec.VnetSubnetID = azure.SubnetID(
+			managedControlPlane.Spec.SubscriptionID,
+			virtualNetworkResourceGroup(managedControlPlane),
+			managedControlPlane.Spec.VirtualNetwork.Name,
+			managedMachinePool.Spec.Subnet.Name,
+		)
+	}
+
+	if managedMachinePool.Spec.PodSubnet != nil {
+		agentPoolSpec.PodSubnetID = to.StringPtr(azure.SubnetID(
+			managedControlPlane.Spec.SubscriptionID,
+			virtualNetworkResourceGroup(managedControlPlane),
+			managedControlPlane.Spec.VirtualNetwork.Name,
+			managedMachinePool.Spec.PodSubnet.Name,
+		))
+	}
+
+	if managedMachinePool.Spec.UpgradeSettings != nil {
+		agentPoolSpec.MaxSurge = managedMachinePool.Spec.UpgradeSettings.MaxSurge
 	}
 
 	if managedMachinePool.Spec.OSDiskSizeGB != nil {
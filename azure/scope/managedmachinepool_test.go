@@ -21,17 +21,19 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -524,6 +526,118 @@ func TestManagedMachinePoolScope_OSDiskType(t *testing.T) {
 	}
 }
 
+func TestManagedMachinePoolScope_Subnets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	infraMachinePool := getAzureMachinePool("pool1", infrav1exp.NodePoolModeUser)
+	infraMachinePool.Spec.Subnet = &infrav1exp.ManagedControlPlaneSubnet{
+		Name:      "pool1",
+		CIDRBlock: "10.242.0.0/16",
+	}
+	infraMachinePool.Spec.PodSubnet = &infrav1exp.ManagedControlPlaneSubnet{
+		Name:      "pool1-pods",
+		CIDRBlock: "10.241.0.0/16",
+	}
+	input := ManagedMachinePoolScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+				ResourceGroupName: "rg1",
+				VirtualNetwork: infrav1exp.ManagedControlPlaneVirtualNetwork{
+					Name:          "vnet1",
+					ResourceGroup: "network-rg",
+				},
+			},
+		},
+		ManagedMachinePool: ManagedMachinePool{
+			MachinePool:      getMachinePool("pool1"),
+			InfraMachinePool: infraMachinePool,
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.MachinePool, input.InfraMachinePool, input.ControlPlane).Build()
+	s, err := NewManagedMachinePoolScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+	agentPool, ok := s.AgentPoolSpec().(*agentpools.AgentPoolSpec)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(agentPool.VnetSubnetID).To(Equal("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/network-rg/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/pool1"))
+	g.Expect(agentPool.PodSubnetID).To(Equal(to.StringPtr("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/network-rg/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/pool1-pods")))
+}
+
+func TestManagedMachinePoolScope_UpgradeOrchestration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = expv1.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	g := NewWithT(t)
+	input := ManagedMachinePoolScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1",
+				Namespace: "default",
+			},
+			Spec: infrav1exp.AzureManagedControlPlaneSpec{
+				Version:              "v1.24.6",
+				UpgradeOrchestration: &infrav1exp.UpgradeOrchestration{},
+			},
+			Status: infrav1exp.AzureManagedControlPlaneStatus{
+				AgentPoolUpgrades: []infrav1exp.AgentPoolUpgradeStatus{
+					{Name: "pool0", CurrentVersion: "1.23.12", TargetVersion: "1.24.6", Phase: infrav1exp.AgentPoolUpgradePhaseFailed},
+					{Name: "pool1", CurrentVersion: "1.23.12", TargetVersion: "1.24.6", Phase: infrav1exp.AgentPoolUpgradePhasePending},
+				},
+			},
+		},
+		ManagedMachinePool: ManagedMachinePool{
+			MachinePool:      getMachinePoolWithVersion("pool1", "v1.24.6"),
+			InfraMachinePool: getAzureMachinePool("pool1", infrav1exp.NodePoolModeUser),
+		},
+	}
+	input.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(input.MachinePool, input.InfraMachinePool, input.ControlPlane).Build()
+	s, err := NewManagedMachinePoolScope(context.TODO(), input)
+	g.Expect(err).To(Succeed())
+
+	// A pending agent pool keeps the version it runs.
+	g.Expect(s.AgentPoolSpec().(*agentpools.AgentPoolSpec).Version).To(Equal(to.StringPtr("1.23.12")))
+	s.SetAgentPoolUpgradeCondition()
+	g.Expect(conditions.GetReason(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)).To(Equal(infrav1.AgentPoolUpgradePausedReason))
+
+	s.ControlPlane.Status.AgentPoolUpgrades[0].Phase = infrav1exp.AgentPoolUpgradePhaseUpToDate
+	s.SetAgentPoolUpgradeCondition()
+	g.Expect(conditions.GetReason(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)).To(Equal(infrav1.AgentPoolUpgradePendingReason))
+
+	s.ControlPlane.Status.AgentPoolUpgrades[1].Phase = infrav1exp.AgentPoolUpgradePhaseUpgrading
+	g.Expect(s.AgentPoolSpec().(*agentpools.AgentPoolSpec).Version).To(Equal(to.StringPtr("1.24.6")))
+	s.SetAgentPoolUpgradeCondition()
+	g.Expect(conditions.GetReason(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)).To(Equal(infrav1.AgentPoolUpgradingReason))
+
+	// A version the managed control plane has not planned an upgrade to yet is held as well.
+	s.MachinePool.Spec.Template.Spec.Version = to.StringPtr("v1.25.2")
+	g.Expect(s.AgentPoolSpec().(*agentpools.AgentPoolSpec).Version).To(Equal(to.StringPtr("1.23.12")))
+
+	s.ControlPlane.Spec.UpgradeOrchestration = nil
+	g.Expect(s.AgentPoolSpec().(*agentpools.AgentPoolSpec).Version).To(Equal(to.StringPtr("1.25.2")))
+	s.SetAgentPoolUpgradeCondition()
+	g.Expect(conditions.Has(s.InfraMachinePool, infrav1.AgentPoolUpgradedCondition)).To(BeFalse())
+}
+
 func getAzureMachinePool(name string, mode infrav1exp.NodePoolMode) *infrav1exp.AzureManagedMachinePool {
 	return &infrav1exp.AzureManagedMachinePool{
 		ObjectMeta: metav1.ObjectMeta{
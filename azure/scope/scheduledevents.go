@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScheduledEventsPollInterval is how often the Scheduled Events reported for the VM of a node are checked. Most events
+// are scheduled at least 5 minutes ahead, but Preempt events only 30 seconds ahead, so draining ahead of them is
+// best effort.
+const ScheduledEventsPollInterval = time.Minute
+
+// disruptiveScheduledEventTypes are the types of Scheduled Events which stop the VM long enough for its pods to be
+// drained first. Freeze events only pause the VM for a few seconds, so they are ignored.
+var disruptiveScheduledEventTypes = sets.NewString("Reboot", "Redeploy", "Preempt", "Terminate")
+
+// scheduledEvent is a Scheduled Event as reported by the Azure Instance Metadata Service.
+type scheduledEvent struct {
+	EventID     string `json:"EventId"`
+	EventType   string `json:"EventType"`
+	EventStatus string `json:"EventStatus"`
+	NotBefore   string `json:"NotBefore,omitempty"`
+	Description string `json:"Description,omitempty"`
+}
+
+// disruptiveScheduledEvents returns the sorted IDs of the disruptive Scheduled Events reported for the VM of a node.
+func disruptiveScheduledEvents(node *corev1.Node) ([]string, error) {
+	value, ok := node.Annotations[azure.ScheduledEventsAnnotation]
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	var events []scheduledEvent
+	if err := json.Unmarshal([]byte(value), &events); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the %s annotation of node %s", azure.ScheduledEventsAnnotation, node.Name)
+	}
+
+	var ids []string
+	for _, event := range events {
+		if event.EventID != "" && disruptiveScheduledEventTypes.Has(event.EventType) {
+			ids = append(ids, event.EventID)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// reconcileScheduledEvents cordons and drains a node ahead of the disruptive Scheduled Events reported for its VM,
+// then lets the node agent approve them. Once the events are over, the node is uncordoned if it was cordoned for
+// them. It returns the IDs of the events approved by this call, if any.
+func reconcileScheduledEvents(ctx context.Context, workloadClient client.Client, node *corev1.Node, drain func(context.Context, *corev1.Node) error) ([]string, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scope.reconcileScheduledEvents")
+	defer done()
+
+	events, err := disruptiveScheduledEvents(node)
+	if err != nil {
+		return nil, err
+	}
+
+	patchHelper, err := patch.NewHelper(node, workloadClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	if len(events) == 0 {
+		_, cordoned := node.Annotations[azure.CordonedForScheduledEventsAnnotation]
+		_, approved := node.Annotations[azure.ApprovedScheduledEventsAnnotation]
+		if !cordoned && !approved {
+			return nil, nil
+		}
+
+		log.V(2).Info("Scheduled events are over", "node", node.Name)
+		if cordoned {
+			node.Spec.Unschedulable = false
+		}
+		delete(node.Annotations, azure.CordonedForScheduledEventsAnnotation)
+		delete(node.Annotations, azure.ApprovedScheduledEventsAnnotation)
+		return nil, errors.Wrapf(patchHelper.Patch(ctx, node), "failed to patch node %s", node.Name)
+	}
+
+	approved := strings.Join(events, ",")
+	if node.Annotations[azure.ApprovedScheduledEventsAnnotation] == approved {
+		return nil, nil
+	}
+
+	// a node cordoned by someone else stays cordoned once the events are over
+	if _, cordoned := node.Annotations[azure.CordonedForScheduledEventsAnnotation]; !cordoned && !node.Spec.Unschedulable {
+		node.Annotations[azure.CordonedForScheduledEventsAnnotation] = "true"
+		if err := patchHelper.Patch(ctx, node); err != nil {
+			return nil, errors.Wrapf(err, "failed to patch node %s", node.Name)
+		}
+	}
+
+	log.V(2).Info("Draining node ahead of scheduled events", "node", node.Name, "events", approved)
+	if err := drain(ctx, node); err != nil {
+		return nil, err
+	}
+
+	node.Annotations[azure.ApprovedScheduledEventsAnnotation] = approved
+	if err := patchHelper.Patch(ctx, node); err != nil {
+		return nil, errors.Wrapf(err, "failed to patch node %s", node.Name)
+	}
+
+	return events, nil
+}
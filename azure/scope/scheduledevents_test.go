@@ -0,0 +1,274 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	rebootAndFreezeEvents = `[
+		{"EventId": "B", "EventType": "Freeze", "EventStatus": "Scheduled"},
+		{"EventId": "C", "EventType": "Reboot", "EventStatus": "Scheduled", "NotBefore": "Mon, 19 Sep 2022 18:29:47 GMT"},
+		{"EventId": "A", "EventType": "Redeploy", "EventStatus": "Scheduled"}
+	]`
+)
+
+func TestDisruptiveScheduledEvents(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Annotations map[string]string
+		Expected    []string
+		ExpectedErr string
+	}{
+		{
+			Name:     "without annotation",
+			Expected: nil,
+		},
+		{
+			Name:        "with no event",
+			Annotations: map[string]string{azure.ScheduledEventsAnnotation: "[]"},
+			Expected:    nil,
+		},
+		{
+			Name:        "with disruptive and freeze events",
+			Annotations: map[string]string{azure.ScheduledEventsAnnotation: rebootAndFreezeEvents},
+			Expected:    []string{"A", "C"},
+		},
+		{
+			Name:        "with preempt and terminate events",
+			Annotations: map[string]string{azure.ScheduledEventsAnnotation: `[{"EventId": "A", "EventType": "Preempt"}, {"EventId": "B", "EventType": "Terminate"}]`},
+			Expected:    []string{"A", "B"},
+		},
+		{
+			Name:        "with invalid annotation",
+			Annotations: map[string]string{azure.ScheduledEventsAnnotation: "not json"},
+			ExpectedErr: "failed to parse the sigs.k8s.io/cluster-api-provider-azure-scheduled-events annotation of node node1",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "node1",
+					Annotations: c.Annotations,
+				},
+			}
+
+			events, err := disruptiveScheduledEvents(node)
+			if c.ExpectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(c.ExpectedErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(events).To(Equal(c.Expected))
+		})
+	}
+}
+
+func TestReconcileScheduledEvents(t *testing.T) {
+	cases := []struct {
+		Name                string
+		Node                *corev1.Node
+		DrainErr            error
+		ExpectedApproved    []string
+		ExpectedErr         string
+		ExpectedDrained     bool
+		ExpectedAnnotations map[string]string
+		ExpectUnschedulable bool
+	}{
+		{
+			Name: "without events",
+			Node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			},
+		},
+		{
+			Name: "with new events",
+			Node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "node1",
+					Annotations: map[string]string{azure.ScheduledEventsAnnotation: rebootAndFreezeEvents},
+				},
+			},
+			ExpectedApproved: []string{"A", "C"},
+			ExpectedDrained:  true,
+			ExpectedAnnotations: map[string]string{
+				azure.ScheduledEventsAnnotation:            rebootAndFreezeEvents,
+				azure.CordonedForScheduledEventsAnnotation: "true",
+				azure.ApprovedScheduledEventsAnnotation:    "A,C",
+			},
+		},
+		{
+			Name: "with events already approved",
+			Node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node1",
+					Annotations: map[string]string{
+						azure.ScheduledEventsAnnotation:            rebootAndFreezeEvents,
+						azure.CordonedForScheduledEventsAnnotation: "true",
+						azure.ApprovedScheduledEventsAnnotation:    "A,C",
+					},
+				},
+				Spec: corev1.NodeSpec{Unschedulable: true},
+			},
+			ExpectedAnnotations: map[string]string{
+				azure.ScheduledEventsAnnotation:            rebootAndFreezeEvents,
+				azure.CordonedForScheduledEventsAnnotation: "true",
+				azure.ApprovedScheduledEventsAnnotation:    "A,C",
+			},
+			ExpectUnschedulable: true,
+		},
+		{
+			Name: "with events on a node cordoned by someone else",
+			Node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "node1",
+					Annotations: map[string]string{azure.ScheduledEventsAnnotation: rebootAndFreezeEvents},
+				},
+				Spec: corev1.NodeSpec{Unschedulable: true},
+			},
+			ExpectedApproved: []string{"A", "C"},
+			ExpectedDrained:  true,
+			ExpectedAnnotations: map[string]string{
+				azure.ScheduledEventsAnnotation:         rebootAndFreezeEvents,
+				azure.ApprovedScheduledEventsAnnotation: "A,C",
+			},
+			ExpectUnschedulable: true,
+		},
+		{
+			Name: "with a failed drain",
+			Node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "node1",
+					Annotations: map[string]string{azure.ScheduledEventsAnnotation: rebootAndFreezeEvents},
+				},
+			},
+			DrainErr:        errors.New("drain failed"),
+			ExpectedErr:     "drain failed",
+			ExpectedDrained: true,
+			ExpectedAnnotations: map[string]string{
+				azure.ScheduledEventsAnnotation:            rebootAndFreezeEvents,
+				azure.CordonedForScheduledEventsAnnotation: "true",
+			},
+		},
+		{
+			Name: "with events over",
+			Node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node1",
+					Annotations: map[string]string{
+						azure.ScheduledEventsAnnotation:            "[]",
+						azure.CordonedForScheduledEventsAnnotation: "true",
+						azure.ApprovedScheduledEventsAnnotation:    "A,C",
+					},
+				},
+				Spec: corev1.NodeSpec{Unschedulable: true},
+			},
+			ExpectedAnnotations: map[string]string{
+				azure.ScheduledEventsAnnotation: "[]",
+			},
+		},
+		{
+			Name: "with events over on a node cordoned by someone else",
+			Node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node1",
+					Annotations: map[string]string{
+						azure.ApprovedScheduledEventsAnnotation: "A,C",
+					},
+				},
+				Spec: corev1.NodeSpec{Unschedulable: true},
+			},
+			ExpectUnschedulable: true,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			workloadClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(c.Node.DeepCopy()).Build()
+
+			node := &corev1.Node{}
+			g.Expect(workloadClient.Get(context.TODO(), client.ObjectKey{Name: c.Node.Name}, node)).To(Succeed())
+
+			drained := false
+			approved, err := reconcileScheduledEvents(context.TODO(), workloadClient, node, func(_ context.Context, _ *corev1.Node) error {
+				drained = true
+				return c.DrainErr
+			})
+			if c.ExpectedErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(c.ExpectedErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(approved).To(Equal(c.ExpectedApproved))
+			g.Expect(drained).To(Equal(c.ExpectedDrained))
+
+			updated := &corev1.Node{}
+			g.Expect(workloadClient.Get(context.TODO(), client.ObjectKey{Name: c.Node.Name}, updated)).To(Succeed())
+			if c.ExpectedAnnotations == nil {
+				g.Expect(updated.Annotations).To(BeEmpty())
+			} else {
+				g.Expect(updated.Annotations).To(Equal(c.ExpectedAnnotations))
+			}
+			g.Expect(updated.Spec.Unschedulable).To(Equal(c.ExpectUnschedulable))
+		})
+	}
+}
+
+func TestDrainWorkloadNodeWithoutWorkloadClient(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	// without a kubeconfig secret, no client can be created for the workload cluster
+	managementClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node1",
+			Annotations: map[string]string{azure.ScheduledEventsAnnotation: rebootAndFreezeEvents},
+		},
+	}
+	workloadClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node.DeepCopy()).Build()
+
+	approved, err := reconcileScheduledEvents(context.TODO(), workloadClient, node, func(ctx context.Context, node *corev1.Node) error {
+		return drainWorkloadNode(ctx, managementClient, client.ObjectKey{Name: "my-cluster", Namespace: "default"}, node, nil)
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to create the workload cluster REST config")))
+	g.Expect(approved).To(BeEmpty())
+
+	updated := &corev1.Node{}
+	g.Expect(workloadClient.Get(context.TODO(), client.ObjectKey{Name: node.Name}, updated)).To(Succeed())
+	g.Expect(updated.Annotations).NotTo(HaveKey(azure.ApprovedScheduledEventsAnnotation))
+}
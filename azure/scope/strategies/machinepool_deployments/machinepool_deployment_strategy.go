@@ -20,11 +20,13 @@ import (
 	"context"
 	"math/rand"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -48,6 +50,12 @@ type (
 		Type() infrav1exp.AzureMachinePoolDeploymentStrategyType
 	}
 
+	// ProgressReporter is the ability to report the progress of the rollout of the latest model with respect to a
+	// desired number of replicas.
+	ProgressReporter interface {
+		Progress(desiredReplicas int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (*infrav1exp.AzureMachinePoolDeploymentStatus, error)
+	}
+
 	rollingUpdateStrategy struct {
 		infrav1exp.MachineRollingUpdateDeployment
 	}
@@ -66,6 +74,24 @@ func NewMachinePoolDeploymentStrategy(strategy infrav1exp.AzureMachinePoolDeploy
 		return &rollingUpdateStrategy{
 			MachineRollingUpdateDeployment: *rollingUpdate,
 		}
+	case infrav1exp.CanaryAzureMachinePoolDeploymentStrategyType:
+		canary := strategy.Canary
+		if canary == nil {
+			canary = &infrav1exp.MachineCanaryDeployment{}
+		}
+
+		return &canaryStrategy{
+			MachineCanaryDeployment: *canary,
+		}
+	case infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType:
+		blueGreen := strategy.BlueGreen
+		if blueGreen == nil {
+			blueGreen = &infrav1exp.MachineBlueGreenDeployment{}
+		}
+
+		return &blueGreenStrategy{
+			MachineBlueGreenDeployment: *blueGreen,
+		}
 	default:
 		// default to a rolling update strategy if unknown type
 		return &rollingUpdateStrategy{
@@ -102,6 +128,20 @@ func (rollingUpdateStrategy *rollingUpdateStrategy) maxUnavailable(desiredReplic
 	return 0, nil
 }
 
+// Progress reports the progress of the rollout of the latest model.
+func (rollingUpdateStrategy *rollingUpdateStrategy) Progress(_ int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (*infrav1exp.AzureMachinePoolDeploymentStatus, error) {
+	health, err := rollingUpdateStrategy.RolloutHealth(machinesByProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if health.Paused {
+		return deploymentProgress(rollingUpdateStrategy.Type(), machinesByProviderID, &healthGate{}), nil
+	}
+
+	return deploymentProgress(rollingUpdateStrategy.Type(), machinesByProviderID, nil), nil
+}
+
 // SelectMachinesToDelete selects the machines to delete based on the machine state, desired replica count, and
 // the DeletePolicy.
 func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx context.Context, desiredReplicaCount int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) ([]infrav1exp.AzureMachinePoolMachine, error) {
@@ -117,16 +157,7 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 	}
 
 	var (
-		order = func() func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
-			switch rollingUpdateStrategy.DeletePolicy {
-			case infrav1exp.OldestDeletePolicyType:
-				return orderByOldest
-			case infrav1exp.NewestDeletePolicyType:
-				return orderByNewest
-			default:
-				return orderRandom
-			}
-		}()
+		order                      = orderByDeletePriority(rollingUpdateStrategy.deleteOrder(getReadyMachines(machinesByProviderID)))
 		log                        = ctrl.LoggerFrom(ctx).V(4)
 		failedMachines             = order(getFailedMachines(machinesByProviderID))
 		deletingMachines           = order(getDeletingMachines(machinesByProviderID))
@@ -164,6 +195,14 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 		return failedMachines, nil
 	}
 
+	// if the machines based on the latest model are unhealthy, don't replace any more machines with older models
+	if health, err := rollingUpdateStrategy.RolloutHealth(machinesByProviderID); err != nil {
+		return nil, err
+	} else if health.Paused {
+		log.Info("rollout paused", "reason", health.Reason, "message", health.Message)
+		return []infrav1exp.AzureMachinePoolMachine{}, nil
+	}
+
 	// if we have not yet reached our desired count, don't try to delete anything but failed machines
 	if len(readyMachines) < int(desiredReplicaCount) {
 		log.Info("not enough ready machines", "desiredReplicaCount", desiredReplicaCount, "readyMachinesCount", len(readyMachines), "machinesByProviderID", len(machinesByProviderID))
@@ -180,7 +219,9 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 				return toDelete, nil
 			}
 
-			toDelete = append(toDelete, v)
+			if !isProtectedFromScaleIn(v) {
+				toDelete = append(toDelete, v)
+			}
 		}
 
 		log.Info("over-provisioned ready", "desiredReplicaCount", desiredReplicaCount, "overProvisionCount", overProvisionCount, "readyMachines", getProviderIDs(readyMachines))
@@ -190,7 +231,9 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 				return toDelete, nil
 			}
 
-			toDelete = append(toDelete, v)
+			if !isProtectedFromScaleIn(v) {
+				toDelete = append(toDelete, v)
+			}
 		}
 
 		return toDelete, nil
@@ -213,7 +256,7 @@ func (rollingUpdateStrategy rollingUpdateStrategy) SelectMachinesToDelete(ctx co
 			return toDelete, nil
 		}
 
-		if !v.Status.LatestModelApplied {
+		if !v.Status.LatestModelApplied && !isProtectedFromScaleSetActions(v) {
 			toDelete = append(toDelete, v)
 		}
 	}
@@ -268,10 +311,12 @@ func getReadyMachines(machinesByProviderID map[string]infrav1exp.AzureMachinePoo
 	return readyMachines
 }
 
+// getMachinesWithoutLatestModel returns the machines without the latest model, except for the machines protected from
+// scale set actions, which are never replaced.
 func getMachinesWithoutLatestModel(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
 	var machinesWithLatestModel []infrav1exp.AzureMachinePoolMachine
 	for _, v := range machinesByProviderID {
-		if !v.Status.LatestModelApplied {
+		if !v.Status.LatestModelApplied && !isProtectedFromScaleSetActions(v) {
 			machinesWithLatestModel = append(machinesWithLatestModel, v)
 		}
 	}
@@ -295,6 +340,107 @@ func orderByOldest(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.A
 	return machines
 }
 
+// deleteOrder returns the order in which machines are deleted, which follows the DeletePolicy and, when BalanceZones
+// is set, deletes machines from the availability zones with the most ready machines first.
+func (rollingUpdateStrategy rollingUpdateStrategy) deleteOrder(readyMachines []infrav1exp.AzureMachinePoolMachine) func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+	var order func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine
+	switch rollingUpdateStrategy.DeletePolicy {
+	case infrav1exp.OldestDeletePolicyType:
+		order = orderByOldest
+	case infrav1exp.NewestDeletePolicyType:
+		order = orderByNewest
+	default:
+		order = orderRandom
+	}
+
+	if rollingUpdateStrategy.BalanceZones {
+		order = balanceZones(order, readyMachines)
+	}
+
+	return order
+}
+
+// isProtectedFromScaleIn tells whether the protection policy of a machine keeps it from being deleted when the pool
+// is scaled in.
+func isProtectedFromScaleIn(machine infrav1exp.AzureMachinePoolMachine) bool {
+	protectionPolicy := machine.Spec.ProtectionPolicy
+	return protectionPolicy != nil && (protectionPolicy.ProtectFromScaleIn || protectionPolicy.ProtectFromScaleSetActions)
+}
+
+// isProtectedFromScaleSetActions tells whether the protection policy of a machine keeps it from being replaced by a
+// machine based on the latest model.
+func isProtectedFromScaleSetActions(machine infrav1exp.AzureMachinePoolMachine) bool {
+	protectionPolicy := machine.Spec.ProtectionPolicy
+	return protectionPolicy != nil && protectionPolicy.ProtectFromScaleSetActions
+}
+
+// deletePriority returns the priority of the deletion of a machine from its azure.DeletePriorityAnnotation, or 0 when
+// the annotation is not set or is not an integer.
+func deletePriority(machine infrav1exp.AzureMachinePoolMachine) int {
+	priority, err := strconv.Atoi(machine.Annotations[azure.DeletePriorityAnnotation])
+	if err != nil {
+		return 0
+	}
+
+	return priority
+}
+
+// orderByDeletePriority wraps an order so that machines with a higher delete priority are deleted first. Machines
+// with the same priority keep their order.
+func orderByDeletePriority(order func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine) func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+	return func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+		machines = order(machines)
+		sort.SliceStable(machines, func(i, j int) bool {
+			return deletePriority(machines[i]) > deletePriority(machines[j])
+		})
+
+		return machines
+	}
+}
+
+// balanceZones wraps an order so that machines are deleted from the availability zones with the most ready machines
+// first, which keeps the pool balanced across zones when scaling in. The machines of a zone keep their order.
+func balanceZones(order func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine, readyMachines []infrav1exp.AzureMachinePoolMachine) func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+	readyByZone := make(map[string]int)
+	for _, v := range readyMachines {
+		readyByZone[v.Status.AvailabilityZone]++
+	}
+
+	if len(readyByZone) < 2 {
+		return order
+	}
+
+	return func(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+		machines = order(machines)
+		remainingByZone := make(map[string]int, len(readyByZone))
+		for zone, count := range readyByZone {
+			remainingByZone[zone] = count
+		}
+
+		ordered := make([]infrav1exp.AzureMachinePoolMachine, 0, len(machines))
+		selected := make([]bool, len(machines))
+		for len(ordered) < len(machines) {
+			next := -1
+			for i, v := range machines {
+				if selected[i] {
+					continue
+				}
+
+				// ties go to the machine which comes first in the order
+				if next == -1 || remainingByZone[v.Status.AvailabilityZone] > remainingByZone[machines[next].Status.AvailabilityZone] {
+					next = i
+				}
+			}
+
+			selected[next] = true
+			remainingByZone[machines[next].Status.AvailabilityZone]--
+			ordered = append(ordered, machines[next])
+		}
+
+		return ordered
+	}
+}
+
 func orderRandom(machines []infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
 	rand.Seed(time.Now().UnixNano())
 	rand.Shuffle(len(machines), func(i, j int) { machines[i], machines[j] = machines[j], machines[i] })
@@ -23,11 +23,14 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestMachinePoolRollingUpdateStrategy_Type(t *testing.T) {
@@ -267,6 +270,81 @@ func TestMachinePoolRollingUpdateStrategy_SelectMachinesToDelete(t *testing.T) {
 				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour))}),
 			}),
 		},
+		{
+			name:            "if over-provisioned across zones without balancing zones, select the oldest machines",
+			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{DeletePolicy: infrav1exp.OldestDeletePolicyType}),
+			desiredReplicas: 4,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(1 * time.Hour))}),
+				"bin":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(2 * time.Hour))}),
+				"baz":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "2", CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour))}),
+				"bar":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "2", CreationTime: metav1.NewTime(baseTime.Add(4 * time.Hour))}),
+				"qux":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "2", CreationTime: metav1.NewTime(baseTime.Add(5 * time.Hour))}),
+				"quux": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "3", CreationTime: metav1.NewTime(baseTime.Add(6 * time.Hour))}),
+			},
+			want: gomega.DiffEq([]infrav1exp.AzureMachinePoolMachine{
+				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(1 * time.Hour))}),
+				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(2 * time.Hour))}),
+			}),
+		},
+		{
+			name:            "if over-provisioned across zones and balancing zones, select the oldest machines of the zones with the most machines",
+			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{DeletePolicy: infrav1exp.OldestDeletePolicyType, BalanceZones: true}),
+			desiredReplicas: 4,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(1 * time.Hour))}),
+				"bin":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(2 * time.Hour))}),
+				"baz":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "2", CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour))}),
+				"bar":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "2", CreationTime: metav1.NewTime(baseTime.Add(4 * time.Hour))}),
+				"qux":  makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "2", CreationTime: metav1.NewTime(baseTime.Add(5 * time.Hour))}),
+				"quux": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "3", CreationTime: metav1.NewTime(baseTime.Add(6 * time.Hour))}),
+			},
+			want: gomega.DiffEq([]infrav1exp.AzureMachinePoolMachine{
+				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "2", CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour))}),
+				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(1 * time.Hour))}),
+			}),
+		},
+		{
+			name:            "if over-provisioned across zones and balancing zones, select the newest machine of the zone with the most machines",
+			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{DeletePolicy: infrav1exp.NewestDeletePolicyType, BalanceZones: true}),
+			desiredReplicas: 3,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(1 * time.Hour))}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(2 * time.Hour))}),
+				"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour))}),
+				"bar": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "2", CreationTime: metav1.NewTime(baseTime.Add(4 * time.Hour))}),
+			},
+			want: gomega.DiffEq([]infrav1exp.AzureMachinePoolMachine{
+				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, Zone: "1", CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour))}),
+			}),
+		},
+		{
+			name:            "if over-provisioned, do not select machines protected from scale-in",
+			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{DeletePolicy: infrav1exp.OldestDeletePolicyType}),
+			desiredReplicas: 1,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(1 * time.Hour)), ProtectionPolicy: &infrav1exp.AzureMachinePoolMachineProtectionPolicy{ProtectFromScaleIn: true}}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(2 * time.Hour)), ProtectionPolicy: &infrav1exp.AzureMachinePoolMachineProtectionPolicy{ProtectFromScaleSetActions: true}}),
+				"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour))}),
+			},
+			want: gomega.DiffEq([]infrav1exp.AzureMachinePoolMachine{
+				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour))}),
+			}),
+		},
+		{
+			name:            "if over-provisioned, select the machines with the highest delete priority first",
+			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{DeletePolicy: infrav1exp.OldestDeletePolicyType}),
+			desiredReplicas: 1,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(1 * time.Hour)), DeletePriority: "-10"}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(2 * time.Hour)), DeletePriority: "not a number"}),
+				"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour)), DeletePriority: "100"}),
+			},
+			want: gomega.DiffEq([]infrav1exp.AzureMachinePoolMachine{
+				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(3 * time.Hour)), DeletePriority: "100"}),
+				makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(2 * time.Hour)), DeletePriority: "not a number"}),
+			}),
+		},
 		{
 			name:            "if over-provisioned but with an equivalent number marked for deletion, nothing to do; this is the case where Azure has not yet caught up to capz",
 			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{DeletePolicy: infrav1exp.OldestDeletePolicyType}),
@@ -333,6 +411,28 @@ func TestMachinePoolRollingUpdateStrategy_SelectMachinesToDelete(t *testing.T) {
 				makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
 			}),
 		},
+		{
+			name:            "if maxUnavailable is 1, and 1 is not the latest model but protected from scale set actions, delete nothing.",
+			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{MaxUnavailable: &one}),
+			desiredReplicas: 3,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded, ProtectionPolicy: &infrav1exp.AzureMachinePoolMachineProtectionPolicy{ProtectFromScaleSetActions: true}}),
+				"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+			},
+			want: BeEmpty(),
+		},
+		{
+			name:            "if maxSurge is 1, and the only machine without the latest model is protected from scale set actions, delete nothing.",
+			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{MaxSurge: &one}),
+			desiredReplicas: 3,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+				"bin": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded, ProtectionPolicy: &infrav1exp.AzureMachinePoolMachineProtectionPolicy{ProtectFromScaleSetActions: true}}),
+				"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded}),
+			},
+			want: BeEmpty(),
+		},
 		{
 			name:            "if maxUnavailable is 1, and all are the latest model, delete nothing.",
 			strategy:        makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{MaxUnavailable: &one}),
@@ -408,10 +508,14 @@ type ampmOptions struct {
 	ProvisioningState infrav1.ProvisioningState
 	CreationTime      metav1.Time
 	DeletionTime      *metav1.Time
+	HealthySince      *metav1.Time
+	Zone              string
+	DeletePriority    string
+	ProtectionPolicy  *infrav1exp.AzureMachinePoolMachineProtectionPolicy
 }
 
 func makeAMPM(opts ampmOptions) infrav1exp.AzureMachinePoolMachine {
-	return infrav1exp.AzureMachinePoolMachine{
+	ampm := infrav1exp.AzureMachinePoolMachine{
 		ObjectMeta: metav1.ObjectMeta{
 			CreationTimestamp: opts.CreationTime,
 			DeletionTimestamp: opts.DeletionTime,
@@ -420,6 +524,22 @@ func makeAMPM(opts ampmOptions) infrav1exp.AzureMachinePoolMachine {
 			Ready:              opts.Ready,
 			LatestModelApplied: opts.LatestModel,
 			ProvisioningState:  &opts.ProvisioningState,
+			AvailabilityZone:   opts.Zone,
 		},
 	}
+	ampm.Spec.ProtectionPolicy = opts.ProtectionPolicy
+	if opts.DeletePriority != "" {
+		ampm.Annotations = map[string]string{azure.DeletePriorityAnnotation: opts.DeletePriority}
+	}
+	if opts.HealthySince != nil {
+		ampm.Status.Conditions = clusterv1.Conditions{
+			{
+				Type:               clusterv1.MachineNodeHealthyCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: *opts.HealthySince,
+			},
+		}
+	}
+
+	return ampm
 }
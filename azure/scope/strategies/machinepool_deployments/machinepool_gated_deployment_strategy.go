@@ -0,0 +1,273 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type (
+	canaryStrategy struct {
+		infrav1exp.MachineCanaryDeployment
+	}
+
+	blueGreenStrategy struct {
+		infrav1exp.MachineBlueGreenDeployment
+	}
+
+	// healthGate describes whether enough machines based on the latest model have been healthy for long enough for
+	// the machines with older models to be replaced.
+	healthGate struct {
+		passed    bool
+		bakeUntil *metav1.Time
+	}
+)
+
+// Type is the AzureMachinePoolDeploymentStrategyType for the strategy.
+func (canaryStrategy *canaryStrategy) Type() infrav1exp.AzureMachinePoolDeploymentStrategyType {
+	return infrav1exp.CanaryAzureMachinePoolDeploymentStrategyType
+}
+
+// Surge calculates the number of replicas that can be added during an upgrade operation, which is the number of
+// canaries.
+func (canaryStrategy *canaryStrategy) Surge(desiredReplicaCount int) (int, error) {
+	return canaryStrategy.canaryReplicas(desiredReplicaCount)
+}
+
+// canaryReplicas calculates the number of machines that are replaced in the canary step.
+func (canaryStrategy *canaryStrategy) canaryReplicas(desiredReplicaCount int) (int, error) {
+	if canaryStrategy.Replicas == nil {
+		return 1, nil
+	}
+
+	val, err := intstr.GetScaledValueFromIntOrPercent(canaryStrategy.Replicas, desiredReplicaCount, true)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get scaled value or int from replicas")
+	}
+
+	return val, nil
+}
+
+// healthGate evaluates whether the canaries have been healthy for the bake time.
+func (canaryStrategy *canaryStrategy) healthGate(desiredReplicaCount int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (healthGate, error) {
+	canaries, err := canaryStrategy.canaryReplicas(int(desiredReplicaCount))
+	if err != nil {
+		return healthGate{}, err
+	}
+
+	if canaries > int(desiredReplicaCount) {
+		canaries = int(desiredReplicaCount)
+	}
+
+	return evaluateHealthGate(machinesByProviderID, canaries, bakeTime(canaryStrategy.BakeTime)), nil
+}
+
+// Progress reports the progress of the rollout of the latest model.
+func (canaryStrategy *canaryStrategy) Progress(desiredReplicaCount int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (*infrav1exp.AzureMachinePoolDeploymentStatus, error) {
+	gate, err := canaryStrategy.healthGate(desiredReplicaCount, machinesByProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return deploymentProgress(canaryStrategy.Type(), machinesByProviderID, &gate), nil
+}
+
+// SelectMachinesToDelete selects the machines to delete based on the machine state and desired replica count. Machines
+// with older models are only deleted once the canaries have been healthy for the bake time, after which the remaining
+// machines are replaced in steps of the size of the canary step.
+func (canaryStrategy canaryStrategy) SelectMachinesToDelete(ctx context.Context, desiredReplicaCount int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) ([]infrav1exp.AzureMachinePoolMachine, error) {
+	ctx, log, done := tele.StartSpanWithLogger(
+		ctx,
+		"strategies.canaryStrategy.SelectMachinesToDelete",
+	)
+	defer done()
+
+	if len(getMachinesWithoutLatestModel(machinesByProviderID)) > 0 {
+		gate, err := canaryStrategy.healthGate(desiredReplicaCount, machinesByProviderID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !gate.passed {
+			log.V(4).Info("holding machines with older models until the canaries are healthy", "bakeUntil", gate.bakeUntil)
+			return getFailedOrDeletingMachines(machinesByProviderID), nil
+		}
+	}
+
+	replicas := canaryStrategy.Replicas
+	if replicas == nil {
+		one := intstr.FromInt(1)
+		replicas = &one
+	}
+
+	rollingUpdate := rollingUpdateStrategy{
+		MachineRollingUpdateDeployment: infrav1exp.MachineRollingUpdateDeployment{
+			MaxSurge:     replicas,
+			DeletePolicy: canaryStrategy.DeletePolicy,
+		},
+	}
+
+	return rollingUpdate.SelectMachinesToDelete(ctx, desiredReplicaCount, machinesByProviderID)
+}
+
+// Type is the AzureMachinePoolDeploymentStrategyType for the strategy.
+func (blueGreenStrategy *blueGreenStrategy) Type() infrav1exp.AzureMachinePoolDeploymentStrategyType {
+	return infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType
+}
+
+// Surge calculates the number of replicas that can be added during an upgrade operation, which is a full set of
+// replicas.
+func (blueGreenStrategy *blueGreenStrategy) Surge(desiredReplicaCount int) (int, error) {
+	return desiredReplicaCount, nil
+}
+
+// Progress reports the progress of the rollout of the latest model.
+func (blueGreenStrategy *blueGreenStrategy) Progress(desiredReplicaCount int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (*infrav1exp.AzureMachinePoolDeploymentStatus, error) {
+	gate := evaluateHealthGate(machinesByProviderID, int(desiredReplicaCount), bakeTime(blueGreenStrategy.BakeTime))
+	return deploymentProgress(blueGreenStrategy.Type(), machinesByProviderID, &gate), nil
+}
+
+// SelectMachinesToDelete selects the machines to delete based on the machine state and desired replica count. Machines
+// with older models are only deleted once a full set of machines based on the latest model has been healthy for the
+// bake time, and are then deleted all at once.
+func (blueGreenStrategy blueGreenStrategy) SelectMachinesToDelete(ctx context.Context, desiredReplicaCount int32, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) ([]infrav1exp.AzureMachinePoolMachine, error) {
+	ctx, log, done := tele.StartSpanWithLogger(
+		ctx,
+		"strategies.blueGreenStrategy.SelectMachinesToDelete",
+	)
+	defer done()
+
+	if len(getMachinesWithoutLatestModel(machinesByProviderID)) > 0 {
+		gate := evaluateHealthGate(machinesByProviderID, int(desiredReplicaCount), bakeTime(blueGreenStrategy.BakeTime))
+		if !gate.passed {
+			log.V(4).Info("holding machines with older models until the machines based on the latest model are healthy", "bakeUntil", gate.bakeUntil)
+			return getFailedOrDeletingMachines(machinesByProviderID), nil
+		}
+	}
+
+	// Machines with older models are over-provisioned once the full set of machines based on the latest model is
+	// healthy, so the rolling update deletes them first.
+	rollingUpdate := rollingUpdateStrategy{
+		MachineRollingUpdateDeployment: infrav1exp.MachineRollingUpdateDeployment{
+			DeletePolicy: infrav1exp.OldestDeletePolicyType,
+		},
+	}
+
+	return rollingUpdate.SelectMachinesToDelete(ctx, desiredReplicaCount, machinesByProviderID)
+}
+
+// evaluateHealthGate evaluates whether at least the required number of machines based on the latest model have been
+// healthy for the bake time.
+func evaluateHealthGate(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine, required int, bakeTime time.Duration) healthGate {
+	if required <= 0 {
+		return healthGate{passed: true}
+	}
+
+	healthySince := make([]time.Time, 0, len(machinesByProviderID))
+	for _, v := range getHealthyMachinesWithLatestModel(machinesByProviderID) {
+		v := v
+		if lastTransitionTime := conditions.GetLastTransitionTime(&v, clusterv1.MachineNodeHealthyCondition); lastTransitionTime != nil {
+			healthySince = append(healthySince, lastTransitionTime.Time)
+		}
+	}
+
+	if len(healthySince) < required {
+		return healthGate{}
+	}
+
+	// The gate passes once the required number of machines that have been healthy the longest have all been healthy
+	// for the bake time.
+	sort.Slice(healthySince, func(i, j int) bool {
+		return healthySince[i].Before(healthySince[j])
+	})
+	bakeUntil := healthySince[required-1].Add(bakeTime)
+	if bakeUntil.After(time.Now()) {
+		return healthGate{bakeUntil: &metav1.Time{Time: bakeUntil}}
+	}
+
+	return healthGate{passed: true}
+}
+
+// deploymentProgress describes the progress of the rollout of the latest model, which is gated by the health gate, if any.
+func deploymentProgress(strategyType infrav1exp.AzureMachinePoolDeploymentStrategyType, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine, gate *healthGate) *infrav1exp.AzureMachinePoolDeploymentStatus {
+	progress := &infrav1exp.AzureMachinePoolDeploymentStatus{
+		Type:                   strategyType,
+		HealthyUpdatedReplicas: int32(len(getHealthyMachinesWithLatestModel(machinesByProviderID))),
+	}
+	for _, v := range machinesByProviderID {
+		if !v.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if v.Status.LatestModelApplied {
+			progress.UpdatedReplicas++
+		} else {
+			progress.OutdatedReplicas++
+		}
+	}
+
+	switch {
+	case progress.OutdatedReplicas == 0:
+		progress.Phase = infrav1exp.CompleteAzureMachinePoolDeploymentPhase
+	case gate != nil && !gate.passed:
+		progress.Phase = infrav1exp.GatedAzureMachinePoolDeploymentPhase
+		progress.BakeUntil = gate.bakeUntil
+	default:
+		progress.Phase = infrav1exp.ProgressingAzureMachinePoolDeploymentPhase
+	}
+
+	return progress
+}
+
+func getHealthyMachinesWithLatestModel(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+	var machines []infrav1exp.AzureMachinePoolMachine
+	for _, v := range getReadyMachines(machinesByProviderID) {
+		v := v
+		if v.Status.LatestModelApplied && conditions.IsTrue(&v, clusterv1.MachineNodeHealthyCondition) {
+			machines = append(machines, v)
+		}
+	}
+
+	return machines
+}
+
+func getFailedOrDeletingMachines(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) []infrav1exp.AzureMachinePoolMachine {
+	machines := append(getFailedMachines(machinesByProviderID), getDeletingMachines(machinesByProviderID)...)
+	if machines == nil {
+		return []infrav1exp.AzureMachinePoolMachine{}
+	}
+
+	return machines
+}
+
+func bakeTime(duration *metav1.Duration) time.Duration {
+	if duration == nil {
+		return 0
+	}
+
+	return duration.Duration
+}
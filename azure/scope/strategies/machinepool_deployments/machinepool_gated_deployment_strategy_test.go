@@ -0,0 +1,290 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+)
+
+func TestMachinePoolGatedStrategies_Type(t *testing.T) {
+	g := NewWithT(t)
+	strategy := NewMachinePoolDeploymentStrategy(infrav1exp.AzureMachinePoolDeploymentStrategy{
+		Type: infrav1exp.CanaryAzureMachinePoolDeploymentStrategyType,
+	})
+	g.Expect(strategy.Type()).To(Equal(infrav1exp.CanaryAzureMachinePoolDeploymentStrategyType))
+
+	strategy = NewMachinePoolDeploymentStrategy(infrav1exp.AzureMachinePoolDeploymentStrategy{
+		Type: infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType,
+	})
+	g.Expect(strategy.Type()).To(Equal(infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType))
+}
+
+func TestMachinePoolGatedStrategies_Surge(t *testing.T) {
+	var (
+		two           = intstr.FromInt(2)
+		twentyPercent = intstr.FromString("20%")
+	)
+
+	tests := []struct {
+		name            string
+		strategy        Surger
+		desiredReplicas int
+		want            int
+	}{
+		{
+			name:            "canary Replicas is empty",
+			strategy:        &canaryStrategy{},
+			desiredReplicas: 10,
+			want:            1,
+		},
+		{
+			name: "canary Replicas is set to 2",
+			strategy: &canaryStrategy{
+				MachineCanaryDeployment: infrav1exp.MachineCanaryDeployment{
+					Replicas: &two,
+				},
+			},
+			desiredReplicas: 10,
+			want:            2,
+		},
+		{
+			name: "canary Replicas is set to 20% and desiredReplicas is 11; rounds up",
+			strategy: &canaryStrategy{
+				MachineCanaryDeployment: infrav1exp.MachineCanaryDeployment{
+					Replicas: &twentyPercent,
+				},
+			},
+			desiredReplicas: 11,
+			want:            3,
+		},
+		{
+			name:            "blue/green surges a full set of replicas",
+			strategy:        &blueGreenStrategy{},
+			desiredReplicas: 5,
+			want:            5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := tt.strategy.Surge(tt.desiredReplicas)
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMachinePoolGatedStrategies_SelectMachinesToDelete(t *testing.T) {
+	var (
+		succeeded  = infrav1.Succeeded
+		failed     = infrav1.Failed
+		baseTime   = time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+		longAgo    = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		justNow    = metav1.NewTime(time.Now().Truncate(time.Second))
+		tenMinutes = &metav1.Duration{Duration: 10 * time.Minute}
+		outdated   = func(hours int) infrav1exp.AzureMachinePoolMachine {
+			return makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(time.Duration(hours) * time.Hour))})
+		}
+		updated = func(hours int, healthySince *metav1.Time) infrav1exp.AzureMachinePoolMachine {
+			return makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: metav1.NewTime(baseTime.Add(time.Duration(hours) * time.Hour)), HealthySince: healthySince})
+		}
+	)
+
+	tests := []struct {
+		name            string
+		strategy        DeleteSelector
+		input           map[string]infrav1exp.AzureMachinePoolMachine
+		desiredReplicas int32
+		want            types.GomegaMatcher
+	}{
+		{
+			name:            "canary holds machines with older models until the canary is healthy",
+			strategy:        &canaryStrategy{},
+			desiredReplicas: 2,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": outdated(1),
+				"bar": outdated(2),
+				"baz": updated(3, nil),
+			},
+			want: BeEmpty(),
+		},
+		{
+			name:            "canary holds machines with older models during the bake time",
+			strategy:        &canaryStrategy{MachineCanaryDeployment: infrav1exp.MachineCanaryDeployment{BakeTime: tenMinutes}},
+			desiredReplicas: 2,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": outdated(1),
+				"bar": outdated(2),
+				"baz": updated(3, &justNow),
+			},
+			want: BeEmpty(),
+		},
+		{
+			name:            "canary deletes failed machines while holding",
+			strategy:        &canaryStrategy{MachineCanaryDeployment: infrav1exp.MachineCanaryDeployment{BakeTime: tenMinutes}},
+			desiredReplicas: 2,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": outdated(1),
+				"bar": makeAMPM(ampmOptions{ProvisioningState: failed}),
+				"baz": updated(3, &justNow),
+			},
+			want: Equal([]infrav1exp.AzureMachinePoolMachine{
+				makeAMPM(ampmOptions{ProvisioningState: failed}),
+			}),
+		},
+		{
+			name:            "canary replaces the oldest machine once the canary has been healthy for the bake time",
+			strategy:        &canaryStrategy{MachineCanaryDeployment: infrav1exp.MachineCanaryDeployment{BakeTime: tenMinutes, DeletePolicy: infrav1exp.OldestDeletePolicyType}},
+			desiredReplicas: 2,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": outdated(1),
+				"bar": outdated(2),
+				"baz": updated(3, &longAgo),
+			},
+			want: Equal([]infrav1exp.AzureMachinePoolMachine{
+				outdated(1),
+			}),
+		},
+		{
+			name:            "blue/green holds machines with older models until a full set of machines is healthy",
+			strategy:        &blueGreenStrategy{},
+			desiredReplicas: 2,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": outdated(1),
+				"bar": outdated(2),
+				"baz": updated(3, &longAgo),
+				"bin": updated(4, nil),
+			},
+			want: BeEmpty(),
+		},
+		{
+			name:            "blue/green deletes all the machines with older models once the full set of machines has been healthy for the bake time",
+			strategy:        &blueGreenStrategy{MachineBlueGreenDeployment: infrav1exp.MachineBlueGreenDeployment{BakeTime: tenMinutes}},
+			desiredReplicas: 2,
+			input: map[string]infrav1exp.AzureMachinePoolMachine{
+				"foo": outdated(1),
+				"bar": outdated(2),
+				"baz": updated(3, &longAgo),
+				"bin": updated(4, &longAgo),
+			},
+			want: Equal([]infrav1exp.AzureMachinePoolMachine{
+				outdated(1),
+				outdated(2),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := tt.strategy.SelectMachinesToDelete(context.Background(), tt.desiredReplicas, tt.input)
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(tt.want)
+		})
+	}
+}
+
+func TestMachinePoolGatedStrategies_Progress(t *testing.T) {
+	var (
+		succeeded  = infrav1.Succeeded
+		justNow    = metav1.NewTime(time.Now().Truncate(time.Second))
+		tenMinutes = &metav1.Duration{Duration: 10 * time.Minute}
+		input      = map[string]infrav1exp.AzureMachinePoolMachine{
+			"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+			"bar": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded}),
+			"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, HealthySince: &justNow}),
+		}
+	)
+
+	tests := []struct {
+		name     string
+		strategy ProgressReporter
+		want     *infrav1exp.AzureMachinePoolDeploymentStatus
+	}{
+		{
+			name:     "rolling update without a health check is never gated",
+			strategy: &rollingUpdateStrategy{},
+			want: &infrav1exp.AzureMachinePoolDeploymentStatus{
+				Type:                   infrav1exp.RollingUpdateAzureMachinePoolDeploymentStrategyType,
+				Phase:                  infrav1exp.ProgressingAzureMachinePoolDeploymentPhase,
+				UpdatedReplicas:        1,
+				HealthyUpdatedReplicas: 1,
+				OutdatedReplicas:       2,
+			},
+		},
+		{
+			name:     "canary is gated until the end of the bake time",
+			strategy: &canaryStrategy{MachineCanaryDeployment: infrav1exp.MachineCanaryDeployment{BakeTime: tenMinutes}},
+			want: &infrav1exp.AzureMachinePoolDeploymentStatus{
+				Type:                   infrav1exp.CanaryAzureMachinePoolDeploymentStrategyType,
+				Phase:                  infrav1exp.GatedAzureMachinePoolDeploymentPhase,
+				UpdatedReplicas:        1,
+				HealthyUpdatedReplicas: 1,
+				OutdatedReplicas:       2,
+				BakeUntil:              &metav1.Time{Time: justNow.Add(10 * time.Minute)},
+			},
+		},
+		{
+			name:     "canary progresses once the canary is healthy",
+			strategy: &canaryStrategy{},
+			want: &infrav1exp.AzureMachinePoolDeploymentStatus{
+				Type:                   infrav1exp.CanaryAzureMachinePoolDeploymentStrategyType,
+				Phase:                  infrav1exp.ProgressingAzureMachinePoolDeploymentPhase,
+				UpdatedReplicas:        1,
+				HealthyUpdatedReplicas: 1,
+				OutdatedReplicas:       2,
+			},
+		},
+		{
+			name:     "blue/green is gated until a full set of machines is healthy",
+			strategy: &blueGreenStrategy{},
+			want: &infrav1exp.AzureMachinePoolDeploymentStatus{
+				Type:                   infrav1exp.BlueGreenAzureMachinePoolDeploymentStrategyType,
+				Phase:                  infrav1exp.GatedAzureMachinePoolDeploymentPhase,
+				UpdatedReplicas:        1,
+				HealthyUpdatedReplicas: 1,
+				OutdatedReplicas:       2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := tt.strategy.Progress(2, input)
+			g.Expect(err).To(Succeed())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+
+	g := NewWithT(t)
+	got, err := (&blueGreenStrategy{}).Progress(1, map[string]infrav1exp.AzureMachinePoolMachine{
+		"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, HealthySince: &justNow}),
+	})
+	g.Expect(err).To(Succeed())
+	g.Expect(got.Phase).To(Equal(infrav1exp.CompleteAzureMachinePoolDeploymentPhase))
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// defaultNodeReadyTimeout is the time the node of a machine based on the latest model has to become ready when the
+// health check does not specify a timeout.
+const defaultNodeReadyTimeout = 10 * time.Minute
+
+type (
+	// RolloutHealthChecker is the ability to tell whether the rollout of the latest model is paused because the
+	// machines based on it are unhealthy.
+	RolloutHealthChecker interface {
+		RolloutHealth(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (RolloutHealth, error)
+	}
+
+	// RolloutHealth describes whether the rollout of the latest model is paused, and why.
+	RolloutHealth struct {
+		Paused  bool
+		Reason  string
+		Message string
+	}
+)
+
+// RolloutHealth tells whether the rollout of the latest model is paused because too many of the machines based on it
+// failed to provision or have nodes which did not become ready in time.
+func (rollingUpdateStrategy *rollingUpdateStrategy) RolloutHealth(machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (RolloutHealth, error) {
+	if rollingUpdateStrategy.HealthCheck == nil {
+		return RolloutHealth{}, nil
+	}
+
+	return evaluateRolloutHealth(*rollingUpdateStrategy.HealthCheck, machinesByProviderID)
+}
+
+// evaluateRolloutHealth pauses a rollout in progress when more machines based on the latest model are unhealthy than
+// the health check tolerates. A machine is unhealthy when it failed to provision, or when its node is not ready
+// after the node ready timeout.
+func evaluateRolloutHealth(healthCheck infrav1exp.MachineRolloutHealthCheck, machinesByProviderID map[string]infrav1exp.AzureMachinePoolMachine) (RolloutHealth, error) {
+	nodeReadyTimeout := defaultNodeReadyTimeout
+	if healthCheck.NodeReadyTimeout != nil {
+		nodeReadyTimeout = healthCheck.NodeReadyTimeout.Duration
+	}
+
+	var (
+		latestModelCount, outdatedCount  int
+		failedMachines, timedOutMachines []infrav1exp.AzureMachinePoolMachine
+	)
+	for _, v := range machinesByProviderID {
+		v := v
+		if !v.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if !v.Status.LatestModelApplied {
+			outdatedCount++
+			continue
+		}
+
+		latestModelCount++
+		switch {
+		case v.Status.ProvisioningState != nil && *v.Status.ProvisioningState == infrav1.Failed:
+			failedMachines = append(failedMachines, v)
+		case conditions.IsTrue(&v, clusterv1.MachineNodeHealthyCondition):
+		case time.Since(v.CreationTimestamp.Time) > nodeReadyTimeout:
+			timedOutMachines = append(timedOutMachines, v)
+		}
+	}
+
+	// the rollout is complete, so there is nothing left to pause
+	if outdatedCount == 0 {
+		return RolloutHealth{}, nil
+	}
+
+	maxUnhealthy := 0
+	if healthCheck.MaxUnhealthy != nil {
+		var err error
+		maxUnhealthy, err = intstr.GetScaledValueFromIntOrPercent(healthCheck.MaxUnhealthy, latestModelCount, false)
+		if err != nil {
+			return RolloutHealth{}, errors.Wrap(err, "failed to get scaled value or int from maxUnhealthy")
+		}
+	}
+
+	unhealthyCount := len(failedMachines) + len(timedOutMachines)
+	if unhealthyCount <= maxUnhealthy {
+		return RolloutHealth{}, nil
+	}
+
+	reason := infrav1.TooManyUnhealthyMachinesReason
+	if len(failedMachines) == 0 {
+		reason = infrav1.NodeReadyTimeoutReason
+	}
+
+	return RolloutHealth{
+		Paused: true,
+		Reason: reason,
+		Message: fmt.Sprintf("%d of %d machines based on the latest model are unhealthy, which is more than the %d allowed: %d failed to provision and %d have nodes which did not become ready within %s",
+			unhealthyCount, latestModelCount, maxUnhealthy, len(failedMachines), len(timedOutMachines), nodeReadyTimeout),
+	}, nil
+}
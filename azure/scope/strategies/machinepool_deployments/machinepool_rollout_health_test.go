@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+)
+
+func TestMachinePoolRollingUpdateStrategy_RolloutHealth(t *testing.T) {
+	var (
+		succeeded         = infrav1.Succeeded
+		failed            = infrav1.Failed
+		longAgo           = metav1.NewTime(time.Now().Add(-time.Hour))
+		justNow           = metav1.NewTime(time.Now())
+		fiftyPercent      = intstr.FromString("50%")
+		outdated          = makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded, CreationTime: longAgo})
+		healthy           = makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: longAgo, HealthySince: &longAgo})
+		joining           = makeAMPM(ampmOptions{LatestModel: true, ProvisioningState: succeeded, CreationTime: justNow})
+		timedOut          = makeAMPM(ampmOptions{LatestModel: true, ProvisioningState: succeeded, CreationTime: longAgo})
+		failedToProvision = makeAMPM(ampmOptions{LatestModel: true, ProvisioningState: failed, CreationTime: justNow})
+	)
+
+	tests := []struct {
+		name        string
+		healthCheck *infrav1exp.MachineRolloutHealthCheck
+		input       map[string]infrav1exp.AzureMachinePoolMachine
+		want        RolloutHealth
+	}{
+		{
+			name:  "rollout is never paused without a health check",
+			input: map[string]infrav1exp.AzureMachinePoolMachine{"foo": outdated, "bar": timedOut},
+			want:  RolloutHealth{},
+		},
+		{
+			name:        "rollout is not paused while nodes are joining",
+			healthCheck: &infrav1exp.MachineRolloutHealthCheck{},
+			input:       map[string]infrav1exp.AzureMachinePoolMachine{"foo": outdated, "bar": joining, "baz": healthy},
+			want:        RolloutHealth{},
+		},
+		{
+			name:        "rollout is paused when a node does not become ready in time",
+			healthCheck: &infrav1exp.MachineRolloutHealthCheck{},
+			input:       map[string]infrav1exp.AzureMachinePoolMachine{"foo": outdated, "bar": timedOut, "baz": healthy},
+			want: RolloutHealth{
+				Paused:  true,
+				Reason:  infrav1.NodeReadyTimeoutReason,
+				Message: "1 of 2 machines based on the latest model are unhealthy, which is more than the 0 allowed: 0 failed to provision and 1 have nodes which did not become ready within 10m0s",
+			},
+		},
+		{
+			name: "rollout is not paused when a node has more time to become ready",
+			healthCheck: &infrav1exp.MachineRolloutHealthCheck{
+				NodeReadyTimeout: &metav1.Duration{Duration: 2 * time.Hour},
+			},
+			input: map[string]infrav1exp.AzureMachinePoolMachine{"foo": outdated, "bar": timedOut, "baz": healthy},
+			want:  RolloutHealth{},
+		},
+		{
+			name:        "rollout is paused when a machine fails to provision",
+			healthCheck: &infrav1exp.MachineRolloutHealthCheck{},
+			input:       map[string]infrav1exp.AzureMachinePoolMachine{"foo": outdated, "bar": failedToProvision, "baz": healthy},
+			want: RolloutHealth{
+				Paused:  true,
+				Reason:  infrav1.TooManyUnhealthyMachinesReason,
+				Message: "1 of 2 machines based on the latest model are unhealthy, which is more than the 0 allowed: 1 failed to provision and 0 have nodes which did not become ready within 10m0s",
+			},
+		},
+		{
+			name:        "rollout is not paused while unhealthy machines are within MaxUnhealthy",
+			healthCheck: &infrav1exp.MachineRolloutHealthCheck{MaxUnhealthy: &fiftyPercent},
+			input:       map[string]infrav1exp.AzureMachinePoolMachine{"foo": outdated, "bar": timedOut, "baz": healthy},
+			want:        RolloutHealth{},
+		},
+		{
+			name:        "rollout is not paused once it is complete",
+			healthCheck: &infrav1exp.MachineRolloutHealthCheck{},
+			input:       map[string]infrav1exp.AzureMachinePoolMachine{"bar": timedOut, "baz": healthy},
+			want:        RolloutHealth{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			strategy := makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{HealthCheck: tt.healthCheck})
+			got, err := strategy.RolloutHealth(tt.input)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMachinePoolRollingUpdateStrategy_SelectMachinesToDeleteWhilePaused(t *testing.T) {
+	var (
+		g         = NewWithT(t)
+		succeeded = infrav1.Succeeded
+		longAgo   = metav1.NewTime(time.Now().Add(-time.Hour))
+		strategy  = makeRollingUpdateStrategy(infrav1exp.MachineRollingUpdateDeployment{
+			HealthCheck: &infrav1exp.MachineRolloutHealthCheck{},
+		})
+		input = map[string]infrav1exp.AzureMachinePoolMachine{
+			"foo": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded, CreationTime: longAgo}),
+			"bar": makeAMPM(ampmOptions{Ready: true, LatestModel: false, ProvisioningState: succeeded, CreationTime: longAgo}),
+			"baz": makeAMPM(ampmOptions{Ready: true, LatestModel: true, ProvisioningState: succeeded, CreationTime: longAgo}),
+		}
+	)
+
+	got, err := strategy.SelectMachinesToDelete(context.Background(), 2, input)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeEmpty())
+
+	progress, err := strategy.Progress(2, input)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(progress.Phase).To(Equal(infrav1exp.GatedAzureMachinePoolDeploymentPhase))
+}
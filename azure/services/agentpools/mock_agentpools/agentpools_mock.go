@@ -365,6 +365,18 @@ func (mr *MockAgentPoolScopeMockRecorder) SetAgentPoolReplicas(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentPoolReplicas", reflect.TypeOf((*MockAgentPoolScope)(nil).SetAgentPoolReplicas), arg0)
 }
 
+// SetAgentPoolUpgradeCondition mocks base method.
+func (m *MockAgentPoolScope) SetAgentPoolUpgradeCondition() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAgentPoolUpgradeCondition")
+}
+
+// SetAgentPoolUpgradeCondition indicates an expected call of SetAgentPoolUpgradeCondition.
+func (mr *MockAgentPoolScopeMockRecorder) SetAgentPoolUpgradeCondition() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentPoolUpgradeCondition", reflect.TypeOf((*MockAgentPoolScope)(nil).SetAgentPoolUpgradeCondition))
+}
+
 // SetCAPIMachinePoolAnnotation mocks base method.
 func (m *MockAgentPoolScope) SetCAPIMachinePoolAnnotation(key, value string) {
 	m.ctrl.T.Helper()
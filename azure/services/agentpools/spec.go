@@ -20,14 +20,19 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 )
 
+// spotNodeLabel is the node label AKS adds to the nodes of Spot agent pools.
+const spotNodeLabel = "kubernetes.azure.com/scalesetpriority"
+
 // AgentPoolSpec contains agent pool specification details.
 type AgentPoolSpec struct {
 	// Name is the name of agent pool.
@@ -87,6 +92,33 @@ type AgentPoolSpec struct {
 	// OSType specifies the operating system for the node pool. Allowed values are 'Linux' and 'Windows'
 	OSType *string `json:"osType,omitempty"`
 
+	// OSSKU specifies the OS SKU used by the agent pool. Allowed values are 'Ubuntu' and 'CBLMariner'.
+	OSSKU *string `json:"osSKU,omitempty"`
+
+	// EnableNodePublicIP controls whether or not nodes in the agent pool each have a public IP address.
+	EnableNodePublicIP *bool `json:"enableNodePublicIP,omitempty"`
+
+	// NodePublicIPPrefixID specifies the public IP prefix resource ID which nodes should use IPs from.
+	NodePublicIPPrefixID *string `json:"nodePublicIPPrefixID,omitempty"`
+
+	// ScaleSetPriority specifies the priority of the agent pool VMs. Allowed values are 'Regular' and 'Spot'.
+	ScaleSetPriority *string `json:"scaleSetPriority,omitempty"`
+
+	// ScaleDownMode affects the cluster autoscaler behavior. Allowed values are 'Deallocate' and 'Delete'.
+	ScaleDownMode *string `json:"scaleDownMode,omitempty"`
+
+	// MaxSurge is the maximum number or percentage of nodes that are surged during an upgrade.
+	MaxSurge *string `json:"maxSurge,omitempty"`
+
+	// KubeletConfig specifies the kubelet configuration for the nodes of the agent pool.
+	KubeletConfig *infrav1exp.KubeletConfig `json:"kubeletConfig,omitempty"`
+
+	// LinuxOSConfig specifies the OS configuration for the Linux nodes of the agent pool.
+	LinuxOSConfig *infrav1exp.LinuxOSConfig `json:"linuxOSConfig,omitempty"`
+
+	// PodSubnetID is the Azure Resource ID for the subnet from which pod IPs are dynamically allocated.
+	PodSubnetID *string `json:"podSubnetID,omitempty"`
+
 	// Headers is the list of headers to add to the HTTP requests to update this resource.
 	Headers map[string]string
 }
@@ -129,28 +161,49 @@ func (s *AgentPoolSpec) Parameters(existing interface{}) (params interface{}, er
 		// Normalize individual agent pools to diff in case we need to update
 		existingProfile := containerservice.AgentPool{
 			ManagedClusterAgentPoolProfileProperties: &containerservice.ManagedClusterAgentPoolProfileProperties{
-				Count:               existingPool.Count,
-				OrchestratorVersion: existingPool.OrchestratorVersion,
-				Mode:                existingPool.Mode,
-				EnableAutoScaling:   existingPool.EnableAutoScaling,
-				MinCount:            existingPool.MinCount,
-				MaxCount:            existingPool.MaxCount,
-				NodeLabels:          existingPool.NodeLabels,
+				Count:                existingPool.Count,
+				OrchestratorVersion:  existingPool.OrchestratorVersion,
+				Mode:                 existingPool.Mode,
+				EnableAutoScaling:    existingPool.EnableAutoScaling,
+				MinCount:             existingPool.MinCount,
+				MaxCount:             existingPool.MaxCount,
+				NodeLabels:           existingPool.NodeLabels,
+				OsSKU:                existingPool.OsSKU,
+				EnableNodePublicIP:   existingPool.EnableNodePublicIP,
+				NodePublicIPPrefixID: existingPool.NodePublicIPPrefixID,
+				ScaleSetPriority:     existingPool.ScaleSetPriority,
+				ScaleDownMode:        existingPool.ScaleDownMode,
+				UpgradeSettings:      existingPool.UpgradeSettings,
+				KubeletConfig:        existingPool.KubeletConfig,
+				LinuxOSConfig:        existingPool.LinuxOSConfig,
+				PodSubnetID:          existingPool.PodSubnetID,
 			},
 		}
 
 		normalizedProfile := containerservice.AgentPool{
 			ManagedClusterAgentPoolProfileProperties: &containerservice.ManagedClusterAgentPoolProfileProperties{
-				Count:               &s.Replicas,
-				OrchestratorVersion: s.Version,
-				Mode:                containerservice.AgentPoolMode(s.Mode),
-				EnableAutoScaling:   s.EnableAutoScaling,
-				MinCount:            s.MinCount,
-				MaxCount:            s.MaxCount,
-				NodeLabels:          s.NodeLabels,
+				Count:                &s.Replicas,
+				OrchestratorVersion:  s.Version,
+				Mode:                 containerservice.AgentPoolMode(s.Mode),
+				EnableAutoScaling:    s.EnableAutoScaling,
+				MinCount:             s.MinCount,
+				MaxCount:             s.MaxCount,
+				NodeLabels:           s.NodeLabels,
+				OsSKU:                containerservice.OSSKU(to.String(s.OSSKU)),
+				EnableNodePublicIP:   s.EnableNodePublicIP,
+				NodePublicIPPrefixID: s.NodePublicIPPrefixID,
+				ScaleSetPriority:     containerservice.ScaleSetPriority(to.String(s.ScaleSetPriority)),
+				ScaleDownMode:        containerservice.ScaleDownMode(to.String(s.ScaleDownMode)),
+				UpgradeSettings:      s.upgradeSettings(),
+				KubeletConfig:        converters.KubeletConfigToSDK(s.KubeletConfig),
+				LinuxOSConfig:        converters.LinuxOSConfigToSDK(s.LinuxOSConfig),
+				PodSubnetID:          s.PodSubnetID,
 			},
 		}
 
+		// Fields which are not set in the spec are defaulted by AKS, so they should not trigger an update.
+		normalizeUnsetFields(normalizedProfile.ManagedClusterAgentPoolProfileProperties, existingProfile.ManagedClusterAgentPoolProfileProperties)
+
 		// When autoscaling is set, the count of the nodes differ based on the autoscaler and should not depend on the
 		// count present in MachinePool or AzureManagedMachinePool, hence we should not make an update API call based
 		// on difference in count.
@@ -189,23 +242,84 @@ func (s *AgentPoolSpec) Parameters(existing interface{}) (params interface{}, er
 
 	return containerservice.AgentPool{
 		ManagedClusterAgentPoolProfileProperties: &containerservice.ManagedClusterAgentPoolProfileProperties{
-			AvailabilityZones:   availabilityZones,
-			Count:               replicas,
-			EnableAutoScaling:   s.EnableAutoScaling,
-			EnableUltraSSD:      s.EnableUltraSSD,
-			MaxCount:            s.MaxCount,
-			MaxPods:             s.MaxPods,
-			MinCount:            s.MinCount,
-			Mode:                containerservice.AgentPoolMode(s.Mode),
-			NodeLabels:          s.NodeLabels,
-			NodeTaints:          nodeTaints,
-			OrchestratorVersion: s.Version,
-			OsDiskSizeGB:        &s.OSDiskSizeGB,
-			OsDiskType:          containerservice.OSDiskType(to.String(s.OsDiskType)),
-			OsType:              containerservice.OSType(to.String(s.OSType)),
-			Type:                containerservice.AgentPoolTypeVirtualMachineScaleSets,
-			VMSize:              sku,
-			VnetSubnetID:        vnetSubnetID,
+			AvailabilityZones:    availabilityZones,
+			Count:                replicas,
+			EnableAutoScaling:    s.EnableAutoScaling,
+			EnableUltraSSD:       s.EnableUltraSSD,
+			MaxCount:             s.MaxCount,
+			MaxPods:              s.MaxPods,
+			MinCount:             s.MinCount,
+			Mode:                 containerservice.AgentPoolMode(s.Mode),
+			NodeLabels:           s.NodeLabels,
+			NodeTaints:           nodeTaints,
+			OrchestratorVersion:  s.Version,
+			OsDiskSizeGB:         &s.OSDiskSizeGB,
+			OsDiskType:           containerservice.OSDiskType(to.String(s.OsDiskType)),
+			OsType:               containerservice.OSType(to.String(s.OSType)),
+			Type:                 containerservice.AgentPoolTypeVirtualMachineScaleSets,
+			VMSize:               sku,
+			VnetSubnetID:         vnetSubnetID,
+			OsSKU:                containerservice.OSSKU(to.String(s.OSSKU)),
+			EnableNodePublicIP:   s.EnableNodePublicIP,
+			NodePublicIPPrefixID: s.NodePublicIPPrefixID,
+			ScaleSetPriority:     containerservice.ScaleSetPriority(to.String(s.ScaleSetPriority)),
+			ScaleDownMode:        containerservice.ScaleDownMode(to.String(s.ScaleDownMode)),
+			UpgradeSettings:      s.upgradeSettings(),
+			KubeletConfig:        converters.KubeletConfigToSDK(s.KubeletConfig),
+			LinuxOSConfig:        converters.LinuxOSConfigToSDK(s.LinuxOSConfig),
+			PodSubnetID:          s.PodSubnetID,
 		},
 	}, nil
 }
+
+// upgradeSettings returns the upgrade settings of the agent pool, if any.
+func (s *AgentPoolSpec) upgradeSettings() *containerservice.AgentPoolUpgradeSettings {
+	if s.MaxSurge == nil {
+		return nil
+	}
+	return &containerservice.AgentPoolUpgradeSettings{MaxSurge: s.MaxSurge}
+}
+
+// normalizeUnsetFields copies the values AKS defaults for fields left unset in the spec from the existing agent pool
+// to the normalized one, so that they are not considered as a difference.
+func normalizeUnsetFields(normalized, existing *containerservice.ManagedClusterAgentPoolProfileProperties) {
+	if normalized.OsSKU == "" {
+		normalized.OsSKU = existing.OsSKU
+	}
+	if normalized.EnableNodePublicIP == nil {
+		normalized.EnableNodePublicIP = existing.EnableNodePublicIP
+	}
+	if normalized.NodePublicIPPrefixID == nil {
+		normalized.NodePublicIPPrefixID = existing.NodePublicIPPrefixID
+	}
+	if normalized.ScaleSetPriority == "" {
+		normalized.ScaleSetPriority = existing.ScaleSetPriority
+	}
+	if normalized.ScaleDownMode == "" {
+		normalized.ScaleDownMode = existing.ScaleDownMode
+	}
+	if normalized.UpgradeSettings == nil {
+		normalized.UpgradeSettings = existing.UpgradeSettings
+	}
+	if normalized.KubeletConfig == nil {
+		normalized.KubeletConfig = existing.KubeletConfig
+	}
+	if normalized.LinuxOSConfig == nil {
+		normalized.LinuxOSConfig = existing.LinuxOSConfig
+	}
+	if normalized.PodSubnetID == nil {
+		normalized.PodSubnetID = existing.PodSubnetID
+	}
+
+	// AKS labels the nodes of Spot agent pools with their priority.
+	if normalized.ScaleSetPriority == containerservice.ScaleSetPrioritySpot {
+		if value, ok := existing.NodeLabels[spotNodeLabel]; ok {
+			nodeLabels := make(map[string]*string, len(normalized.NodeLabels)+1)
+			for k, v := range normalized.NodeLabels {
+				nodeLabels[k] = v
+			}
+			nodeLabels[spotNodeLabel] = value
+			normalized.NodeLabels = nodeLabels
+		}
+	}
+}
@@ -21,12 +21,13 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 )
 
 var (
@@ -247,7 +248,51 @@ func fakeAgentPoolWithAutoscalingAndCount(enableAutoScaling bool, count int32) c
 	}
 }
 
+func fakeAgentPoolWithAKSDefaults(scaleDownMode containerservice.ScaleDownMode, maxSurge *string) containerservice.AgentPool {
+	pool := fakeAgentPoolWithProvisioningState("Succeeded")
+	pool.OsSKU = containerservice.OSSKUUbuntu
+	pool.EnableNodePublicIP = to.BoolPtr(false)
+	pool.ScaleSetPriority = containerservice.ScaleSetPriorityRegular
+	pool.ScaleDownMode = scaleDownMode
+	pool.UpgradeSettings = &containerservice.AgentPoolUpgradeSettings{MaxSurge: maxSurge}
+	return pool
+}
+
 func TestParameters(t *testing.T) {
+	fakeAgentPoolSpecWithUpgradeSettings := fakeAgentPoolSpecWithAutoscaling
+	fakeAgentPoolSpecWithUpgradeSettings.ScaleDownMode = to.StringPtr("Deallocate")
+	fakeAgentPoolSpecWithUpgradeSettings.MaxSurge = to.StringPtr("33%")
+
+	fakeAgentPoolWithUpgradeSettings := fakeAgentPoolWithProvisioningState("")
+	fakeAgentPoolWithUpgradeSettings.ScaleDownMode = containerservice.ScaleDownModeDeallocate
+	fakeAgentPoolWithUpgradeSettings.UpgradeSettings = &containerservice.AgentPoolUpgradeSettings{MaxSurge: to.StringPtr("33%")}
+
+	fakeSpotAgentPoolSpec := fakeAgentPoolSpecWithAutoscaling
+	fakeSpotAgentPoolSpec.ScaleSetPriority = to.StringPtr("Spot")
+
+	fakeSpotAgentPool := fakeAgentPoolWithProvisioningState("Succeeded")
+	fakeSpotAgentPool.ScaleSetPriority = containerservice.ScaleSetPrioritySpot
+	fakeSpotAgentPool.NodeLabels = map[string]*string{
+		"fake-label":                            to.StringPtr("fake-value"),
+		"kubernetes.azure.com/scalesetpriority": to.StringPtr("spot"),
+	}
+
+	fakeAgentPoolSpecWithNodeConfig := fakeAgentPoolSpecWithAutoscaling
+	fakeAgentPoolSpecWithNodeConfig.OSSKU = to.StringPtr("CBLMariner")
+	fakeAgentPoolSpecWithNodeConfig.EnableNodePublicIP = to.BoolPtr(true)
+	fakeAgentPoolSpecWithNodeConfig.NodePublicIPPrefixID = to.StringPtr("fake-public-ip-prefix-id")
+	fakeAgentPoolSpecWithNodeConfig.KubeletConfig = &infrav1exp.KubeletConfig{CPUManagerPolicy: to.StringPtr("static")}
+	fakeAgentPoolSpecWithNodeConfig.LinuxOSConfig = &infrav1exp.LinuxOSConfig{TransparentHugePageEnabled: to.StringPtr("madvise")}
+	fakeAgentPoolSpecWithNodeConfig.PodSubnetID = to.StringPtr("fake-pod-subnet-id")
+
+	fakeAgentPoolWithNodeConfig := fakeAgentPoolWithProvisioningState("")
+	fakeAgentPoolWithNodeConfig.OsSKU = containerservice.OSSKUCBLMariner
+	fakeAgentPoolWithNodeConfig.EnableNodePublicIP = to.BoolPtr(true)
+	fakeAgentPoolWithNodeConfig.NodePublicIPPrefixID = to.StringPtr("fake-public-ip-prefix-id")
+	fakeAgentPoolWithNodeConfig.KubeletConfig = &containerservice.KubeletConfig{CPUManagerPolicy: to.StringPtr("static")}
+	fakeAgentPoolWithNodeConfig.LinuxOSConfig = &containerservice.LinuxOSConfig{TransparentHugePageEnabled: to.StringPtr("madvise")}
+	fakeAgentPoolWithNodeConfig.PodSubnetID = to.StringPtr("fake-pod-subnet-id")
+
 	testcases := []struct {
 		name          string
 		spec          AgentPoolSpec
@@ -353,6 +398,41 @@ func TestParameters(t *testing.T) {
 			expected:      fakeAgentPoolWithProvisioningState(""),
 			expectedError: nil,
 		},
+		{
+			name:          "parameters without an existing agent pool and with node configuration",
+			spec:          fakeAgentPoolSpecWithNodeConfig,
+			existing:      nil,
+			expected:      fakeAgentPoolWithNodeConfig,
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing agent pool, do not update when unset fields are defaulted by AKS",
+			spec:          fakeAgentPoolSpecWithAutoscaling,
+			existing:      fakeAgentPoolWithAKSDefaults(containerservice.ScaleDownModeDelete, nil),
+			expected:      nil,
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing agent pool and update needed on scale down mode",
+			spec:          fakeAgentPoolSpecWithUpgradeSettings,
+			existing:      fakeAgentPoolWithAKSDefaults(containerservice.ScaleDownModeDelete, to.StringPtr("33%")),
+			expected:      fakeAgentPoolWithUpgradeSettings,
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing agent pool and update needed on max surge",
+			spec:          fakeAgentPoolSpecWithUpgradeSettings,
+			existing:      fakeAgentPoolWithAKSDefaults(containerservice.ScaleDownModeDeallocate, to.StringPtr("1")),
+			expected:      fakeAgentPoolWithUpgradeSettings,
+			expectedError: nil,
+		},
+		{
+			name:          "parameters with an existing Spot agent pool, do not update because of the Spot node label",
+			spec:          fakeSpotAgentPoolSpec,
+			existing:      fakeSpotAgentPool,
+			expected:      nil,
+			expectedError: nil,
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootdiagnostics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// IsManaged returns true when the diagnostics store the boot diagnostics in a storage account managed by Azure, which
+// is the default when boot diagnostics are not configured.
+func IsManaged(diagnostics *infrav1.Diagnostics) bool {
+	if diagnostics == nil || diagnostics.Boot == nil {
+		return true
+	}
+	return diagnostics.Boot.StorageAccountType == infrav1.ManagedDiagnosticsStorage
+}
+
+// GetSerialConsoleLogTail returns at most maxBytes from the end of the serial console log of a virtual machine
+// with managed boot diagnostics. The tail starts at the beginning of a line whenever possible.
+func GetSerialConsoleLogTail(ctx context.Context, client Client, resourceGroupName, vmName string, maxBytes int) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootdiagnostics.GetSerialConsoleLogTail")
+	defer done()
+
+	data, err := client.RetrieveBootDiagnosticsData(ctx, resourceGroupName, vmName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve boot diagnostics data of VM %s", vmName)
+	}
+
+	return getSerialConsoleLogTail(ctx, client, data, vmName, maxBytes)
+}
+
+// GetScaleSetVMSerialConsoleLogTail returns at most maxBytes from the end of the serial console log of a virtual machine
+// scale set VM with managed boot diagnostics. The tail starts at the beginning of a line whenever possible.
+func GetScaleSetVMSerialConsoleLogTail(ctx context.Context, client Client, resourceGroupName, vmssName, instanceID string, maxBytes int) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootdiagnostics.GetScaleSetVMSerialConsoleLogTail")
+	defer done()
+
+	vmName := fmt.Sprintf("%s_%s", vmssName, instanceID)
+	data, err := client.RetrieveScaleSetVMBootDiagnosticsData(ctx, resourceGroupName, vmssName, instanceID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve boot diagnostics data of VM %s", vmName)
+	}
+
+	return getSerialConsoleLogTail(ctx, client, data, vmName, maxBytes)
+}
+
+// getSerialConsoleLogTail downloads the serial console log referenced by the boot diagnostics data and returns its tail.
+func getSerialConsoleLogTail(ctx context.Context, client Client, data compute.RetrieveBootDiagnosticsDataResult, vmName string, maxBytes int) (string, error) {
+	uri := to.String(data.SerialConsoleLogBlobURI)
+	if uri == "" {
+		return "", errors.Errorf("no serial console log is available for VM %s", vmName)
+	}
+
+	// Download one more byte than needed to tell whether the log is truncated, in which case tail drops its first partial line.
+	log, err := client.GetBlobTail(ctx, uri, maxBytes+1)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to download the serial console log of VM %s", vmName)
+	}
+
+	return string(tail(log, maxBytes)), nil
+}
+
+// tail returns at most maxBytes from the end of data, starting after the first line break within that range.
+func tail(data []byte, maxBytes int) []byte {
+	if len(data) <= maxBytes {
+		return data
+	}
+
+	data = data[len(data)-maxBytes:]
+	if i := bytes.IndexByte(data, '\n'); i >= 0 && i < len(data)-1 {
+		return data[i+1:]
+	}
+	return data
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootdiagnostics
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootdiagnostics/mock_bootdiagnostics"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const serialConsoleLogURI = "https://fake.blob.core.windows.net/bootdiagnostics/my-vm.serialconsole.log?sig=fake"
+
+func TestGetSerialConsoleLogTail(t *testing.T) {
+	testcases := []struct {
+		name          string
+		maxBytes      int
+		expectedLog   string
+		expectedError string
+		expect        func(m *mock_bootdiagnostics.MockClientMockRecorder)
+	}{
+		{
+			name:        "returns the whole log when it is small enough",
+			maxBytes:    1024,
+			expectedLog: "line 1\nline 2\n",
+			expect: func(m *mock_bootdiagnostics.MockClientMockRecorder) {
+				m.RetrieveBootDiagnosticsData(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.RetrieveBootDiagnosticsDataResult{
+					SerialConsoleLogBlobURI: to.StringPtr(serialConsoleLogURI),
+				}, nil)
+				m.GetBlobTail(gomockinternal.AContext(), serialConsoleLogURI, 1025).Return([]byte("line 1\nline 2\n"), nil)
+			},
+		},
+		{
+			name:        "returns the last complete lines of the log",
+			maxBytes:    16,
+			expectedLog: "line 3\nline 4\n",
+			expect: func(m *mock_bootdiagnostics.MockClientMockRecorder) {
+				m.RetrieveBootDiagnosticsData(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.RetrieveBootDiagnosticsDataResult{
+					SerialConsoleLogBlobURI: to.StringPtr(serialConsoleLogURI),
+				}, nil)
+				m.GetBlobTail(gomockinternal.AContext(), serialConsoleLogURI, 17).Return([]byte(" 2\nline 3\nline 4\n"), nil)
+			},
+		},
+		{
+			name:          "fails to retrieve the boot diagnostics data",
+			maxBytes:      1024,
+			expectedError: "failed to retrieve boot diagnostics data of VM my-vm",
+			expect: func(m *mock_bootdiagnostics.MockClientMockRecorder) {
+				m.RetrieveBootDiagnosticsData(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.RetrieveBootDiagnosticsDataResult{},
+					autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+			},
+		},
+		{
+			name:          "no serial console log is available",
+			maxBytes:      1024,
+			expectedError: "no serial console log is available for VM my-vm",
+			expect: func(m *mock_bootdiagnostics.MockClientMockRecorder) {
+				m.RetrieveBootDiagnosticsData(gomockinternal.AContext(), "my-rg", "my-vm").Return(compute.RetrieveBootDiagnosticsDataResult{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clientMock := mock_bootdiagnostics.NewMockClient(mockCtrl)
+
+			tc.expect(clientMock.EXPECT())
+
+			log, err := GetSerialConsoleLogTail(context.TODO(), clientMock, "my-rg", "my-vm", tc.maxBytes)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(log).To(Equal(tc.expectedLog))
+			}
+		})
+	}
+}
+
+func TestGetScaleSetVMSerialConsoleLogTail(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	clientMock := mock_bootdiagnostics.NewMockClient(mockCtrl)
+
+	clientMock.EXPECT().RetrieveScaleSetVMBootDiagnosticsData(gomockinternal.AContext(), "my-rg", "my-vmss", "2").Return(compute.RetrieveBootDiagnosticsDataResult{
+		SerialConsoleLogBlobURI: to.StringPtr(serialConsoleLogURI),
+	}, nil)
+	clientMock.EXPECT().GetBlobTail(gomockinternal.AContext(), serialConsoleLogURI, 1025).Return([]byte("line 1\nline 2\n"), nil)
+
+	log, err := GetScaleSetVMSerialConsoleLogTail(context.TODO(), clientMock, "my-rg", "my-vmss", "2", 1024)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(log).To(Equal("line 1\nline 2\n"))
+}
+
+func TestIsManaged(t *testing.T) {
+	testcases := []struct {
+		name        string
+		diagnostics *infrav1.Diagnostics
+		expected    bool
+	}{
+		{
+			name:     "boot diagnostics are not configured",
+			expected: true,
+		},
+		{
+			name:        "managed storage",
+			diagnostics: &infrav1.Diagnostics{Boot: &infrav1.BootDiagnostics{StorageAccountType: infrav1.ManagedDiagnosticsStorage}},
+			expected:    true,
+		},
+		{
+			name: "user managed storage",
+			diagnostics: &infrav1.Diagnostics{Boot: &infrav1.BootDiagnostics{
+				StorageAccountType: infrav1.UserManagedDiagnosticsStorage,
+				UserManaged:        &infrav1.UserManagedBootDiagnostics{StorageAccountURI: "https://fake.blob.core.windows.net/"},
+			}},
+		},
+		{
+			name:        "disabled",
+			diagnostics: &infrav1.Diagnostics{Boot: &infrav1.BootDiagnostics{StorageAccountType: infrav1.DisabledDiagnosticsStorage}},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(IsManaged(tc.diagnostics)).To(Equal(tc.expected))
+		})
+	}
+}
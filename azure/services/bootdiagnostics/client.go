@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootdiagnostics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk.
+type Client interface {
+	RetrieveBootDiagnosticsData(context.Context, string, string) (compute.RetrieveBootDiagnosticsDataResult, error)
+	RetrieveScaleSetVMBootDiagnosticsData(context.Context, string, string, string) (compute.RetrieveBootDiagnosticsDataResult, error)
+	GetBlobTail(context.Context, string, int) ([]byte, error)
+}
+
+// blobDownloadTimeout is the time after which the download of a boot diagnostics blob is abandoned, so that a slow
+// storage account does not block the reconciliation.
+const blobDownloadTimeout = 30 * time.Second
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	virtualmachines compute.VirtualMachinesClient
+	scalesetvms     compute.VirtualMachineScaleSetVMsClient
+	httpClient      *http.Client
+}
+
+var (
+	_           Client = &AzureClient{}
+	doOnce      sync.Once
+	clientCache ttllru.Cacher
+)
+
+// NewClient creates a new boot diagnostics client from subscription ID.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	return &AzureClient{
+		virtualmachines: newVirtualMachinesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		scalesetvms:     newScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		httpClient:      &http.Client{Timeout: blobDownloadTimeout},
+	}
+}
+
+// GetClient either creates a new boot diagnostics client or returns an existing one based on the Authorizer HashKey().
+func GetClient(auth azure.Authorizer) (*AzureClient, error) {
+	var err error
+	doOnce.Do(func() {
+		clientCache, err = ttllru.New(128, 24*time.Hour)
+	})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating LRU cache for boot diagnostics clients")
+	}
+
+	key := auth.HashKey()
+	c, ok := clientCache.Get(key)
+	if ok {
+		return c.(*AzureClient), nil
+	}
+
+	c = NewClient(auth)
+	_ = clientCache.Add(key, c)
+	return c.(*AzureClient), nil
+}
+
+// newVirtualMachinesClient creates a new VM client from subscription ID.
+func newVirtualMachinesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachinesClient {
+	c := compute.NewVirtualMachinesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
+// newScaleSetVMsClient creates a new VMSS VM client from subscription ID.
+func newScaleSetVMsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachineScaleSetVMsClient {
+	c := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
+// RetrieveBootDiagnosticsData retrieves the SAS URIs of the boot diagnostics logs of a virtual machine.
+func (ac *AzureClient) RetrieveBootDiagnosticsData(ctx context.Context, resourceGroupName, vmName string) (compute.RetrieveBootDiagnosticsDataResult, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootdiagnostics.AzureClient.RetrieveBootDiagnosticsData")
+	defer done()
+
+	return ac.virtualmachines.RetrieveBootDiagnosticsData(ctx, resourceGroupName, vmName, nil)
+}
+
+// RetrieveScaleSetVMBootDiagnosticsData retrieves the SAS URIs of the boot diagnostics logs of a virtual machine scale set VM.
+func (ac *AzureClient) RetrieveScaleSetVMBootDiagnosticsData(ctx context.Context, resourceGroupName, vmssName, instanceID string) (compute.RetrieveBootDiagnosticsDataResult, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootdiagnostics.AzureClient.RetrieveScaleSetVMBootDiagnosticsData")
+	defer done()
+
+	return ac.scalesetvms.RetrieveBootDiagnosticsData(ctx, resourceGroupName, vmssName, instanceID, nil)
+}
+
+// GetBlobTail downloads at most maxBytes from the end of a blob from its SAS URI. Only the requested range of the blob
+// is downloaded, so large blobs are not read into memory.
+func (ac *AzureClient) GetBlobTail(ctx context.Context, uri string, maxBytes int) ([]byte, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "bootdiagnostics.AzureClient.GetBlobTail")
+	defer done()
+
+	size, err := ac.getBlobSize(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request for blob")
+	}
+	if size > int64(maxBytes) {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", size-int64(maxBytes)))
+	}
+
+	resp, err := ac.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get blob")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return nil, errors.Errorf("failed to get blob: unexpected status code %d", resp.StatusCode)
+	}
+
+	// The blob may have grown since its size was read, so only keep the end of the response.
+	return readTail(resp.Body, maxBytes)
+}
+
+// getBlobSize returns the size in bytes of a blob from its SAS URI.
+func (ac *AzureClient) getBlobSize(ctx context.Context, uri string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, http.NoBody)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create request for blob properties")
+	}
+
+	resp, err := ac.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get blob properties")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("failed to get blob properties: unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.ContentLength, nil
+}
+
+// readTail reads r to the end and returns at most maxBytes from its end, without holding more than that in memory.
+func readTail(r io.Reader, maxBytes int) ([]byte, error) {
+	data := make([]byte, 0, maxBytes)
+	chunk := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(chunk)
+		data = append(data, chunk[:n]...)
+		if len(data) > maxBytes {
+			data = append(data[:0], data[len(data)-maxBytes:]...)
+		}
+		if errors.Is(err, io.EOF) {
+			return data, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read blob")
+		}
+	}
+}
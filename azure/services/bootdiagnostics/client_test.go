@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootdiagnostics
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGetBlobTail(t *testing.T) {
+	blob := strings.Repeat("boot log line\n", 10000)
+
+	testcases := []struct {
+		name          string
+		ignoreRange   bool
+		maxBytes      int
+		expectedRange string
+	}{
+		{
+			name:          "downloads only the end of a large blob",
+			maxBytes:      1024,
+			expectedRange: "bytes=138976-",
+		},
+		{
+			name:     "downloads the whole blob when it is small enough",
+			maxBytes: len(blob) + 1,
+		},
+		{
+			name:          "keeps only the end of the response when the range is ignored",
+			ignoreRange:   true,
+			maxBytes:      1024,
+			expectedRange: "bytes=138976-",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var requestedRange string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					requestedRange = r.Header.Get("Range")
+				}
+				if tc.ignoreRange {
+					r.Header.Del("Range")
+				}
+				http.ServeContent(w, r, "serial.log", time.Time{}, bytes.NewReader([]byte(blob)))
+			}))
+			defer server.Close()
+
+			client := &AzureClient{httpClient: server.Client()}
+			data, err := client.GetBlobTail(context.TODO(), server.URL, tc.maxBytes)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(requestedRange).To(Equal(tc.expectedRange))
+			expected := blob
+			if len(expected) > tc.maxBytes {
+				expected = expected[len(expected)-tc.maxBytes:]
+			}
+			g.Expect(string(data)).To(Equal(expected))
+		})
+	}
+}
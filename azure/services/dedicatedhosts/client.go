@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedicatedhosts
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk.
+type Client interface {
+	GetHostGroup(context.Context, string, string) (compute.DedicatedHostGroup, error)
+}
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	hostGroups compute.DedicatedHostGroupsClient
+}
+
+var _ Client = &AzureClient{}
+
+// NewClient creates a new dedicated host groups client from subscription ID.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	return &AzureClient{
+		hostGroups: newDedicatedHostGroupsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+	}
+}
+
+// newDedicatedHostGroupsClient creates a new dedicated host groups client from subscription ID.
+func newDedicatedHostGroupsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.DedicatedHostGroupsClient {
+	c := compute.NewDedicatedHostGroupsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
+// GetHostGroup retrieves a dedicated host group including the instance view of its hosts.
+func (ac *AzureClient) GetHostGroup(ctx context.Context, resourceGroupName, hostGroupName string) (compute.DedicatedHostGroup, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.AzureClient.GetHostGroup")
+	defer done()
+
+	return ac.hostGroups.Get(ctx, resourceGroupName, hostGroupName, compute.InstanceViewTypesInstanceView)
+}
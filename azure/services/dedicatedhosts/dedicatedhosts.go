@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedicatedhosts
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/slice"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ValidatePlacement checks that a virtual machine of the given size and zones can be placed on the dedicated host
+// group, and host if one is specified. Incompatibilities are returned as terminal errors since they can only be fixed
+// by changing the spec or the dedicated host group.
+func ValidatePlacement(ctx context.Context, client Client, dedicatedHost *infrav1.DedicatedHost, vmSize string, zones []string) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.ValidatePlacement")
+	defer done()
+
+	if dedicatedHost == nil {
+		return nil
+	}
+
+	resource, err := azureautorest.ParseResourceID(dedicatedHost.HostGroupID)
+	if err != nil {
+		return azure.WithTerminalError(errors.Wrapf(err, "failed to parse dedicated host group ID %s", dedicatedHost.HostGroupID))
+	}
+
+	group, err := client.GetHostGroup(ctx, resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		if azure.ResourceNotFound(err) {
+			return azure.WithTerminalError(errors.Errorf("dedicated host group %s does not exist", dedicatedHost.HostGroupID))
+		}
+		return errors.Wrapf(err, "failed to get dedicated host group %s", dedicatedHost.HostGroupID)
+	}
+
+	// A zonal host group can only host virtual machines in the same zone.
+	if group.Zones != nil && len(*group.Zones) > 0 {
+		if len(zones) == 0 {
+			return azure.WithTerminalError(errors.Errorf("dedicated host group %s is in zone(s) %v, a matching failure domain must be set", resource.ResourceName, *group.Zones))
+		}
+		for _, zone := range zones {
+			if !slice.Contains(*group.Zones, zone) {
+				return azure.WithTerminalError(errors.Errorf("zone %s is not available in dedicated host group %s which is in zone(s) %v", zone, resource.ResourceName, *group.Zones))
+			}
+		}
+	}
+
+	hostName := ""
+	if dedicatedHost.HostID != "" {
+		hostName = dedicatedHost.HostID[strings.LastIndex(dedicatedHost.HostID, "/")+1:]
+	} else if group.DedicatedHostGroupProperties == nil || !to.Bool(group.SupportAutomaticPlacement) {
+		return azure.WithTerminalError(errors.Errorf("dedicated host group %s does not support automatic placement, a host ID must be set", resource.ResourceName))
+	}
+
+	if group.DedicatedHostGroupProperties == nil || group.InstanceView == nil || group.InstanceView.Hosts == nil || len(*group.InstanceView.Hosts) == 0 {
+		// Without hosts there is nothing to validate the VM size against; let Azure report allocation failures.
+		return nil
+	}
+
+	hostFound := false
+	for _, host := range *group.InstanceView.Hosts {
+		if hostName != "" && !strings.EqualFold(to.String(host.Name), hostName) {
+			continue
+		}
+		hostFound = true
+		if hostSupportsVMSize(host.AvailableCapacity, vmSize) {
+			return nil
+		}
+	}
+
+	if !hostFound {
+		return azure.WithTerminalError(errors.Errorf("dedicated host %s does not exist in dedicated host group %s", hostName, resource.ResourceName))
+	}
+
+	return azure.WithTerminalError(errors.Errorf("vm size %s is not supported by the dedicated hosts in dedicated host group %s", vmSize, resource.ResourceName))
+}
+
+// hostSupportsVMSize returns true if the VM size is one of the sizes that can be allocated on a dedicated host.
+func hostSupportsVMSize(capacity *compute.DedicatedHostAvailableCapacity, vmSize string) bool {
+	if capacity == nil || capacity.AllocatableVMs == nil {
+		return false
+	}
+	for _, allocatable := range *capacity.AllocatableVMs {
+		if strings.EqualFold(to.String(allocatable.VMSize), vmSize) {
+			return true
+		}
+	}
+	return false
+}
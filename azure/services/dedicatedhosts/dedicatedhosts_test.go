@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedicatedhosts
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts/mock_dedicatedhosts"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+const hostGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+
+func hostGroup(zones []string, automaticPlacement bool, hosts ...compute.DedicatedHostInstanceViewWithName) compute.DedicatedHostGroup {
+	group := compute.DedicatedHostGroup{
+		DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+			SupportAutomaticPlacement: to.BoolPtr(automaticPlacement),
+			InstanceView: &compute.DedicatedHostGroupInstanceView{
+				Hosts: &hosts,
+			},
+		},
+	}
+	if zones != nil {
+		group.Zones = &zones
+	}
+	return group
+}
+
+func host(name string, vmSizes ...string) compute.DedicatedHostInstanceViewWithName {
+	allocatable := make([]compute.DedicatedHostAllocatableVM, len(vmSizes))
+	for i, size := range vmSizes {
+		allocatable[i] = compute.DedicatedHostAllocatableVM{VMSize: to.StringPtr(size), Count: to.Float64Ptr(1)}
+	}
+	return compute.DedicatedHostInstanceViewWithName{
+		Name: to.StringPtr(name),
+		AvailableCapacity: &compute.DedicatedHostAvailableCapacity{
+			AllocatableVMs: &allocatable,
+		},
+	}
+}
+
+func TestValidatePlacement(t *testing.T) {
+	testcases := []struct {
+		name          string
+		dedicatedHost *infrav1.DedicatedHost
+		vmSize        string
+		zones         []string
+		expectedError string
+		expect        func(m *mock_dedicatedhosts.MockClientMockRecorder)
+	}{
+		{
+			name:          "no dedicated host",
+			dedicatedHost: nil,
+			vmSize:        "Standard_D2s_v3",
+			expectedError: "",
+			expect:        func(m *mock_dedicatedhosts.MockClientMockRecorder) {},
+		},
+		{
+			name:          "automatic placement in a regional host group",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID},
+			vmSize:        "Standard_D2s_v3",
+			expectedError: "",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup(nil, true, host("host-1", "Standard_D2s_v3")), nil)
+			},
+		},
+		{
+			name:          "automatic placement not supported by host group",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID},
+			vmSize:        "Standard_D2s_v3",
+			expectedError: "dedicated host group my-host-group does not support automatic placement, a host ID must be set",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup(nil, false, host("host-1", "Standard_D2s_v3")), nil)
+			},
+		},
+		{
+			name:          "host group does not exist",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID},
+			vmSize:        "Standard_D2s_v3",
+			expectedError: "dedicated host group " + hostGroupID + " does not exist",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(compute.DedicatedHostGroup{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "zone matches zonal host group",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID},
+			vmSize:        "Standard_D2s_v3",
+			zones:         []string{"1"},
+			expectedError: "",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup([]string{"1"}, true, host("host-1", "Standard_D2s_v3")), nil)
+			},
+		},
+		{
+			name:          "zone does not match zonal host group",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID},
+			vmSize:        "Standard_D2s_v3",
+			zones:         []string{"1", "2"},
+			expectedError: "zone 2 is not available in dedicated host group my-host-group which is in zone(s) [1]",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup([]string{"1"}, true, host("host-1", "Standard_D2s_v3")), nil)
+			},
+		},
+		{
+			name:          "no zone set for zonal host group",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID},
+			vmSize:        "Standard_D2s_v3",
+			expectedError: "dedicated host group my-host-group is in zone(s) [1], a matching failure domain must be set",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup([]string{"1"}, true, host("host-1", "Standard_D2s_v3")), nil)
+			},
+		},
+		{
+			name:          "vm size not supported by any host",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID},
+			vmSize:        "Standard_E2s_v3",
+			expectedError: "vm size Standard_E2s_v3 is not supported by the dedicated hosts in dedicated host group my-host-group",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup(nil, true, host("host-1", "Standard_D2s_v3")), nil)
+			},
+		},
+		{
+			name:          "vm size supported by the specified host",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID, HostID: hostGroupID + "/hosts/host-2"},
+			vmSize:        "Standard_E2s_v3",
+			expectedError: "",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup(nil, false, host("host-1", "Standard_D2s_v3"), host("host-2", "Standard_E2s_v3")), nil)
+			},
+		},
+		{
+			name:          "vm size only supported by another host",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID, HostID: hostGroupID + "/hosts/host-1"},
+			vmSize:        "Standard_E2s_v3",
+			expectedError: "vm size Standard_E2s_v3 is not supported by the dedicated hosts in dedicated host group my-host-group",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup(nil, false, host("host-1", "Standard_D2s_v3"), host("host-2", "Standard_E2s_v3")), nil)
+			},
+		},
+		{
+			name:          "specified host does not exist",
+			dedicatedHost: &infrav1.DedicatedHost{HostGroupID: hostGroupID, HostID: hostGroupID + "/hosts/host-3"},
+			vmSize:        "Standard_D2s_v3",
+			expectedError: "dedicated host host-3 does not exist in dedicated host group my-host-group",
+			expect: func(m *mock_dedicatedhosts.MockClientMockRecorder) {
+				m.GetHostGroup(gomockinternal.AContext(), "my-rg", "my-host-group").Return(hostGroup(nil, false, host("host-1", "Standard_D2s_v3")), nil)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			clientMock := mock_dedicatedhosts.NewMockClient(mockCtrl)
+
+			tc.expect(clientMock.EXPECT())
+
+			err := ValidatePlacement(context.TODO(), clientMock, tc.dedicatedHost, tc.vmSize, tc.zones)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -26,11 +26,20 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// Client wraps go-sdk.
+type Client interface {
+	Get(ctx context.Context, resourceGroupName, name string) (msi.Identity, error)
+	GetClientID(ctx context.Context, providerID string) (string, error)
+	GetPrincipalID(ctx context.Context, providerID string) (string, error)
+}
+
 // AzureClient contains the Azure go-sdk Client.
 type AzureClient struct {
 	userAssignedIdentities msi.UserAssignedIdentitiesClient
 }
 
+var _ Client = &AzureClient{}
+
 // NewClient creates a new MSI client from auth info.
 func NewClient(auth azure.Authorizer) *AzureClient {
 	c := newUserAssignedIdentitiesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
@@ -67,3 +76,19 @@ func (ac *AzureClient) GetClientID(ctx context.Context, providerID string) (stri
 	}
 	return ident.ClientID.String(), nil
 }
+
+// GetPrincipalID returns the principal ID of a managed service identity, given its full URL identifier.
+func (ac *AzureClient) GetPrincipalID(ctx context.Context, providerID string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "identities.GetPrincipalID")
+	defer done()
+
+	parsed, err := azuresdk.ParseResourceID(providerID)
+	if err != nil {
+		return "", err
+	}
+	ident, err := ac.Get(ctx, parsed.ResourceGroup, parsed.ResourceName)
+	if err != nil {
+		return "", err
+	}
+	return ident.PrincipalID.String(), nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// client wraps go-sdk.
+type client interface {
+	List(context.Context, string, string) (result []containerservice.MaintenanceConfiguration, err error)
+	Get(context.Context, azure.ResourceSpecGetter) (result interface{}, err error)
+	CreateOrUpdateAsync(context.Context, azure.ResourceSpecGetter, interface{}) (result interface{}, future azureautorest.FutureAPI, err error)
+	DeleteAsync(context.Context, azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)
+	IsDone(context.Context, azureautorest.FutureAPI) (isDone bool, err error)
+	Result(context.Context, azureautorest.FutureAPI, string) (result interface{}, err error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	maintenanceconfigurations containerservice.MaintenanceConfigurationsClient
+}
+
+var _ client = (*azureClient)(nil)
+
+// newClient creates a new maintenance configurations client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	c := newMaintenanceConfigurationsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{c}
+}
+
+// newMaintenanceConfigurationsClient creates a new maintenance configurations client from subscription ID.
+func newMaintenanceConfigurationsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) containerservice.MaintenanceConfigurationsClient {
+	maintenanceConfigurationsClient := containerservice.NewMaintenanceConfigurationsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&maintenanceConfigurationsClient.Client, authorizer)
+	return maintenanceConfigurationsClient
+}
+
+// Get gets the specified maintenance configuration of a managed cluster.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.azureClient.Get")
+	defer done()
+
+	return ac.maintenanceconfigurations.Get(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+}
+
+// List returns all maintenance configurations of a managed cluster.
+func (ac *azureClient) List(ctx context.Context, resourceGroupName, clusterName string) (result []containerservice.MaintenanceConfiguration, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.azureClient.List")
+	defer done()
+
+	iter, err := ac.maintenanceconfigurations.ListByManagedClusterComplete(ctx, resourceGroupName, clusterName)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("could not list maintenance configurations for managed cluster %s", clusterName))
+	}
+
+	var configs []containerservice.MaintenanceConfiguration
+	for iter.NotDone() {
+		configs = append(configs, iter.Value())
+		if err := iter.NextWithContext(ctx); err != nil {
+			return configs, errors.Wrap(err, "could not iterate maintenance configurations")
+		}
+	}
+
+	return configs, nil
+}
+
+// CreateOrUpdateAsync creates or updates a maintenance configuration.
+// Creating a maintenance configuration is not a long running operation, so we don't ever return a future.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	config, ok := parameters.(containerservice.MaintenanceConfiguration)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a containerservice.MaintenanceConfiguration", parameters)
+	}
+
+	result, err = ac.maintenanceconfigurations.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName(), config)
+	return result, nil, err
+}
+
+// DeleteAsync deletes a maintenance configuration.
+// Deleting a maintenance configuration is not a long running operation, so we don't ever return a future.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.azureClient.DeleteAsync")
+	defer done()
+
+	_, err = ac.maintenanceconfigurations.Delete(ctx, spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+// Maintenance configuration operations are not long running, so there is never a future to check.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	return true, nil
+}
+
+// Result fetches the result of a long-running operation future.
+// Result is a no-op for maintenance configurations as no operation returns a future.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	return nil, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+const serviceName = "maintenanceconfigurations"
+
+// MaintenanceConfigurationScope defines the scope interface for a maintenance configuration service.
+type MaintenanceConfigurationScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	ResourceGroup() string
+	ManagedClusterName() string
+	MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter
+	OwnedMaintenanceConfigurations() []string
+	SetOwnedMaintenanceConfigurations([]string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope MaintenanceConfigurationScope
+	client
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope MaintenanceConfigurationScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		client:     client,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return serviceName
+}
+
+// Reconcile creates or updates the maintenance configurations of a managed cluster and deletes the ones created by
+// CAPZ that are no longer specified.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "maintenanceconfigurations.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	existingConfigs, err := s.client.List(ctx, s.Scope.ResourceGroup(), s.Scope.ManagedClusterName())
+	if err != nil {
+		result := errors.Wrap(err, "failed to get existing maintenance configurations")
+		s.Scope.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, result)
+		return result
+	}
+
+	owned := make(map[string]struct{})
+	for _, name := range s.Scope.OwnedMaintenanceConfigurations() {
+		owned[name] = struct{}{}
+	}
+
+	specs := s.Scope.MaintenanceConfigurationSpecs()
+	specified := make(map[string]struct{}, len(specs))
+	stillOwned := make([]string, 0, len(specs))
+
+	// We go through the list of MaintenanceConfigurationSpecs to reconcile each one, independently of the result of the previous one.
+	// If multiple errors occur, we return the most pressing one.
+	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
+	var result error
+	for _, spec := range specs {
+		specified[spec.ResourceName()] = struct{}{}
+		stillOwned = append(stillOwned, spec.ResourceName())
+		if _, err := s.CreateResource(ctx, spec, serviceName); err != nil {
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+
+	// Maintenance configurations created by CAPZ that were removed from the spec are deleted so AKS stops honoring
+	// them. Maintenance configurations created outside of CAPZ are left untouched.
+	for _, config := range existingConfigs {
+		name := to.String(config.Name)
+		if _, ok := specified[name]; ok {
+			continue
+		}
+		if _, ok := owned[name]; !ok {
+			log.V(4).Info("skipping maintenance configuration not created by CAPZ", "maintenanceConfiguration", name)
+			continue
+		}
+		log.V(2).Info("deleting maintenance configuration no longer in spec", "maintenanceConfiguration", name)
+		spec := &MaintenanceConfigurationSpec{
+			Name:          name,
+			ResourceGroup: s.Scope.ResourceGroup(),
+			Cluster:       s.Scope.ManagedClusterName(),
+		}
+		if err := s.DeleteResource(ctx, spec, serviceName); err != nil {
+			// Keep track of the maintenance configuration until it is deleted.
+			stillOwned = append(stillOwned, name)
+			if !azure.IsOperationNotDoneError(err) || result == nil {
+				result = err
+			}
+		}
+	}
+
+	sort.Strings(stillOwned)
+	s.Scope.SetOwnedMaintenanceConfigurations(stillOwned)
+	s.Scope.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, result)
+	return result
+}
+
+// Delete is a no-op as maintenance configurations are deleted as part of the managed cluster deletion.
+func (s *Service) Delete(ctx context.Context) error {
+	return nil
+}
+
+// IsManaged always returns true as CAPZ only deletes the maintenance configurations it created.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
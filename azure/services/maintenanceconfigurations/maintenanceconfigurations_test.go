@@ -0,0 +1,202 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations/mock_maintenanceconfigurations"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeGroupName   = "my-rg"
+	fakeClusterName = "my-cluster"
+
+	fakeDefaultSpec = MaintenanceConfigurationSpec{
+		Name:          "default",
+		ResourceGroup: fakeGroupName,
+		Cluster:       fakeClusterName,
+		TimeInWeek: []infrav1exp.TimeInWeek{
+			{Day: "Saturday", HourSlots: []int32{1, 2}},
+		},
+	}
+	fakeStaleSpec = MaintenanceConfigurationSpec{
+		Name:          "stale",
+		ResourceGroup: fakeGroupName,
+		Cluster:       fakeClusterName,
+	}
+
+	fakeExistingConfigs = []containerservice.MaintenanceConfiguration{
+		{Name: to.StringPtr("default")},
+		{Name: to.StringPtr("stale")},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
+)
+
+func TestReconcileMaintenanceConfigurations(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder,
+			m *mock_maintenanceconfigurations.MockclientMockRecorder,
+			r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no maintenance configurations are specified or exist",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder,
+				m *mock_maintenanceconfigurations.MockclientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
+				s.ManagedClusterName().AnyTimes().Return(fakeClusterName)
+				m.List(gomockinternal.AContext(), fakeGroupName, fakeClusterName).Return(nil, nil)
+				s.OwnedMaintenanceConfigurations().Return(nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{})
+				s.SetOwnedMaintenanceConfigurations([]string{})
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "maintenance configuration successfully created and stale one deleted",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder,
+				m *mock_maintenanceconfigurations.MockclientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
+				s.ManagedClusterName().AnyTimes().Return(fakeClusterName)
+				m.List(gomockinternal.AContext(), fakeGroupName, fakeClusterName).Return(fakeExistingConfigs, nil)
+				s.OwnedMaintenanceConfigurations().Return([]string{"default", "stale"})
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&fakeDefaultSpec})
+				gomock.InOrder(
+					r.CreateResource(gomockinternal.AContext(), &fakeDefaultSpec, serviceName).Return(nil, nil),
+					r.DeleteResource(gomockinternal.AContext(), &fakeStaleSpec, serviceName).Return(nil),
+					s.SetOwnedMaintenanceConfigurations([]string{"default"}),
+					s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, nil),
+				)
+			},
+		},
+		{
+			name:          "maintenance configuration not created by CAPZ is not deleted",
+			expectedError: "",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder,
+				m *mock_maintenanceconfigurations.MockclientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
+				s.ManagedClusterName().AnyTimes().Return(fakeClusterName)
+				m.List(gomockinternal.AContext(), fakeGroupName, fakeClusterName).Return(fakeExistingConfigs, nil)
+				s.OwnedMaintenanceConfigurations().Return([]string{"default"})
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&fakeDefaultSpec})
+				gomock.InOrder(
+					r.CreateResource(gomockinternal.AContext(), &fakeDefaultSpec, serviceName).Return(nil, nil),
+					s.SetOwnedMaintenanceConfigurations([]string{"default"}),
+					s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, nil),
+				)
+			},
+		},
+		{
+			name:          "maintenance configuration created by CAPZ is owned until it is deleted",
+			expectedError: "operation type DELETE on Azure resource my-rg/stale is not done",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder,
+				m *mock_maintenanceconfigurations.MockclientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				deleteInProgress := azure.NewOperationNotDoneError(&infrav1.Future{Type: infrav1.DeleteFuture, ResourceGroup: fakeGroupName, Name: "stale"})
+				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
+				s.ManagedClusterName().AnyTimes().Return(fakeClusterName)
+				m.List(gomockinternal.AContext(), fakeGroupName, fakeClusterName).Return(fakeExistingConfigs[1:], nil)
+				s.OwnedMaintenanceConfigurations().Return([]string{"stale"})
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{})
+				gomock.InOrder(
+					r.DeleteResource(gomockinternal.AContext(), &fakeStaleSpec, serviceName).Return(deleteInProgress),
+					s.SetOwnedMaintenanceConfigurations([]string{"stale"}),
+					s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, deleteInProgress),
+				)
+			},
+		},
+		{
+			name:          "fail to get existing maintenance configurations",
+			expectedError: "failed to get existing maintenance configurations: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder,
+				m *mock_maintenanceconfigurations.MockclientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
+				s.ManagedClusterName().AnyTimes().Return(fakeClusterName)
+				m.List(gomockinternal.AContext(), fakeGroupName, fakeClusterName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, gomockinternal.ErrStrEq("failed to get existing maintenance configurations: #: Internal Server Error: StatusCode=500"))
+			},
+		},
+		{
+			name:          "fail to create maintenance configuration",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_maintenanceconfigurations.MockMaintenanceConfigurationScopeMockRecorder,
+				m *mock_maintenanceconfigurations.MockclientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
+				s.ManagedClusterName().AnyTimes().Return(fakeClusterName)
+				m.List(gomockinternal.AContext(), fakeGroupName, fakeClusterName).Return(fakeExistingConfigs[:1], nil)
+				s.OwnedMaintenanceConfigurations().Return(nil)
+				s.MaintenanceConfigurationSpecs().Return([]azure.ResourceSpecGetter{&fakeDefaultSpec})
+				gomock.InOrder(
+					r.CreateResource(gomockinternal.AContext(), &fakeDefaultSpec, serviceName).Return(nil, internalError),
+					s.SetOwnedMaintenanceConfigurations([]string{"default"}),
+					s.UpdatePutStatus(infrav1.MaintenanceConfigurationsReadyCondition, serviceName, internalError),
+				)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_maintenanceconfigurations.NewMockMaintenanceConfigurationScope(mockCtrl)
+			clientMock := mock_maintenanceconfigurations.NewMockclient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				client:     clientMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../maintenanceconfigurations.go
+
+// Package mock_maintenanceconfigurations is a generated GoMock package.
+package mock_maintenanceconfigurations
+
+import (
+	reflect "reflect"
+
+	autorest "github.com/Azure/go-autorest/autorest"
+	gomock "github.com/golang/mock/gomock"
+	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
+	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MockMaintenanceConfigurationScope is a mock of MaintenanceConfigurationScope interface.
+type MockMaintenanceConfigurationScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceConfigurationScopeMockRecorder
+}
+
+// MockMaintenanceConfigurationScopeMockRecorder is the mock recorder for MockMaintenanceConfigurationScope.
+type MockMaintenanceConfigurationScopeMockRecorder struct {
+	mock *MockMaintenanceConfigurationScope
+}
+
+// NewMockMaintenanceConfigurationScope creates a new mock instance.
+func NewMockMaintenanceConfigurationScope(ctrl *gomock.Controller) *MockMaintenanceConfigurationScope {
+	mock := &MockMaintenanceConfigurationScope{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceConfigurationScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceConfigurationScope) EXPECT() *MockMaintenanceConfigurationScopeMockRecorder {
+	return m.recorder
+}
+
+// Authorizer mocks base method.
+func (m *MockMaintenanceConfigurationScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).Authorizer))
+}
+
+// BaseURI mocks base method.
+func (m *MockMaintenanceConfigurationScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).BaseURI))
+}
+
+// ClientID mocks base method.
+func (m *MockMaintenanceConfigurationScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockMaintenanceConfigurationScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockMaintenanceConfigurationScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).CloudEnvironment))
+}
+
+// DeleteLongRunningOperationState mocks base method.
+func (m *MockMaintenanceConfigurationScope) DeleteLongRunningOperationState(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteLongRunningOperationState", arg0, arg1)
+}
+
+// DeleteLongRunningOperationState indicates an expected call of DeleteLongRunningOperationState.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) DeleteLongRunningOperationState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).DeleteLongRunningOperationState), arg0, arg1)
+}
+
+// GetLongRunningOperationState mocks base method.
+func (m *MockMaintenanceConfigurationScope) GetLongRunningOperationState(arg0, arg1 string) *v1beta1.Future {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLongRunningOperationState", arg0, arg1)
+	ret0, _ := ret[0].(*v1beta1.Future)
+	return ret0
+}
+
+// GetLongRunningOperationState indicates an expected call of GetLongRunningOperationState.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) GetLongRunningOperationState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongRunningOperationState", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).GetLongRunningOperationState), arg0, arg1)
+}
+
+// HashKey mocks base method.
+func (m *MockMaintenanceConfigurationScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).HashKey))
+}
+
+// MaintenanceConfigurationSpecs mocks base method.
+func (m *MockMaintenanceConfigurationScope) MaintenanceConfigurationSpecs() []azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaintenanceConfigurationSpecs")
+	ret0, _ := ret[0].([]azure.ResourceSpecGetter)
+	return ret0
+}
+
+// MaintenanceConfigurationSpecs indicates an expected call of MaintenanceConfigurationSpecs.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) MaintenanceConfigurationSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaintenanceConfigurationSpecs", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).MaintenanceConfigurationSpecs))
+}
+
+// ManagedClusterName mocks base method.
+func (m *MockMaintenanceConfigurationScope) ManagedClusterName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManagedClusterName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ManagedClusterName indicates an expected call of ManagedClusterName.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) ManagedClusterName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagedClusterName", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).ManagedClusterName))
+}
+
+// OwnedMaintenanceConfigurations mocks base method.
+func (m *MockMaintenanceConfigurationScope) OwnedMaintenanceConfigurations() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnedMaintenanceConfigurations")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OwnedMaintenanceConfigurations indicates an expected call of OwnedMaintenanceConfigurations.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) OwnedMaintenanceConfigurations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnedMaintenanceConfigurations", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).OwnedMaintenanceConfigurations))
+}
+
+// ResourceGroup mocks base method.
+func (m *MockMaintenanceConfigurationScope) ResourceGroup() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceGroup")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceGroup indicates an expected call of ResourceGroup.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) ResourceGroup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).ResourceGroup))
+}
+
+// SetLongRunningOperationState mocks base method.
+func (m *MockMaintenanceConfigurationScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLongRunningOperationState", arg0)
+}
+
+// SetLongRunningOperationState indicates an expected call of SetLongRunningOperationState.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) SetLongRunningOperationState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).SetLongRunningOperationState), arg0)
+}
+
+// SetOwnedMaintenanceConfigurations mocks base method.
+func (m *MockMaintenanceConfigurationScope) SetOwnedMaintenanceConfigurations(arg0 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOwnedMaintenanceConfigurations", arg0)
+}
+
+// SetOwnedMaintenanceConfigurations indicates an expected call of SetOwnedMaintenanceConfigurations.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) SetOwnedMaintenanceConfigurations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOwnedMaintenanceConfigurations", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).SetOwnedMaintenanceConfigurations), arg0)
+}
+
+// SubscriptionID mocks base method.
+func (m *MockMaintenanceConfigurationScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockMaintenanceConfigurationScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).TenantID))
+}
+
+// UpdateDeleteStatus mocks base method.
+func (m *MockMaintenanceConfigurationScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateDeleteStatus", arg0, arg1, arg2)
+}
+
+// UpdateDeleteStatus indicates an expected call of UpdateDeleteStatus.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) UpdateDeleteStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeleteStatus", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).UpdateDeleteStatus), arg0, arg1, arg2)
+}
+
+// UpdatePatchStatus mocks base method.
+func (m *MockMaintenanceConfigurationScope) UpdatePatchStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePatchStatus", arg0, arg1, arg2)
+}
+
+// UpdatePatchStatus indicates an expected call of UpdatePatchStatus.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) UpdatePatchStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatchStatus", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).UpdatePatchStatus), arg0, arg1, arg2)
+}
+
+// UpdatePutStatus mocks base method.
+func (m *MockMaintenanceConfigurationScope) UpdatePutStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePutStatus", arg0, arg1, arg2)
+}
+
+// UpdatePutStatus indicates an expected call of UpdatePutStatus.
+func (mr *MockMaintenanceConfigurationScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockMaintenanceConfigurationScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+)
+
+// MaintenanceConfigurationSpec defines the specification for a planned maintenance configuration of a managed cluster.
+type MaintenanceConfigurationSpec struct {
+	Name           string
+	ResourceGroup  string
+	Cluster        string
+	TimeInWeek     []infrav1exp.TimeInWeek
+	NotAllowedTime []infrav1exp.TimeSpan
+}
+
+// ResourceName returns the name of the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *MaintenanceConfigurationSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the managed cluster that owns the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) OwnerResourceName() string {
+	return s.Cluster
+}
+
+// Parameters returns the parameters for the maintenance configuration.
+func (s *MaintenanceConfigurationSpec) Parameters(existing interface{}) (params interface{}, err error) {
+	properties := &containerservice.MaintenanceConfigurationProperties{}
+
+	if len(s.TimeInWeek) > 0 {
+		timeInWeek := make([]containerservice.TimeInWeek, 0, len(s.TimeInWeek))
+		for _, t := range s.TimeInWeek {
+			hourSlots := t.HourSlots
+			timeInWeek = append(timeInWeek, containerservice.TimeInWeek{
+				Day:       containerservice.WeekDay(t.Day),
+				HourSlots: &hourSlots,
+			})
+		}
+		properties.TimeInWeek = &timeInWeek
+	}
+
+	if len(s.NotAllowedTime) > 0 {
+		notAllowedTime := make([]containerservice.TimeSpan, 0, len(s.NotAllowedTime))
+		for _, t := range s.NotAllowedTime {
+			notAllowedTime = append(notAllowedTime, containerservice.TimeSpan{
+				Start: &date.Time{Time: t.Start.UTC()},
+				End:   &date.Time{Time: t.End.UTC()},
+			})
+		}
+		properties.NotAllowedTime = &notAllowedTime
+	}
+
+	if existing != nil {
+		existingConfig, ok := existing.(containerservice.MaintenanceConfiguration)
+		if !ok {
+			return nil, errors.Errorf("%T is not a containerservice.MaintenanceConfiguration", existing)
+		}
+		if cmp.Diff(normalizeProperties(properties), normalizeProperties(existingConfig.MaintenanceConfigurationProperties)) == "" {
+			// maintenance configuration is up to date, nothing to do
+			return nil, nil
+		}
+	}
+
+	return containerservice.MaintenanceConfiguration{
+		MaintenanceConfigurationProperties: properties,
+	}, nil
+}
+
+// normalizedTimeSpan is a time span whose times are comparable regardless of their location.
+type normalizedTimeSpan struct {
+	Start int64
+	End   int64
+}
+
+// normalizedProperties are the maintenance configuration properties that can be specified, in a comparable form.
+type normalizedProperties struct {
+	TimeInWeek     map[containerservice.WeekDay][]int32
+	NotAllowedTime []normalizedTimeSpan
+}
+
+// normalizeProperties returns the properties in a form that doesn't get thrown off by how Azure returns them,
+// e.g. empty lists instead of nil ones or times in a different location.
+func normalizeProperties(properties *containerservice.MaintenanceConfigurationProperties) normalizedProperties {
+	normalized := normalizedProperties{}
+	if properties == nil {
+		return normalized
+	}
+
+	if properties.TimeInWeek != nil {
+		for _, t := range *properties.TimeInWeek {
+			if normalized.TimeInWeek == nil {
+				normalized.TimeInWeek = map[containerservice.WeekDay][]int32{}
+			}
+			if t.HourSlots != nil {
+				normalized.TimeInWeek[t.Day] = append(normalized.TimeInWeek[t.Day], *t.HourSlots...)
+			} else if _, ok := normalized.TimeInWeek[t.Day]; !ok {
+				normalized.TimeInWeek[t.Day] = nil
+			}
+		}
+	}
+
+	if properties.NotAllowedTime != nil {
+		for _, t := range *properties.NotAllowedTime {
+			span := normalizedTimeSpan{}
+			if t.Start != nil {
+				span.Start = t.Start.Unix()
+			}
+			if t.End != nil {
+				span.End = t.End.Unix()
+			}
+			normalized.NotAllowedTime = append(normalized.NotAllowedTime, span)
+		}
+	}
+
+	return normalized
+}
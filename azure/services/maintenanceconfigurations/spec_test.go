@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenanceconfigurations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest/date"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+)
+
+func TestParameters(t *testing.T) {
+	start := time.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, time.December, 26, 0, 0, 0, 0, time.UTC)
+	spec := &MaintenanceConfigurationSpec{
+		Name:          "default",
+		ResourceGroup: "my-rg",
+		Cluster:       "my-cluster",
+		TimeInWeek: []infrav1exp.TimeInWeek{
+			{Day: "Saturday", HourSlots: []int32{1, 2}},
+			{Day: "Sunday"},
+		},
+		NotAllowedTime: []infrav1exp.TimeSpan{
+			{Start: metav1.NewTime(start), End: metav1.NewTime(end)},
+		},
+	}
+	expected := containerservice.MaintenanceConfiguration{
+		MaintenanceConfigurationProperties: &containerservice.MaintenanceConfigurationProperties{
+			TimeInWeek: &[]containerservice.TimeInWeek{
+				{Day: containerservice.WeekDaySaturday, HourSlots: &[]int32{1, 2}},
+				{Day: containerservice.WeekDaySunday, HourSlots: &[]int32{}},
+			},
+			NotAllowedTime: &[]containerservice.TimeSpan{
+				{Start: &date.Time{Time: start}, End: &date.Time{Time: end}},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		existing      interface{}
+		expectedError string
+		expect        func(g *WithT, result interface{})
+	}{
+		{
+			name:     "maintenance configuration does not exist",
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.MaintenanceConfiguration{}))
+				config := result.(containerservice.MaintenanceConfiguration)
+				g.Expect(normalizeProperties(config.MaintenanceConfigurationProperties)).To(Equal(normalizeProperties(expected.MaintenanceConfigurationProperties)))
+			},
+		},
+		{
+			name: "maintenance configuration exists and is up to date",
+			existing: containerservice.MaintenanceConfiguration{
+				MaintenanceConfigurationProperties: &containerservice.MaintenanceConfigurationProperties{
+					TimeInWeek: &[]containerservice.TimeInWeek{
+						{Day: containerservice.WeekDaySaturday, HourSlots: &[]int32{1, 2}},
+						{Day: containerservice.WeekDaySunday, HourSlots: &[]int32{}},
+					},
+					NotAllowedTime: &[]containerservice.TimeSpan{
+						{Start: &date.Time{Time: start.In(time.FixedZone("UTC+1", 3600))}, End: &date.Time{Time: end}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "maintenance configuration exists and needs an update",
+			existing: containerservice.MaintenanceConfiguration{
+				MaintenanceConfigurationProperties: &containerservice.MaintenanceConfigurationProperties{
+					TimeInWeek: &[]containerservice.TimeInWeek{
+						{Day: containerservice.WeekDaySaturday, HourSlots: &[]int32{1}},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.MaintenanceConfiguration{}))
+			},
+		},
+		{
+			name:          "existing is not a maintenance configuration",
+			existing:      "not a maintenance configuration",
+			expectedError: "string is not a containerservice.MaintenanceConfiguration",
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := spec.Parameters(tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
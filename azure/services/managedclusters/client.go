@@ -20,19 +20,20 @@ import (
 	"context"
 	"encoding/json"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
 // CredentialGetter is a helper interface for getting managed cluster credentials.
 type CredentialGetter interface {
-	GetCredentials(context.Context, string, string) ([]byte, error)
+	GetCredentials(context.Context, string, string, infrav1exp.KubeconfigCredentialKind) ([]byte, error)
 }
 
 // azureClient contains the Azure go-sdk Client.
@@ -62,12 +63,21 @@ func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (
 	return ac.managedclusters.Get(ctx, spec.ResourceGroupName(), spec.ResourceName())
 }
 
-// GetCredentials fetches the admin kubeconfig for a managed cluster.
-func (ac *azureClient) GetCredentials(ctx context.Context, resourceGroupName, name string) ([]byte, error) {
+// GetCredentials fetches the kubeconfig of the given credential kind for a managed cluster.
+func (ac *azureClient) GetCredentials(ctx context.Context, resourceGroupName, name string, kind infrav1exp.KubeconfigCredentialKind) ([]byte, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.GetCredentials")
 	defer done()
 
-	credentialList, err := ac.managedclusters.ListClusterAdminCredentials(ctx, resourceGroupName, name, "")
+	var credentialList containerservice.CredentialResults
+	var err error
+	switch kind {
+	case infrav1exp.KubeconfigCredentialKindUser:
+		credentialList, err = ac.managedclusters.ListClusterUserCredentials(ctx, resourceGroupName, name, "", containerservice.FormatAzure)
+	case infrav1exp.KubeconfigCredentialKindExec, infrav1exp.KubeconfigCredentialKindAADToken:
+		credentialList, err = ac.managedclusters.ListClusterUserCredentials(ctx, resourceGroupName, name, "", containerservice.FormatExec)
+	default:
+		credentialList, err = ac.managedclusters.ListClusterAdminCredentials(ctx, resourceGroupName, name, "")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +142,7 @@ func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.azureClient.DeleteAsync")
 	defer done()
 
-	deleteFuture, err := ac.managedclusters.Delete(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	deleteFuture, err := ac.managedclusters.Delete(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
 	if err != nil {
 		return nil, err
 	}
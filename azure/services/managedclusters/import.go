@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedclusters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+)
+
+// importConflicts returns the differences between the spec and an existing managed cluster that cannot be reconciled
+// once CAPZ takes over the cluster, because AKS does not allow changing them.
+func (s *ManagedClusterSpec) importConflicts(existing containerservice.ManagedCluster) ([]string, error) {
+	if existing.ManagedClusterProperties == nil {
+		return nil, errors.Errorf("managed cluster %s has no properties", s.Name)
+	}
+
+	var conflicts []string
+	conflict := func(field string, desired, actual string) {
+		conflicts = append(conflicts, fmt.Sprintf("%s is %q in the spec but %q in the existing cluster", field, desired, actual))
+	}
+
+	if normalizeLocation(s.Location) != normalizeLocation(to.String(existing.Location)) {
+		conflict("location", s.Location, to.String(existing.Location))
+	}
+	if !strings.EqualFold(s.NodeResourceGroup, to.String(existing.NodeResourceGroup)) {
+		conflict("node resource group", s.NodeResourceGroup, to.String(existing.NodeResourceGroup))
+	}
+	if existing.KubernetesVersion != nil && semver.Compare(semverString(s.Version), semverString(*existing.KubernetesVersion)) < 0 &&
+		!s.AutoUpgradeProfile.upgradesKubernetesVersion() {
+		conflict("Kubernetes version", s.Version, *existing.KubernetesVersion)
+	}
+
+	if existing.ServicePrincipalProfile != nil && !strings.EqualFold(to.String(existing.ServicePrincipalProfile.ClientID), "msi") {
+		conflicts = append(conflicts, "the existing cluster uses a service principal instead of a managed identity")
+	}
+	desiredIdentity, existingIdentity := s.identityResourceID(), existingIdentityResourceID(existing)
+	if !strings.EqualFold(desiredIdentity, existingIdentity) {
+		conflict("control plane identity", desiredIdentity, existingIdentity)
+	}
+
+	if existing.AadProfile != nil && to.Bool(existing.AadProfile.Managed) && s.AADProfile == nil {
+		conflicts = append(conflicts, "the existing cluster uses managed AAD, which cannot be disabled")
+	}
+
+	if profile := existing.NetworkProfile; profile != nil {
+		if !strings.EqualFold(s.NetworkPlugin, string(profile.NetworkPlugin)) {
+			conflict("network plugin", s.NetworkPlugin, string(profile.NetworkPlugin))
+		}
+		if !strings.EqualFold(s.NetworkPolicy, string(profile.NetworkPolicy)) {
+			conflict("network policy", s.NetworkPolicy, string(profile.NetworkPolicy))
+		}
+		if !strings.EqualFold(s.LoadBalancerSKU, string(profile.LoadBalancerSku)) {
+			conflict("load balancer SKU", s.LoadBalancerSKU, string(profile.LoadBalancerSku))
+		}
+		if outboundType := s.outboundType(); !strings.EqualFold(outboundType, string(profile.OutboundType)) {
+			conflict("outbound type", outboundType, string(profile.OutboundType))
+		}
+		if s.PodCIDR != "" && s.PodCIDR != to.String(profile.PodCidr) {
+			conflict("pod CIDR", s.PodCIDR, to.String(profile.PodCidr))
+		}
+		if s.ServiceCIDR != "" && s.ServiceCIDR != to.String(profile.ServiceCidr) {
+			conflict("service CIDR", s.ServiceCIDR, to.String(profile.ServiceCidr))
+		}
+		if s.DNSServiceIP != nil && *s.DNSServiceIP != to.String(profile.DNSServiceIP) {
+			conflict("DNS service IP", *s.DNSServiceIP, to.String(profile.DNSServiceIP))
+		}
+	}
+
+	agentPoolConflicts, err := s.agentPoolImportConflicts(existing)
+	if err != nil {
+		return nil, err
+	}
+	return append(conflicts, agentPoolConflicts...), nil
+}
+
+// agentPoolImportConflicts returns the differences between the agent pool specs and the agent pools of an existing
+// managed cluster that cannot be reconciled, because AKS does not allow changing them.
+func (s *ManagedClusterSpec) agentPoolImportConflicts(existing containerservice.ManagedCluster) ([]string, error) {
+	if s.GetAllAgentPools == nil || existing.AgentPoolProfiles == nil {
+		return nil, nil
+	}
+
+	existingPools := make(map[string]containerservice.ManagedClusterAgentPoolProfile, len(*existing.AgentPoolProfiles))
+	for _, profile := range *existing.AgentPoolProfiles {
+		existingPools[to.String(profile.Name)] = profile
+	}
+
+	agentPoolSpecs, err := s.GetAllAgentPools()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get agent pool specs for managed cluster %s", s.Name)
+	}
+
+	var conflicts []string
+	for _, spec := range agentPoolSpecs {
+		existingPool, ok := existingPools[spec.ResourceName()]
+		if !ok {
+			// The agent pool will be created once the import is confirmed.
+			continue
+		}
+		params, err := spec.Parameters(nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get agent pool parameters for managed cluster %s", s.Name)
+		}
+		agentPool, ok := params.(containerservice.AgentPool)
+		if !ok {
+			return nil, errors.Errorf("%T is not a containerservice.AgentPool", params)
+		}
+
+		conflict := func(field string, desired, actual string) {
+			conflicts = append(conflicts, fmt.Sprintf("%s of agent pool %s is %q in the spec but %q in the existing cluster",
+				field, spec.ResourceName(), desired, actual))
+		}
+		if !strings.EqualFold(to.String(agentPool.VMSize), to.String(existingPool.VMSize)) {
+			conflict("VM size", to.String(agentPool.VMSize), to.String(existingPool.VMSize))
+		}
+		if agentPool.OsDiskSizeGB != nil && existingPool.OsDiskSizeGB != nil && *agentPool.OsDiskSizeGB != *existingPool.OsDiskSizeGB {
+			conflict("OS disk size", fmt.Sprint(*agentPool.OsDiskSizeGB), fmt.Sprint(*existingPool.OsDiskSizeGB))
+		}
+		if agentPool.OsDiskType != "" && agentPool.OsDiskType != existingPool.OsDiskType {
+			conflict("OS disk type", string(agentPool.OsDiskType), string(existingPool.OsDiskType))
+		}
+		if agentPool.OsType != "" && agentPool.OsType != existingPool.OsType {
+			conflict("OS type", string(agentPool.OsType), string(existingPool.OsType))
+		}
+		if agentPool.MaxPods != nil && existingPool.MaxPods != nil && *agentPool.MaxPods != *existingPool.MaxPods {
+			conflict("max pods", fmt.Sprint(*agentPool.MaxPods), fmt.Sprint(*existingPool.MaxPods))
+		}
+		if agentPool.VnetSubnetID != nil && !strings.EqualFold(*agentPool.VnetSubnetID, to.String(existingPool.VnetSubnetID)) {
+			conflict("subnet", *agentPool.VnetSubnetID, to.String(existingPool.VnetSubnetID))
+		}
+		if agentPool.AvailabilityZones != nil && !equalZones(*agentPool.AvailabilityZones, existingPool.AvailabilityZones) {
+			conflict("availability zones", strings.Join(*agentPool.AvailabilityZones, ","), strings.Join(to.StringSlice(existingPool.AvailabilityZones), ","))
+		}
+	}
+	return conflicts, nil
+}
+
+// identityResourceID returns the resource ID of the user-assigned control plane identity, or an empty string
+// if the control plane uses a system-assigned identity.
+func (s *ManagedClusterSpec) identityResourceID() string {
+	if s.Identity == nil {
+		return ""
+	}
+	return s.Identity.ControlPlaneIdentityResourceID
+}
+
+// outboundType returns the outbound type of the spec, or the AKS default if it is not set.
+func (s *ManagedClusterSpec) outboundType() string {
+	if s.OutboundType == "" {
+		return string(containerservice.OutboundTypeLoadBalancer)
+	}
+	return s.OutboundType
+}
+
+// existingIdentityResourceID returns the resource ID of the user-assigned control plane identity of an existing
+// managed cluster, or an empty string if it uses a system-assigned identity.
+func existingIdentityResourceID(existing containerservice.ManagedCluster) string {
+	if existing.Identity == nil || existing.Identity.Type != containerservice.ResourceIdentityTypeUserAssigned {
+		return ""
+	}
+	for id := range existing.Identity.UserAssignedIdentities {
+		return id
+	}
+	return ""
+}
+
+// normalizeLocation returns a location in the lowercase form without spaces used by Azure Resource Manager.
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
+
+// equalZones returns true if both lists contain the same availability zones, in any order.
+func equalZones(desired []string, existing *[]string) bool {
+	actual := to.StringSlice(existing)
+	if len(desired) != len(actual) {
+		return false
+	}
+	desired = append([]string{}, desired...)
+	actual = append([]string{}, actual...)
+	sort.Strings(desired)
+	sort.Strings(actual)
+	for i := range desired {
+		if desired[i] != actual[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedclusters
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
+)
+
+func TestImportConflicts(t *testing.T) {
+	testcases := []struct {
+		name     string
+		existing func() containerservice.ManagedCluster
+		spec     func(s *ManagedClusterSpec)
+		expect   []string
+	}{
+		{
+			name:     "existing cluster matches the spec",
+			existing: getImportedCluster,
+			spec:     func(s *ManagedClusterSpec) {},
+			expect:   nil,
+		},
+		{
+			name:     "newer Kubernetes version in the spec is an upgrade",
+			existing: getImportedCluster,
+			spec: func(s *ManagedClusterSpec) {
+				s.Version = "v1.23.5"
+			},
+			expect: nil,
+		},
+		{
+			name:     "older Kubernetes version in the spec cannot be applied",
+			existing: getImportedCluster,
+			spec: func(s *ManagedClusterSpec) {
+				s.Version = "v1.21.2"
+			},
+			expect: []string{`Kubernetes version is "v1.21.2" in the spec but "v1.22.0" in the existing cluster`},
+		},
+		{
+			name: "immutable cluster settings differ",
+			existing: func() containerservice.ManagedCluster {
+				mc := getImportedCluster()
+				mc.NetworkProfile.NetworkPlugin = containerservice.NetworkPluginKubenet
+				mc.ServicePrincipalProfile.ClientID = to.StringPtr("00000000-0000-0000-0000-000000000000")
+				return mc
+			},
+			spec: func(s *ManagedClusterSpec) {
+				s.Location = "West Europe"
+				s.PodCIDR = "10.245.0.0/16"
+			},
+			expect: []string{
+				`location is "West Europe" in the spec but "test-location" in the existing cluster`,
+				"the existing cluster uses a service principal instead of a managed identity",
+				`network plugin is "azure" in the spec but "kubenet" in the existing cluster`,
+				`pod CIDR is "10.245.0.0/16" in the spec but "10.244.0.0/16" in the existing cluster`,
+			},
+		},
+		{
+			name:     "immutable agent pool settings differ",
+			existing: getImportedCluster,
+			spec: func(s *ManagedClusterSpec) {
+				s.GetAllAgentPools = func() ([]azure.ResourceSpecGetter, error) {
+					return []azure.ResourceSpecGetter{
+						&agentpools.AgentPoolSpec{
+							Name:              "test-agentpool-1",
+							SKU:               "Standard_D4s_v3",
+							VnetSubnetID:      "fake/subnet/id",
+							AvailabilityZones: []string{"2", "1"},
+						},
+						&agentpools.AgentPoolSpec{
+							Name: "new-agentpool",
+							SKU:  "Standard_D2s_v3",
+						},
+					}, nil
+				}
+			},
+			expect: []string{`VM size of agent pool test-agentpool-1 is "Standard_D4s_v3" in the spec but "test_SKU" in the existing cluster`},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			spec := &ManagedClusterSpec{
+				Name:              "test-managedcluster",
+				ResourceGroup:     "test-rg",
+				NodeResourceGroup: "test-node-rg",
+				Location:          "test-location",
+				Version:           "v1.22.0",
+				NetworkPlugin:     "azure",
+				NetworkPolicy:     "calico",
+				LoadBalancerSKU:   "Standard",
+			}
+			tc.spec(spec)
+			conflicts, err := spec.importConflicts(tc.existing())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(conflicts).To(Equal(tc.expect))
+		})
+	}
+}
+
+func getImportedCluster() containerservice.ManagedCluster {
+	mc := getExistingCluster()
+	mc.NetworkProfile = &containerservice.NetworkProfile{
+		NetworkPlugin:   containerservice.NetworkPluginAzure,
+		NetworkPolicy:   containerservice.NetworkPolicyCalico,
+		LoadBalancerSku: containerservice.LoadBalancerSkuStandard,
+		OutboundType:    containerservice.OutboundTypeLoadBalancer,
+		PodCidr:         to.StringPtr("10.244.0.0/16"),
+		ServiceCidr:     to.StringPtr("10.0.0.0/16"),
+		DNSServiceIP:    to.StringPtr("10.0.0.10"),
+	}
+	return mc
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedclusters
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	kubeloginServerIDFlag    = "--server-id"
+	kubeloginTenantIDFlag    = "--tenant-id"
+	kubeloginClientIDFlag    = "--client-id"
+	kubeloginEnvironmentFlag = "--environment"
+	kubeloginLoginFlag       = "--login"
+
+	// kubeloginServicePrincipalLogin is the login method of kubelogin that logs in with a service principal secret.
+	kubeloginServicePrincipalLogin = "spn"
+
+	// kubeloginClientSecretEnv is the environment variable kubelogin reads the service principal secret from.
+	kubeloginClientSecretEnv = "AAD_SERVICE_PRINCIPAL_CLIENT_SECRET"
+)
+
+// tokenGetter obtains an AAD token of the given tenant for the AAD server application of a managed cluster.
+type tokenGetter func(tenantID, serverID string) (string, error)
+
+// convertToTokenLogin replaces the kubelogin exec plugins of an exec-format AKS kubeconfig with AAD tokens, so the
+// kubeconfig neither requires the kubelogin binary nor holds the credentials the tokens were obtained with.
+func convertToTokenLogin(kubeConfigData []byte, getToken tokenGetter) ([]byte, error) {
+	kubeConfig, err := clientcmd.Load(kubeConfigData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load kubeconfig")
+	}
+
+	converted := false
+	for name, authInfo := range kubeConfig.AuthInfos {
+		if authInfo.Exec == nil {
+			continue
+		}
+		serverID := flagValue(authInfo.Exec.Args, kubeloginServerIDFlag)
+		if serverID == "" {
+			return nil, errors.Errorf("exec credential plugin of user %s has no %s argument", name, kubeloginServerIDFlag)
+		}
+		token, err := getToken(flagValue(authInfo.Exec.Args, kubeloginTenantIDFlag), serverID)
+		if err != nil {
+			return nil, err
+		}
+		kubeConfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: token}
+		converted = true
+	}
+	if !converted {
+		return nil, errors.New("kubeconfig has no exec credential plugin")
+	}
+
+	return clientcmd.Write(*kubeConfig)
+}
+
+// convertToServicePrincipalLogin configures the kubelogin exec plugins of an exec-format AKS kubeconfig to log in with a
+// service principal, so that the kubeconfig can be used without interaction. The kubeconfig holds the service principal
+// secret.
+func convertToServicePrincipalLogin(kubeConfigData []byte, clientID, clientSecret string) ([]byte, error) {
+	kubeConfig, err := clientcmd.Load(kubeConfigData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load kubeconfig")
+	}
+
+	converted := false
+	for name, authInfo := range kubeConfig.AuthInfos {
+		if authInfo.Exec == nil {
+			continue
+		}
+		args := authInfo.Exec.Args
+		serverID := flagValue(args, kubeloginServerIDFlag)
+		if serverID == "" {
+			return nil, errors.Errorf("exec credential plugin of user %s has no %s argument", name, kubeloginServerIDFlag)
+		}
+
+		loginArgs := []string{"get-token"}
+		if environment := flagValue(args, kubeloginEnvironmentFlag); environment != "" {
+			loginArgs = append(loginArgs, kubeloginEnvironmentFlag, environment)
+		}
+		loginArgs = append(loginArgs, kubeloginServerIDFlag, serverID, kubeloginClientIDFlag, clientID)
+		if tenantID := flagValue(args, kubeloginTenantIDFlag); tenantID != "" {
+			loginArgs = append(loginArgs, kubeloginTenantIDFlag, tenantID)
+		}
+		loginArgs = append(loginArgs, kubeloginLoginFlag, kubeloginServicePrincipalLogin)
+
+		authInfo.Exec.Args = loginArgs
+		authInfo.Exec.Env = []clientcmdapi.ExecEnvVar{{Name: kubeloginClientSecretEnv, Value: clientSecret}}
+		authInfo.Exec.InteractiveMode = clientcmdapi.NeverExecInteractiveMode
+		converted = true
+	}
+	if !converted {
+		return nil, errors.New("kubeconfig has no exec credential plugin")
+	}
+
+	return clientcmd.Write(*kubeConfig)
+}
+
+// flagValue returns the value of the given flag in a list of arguments, or an empty string if the flag is not set.
+func flagValue(args []string, flag string) string {
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] == flag {
+			return args[i+1]
+		}
+	}
+	return ""
+}
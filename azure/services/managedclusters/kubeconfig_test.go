@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedclusters
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const fakeExecKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://my-managedcluster-fqdn:443
+  name: my-managedcluster
+contexts:
+- context:
+    cluster: my-managedcluster
+    user: clusterUser_my-rg_my-managedcluster
+  name: my-managedcluster
+current-context: my-managedcluster
+users:
+- name: clusterUser_my-rg_my-managedcluster
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: kubelogin
+      args:
+      - get-token
+      - --environment
+      - AzurePublicCloud
+      - --server-id
+      - 6dae42f8-4368-4678-94ff-3960e28e3630
+      - --client-id
+      - 80faf920-1908-4b52-b5ef-a8e7bedfc67a
+      - --tenant-id
+      - 00000000-0000-0000-0000-000000000000
+      - --login
+      - devicecode
+`
+
+func TestConvertToTokenLogin(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := convertToTokenLogin([]byte(fakeExecKubeconfig), func(tenantID, serverID string) (string, error) {
+		g.Expect(tenantID).To(Equal("00000000-0000-0000-0000-000000000000"))
+		g.Expect(serverID).To(Equal("6dae42f8-4368-4678-94ff-3960e28e3630"))
+		return "aad-token", nil
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	kubeConfig, err := clientcmd.Load(data)
+	g.Expect(err).NotTo(HaveOccurred())
+	authInfo := kubeConfig.AuthInfos["clusterUser_my-rg_my-managedcluster"]
+	g.Expect(authInfo.Exec).To(BeNil())
+	g.Expect(authInfo.Token).To(Equal("aad-token"))
+	g.Expect(kubeConfig.Clusters["my-managedcluster"].Server).To(Equal("https://my-managedcluster-fqdn:443"))
+}
+
+func TestConvertToTokenLoginWithoutExecPlugin(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := convertToTokenLogin([]byte(`apiVersion: v1
+kind: Config
+users:
+- name: clusterAdmin
+  user:
+    token: secret
+`), func(_, _ string) (string, error) {
+		return "aad-token", nil
+	})
+	g.Expect(err).To(MatchError("kubeconfig has no exec credential plugin"))
+}
+
+func TestConvertToTokenLoginTokenError(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := convertToTokenLogin([]byte(fakeExecKubeconfig), func(_, _ string) (string, error) {
+		return "", errors.New("invalid client secret")
+	})
+	g.Expect(err).To(MatchError("invalid client secret"))
+}
+
+func TestConvertToServicePrincipalLogin(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := convertToServicePrincipalLogin([]byte(fakeExecKubeconfig), "my-client-id", "my-client-secret")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	kubeConfig, err := clientcmd.Load(data)
+	g.Expect(err).NotTo(HaveOccurred())
+	authInfo := kubeConfig.AuthInfos["clusterUser_my-rg_my-managedcluster"]
+	g.Expect(authInfo.Exec).NotTo(BeNil())
+	g.Expect(authInfo.Exec.Command).To(Equal("kubelogin"))
+	g.Expect(authInfo.Exec.Args).To(Equal([]string{
+		"get-token",
+		"--environment", "AzurePublicCloud",
+		"--server-id", "6dae42f8-4368-4678-94ff-3960e28e3630",
+		"--client-id", "my-client-id",
+		"--tenant-id", "00000000-0000-0000-0000-000000000000",
+		"--login", "spn",
+	}))
+	g.Expect(authInfo.Exec.Env).To(ConsistOf(clientcmdapi.ExecEnvVar{Name: "AAD_SERVICE_PRINCIPAL_CLIENT_SECRET", Value: "my-client-secret"}))
+	g.Expect(kubeConfig.Clusters["my-managedcluster"].Server).To(Equal("https://my-managedcluster-fqdn:443"))
+}
+
+func TestConvertToServicePrincipalLoginWithoutExecPlugin(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := convertToServicePrincipalLogin([]byte(`apiVersion: v1
+kind: Config
+users:
+- name: clusterAdmin
+  user:
+    token: secret
+`), "my-client-id", "my-client-secret")
+	g.Expect(err).To(MatchError("kubeconfig has no exec credential plugin"))
+}
@@ -18,20 +18,37 @@ package managedclusters
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
-const serviceName = "managedcluster"
+const (
+	serviceName = "managedcluster"
+
+	// importRequeueInterval is how often a managed cluster whose import has not been confirmed is checked again.
+	importRequeueInterval = 1 * time.Minute
+
+	// outboundTypePrerequisitesRequeueInterval is how often the subnet of a managed cluster is checked again when it
+	// does not provide the egress path required by the outbound type.
+	outboundTypePrerequisitesRequeueInterval = 1 * time.Minute
+)
 
 // ManagedClusterScope defines the scope interface for a managed cluster.
 type ManagedClusterScope interface {
@@ -42,13 +59,25 @@ type ManagedClusterScope interface {
 	MakeEmptyKubeConfigSecret() corev1.Secret
 	GetKubeConfigData() []byte
 	SetKubeConfigData([]byte)
+	KubeconfigCredentialKind() infrav1exp.KubeconfigCredentialKind
+	KubeconfigServicePrincipal(ctx context.Context) (clientID, clientSecret string, err error)
+	KubeconfigServicePrincipalToken(ctx context.Context, tenantID, serverID string) (adal.Token, error)
+	SetKubeConfigTokenExpiry(time.Time)
+	SetOIDCIssuerProfileStatus(*infrav1exp.OIDCIssuerStatus)
+	SetAddonsStatus([]infrav1exp.AddonStatus)
+	SetManagedClusterImportConflicts([]string)
+	SetImportedNetworkProfile(podCIDR, serviceCIDR, dnsServiceIP *string)
+	SetOutboundTypePrerequisitesPending(string)
+	UpdateAgentPoolUpgrades(controlPlaneVersion string, agentPools []azure.AgentPoolVersion)
 }
 
 // Service provides operations on azure resources.
 type Service struct {
 	Scope ManagedClusterScope
 	async.Reconciler
+	async.Getter
 	CredentialGetter
+	subnetGetter async.Getter
 }
 
 // New creates a new service.
@@ -57,7 +86,9 @@ func New(scope ManagedClusterScope) *Service {
 	return &Service{
 		Scope:            scope,
 		Reconciler:       async.New(scope, client, client),
+		Getter:           client,
 		CredentialGetter: client,
+		subnetGetter:     subnets.NewClient(scope),
 	}
 }
 
@@ -79,6 +110,16 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
+	pending, err := s.checkOutboundTypePrerequisites(ctx, managedClusterSpec)
+	if err != nil {
+		s.Scope.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, err)
+		return err
+	}
+	if pending != "" {
+		s.Scope.SetOutboundTypePrerequisitesPending(pending)
+		return azure.WithTransientError(errors.New(pending), outboundTypePrerequisitesRequeueInterval)
+	}
+
 	result, resultErr := s.CreateResource(ctx, managedClusterSpec, serviceName)
 	if resultErr == nil {
 		managedCluster, ok := result.(containerservice.ManagedCluster)
@@ -92,18 +133,228 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 		s.Scope.SetControlPlaneEndpoint(endpoint)
 
+		// Update the OIDC issuer URL so federated credentials can be created without calling Azure.
+		if managedCluster.OidcIssuerProfile != nil && to.Bool(managedCluster.OidcIssuerProfile.Enabled) {
+			s.Scope.SetOIDCIssuerProfileStatus(&infrav1exp.OIDCIssuerStatus{
+				IssuerURL: managedCluster.OidcIssuerProfile.IssuerURL,
+			})
+		} else {
+			s.Scope.SetOIDCIssuerProfileStatus(nil)
+		}
+
+		// Report the add-ons so their identities and generated resources can be used without calling Azure.
+		s.Scope.SetAddonsStatus(addonsStatus(managedCluster))
+
 		// Update kubeconfig data
 		// Always fetch credentials in case of rotation
-		kubeConfigData, err := s.GetCredentials(ctx, managedClusterSpec.ResourceGroupName(), managedClusterSpec.ResourceName())
+		kubeConfigData, err := s.getKubeConfigData(ctx, managedClusterSpec)
 		if err != nil {
-			return errors.Wrap(err, "failed to get credentials for managed cluster")
+			return err
 		}
 		s.Scope.SetKubeConfigData(kubeConfigData)
+
+		// Report the versions the control plane and agent pools run so agent pool upgrades can be orchestrated.
+		s.Scope.UpdateAgentPoolUpgrades(to.String(managedCluster.KubernetesVersion), agentPoolVersions(managedCluster))
 	}
 	s.Scope.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, resultErr)
 	return resultErr
 }
 
+// checkOutboundTypePrerequisites checks, before a managed cluster is created, that its subnet provides the egress
+// path required by its outbound type. It returns why the managed cluster cannot be created yet, or an empty string.
+// The route table or NAT gateway can be associated with the subnet outside of CAPZ, so this cannot be fully validated
+// by the webhook.
+func (s *Service) checkOutboundTypePrerequisites(ctx context.Context, spec azure.ResourceSpecGetter) (string, error) {
+	managedClusterSpec, ok := spec.(*ManagedClusterSpec)
+	if !ok {
+		return "", nil
+	}
+	outboundType := containerservice.OutboundType(managedClusterSpec.OutboundType)
+	if outboundType != containerservice.OutboundTypeUserDefinedRouting && outboundType != containerservice.OutboundTypeUserAssignedNATGateway {
+		return "", nil
+	}
+
+	// The outbound type cannot be changed once the managed cluster exists.
+	if _, err := s.Getter.Get(ctx, spec); err == nil {
+		return "", nil
+	} else if !azure.ResourceNotFound(err) {
+		return "", errors.Wrapf(err, "failed to get managed cluster %s", spec.ResourceName())
+	}
+
+	subnetSpec := subnetSpecFromID(managedClusterSpec.VnetSubnetID)
+	result, err := s.subnetGetter.Get(ctx, subnetSpec)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get subnet %s", subnetSpec.Name)
+	}
+	subnet, ok := result.(network.Subnet)
+	if !ok {
+		return "", errors.Errorf("%T is not a network.Subnet", result)
+	}
+
+	switch {
+	case outboundType == containerservice.OutboundTypeUserDefinedRouting && (subnet.SubnetPropertiesFormat == nil || subnet.RouteTable == nil):
+		return fmt.Sprintf("subnet %s must be associated with a route table when the outbound type is %s", subnetSpec.Name, outboundType), nil
+	case outboundType == containerservice.OutboundTypeUserAssignedNATGateway && (subnet.SubnetPropertiesFormat == nil || subnet.NatGateway == nil):
+		return fmt.Sprintf("subnet %s must be associated with a NAT gateway when the outbound type is %s", subnetSpec.Name, outboundType), nil
+	}
+	return "", nil
+}
+
+// subnetSpecFromID returns the spec used to get a subnet from its resource ID.
+func subnetSpecFromID(subnetID string) *subnets.SubnetSpec {
+	spec := &subnets.SubnetSpec{}
+	parts := strings.Split(subnetID, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		switch strings.ToLower(parts[i]) {
+		case "resourcegroups":
+			spec.VNetResourceGroup = parts[i+1]
+		case "virtualnetworks":
+			spec.VNetName = parts[i+1]
+		case "subnets":
+			spec.Name = parts[i+1]
+		}
+	}
+	return spec
+}
+
+// addonsStatus returns the observed state of the add-ons of a managed cluster, sorted by name.
+func addonsStatus(managedCluster containerservice.ManagedCluster) []infrav1exp.AddonStatus {
+	if managedCluster.ManagedClusterProperties == nil || len(managedCluster.AddonProfiles) == 0 {
+		return nil
+	}
+	addons := make([]infrav1exp.AddonStatus, 0, len(managedCluster.AddonProfiles))
+	for name, profile := range managedCluster.AddonProfiles {
+		if profile == nil {
+			continue
+		}
+		addon := infrav1exp.AddonStatus{
+			Name:    name,
+			Enabled: to.Bool(profile.Enabled),
+		}
+		if len(profile.Config) > 0 {
+			addon.Config = make(map[string]string, len(profile.Config))
+			for key, value := range profile.Config {
+				addon.Config[key] = to.String(value)
+			}
+		}
+		if profile.Identity != nil {
+			addon.Identity = &infrav1exp.AddonIdentity{
+				ResourceID: to.String(profile.Identity.ResourceID),
+				ClientID:   to.String(profile.Identity.ClientID),
+				ObjectID:   to.String(profile.Identity.ObjectID),
+			}
+		}
+		addons = append(addons, addon)
+	}
+	sort.Slice(addons, func(i, j int) bool {
+		return addons[i].Name < addons[j].Name
+	})
+	return addons
+}
+
+// agentPoolVersions returns the Kubernetes version and provisioning state of the agent pools of a managed cluster.
+func agentPoolVersions(managedCluster containerservice.ManagedCluster) []azure.AgentPoolVersion {
+	if managedCluster.ManagedClusterProperties == nil || managedCluster.AgentPoolProfiles == nil {
+		return nil
+	}
+	versions := make([]azure.AgentPoolVersion, 0, len(*managedCluster.AgentPoolProfiles))
+	for _, profile := range *managedCluster.AgentPoolProfiles {
+		version := to.String(profile.OrchestratorVersion)
+		if version == "" {
+			version = to.String(profile.CurrentOrchestratorVersion)
+		}
+		versions = append(versions, azure.AgentPoolVersion{
+			Name:              to.String(profile.Name),
+			Version:           version,
+			ProvisioningState: to.String(profile.ProvisioningState),
+		})
+	}
+	return versions
+}
+
+// getKubeConfigData fetches the kubeconfig of the credential kind selected by the scope.
+func (s *Service) getKubeConfigData(ctx context.Context, managedClusterSpec azure.ResourceSpecGetter) ([]byte, error) {
+	kind := s.Scope.KubeconfigCredentialKind()
+	kubeConfigData, err := s.GetCredentials(ctx, managedClusterSpec.ResourceGroupName(), managedClusterSpec.ResourceName(), kind)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get credentials for managed cluster")
+	}
+
+	switch kind {
+	case infrav1exp.KubeconfigCredentialKindExec:
+		clientID, clientSecret, err := s.Scope.KubeconfigServicePrincipal(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the service principal of the kubeconfig")
+		}
+		kubeConfigData, err = convertToServicePrincipalLogin(kubeConfigData, clientID, clientSecret)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert the kubeconfig to service principal login")
+		}
+	case infrav1exp.KubeconfigCredentialKindAADToken:
+		var expiry time.Time
+		kubeConfigData, err = convertToTokenLogin(kubeConfigData, func(tenantID, serverID string) (string, error) {
+			token, err := s.Scope.KubeconfigServicePrincipalToken(ctx, tenantID, serverID)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to get an AAD token for the kubeconfig")
+			}
+			if expiry.IsZero() || token.Expires().Before(expiry) {
+				expiry = token.Expires()
+			}
+			return token.AccessToken, nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert the kubeconfig to token login")
+		}
+		s.Scope.SetKubeConfigTokenExpiry(expiry)
+	}
+
+	return kubeConfigData, nil
+}
+
+// ReconcileImport reads an existing managed cluster that is being imported and reports the differences with the spec
+// that would prevent CAPZ from managing it. It never changes the managed cluster.
+func (s *Service) ReconcileImport(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.ReconcileImport")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.ManagedClusterSpec(ctx)
+	if spec == nil {
+		return nil
+	}
+	managedClusterSpec, ok := spec.(*ManagedClusterSpec)
+	if !ok {
+		return errors.Errorf("%T is not a *ManagedClusterSpec", spec)
+	}
+
+	result, err := s.Get(ctx, spec)
+	if azure.ResourceNotFound(err) {
+		s.Scope.SetManagedClusterImportConflicts([]string{
+			fmt.Sprintf("managed cluster %s does not exist in resource group %s", spec.ResourceName(), spec.ResourceGroupName()),
+		})
+		return azure.WithTransientError(errors.Errorf("managed cluster %s to import does not exist", spec.ResourceName()), importRequeueInterval)
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get managed cluster %s to import", spec.ResourceName())
+	}
+	existing, ok := result.(containerservice.ManagedCluster)
+	if !ok {
+		return errors.Errorf("%T is not a containerservice.ManagedCluster", result)
+	}
+
+	conflicts, err := managedClusterSpec.importConflicts(existing)
+	if err != nil {
+		return errors.Wrapf(err, "failed to compare managed cluster %s to import with the spec", spec.ResourceName())
+	}
+	if existing.NetworkProfile != nil {
+		s.Scope.SetImportedNetworkProfile(existing.NetworkProfile.PodCidr, existing.NetworkProfile.ServiceCidr, existing.NetworkProfile.DNSServiceIP)
+	}
+	s.Scope.SetManagedClusterImportConflicts(conflicts)
+
+	return azure.WithTransientError(errors.Errorf("waiting for the import of managed cluster %s to be confirmed", spec.ResourceName()), importRequeueInterval)
+}
+
 // Delete deletes the managed cluster.
 func (s *Service) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "managedclusters.Service.Delete")
@@ -19,21 +19,40 @@ package managedclusters
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters/mock_managedclusters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var fakeManagedClusterSpec = &ManagedClusterSpec{Name: "my-managedcluster", ResourceGroup: "my-rg"}
 
+var fakeImportedManagedClusterSpec = &ManagedClusterSpec{
+	Name:              "my-managedcluster",
+	ResourceGroup:     "my-rg",
+	NodeResourceGroup: "my-node-rg",
+	Location:          "my-location",
+	Version:           "v1.22.6",
+	NetworkPlugin:     "azure",
+	NetworkPolicy:     "calico",
+	LoadBalancerSKU:   "Standard",
+}
+
 func TestReconcile(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -65,14 +84,116 @@ func TestReconcile(t *testing.T) {
 					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
 						Fqdn:              pointer.String("my-managedcluster-fqdn"),
 						ProvisioningState: pointer.String("Succeeded"),
+						KubernetesVersion: pointer.String("1.24.6"),
+						AddonProfiles: map[string]*containerservice.ManagedClusterAddonProfile{
+							"azurepolicy": {Enabled: pointer.Bool(false)},
+							"azureKeyvaultSecretsProvider": {
+								Enabled: pointer.Bool(true),
+								Config:  map[string]*string{"enableSecretRotation": pointer.String("true")},
+								Identity: &containerservice.ManagedClusterAddonProfileIdentity{
+									ResourceID: pointer.String("/subscriptions/123/resourcegroups/my-node-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/azurekeyvaultsecretsprovider-my-managedcluster"),
+									ClientID:   pointer.String("client-id"),
+									ObjectID:   pointer.String("object-id"),
+								},
+							},
+						},
+						AgentPoolProfiles: &[]containerservice.ManagedClusterAgentPoolProfile{
+							{
+								Name:                       pointer.String("pool0"),
+								OrchestratorVersion:        pointer.String("1.24.6"),
+								CurrentOrchestratorVersion: pointer.String("1.24.6"),
+								ProvisioningState:          pointer.String("Succeeded"),
+							},
+							{
+								Name:                       pointer.String("pool1"),
+								CurrentOrchestratorVersion: pointer.String("1.23.12"),
+								ProvisioningState:          pointer.String("Upgrading"),
+							},
+						},
+					},
+				}, nil)
+				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+					Host: "my-managedcluster-fqdn",
+					Port: 443,
+				})
+				s.SetOIDCIssuerProfileStatus(nil)
+				s.SetAddonsStatus([]infrav1exp.AddonStatus{
+					{
+						Name:    "azureKeyvaultSecretsProvider",
+						Enabled: true,
+						Config:  map[string]string{"enableSecretRotation": "true"},
+						Identity: &infrav1exp.AddonIdentity{
+							ResourceID: "/subscriptions/123/resourcegroups/my-node-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/azurekeyvaultsecretsprovider-my-managedcluster",
+							ClientID:   "client-id",
+							ObjectID:   "object-id",
+						},
+					},
+					{Name: "azurepolicy", Enabled: false},
+				})
+				s.KubeconfigCredentialKind().Return(infrav1exp.KubeconfigCredentialKindAdmin)
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster", infrav1exp.KubeconfigCredentialKindAdmin).Return([]byte("credentials"), nil)
+				s.SetKubeConfigData([]byte("credentials"))
+				s.UpdateAgentPoolUpgrades("1.24.6", []azure.AgentPoolVersion{
+					{Name: "pool0", Version: "1.24.6", ProvisioningState: "Succeeded"},
+					{Name: "pool1", Version: "1.23.12", ProvisioningState: "Upgrading"},
+				})
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "create managed cluster with OIDC issuer succeeds",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeManagedClusterSpec)
+				r.CreateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(containerservice.ManagedCluster{
+					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+						Fqdn:              pointer.String("my-managedcluster-fqdn"),
+						ProvisioningState: pointer.String("Succeeded"),
+						OidcIssuerProfile: &containerservice.ManagedClusterOIDCIssuerProfile{
+							Enabled:   pointer.Bool(true),
+							IssuerURL: pointer.String("https://oidc.prod-aks.azure.com/00000000-0000-0000-0000-000000000000/"),
+						},
 					},
 				}, nil)
 				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
 					Host: "my-managedcluster-fqdn",
 					Port: 443,
 				})
-				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte("credentials"), nil)
+				s.SetOIDCIssuerProfileStatus(&infrav1exp.OIDCIssuerStatus{
+					IssuerURL: pointer.String("https://oidc.prod-aks.azure.com/00000000-0000-0000-0000-000000000000/"),
+				})
+				s.SetAddonsStatus(nil)
+				s.KubeconfigCredentialKind().Return(infrav1exp.KubeconfigCredentialKindAdmin)
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster", infrav1exp.KubeconfigCredentialKindAdmin).Return([]byte("credentials"), nil)
 				s.SetKubeConfigData([]byte("credentials"))
+				s.UpdateAgentPoolUpgrades("", nil)
+				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "create managed cluster with aadToken credentials succeeds",
+			expectedError: "",
+			expect: func(m *mock_managedclusters.MockCredentialGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeManagedClusterSpec)
+				r.CreateResource(gomockinternal.AContext(), fakeManagedClusterSpec, serviceName).Return(containerservice.ManagedCluster{
+					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+						Fqdn:              pointer.String("my-managedcluster-fqdn"),
+						ProvisioningState: pointer.String("Succeeded"),
+					},
+				}, nil)
+				s.SetControlPlaneEndpoint(clusterv1.APIEndpoint{
+					Host: "my-managedcluster-fqdn",
+					Port: 443,
+				})
+				s.SetOIDCIssuerProfileStatus(nil)
+				s.SetAddonsStatus(nil)
+				s.KubeconfigCredentialKind().Return(infrav1exp.KubeconfigCredentialKindAADToken)
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster", infrav1exp.KubeconfigCredentialKindAADToken).Return([]byte(fakeExecKubeconfig), nil)
+				s.KubeconfigServicePrincipalToken(gomockinternal.AContext(), "00000000-0000-0000-0000-000000000000", "6dae42f8-4368-4678-94ff-3960e28e3630").
+					Return(adal.Token{AccessToken: "aad-token", ExpiresOn: "1700000000"}, nil)
+				s.SetKubeConfigTokenExpiry(time.Unix(1700000000, 0).UTC())
+				s.SetKubeConfigData(gomock.Any())
+				s.UpdateAgentPoolUpgrades("", nil)
 				s.UpdatePutStatus(infrav1.ManagedClusterRunningCondition, serviceName, nil)
 			},
 		},
@@ -91,7 +212,10 @@ func TestReconcile(t *testing.T) {
 					Host: "my-managedcluster-fqdn",
 					Port: 443,
 				})
-				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster").Return([]byte(""), errors.New("internal server error"))
+				s.SetOIDCIssuerProfileStatus(nil)
+				s.SetAddonsStatus(nil)
+				s.KubeconfigCredentialKind().Return(infrav1exp.KubeconfigCredentialKindAdmin)
+				m.GetCredentials(gomockinternal.AContext(), "my-rg", "my-managedcluster", infrav1exp.KubeconfigCredentialKindAdmin).Return([]byte(""), errors.New("internal server error"))
 			},
 		},
 	}
@@ -126,6 +250,169 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestCheckOutboundTypePrerequisites(t *testing.T) {
+	notFoundErr := autorest.DetailedError{StatusCode: http.StatusNotFound}
+	udrManagedClusterSpec := &ManagedClusterSpec{
+		Name:          "my-managedcluster",
+		ResourceGroup: "my-rg",
+		VnetSubnetID:  "/subscriptions/123/resourceGroups/my-vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet",
+		OutboundType:  "userDefinedRouting",
+	}
+	subnetSpec := &subnets.SubnetSpec{Name: "my-subnet", VNetName: "my-vnet", VNetResourceGroup: "my-vnet-rg"}
+	testcases := []struct {
+		name            string
+		spec            *ManagedClusterSpec
+		expectedPending string
+		expectedError   string
+		expect          func(g *mock_async.MockGetterMockRecorder, sg *mock_async.MockGetterMockRecorder)
+	}{
+		{
+			name:   "outbound type without prerequisites",
+			spec:   fakeManagedClusterSpec,
+			expect: func(g *mock_async.MockGetterMockRecorder, sg *mock_async.MockGetterMockRecorder) {},
+		},
+		{
+			name: "managed cluster already exists",
+			spec: udrManagedClusterSpec,
+			expect: func(g *mock_async.MockGetterMockRecorder, sg *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), udrManagedClusterSpec).Return(containerservice.ManagedCluster{}, nil)
+			},
+		},
+		{
+			name: "subnet is associated with a route table",
+			spec: udrManagedClusterSpec,
+			expect: func(g *mock_async.MockGetterMockRecorder, sg *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), udrManagedClusterSpec).Return(nil, notFoundErr)
+				sg.Get(gomockinternal.AContext(), subnetSpec).Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						RouteTable: &network.RouteTable{ID: pointer.String("my-route-table-id")},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:            "subnet is not associated with a route table",
+			spec:            udrManagedClusterSpec,
+			expectedPending: "subnet my-subnet must be associated with a route table when the outbound type is userDefinedRouting",
+			expect: func(g *mock_async.MockGetterMockRecorder, sg *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), udrManagedClusterSpec).Return(nil, notFoundErr)
+				sg.Get(gomockinternal.AContext(), subnetSpec).Return(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{},
+				}, nil)
+			},
+		},
+		{
+			name:          "subnet cannot be read",
+			spec:          udrManagedClusterSpec,
+			expectedError: "failed to get subnet my-subnet: #: Not found: StatusCode=404",
+			expect: func(g *mock_async.MockGetterMockRecorder, sg *mock_async.MockGetterMockRecorder) {
+				g.Get(gomockinternal.AContext(), udrManagedClusterSpec).Return(nil, notFoundErr)
+				sg.Get(gomockinternal.AContext(), subnetSpec).Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+			subnetGetterMock := mock_async.NewMockGetter(mockCtrl)
+
+			tc.expect(getterMock.EXPECT(), subnetGetterMock.EXPECT())
+
+			s := &Service{
+				Getter:       getterMock,
+				subnetGetter: subnetGetterMock,
+			}
+
+			pending, err := s.checkOutboundTypePrerequisites(context.TODO(), tc.spec)
+			g.Expect(pending).To(Equal(tc.expectedPending))
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestReconcileImport(t *testing.T) {
+	notFoundErr := autorest.DetailedError{StatusCode: http.StatusNotFound}
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(g *mock_async.MockGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder)
+	}{
+		{
+			name:          "existing managed cluster matches the spec",
+			expectedError: "waiting for the import of managed cluster my-managedcluster to be confirmed",
+			expect: func(g *mock_async.MockGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeImportedManagedClusterSpec)
+				g.Get(gomockinternal.AContext(), fakeImportedManagedClusterSpec).Return(containerservice.ManagedCluster{
+					Location: pointer.String("my-location"),
+					ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+						KubernetesVersion: pointer.String("1.22.6"),
+						NodeResourceGroup: pointer.String("my-node-rg"),
+						NetworkProfile: &containerservice.NetworkProfile{
+							NetworkPlugin:   containerservice.NetworkPluginAzure,
+							NetworkPolicy:   containerservice.NetworkPolicyCalico,
+							LoadBalancerSku: containerservice.LoadBalancerSkuStandard,
+							OutboundType:    containerservice.OutboundTypeLoadBalancer,
+							PodCidr:         pointer.String("10.244.0.0/16"),
+						},
+					},
+				}, nil)
+				s.SetImportedNetworkProfile(pointer.String("10.244.0.0/16"), nil, nil)
+				s.SetManagedClusterImportConflicts(nil)
+			},
+		},
+		{
+			name:          "managed cluster to import does not exist",
+			expectedError: "managed cluster my-managedcluster to import does not exist",
+			expect: func(g *mock_async.MockGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeImportedManagedClusterSpec)
+				g.Get(gomockinternal.AContext(), fakeImportedManagedClusterSpec).Return(nil, notFoundErr)
+				s.SetManagedClusterImportConflicts([]string{"managed cluster my-managedcluster does not exist in resource group my-rg"})
+			},
+		},
+		{
+			name:          "fail to get managed cluster to import",
+			expectedError: "failed to get managed cluster my-managedcluster to import: internal server error",
+			expect: func(g *mock_async.MockGetterMockRecorder, s *mock_managedclusters.MockManagedClusterScopeMockRecorder) {
+				s.ManagedClusterSpec(gomockinternal.AContext()).Return(fakeImportedManagedClusterSpec)
+				g.Get(gomockinternal.AContext(), fakeImportedManagedClusterSpec).Return(nil, errors.New("internal server error"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_managedclusters.NewMockManagedClusterScope(mockCtrl)
+			getterMock := mock_async.NewMockGetter(mockCtrl)
+
+			tc.expect(getterMock.EXPECT(), scopeMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				Getter: getterMock,
+			}
+
+			err := s.ReconcileImport(context.TODO())
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -23,13 +23,16 @@ package mock_managedclusters
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	autorest "github.com/Azure/go-autorest/autorest"
+	adal "github.com/Azure/go-autorest/autorest/adal"
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/core/v1"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
-	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
+	v1beta10 "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	v1beta11 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 // MockManagedClusterScope is a mock of ManagedClusterScope interface.
@@ -179,6 +182,51 @@ func (mr *MockManagedClusterScopeMockRecorder) HashKey() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockManagedClusterScope)(nil).HashKey))
 }
 
+// KubeconfigCredentialKind mocks base method.
+func (m *MockManagedClusterScope) KubeconfigCredentialKind() v1beta10.KubeconfigCredentialKind {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KubeconfigCredentialKind")
+	ret0, _ := ret[0].(v1beta10.KubeconfigCredentialKind)
+	return ret0
+}
+
+// KubeconfigCredentialKind indicates an expected call of KubeconfigCredentialKind.
+func (mr *MockManagedClusterScopeMockRecorder) KubeconfigCredentialKind() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KubeconfigCredentialKind", reflect.TypeOf((*MockManagedClusterScope)(nil).KubeconfigCredentialKind))
+}
+
+// KubeconfigServicePrincipal mocks base method.
+func (m *MockManagedClusterScope) KubeconfigServicePrincipal(ctx context.Context) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KubeconfigServicePrincipal", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// KubeconfigServicePrincipal indicates an expected call of KubeconfigServicePrincipal.
+func (mr *MockManagedClusterScopeMockRecorder) KubeconfigServicePrincipal(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KubeconfigServicePrincipal", reflect.TypeOf((*MockManagedClusterScope)(nil).KubeconfigServicePrincipal), ctx)
+}
+
+// KubeconfigServicePrincipalToken mocks base method.
+func (m *MockManagedClusterScope) KubeconfigServicePrincipalToken(ctx context.Context, tenantID, serverID string) (adal.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KubeconfigServicePrincipalToken", ctx, tenantID, serverID)
+	ret0, _ := ret[0].(adal.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KubeconfigServicePrincipalToken indicates an expected call of KubeconfigServicePrincipalToken.
+func (mr *MockManagedClusterScopeMockRecorder) KubeconfigServicePrincipalToken(ctx, tenantID, serverID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KubeconfigServicePrincipalToken", reflect.TypeOf((*MockManagedClusterScope)(nil).KubeconfigServicePrincipalToken), ctx, tenantID, serverID)
+}
+
 // MakeEmptyKubeConfigSecret mocks base method.
 func (m *MockManagedClusterScope) MakeEmptyKubeConfigSecret() v1.Secret {
 	m.ctrl.T.Helper()
@@ -207,8 +255,20 @@ func (mr *MockManagedClusterScopeMockRecorder) ManagedClusterSpec(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManagedClusterSpec", reflect.TypeOf((*MockManagedClusterScope)(nil).ManagedClusterSpec), arg0)
 }
 
+// SetAddonsStatus mocks base method.
+func (m *MockManagedClusterScope) SetAddonsStatus(arg0 []v1beta10.AddonStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAddonsStatus", arg0)
+}
+
+// SetAddonsStatus indicates an expected call of SetAddonsStatus.
+func (mr *MockManagedClusterScopeMockRecorder) SetAddonsStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAddonsStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetAddonsStatus), arg0)
+}
+
 // SetControlPlaneEndpoint mocks base method.
-func (m *MockManagedClusterScope) SetControlPlaneEndpoint(arg0 v1beta10.APIEndpoint) {
+func (m *MockManagedClusterScope) SetControlPlaneEndpoint(arg0 v1beta11.APIEndpoint) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetControlPlaneEndpoint", arg0)
 }
@@ -219,6 +279,18 @@ func (mr *MockManagedClusterScopeMockRecorder) SetControlPlaneEndpoint(arg0 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetControlPlaneEndpoint", reflect.TypeOf((*MockManagedClusterScope)(nil).SetControlPlaneEndpoint), arg0)
 }
 
+// SetImportedNetworkProfile mocks base method.
+func (m *MockManagedClusterScope) SetImportedNetworkProfile(podCIDR, serviceCIDR, dnsServiceIP *string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetImportedNetworkProfile", podCIDR, serviceCIDR, dnsServiceIP)
+}
+
+// SetImportedNetworkProfile indicates an expected call of SetImportedNetworkProfile.
+func (mr *MockManagedClusterScopeMockRecorder) SetImportedNetworkProfile(podCIDR, serviceCIDR, dnsServiceIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetImportedNetworkProfile", reflect.TypeOf((*MockManagedClusterScope)(nil).SetImportedNetworkProfile), podCIDR, serviceCIDR, dnsServiceIP)
+}
+
 // SetKubeConfigData mocks base method.
 func (m *MockManagedClusterScope) SetKubeConfigData(arg0 []byte) {
 	m.ctrl.T.Helper()
@@ -231,6 +303,18 @@ func (mr *MockManagedClusterScopeMockRecorder) SetKubeConfigData(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKubeConfigData", reflect.TypeOf((*MockManagedClusterScope)(nil).SetKubeConfigData), arg0)
 }
 
+// SetKubeConfigTokenExpiry mocks base method.
+func (m *MockManagedClusterScope) SetKubeConfigTokenExpiry(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetKubeConfigTokenExpiry", arg0)
+}
+
+// SetKubeConfigTokenExpiry indicates an expected call of SetKubeConfigTokenExpiry.
+func (mr *MockManagedClusterScopeMockRecorder) SetKubeConfigTokenExpiry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKubeConfigTokenExpiry", reflect.TypeOf((*MockManagedClusterScope)(nil).SetKubeConfigTokenExpiry), arg0)
+}
+
 // SetLongRunningOperationState mocks base method.
 func (m *MockManagedClusterScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
 	m.ctrl.T.Helper()
@@ -243,6 +327,42 @@ func (mr *MockManagedClusterScopeMockRecorder) SetLongRunningOperationState(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockManagedClusterScope)(nil).SetLongRunningOperationState), arg0)
 }
 
+// SetManagedClusterImportConflicts mocks base method.
+func (m *MockManagedClusterScope) SetManagedClusterImportConflicts(arg0 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetManagedClusterImportConflicts", arg0)
+}
+
+// SetManagedClusterImportConflicts indicates an expected call of SetManagedClusterImportConflicts.
+func (mr *MockManagedClusterScopeMockRecorder) SetManagedClusterImportConflicts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetManagedClusterImportConflicts", reflect.TypeOf((*MockManagedClusterScope)(nil).SetManagedClusterImportConflicts), arg0)
+}
+
+// SetOIDCIssuerProfileStatus mocks base method.
+func (m *MockManagedClusterScope) SetOIDCIssuerProfileStatus(arg0 *v1beta10.OIDCIssuerStatus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOIDCIssuerProfileStatus", arg0)
+}
+
+// SetOIDCIssuerProfileStatus indicates an expected call of SetOIDCIssuerProfileStatus.
+func (mr *MockManagedClusterScopeMockRecorder) SetOIDCIssuerProfileStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOIDCIssuerProfileStatus", reflect.TypeOf((*MockManagedClusterScope)(nil).SetOIDCIssuerProfileStatus), arg0)
+}
+
+// SetOutboundTypePrerequisitesPending mocks base method.
+func (m *MockManagedClusterScope) SetOutboundTypePrerequisitesPending(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOutboundTypePrerequisitesPending", arg0)
+}
+
+// SetOutboundTypePrerequisitesPending indicates an expected call of SetOutboundTypePrerequisitesPending.
+func (mr *MockManagedClusterScopeMockRecorder) SetOutboundTypePrerequisitesPending(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutboundTypePrerequisitesPending", reflect.TypeOf((*MockManagedClusterScope)(nil).SetOutboundTypePrerequisitesPending), arg0)
+}
+
 // SubscriptionID mocks base method.
 func (m *MockManagedClusterScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -271,8 +391,20 @@ func (mr *MockManagedClusterScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockManagedClusterScope)(nil).TenantID))
 }
 
+// UpdateAgentPoolUpgrades mocks base method.
+func (m *MockManagedClusterScope) UpdateAgentPoolUpgrades(controlPlaneVersion string, agentPools []azure.AgentPoolVersion) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateAgentPoolUpgrades", controlPlaneVersion, agentPools)
+}
+
+// UpdateAgentPoolUpgrades indicates an expected call of UpdateAgentPoolUpgrades.
+func (mr *MockManagedClusterScopeMockRecorder) UpdateAgentPoolUpgrades(controlPlaneVersion, agentPools interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAgentPoolUpgrades", reflect.TypeOf((*MockManagedClusterScope)(nil).UpdateAgentPoolUpgrades), controlPlaneVersion, agentPools)
+}
+
 // UpdateDeleteStatus mocks base method.
-func (m *MockManagedClusterScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+func (m *MockManagedClusterScope) UpdateDeleteStatus(arg0 v1beta11.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "UpdateDeleteStatus", arg0, arg1, arg2)
 }
@@ -284,7 +416,7 @@ func (mr *MockManagedClusterScopeMockRecorder) UpdateDeleteStatus(arg0, arg1, ar
 }
 
 // UpdatePatchStatus mocks base method.
-func (m *MockManagedClusterScope) UpdatePatchStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+func (m *MockManagedClusterScope) UpdatePatchStatus(arg0 v1beta11.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "UpdatePatchStatus", arg0, arg1, arg2)
 }
@@ -296,7 +428,7 @@ func (mr *MockManagedClusterScopeMockRecorder) UpdatePatchStatus(arg0, arg1, arg
 }
 
 // UpdatePutStatus mocks base method.
-func (m *MockManagedClusterScope) UpdatePutStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+func (m *MockManagedClusterScope) UpdatePutStatus(arg0 v1beta11.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "UpdatePutStatus", arg0, arg1, arg2)
 }
@@ -20,17 +20,32 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
+const (
+	// kubeletIdentityKey is the key of the kubelet identity in the identity profile of a managed cluster.
+	kubeletIdentityKey = "kubeletidentity"
+
+	// maxAgentPoolMinorVersionSkew is how many minor versions an agent pool may be older than the control plane.
+	maxAgentPoolMinorVersionSkew = 2
+
+	// agentPoolVersionSkewRequeueInterval is how often a control plane upgrade held back by the version skew of the
+	// agent pools is checked again.
+	agentPoolVersionSkewRequeueInterval = 1 * time.Minute
+)
+
 // ManagedClusterSpec contains properties to create a managed cluster.
 type ManagedClusterSpec struct {
 	// Name is the name of this AKS Cluster.
@@ -93,6 +108,30 @@ type ManagedClusterSpec struct {
 	// APIServerAccessProfile is the access profile for AKS API server.
 	APIServerAccessProfile *APIServerAccessProfile
 
+	// OutboundType is the outbound (egress) routing method of the cluster. Possible values include: 'loadBalancer', 'userDefinedRouting', 'managedNATGateway', 'userAssignedNATGateway'.
+	OutboundType string
+
+	// NatGatewayProfile is the profile of the cluster NAT gateway.
+	NatGatewayProfile *NatGatewayProfile
+
+	// AutoScalerProfile is the parameters to be applied to the cluster-autoscaler.
+	AutoScalerProfile *AutoScalerProfile
+
+	// AutoUpgradeProfile is the automatic upgrade configuration of the cluster.
+	AutoUpgradeProfile *AutoUpgradeProfile
+
+	// OIDCIssuerProfile is the OIDC issuer profile of the cluster.
+	OIDCIssuerProfile *OIDCIssuerProfile
+
+	// WorkloadIdentityProfile is the workload identity profile of the cluster.
+	WorkloadIdentityProfile *WorkloadIdentityProfile
+
+	// Identity is the user-assigned identities of the cluster. If nil, the control plane uses a system-assigned identity.
+	Identity *Identity
+
+	// DisableLocalAccounts disables the static admin and user credentials of the cluster.
+	DisableLocalAccounts *bool
+
 	// Headers is the list of headers to add to the HTTP requests to update this resource.
 	Headers map[string]string
 }
@@ -145,6 +184,15 @@ type LoadBalancerProfile struct {
 	IdleTimeoutInMinutes *int32
 }
 
+// NatGatewayProfile is the profile of the cluster NAT gateway.
+type NatGatewayProfile struct {
+	// ManagedOutboundIPs is the desired number of outbound IPs created and managed by Azure for the cluster NAT gateway.
+	ManagedOutboundIPs *int32
+
+	// IdleTimeoutInMinutes is the desired outbound flow idle timeout in minutes.
+	IdleTimeoutInMinutes *int32
+}
+
 // APIServerAccessProfile is the access profile for AKS API server.
 type APIServerAccessProfile struct {
 	// AuthorizedIPRanges are the authorized IP Ranges to kubernetes API server.
@@ -157,6 +205,67 @@ type APIServerAccessProfile struct {
 	EnablePrivateClusterPublicFQDN *bool
 }
 
+// AutoScalerProfile is the parameters to be applied to the cluster-autoscaler.
+type AutoScalerProfile struct {
+	BalanceSimilarNodeGroups      *string
+	Expander                      *string
+	MaxEmptyBulkDelete            *string
+	MaxGracefulTerminationSec     *string
+	MaxNodeProvisionTime          *string
+	MaxTotalUnreadyPercentage     *string
+	NewPodScaleUpDelay            *string
+	OkTotalUnreadyCount           *string
+	ScanInterval                  *string
+	ScaleDownDelayAfterAdd        *string
+	ScaleDownDelayAfterDelete     *string
+	ScaleDownDelayAfterFailure    *string
+	ScaleDownUnneededTime         *string
+	ScaleDownUnreadyTime          *string
+	ScaleDownUtilizationThreshold *string
+	SkipNodesWithLocalStorage     *string
+	SkipNodesWithSystemPods       *string
+}
+
+// AutoUpgradeProfile is the automatic upgrade configuration of the cluster.
+type AutoUpgradeProfile struct {
+	// UpgradeChannel is the channel used to automatically upgrade the cluster.
+	UpgradeChannel string
+}
+
+// OIDCIssuerProfile is the OIDC issuer profile of the cluster.
+type OIDCIssuerProfile struct {
+	// Enabled defines whether the OIDC issuer is enabled.
+	Enabled *bool
+}
+
+// WorkloadIdentityProfile is the workload identity profile of the cluster.
+type WorkloadIdentityProfile struct {
+	// Enabled defines whether workload identity is enabled.
+	Enabled *bool
+}
+
+// Identity is the user-assigned identities of the cluster.
+type Identity struct {
+	// ControlPlaneIdentityResourceID is the resource ID of the user-assigned identity of the control plane.
+	ControlPlaneIdentityResourceID string
+
+	// KubeletIdentityResourceID is the resource ID of the user-assigned identity of the kubelet.
+	KubeletIdentityResourceID string
+}
+
+// upgradesKubernetesVersion returns true if the upgrade channel upgrades the Kubernetes version of the cluster.
+func (p *AutoUpgradeProfile) upgradesKubernetesVersion() bool {
+	if p == nil {
+		return false
+	}
+	switch containerservice.UpgradeChannel(p.UpgradeChannel) {
+	case containerservice.UpgradeChannelPatch, containerservice.UpgradeChannelRapid, containerservice.UpgradeChannelStable:
+		return true
+	default:
+		return false
+	}
+}
+
 var _ azure.ResourceSpecGetterWithHeaders = (*ManagedClusterSpec)(nil)
 
 // ResourceName returns the name of the AKS cluster.
@@ -294,6 +403,21 @@ func (s *ManagedClusterSpec) Parameters(existing interface{}) (params interface{
 		}
 	}
 
+	if s.OutboundType != "" {
+		managedCluster.NetworkProfile.OutboundType = containerservice.OutboundType(s.OutboundType)
+	}
+
+	if s.NatGatewayProfile != nil {
+		managedCluster.NetworkProfile.NatGatewayProfile = &containerservice.ManagedClusterNATGatewayProfile{
+			IdleTimeoutInMinutes: s.NatGatewayProfile.IdleTimeoutInMinutes,
+		}
+		if s.NatGatewayProfile.ManagedOutboundIPs != nil {
+			managedCluster.NetworkProfile.NatGatewayProfile.ManagedOutboundIPProfile = &containerservice.ManagedClusterManagedOutboundIPProfile{
+				Count: s.NatGatewayProfile.ManagedOutboundIPs,
+			}
+		}
+	}
+
 	if s.APIServerAccessProfile != nil {
 		managedCluster.APIServerAccessProfile = &containerservice.ManagedClusterAPIServerAccessProfile{
 			AuthorizedIPRanges:             &s.APIServerAccessProfile.AuthorizedIPRanges,
@@ -303,6 +427,72 @@ func (s *ManagedClusterSpec) Parameters(existing interface{}) (params interface{
 		}
 	}
 
+	if s.AutoScalerProfile != nil {
+		managedCluster.AutoScalerProfile = &containerservice.ManagedClusterPropertiesAutoScalerProfile{
+			BalanceSimilarNodeGroups:      s.AutoScalerProfile.BalanceSimilarNodeGroups,
+			MaxEmptyBulkDelete:            s.AutoScalerProfile.MaxEmptyBulkDelete,
+			MaxGracefulTerminationSec:     s.AutoScalerProfile.MaxGracefulTerminationSec,
+			MaxNodeProvisionTime:          s.AutoScalerProfile.MaxNodeProvisionTime,
+			MaxTotalUnreadyPercentage:     s.AutoScalerProfile.MaxTotalUnreadyPercentage,
+			NewPodScaleUpDelay:            s.AutoScalerProfile.NewPodScaleUpDelay,
+			OkTotalUnreadyCount:           s.AutoScalerProfile.OkTotalUnreadyCount,
+			ScanInterval:                  s.AutoScalerProfile.ScanInterval,
+			ScaleDownDelayAfterAdd:        s.AutoScalerProfile.ScaleDownDelayAfterAdd,
+			ScaleDownDelayAfterDelete:     s.AutoScalerProfile.ScaleDownDelayAfterDelete,
+			ScaleDownDelayAfterFailure:    s.AutoScalerProfile.ScaleDownDelayAfterFailure,
+			ScaleDownUnneededTime:         s.AutoScalerProfile.ScaleDownUnneededTime,
+			ScaleDownUnreadyTime:          s.AutoScalerProfile.ScaleDownUnreadyTime,
+			ScaleDownUtilizationThreshold: s.AutoScalerProfile.ScaleDownUtilizationThreshold,
+			SkipNodesWithLocalStorage:     s.AutoScalerProfile.SkipNodesWithLocalStorage,
+			SkipNodesWithSystemPods:       s.AutoScalerProfile.SkipNodesWithSystemPods,
+		}
+		if s.AutoScalerProfile.Expander != nil {
+			managedCluster.AutoScalerProfile.Expander = containerservice.Expander(*s.AutoScalerProfile.Expander)
+		}
+	}
+
+	if s.AutoUpgradeProfile != nil {
+		managedCluster.AutoUpgradeProfile = &containerservice.ManagedClusterAutoUpgradeProfile{
+			UpgradeChannel: containerservice.UpgradeChannel(s.AutoUpgradeProfile.UpgradeChannel),
+		}
+	}
+
+	if s.OIDCIssuerProfile != nil {
+		managedCluster.OidcIssuerProfile = &containerservice.ManagedClusterOIDCIssuerProfile{
+			Enabled: s.OIDCIssuerProfile.Enabled,
+		}
+	}
+
+	if s.WorkloadIdentityProfile != nil {
+		managedCluster.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{
+			WorkloadIdentity: &containerservice.ManagedClusterSecurityProfileWorkloadIdentity{
+				Enabled: s.WorkloadIdentityProfile.Enabled,
+			},
+		}
+	}
+
+	if s.DisableLocalAccounts != nil {
+		managedCluster.DisableLocalAccounts = s.DisableLocalAccounts
+	}
+
+	if s.Identity != nil {
+		if s.Identity.ControlPlaneIdentityResourceID != "" {
+			managedCluster.Identity = &containerservice.ManagedClusterIdentity{
+				Type: containerservice.ResourceIdentityTypeUserAssigned,
+				UserAssignedIdentities: map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
+					s.Identity.ControlPlaneIdentityResourceID: {},
+				},
+			}
+		}
+		if s.Identity.KubeletIdentityResourceID != "" {
+			managedCluster.IdentityProfile = map[string]*containerservice.UserAssignedIdentity{
+				kubeletIdentityKey: {
+					ResourceID: to.StringPtr(s.Identity.KubeletIdentityResourceID),
+				},
+			}
+		}
+	}
+
 	if existing != nil {
 		existingMC, ok := existing.(containerservice.ManagedCluster)
 		if !ok {
@@ -323,10 +513,33 @@ func (s *ManagedClusterSpec) Parameters(existing interface{}) (params interface{
 			existingMC.NetworkProfile.LoadBalancerProfile.EffectiveOutboundIPs = nil
 		}
 
+		// Normalize the NatGatewayProfile the same way as the LoadBalancerProfile.
+		if managedCluster.NetworkProfile.NatGatewayProfile == nil {
+			existingMC.NetworkProfile.NatGatewayProfile = nil
+		} else if existingMC.NetworkProfile.NatGatewayProfile != nil {
+			existingMC.NetworkProfile.NatGatewayProfile.EffectiveOutboundIPs = nil
+		}
+
+		// When AKS upgrades the Kubernetes version through the auto-upgrade channel, the existing cluster
+		// may be newer than the spec. Keep the existing version rather than attempting a downgrade.
+		if s.AutoUpgradeProfile.upgradesKubernetesVersion() && existingMC.KubernetesVersion != nil &&
+			semver.Compare(semverString(*existingMC.KubernetesVersion), semverString(s.Version)) > 0 {
+			managedCluster.KubernetesVersion = existingMC.KubernetesVersion
+		}
+
 		// Avoid changing agent pool profiles through AMCP and just use the existing agent pool profiles
 		// AgentPool changes are managed through AMMP.
 		managedCluster.AgentPoolProfiles = existingMC.AgentPoolProfiles
 
+		// AKS only supports agent pools up to maxAgentPoolMinorVersionSkew minor versions older than the control plane,
+		// so the control plane is not upgraded past that until the agent pools catch up. The skew is only checked when
+		// the version changes, so that other changes are still applied to a cluster that already exceeds it.
+		if version := to.String(managedCluster.KubernetesVersion); semver.Compare(semverString(version), semverString(to.String(existingMC.KubernetesVersion))) != 0 {
+			if err := checkAgentPoolVersionSkew(version, existingMC.AgentPoolProfiles); err != nil {
+				return nil, azure.WithTransientError(errors.Wrapf(err, "cannot upgrade managed cluster %s", s.Name), agentPoolVersionSkewRequeueInterval)
+			}
+		}
+
 		diff := computeDiffOfNormalizedClusters(managedCluster, existingMC)
 		if diff == "" {
 			return nil, nil
@@ -356,6 +569,48 @@ func (s *ManagedClusterSpec) Parameters(existing interface{}) (params interface{
 	return managedCluster, nil
 }
 
+// checkAgentPoolVersionSkew returns an error if an agent pool would be more than maxAgentPoolMinorVersionSkew minor
+// versions older than the given control plane version.
+func checkAgentPoolVersionSkew(version string, agentPools *[]containerservice.ManagedClusterAgentPoolProfile) error {
+	if version == "" || agentPools == nil {
+		return nil
+	}
+	for _, pool := range *agentPools {
+		poolVersion := to.String(pool.OrchestratorVersion)
+		if poolVersion == "" {
+			continue
+		}
+		if skew, ok := minorVersionSkew(version, poolVersion); ok && skew > maxAgentPoolMinorVersionSkew {
+			return errors.Errorf("agent pool %s at version %s would be more than %d minor versions older than version %s of the control plane",
+				to.String(pool.Name), poolVersion, maxAgentPoolMinorVersionSkew, version)
+		}
+	}
+	return nil
+}
+
+// minorVersionSkew returns how many minor versions older the second version is than the first one. It returns false
+// if the versions are invalid or their major versions differ.
+func minorVersionSkew(version, olderVersion string) (int, bool) {
+	majorMinor, olderMajorMinor := semver.MajorMinor(semverString(version)), semver.MajorMinor(semverString(olderVersion))
+	if majorMinor == "" || olderMajorMinor == "" || semver.Major(majorMinor) != semver.Major(olderMajorMinor) {
+		return 0, false
+	}
+	minor, err := strconv.Atoi(strings.TrimPrefix(majorMinor, semver.Major(majorMinor)+"."))
+	if err != nil {
+		return 0, false
+	}
+	olderMinor, err := strconv.Atoi(strings.TrimPrefix(olderMajorMinor, semver.Major(olderMajorMinor)+"."))
+	if err != nil {
+		return 0, false
+	}
+	return minor - olderMinor, true
+}
+
+// semverString returns the version with the "v" prefix expected by the semver package.
+func semverString(version string) string {
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
 func convertToResourceReferences(resources []string) *[]containerservice.ResourceReference {
 	resourceReferences := make([]containerservice.ResourceReference, len(resources))
 	for i := range resources {
@@ -399,10 +654,12 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 
 	if managedCluster.NetworkProfile != nil {
 		propertiesNormalized.NetworkProfile.LoadBalancerProfile = managedCluster.NetworkProfile.LoadBalancerProfile
+		propertiesNormalized.NetworkProfile.NatGatewayProfile = managedCluster.NetworkProfile.NatGatewayProfile
 	}
 
 	if existingMC.NetworkProfile != nil {
 		existingMCPropertiesNormalized.NetworkProfile.LoadBalancerProfile = existingMC.NetworkProfile.LoadBalancerProfile
+		existingMCPropertiesNormalized.NetworkProfile.NatGatewayProfile = existingMC.NetworkProfile.NatGatewayProfile
 	}
 
 	if managedCluster.APIServerAccessProfile != nil {
@@ -417,6 +674,53 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 		}
 	}
 
+	if managedCluster.AutoScalerProfile != nil {
+		propertiesNormalized.AutoScalerProfile = managedCluster.AutoScalerProfile
+		if existingMC.AutoScalerProfile != nil {
+			existingMCPropertiesNormalized.AutoScalerProfile = normalizeAutoScalerProfile(managedCluster.AutoScalerProfile, existingMC.AutoScalerProfile)
+		}
+	}
+
+	if managedCluster.AutoUpgradeProfile != nil {
+		propertiesNormalized.AutoUpgradeProfile = managedCluster.AutoUpgradeProfile
+		existingMCPropertiesNormalized.AutoUpgradeProfile = &containerservice.ManagedClusterAutoUpgradeProfile{}
+		if existingMC.AutoUpgradeProfile != nil {
+			existingMCPropertiesNormalized.AutoUpgradeProfile.UpgradeChannel = existingMC.AutoUpgradeProfile.UpgradeChannel
+		}
+	}
+
+	if managedCluster.OidcIssuerProfile != nil {
+		propertiesNormalized.OidcIssuerProfile = &containerservice.ManagedClusterOIDCIssuerProfile{
+			Enabled: managedCluster.OidcIssuerProfile.Enabled,
+		}
+		existingMCPropertiesNormalized.OidcIssuerProfile = &containerservice.ManagedClusterOIDCIssuerProfile{}
+		if existingMC.OidcIssuerProfile != nil {
+			existingMCPropertiesNormalized.OidcIssuerProfile.Enabled = existingMC.OidcIssuerProfile.Enabled
+		}
+	}
+
+	if managedCluster.SecurityProfile != nil && managedCluster.SecurityProfile.WorkloadIdentity != nil {
+		propertiesNormalized.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{
+			WorkloadIdentity: managedCluster.SecurityProfile.WorkloadIdentity,
+		}
+		existingMCPropertiesNormalized.SecurityProfile = &containerservice.ManagedClusterSecurityProfile{
+			WorkloadIdentity: &containerservice.ManagedClusterSecurityProfileWorkloadIdentity{},
+		}
+		if existingMC.SecurityProfile != nil && existingMC.SecurityProfile.WorkloadIdentity != nil {
+			existingMCPropertiesNormalized.SecurityProfile.WorkloadIdentity.Enabled = existingMC.SecurityProfile.WorkloadIdentity.Enabled
+		}
+	}
+
+	if managedCluster.AddonProfiles != nil {
+		propertiesNormalized.AddonProfiles = managedCluster.AddonProfiles
+		existingMCPropertiesNormalized.AddonProfiles = normalizeAddonProfiles(managedCluster.AddonProfiles, existingMC.AddonProfiles)
+	}
+
+	if managedCluster.DisableLocalAccounts != nil {
+		propertiesNormalized.DisableLocalAccounts = managedCluster.DisableLocalAccounts
+		existingMCPropertiesNormalized.DisableLocalAccounts = to.BoolPtr(to.Bool(existingMC.DisableLocalAccounts))
+	}
+
 	clusterNormalized := &containerservice.ManagedCluster{
 		ManagedClusterProperties: propertiesNormalized,
 		Tags:                     managedCluster.Tags,
@@ -436,3 +740,72 @@ func computeDiffOfNormalizedClusters(managedCluster containerservice.ManagedClus
 	diff := cmp.Diff(clusterNormalized, existingMCClusterNormalized)
 	return diff
 }
+
+// normalizeAddonProfiles returns the existing profiles of the desired add-ons with only the config keys set in the
+// desired profiles, so config that AKS defaults and resource IDs that only differ in case do not cause an update.
+func normalizeAddonProfiles(desired, existing map[string]*containerservice.ManagedClusterAddonProfile) map[string]*containerservice.ManagedClusterAddonProfile {
+	normalized := make(map[string]*containerservice.ManagedClusterAddonProfile, len(desired))
+	for name, desiredProfile := range desired {
+		var existingProfile *containerservice.ManagedClusterAddonProfile
+		for existingName, profile := range existing {
+			if strings.EqualFold(existingName, name) {
+				existingProfile = profile
+				break
+			}
+		}
+		normalizedProfile := &containerservice.ManagedClusterAddonProfile{
+			Enabled: to.BoolPtr(false),
+		}
+		if existingProfile != nil {
+			normalizedProfile.Enabled = to.BoolPtr(to.Bool(existingProfile.Enabled))
+		}
+		if desiredProfile.Config != nil {
+			normalizedProfile.Config = map[string]*string{}
+			for key, desiredValue := range desiredProfile.Config {
+				if existingProfile == nil || existingProfile.Config[key] == nil {
+					continue
+				}
+				value := existingProfile.Config[key]
+				if strings.EqualFold(to.String(value), to.String(desiredValue)) {
+					value = desiredValue
+				}
+				normalizedProfile.Config[key] = value
+			}
+		}
+		normalized[name] = normalizedProfile
+	}
+	return normalized
+}
+
+// normalizeAutoScalerProfile returns the existing autoscaler profile with only the fields set in the desired profile,
+// since AKS populates every unset field with its default value.
+func normalizeAutoScalerProfile(desired, existing *containerservice.ManagedClusterPropertiesAutoScalerProfile) *containerservice.ManagedClusterPropertiesAutoScalerProfile {
+	pick := func(desired, existing *string) *string {
+		if desired == nil {
+			return nil
+		}
+		return existing
+	}
+	normalized := &containerservice.ManagedClusterPropertiesAutoScalerProfile{
+		BalanceSimilarNodeGroups:      pick(desired.BalanceSimilarNodeGroups, existing.BalanceSimilarNodeGroups),
+		MaxEmptyBulkDelete:            pick(desired.MaxEmptyBulkDelete, existing.MaxEmptyBulkDelete),
+		MaxGracefulTerminationSec:     pick(desired.MaxGracefulTerminationSec, existing.MaxGracefulTerminationSec),
+		MaxNodeProvisionTime:          pick(desired.MaxNodeProvisionTime, existing.MaxNodeProvisionTime),
+		MaxTotalUnreadyPercentage:     pick(desired.MaxTotalUnreadyPercentage, existing.MaxTotalUnreadyPercentage),
+		NewPodScaleUpDelay:            pick(desired.NewPodScaleUpDelay, existing.NewPodScaleUpDelay),
+		OkTotalUnreadyCount:           pick(desired.OkTotalUnreadyCount, existing.OkTotalUnreadyCount),
+		ScanInterval:                  pick(desired.ScanInterval, existing.ScanInterval),
+		ScaleDownDelayAfterAdd:        pick(desired.ScaleDownDelayAfterAdd, existing.ScaleDownDelayAfterAdd),
+		ScaleDownDelayAfterDelete:     pick(desired.ScaleDownDelayAfterDelete, existing.ScaleDownDelayAfterDelete),
+		ScaleDownDelayAfterFailure:    pick(desired.ScaleDownDelayAfterFailure, existing.ScaleDownDelayAfterFailure),
+		ScaleDownUnneededTime:         pick(desired.ScaleDownUnneededTime, existing.ScaleDownUnneededTime),
+		ScaleDownUnreadyTime:          pick(desired.ScaleDownUnreadyTime, existing.ScaleDownUnreadyTime),
+		ScaleDownUtilizationThreshold: pick(desired.ScaleDownUtilizationThreshold, existing.ScaleDownUtilizationThreshold),
+		SkipNodesWithLocalStorage:     pick(desired.SkipNodesWithLocalStorage, existing.SkipNodesWithLocalStorage),
+		SkipNodesWithSystemPods:       pick(desired.SkipNodesWithSystemPods, existing.SkipNodesWithSystemPods),
+	}
+	if desired.Expander != "" {
+		normalized.Expander = existing.Expander
+	}
+	return normalized
+}
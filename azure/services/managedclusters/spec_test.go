@@ -19,7 +19,7 @@ package managedclusters
 import (
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
@@ -128,6 +128,395 @@ func TestParameters(t *testing.T) {
 				g.Expect(result.(containerservice.ManagedCluster).KubernetesVersion).To(Equal(to.StringPtr("v1.22.99")))
 			},
 		},
+		{
+			name: "managedcluster exists with AKS defaulted autoscaler profile, no update needed",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.AutoScalerProfile = &containerservice.ManagedClusterPropertiesAutoScalerProfile{
+					Expander:             containerservice.ExpanderLeastWaste,
+					ScanInterval:         to.StringPtr("10s"),
+					MaxNodeProvisionTime: to.StringPtr("15m"),
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoScalerProfile: &AutoScalerProfile{
+					Expander:     to.StringPtr("least-waste"),
+					ScanInterval: to.StringPtr("10s"),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "managedcluster exists and the autoscaler profile and upgrade channel need an update",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoScalerProfile: &AutoScalerProfile{
+					ScanInterval: to.StringPtr("20s"),
+				},
+				AutoUpgradeProfile: &AutoUpgradeProfile{
+					UpgradeChannel: "node-image",
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				mc := result.(containerservice.ManagedCluster)
+				g.Expect(mc.AutoScalerProfile.ScanInterval).To(Equal(to.StringPtr("20s")))
+				g.Expect(mc.AutoUpgradeProfile.UpgradeChannel).To(Equal(containerservice.UpgradeChannelNodeImage))
+			},
+		},
+		{
+			name:     "managedcluster exists and OIDC issuer and workload identity need to be enabled",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: to.BoolPtr(true),
+				},
+				WorkloadIdentityProfile: &WorkloadIdentityProfile{
+					Enabled: to.BoolPtr(true),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				mc := result.(containerservice.ManagedCluster)
+				g.Expect(mc.OidcIssuerProfile.Enabled).To(Equal(to.BoolPtr(true)))
+				g.Expect(mc.SecurityProfile.WorkloadIdentity.Enabled).To(Equal(to.BoolPtr(true)))
+			},
+		},
+		{
+			name: "managedcluster exists with OIDC issuer enabled, no update needed",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.OidcIssuerProfile = &containerservice.ManagedClusterOIDCIssuerProfile{
+					Enabled:   to.BoolPtr(true),
+					IssuerURL: to.StringPtr("https://oidc.prod-aks.azure.com/00000000-0000-0000-0000-000000000000/"),
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				OIDCIssuerProfile: &OIDCIssuerProfile{
+					Enabled: to.BoolPtr(true),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managedcluster exists with AKS defaulted add-on config, no update needed",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.AddonProfiles = map[string]*containerservice.ManagedClusterAddonProfile{
+					"azureKeyvaultSecretsProvider": {
+						Enabled: to.BoolPtr(true),
+						Config: map[string]*string{
+							"enableSecretRotation": to.StringPtr("true"),
+							"rotationPollInterval": to.StringPtr("2m"),
+						},
+						Identity: &containerservice.ManagedClusterAddonProfileIdentity{
+							ClientID: to.StringPtr("client-id"),
+						},
+					},
+					"omsagent": {
+						Enabled: to.BoolPtr(true),
+						Config: map[string]*string{
+							"logAnalyticsWorkspaceResourceID": to.StringPtr("/subscriptions/123/resourcegroups/rg1/providers/microsoft.operationalinsights/workspaces/ws1"),
+						},
+					},
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AddonProfiles: []AddonProfile{
+					{Name: "azureKeyvaultSecretsProvider", Enabled: true, Config: map[string]string{"enableSecretRotation": "true"}},
+					{Name: "omsagent", Enabled: true, Config: map[string]string{
+						"logAnalyticsWorkspaceResourceID": "/subscriptions/123/resourceGroups/rg1/providers/Microsoft.OperationalInsights/workspaces/ws1",
+					}},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "managedcluster exists and an add-on needs to be enabled",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AddonProfiles: []AddonProfile{
+					{Name: "azurepolicy", Enabled: true},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).AddonProfiles).To(HaveKeyWithValue("azurepolicy", &containerservice.ManagedClusterAddonProfile{
+					Enabled: to.BoolPtr(true),
+				}))
+			},
+		},
+		{
+			name:     "managedcluster exists and local accounts need to be disabled",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:              "v1.22.0",
+				LoadBalancerSKU:      "Standard",
+				DisableLocalAccounts: to.BoolPtr(true),
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).DisableLocalAccounts).To(Equal(to.BoolPtr(true)))
+			},
+		},
+		{
+			name:     "managedcluster exists with local accounts enabled, no update needed",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:              "v1.22.0",
+				LoadBalancerSKU:      "Standard",
+				DisableLocalAccounts: to.BoolPtr(false),
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "managedcluster exists with AKS populated NAT gateway profile, no update needed",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.NetworkProfile.OutboundType = containerservice.OutboundTypeManagedNATGateway
+				mc.NetworkProfile.NatGatewayProfile = &containerservice.ManagedClusterNATGatewayProfile{
+					ManagedOutboundIPProfile: &containerservice.ManagedClusterManagedOutboundIPProfile{
+						Count: to.Int32Ptr(2),
+					},
+					EffectiveOutboundIPs: &[]containerservice.ResourceReference{
+						{ID: to.StringPtr("/subscriptions/123/resourceGroups/test-node-rg/providers/Microsoft.Network/publicIPAddresses/ip-1")},
+					},
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				OutboundType:    "managedNATGateway",
+				NatGatewayProfile: &NatGatewayProfile{
+					ManagedOutboundIPs: to.Int32Ptr(2),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "managedcluster exists and the NAT gateway profile needs an update",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				OutboundType:    "managedNATGateway",
+				NatGatewayProfile: &NatGatewayProfile{
+					ManagedOutboundIPs:   to.Int32Ptr(3),
+					IdleTimeoutInMinutes: to.Int32Ptr(10),
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				mc := result.(containerservice.ManagedCluster)
+				g.Expect(mc.NetworkProfile.OutboundType).To(Equal(containerservice.OutboundTypeManagedNATGateway))
+				g.Expect(mc.NetworkProfile.NatGatewayProfile.ManagedOutboundIPProfile.Count).To(Equal(to.Int32Ptr(3)))
+				g.Expect(mc.NetworkProfile.NatGatewayProfile.IdleTimeoutInMinutes).To(Equal(to.Int32Ptr(10)))
+			},
+		},
+		{
+			name: "managedcluster auto-upgraded to a newer patch version, no downgrade",
+			existing: func() containerservice.ManagedCluster {
+				mc := getExistingCluster()
+				mc.KubernetesVersion = to.StringPtr("v1.22.6")
+				mc.AutoUpgradeProfile = &containerservice.ManagedClusterAutoUpgradeProfile{
+					UpgradeChannel: containerservice.UpgradeChannelPatch,
+				}
+				return mc
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.22.0",
+				LoadBalancerSKU: "Standard",
+				AutoUpgradeProfile: &AutoUpgradeProfile{
+					UpgradeChannel: "patch",
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "managedcluster exists and the control plane upgrade keeps agent pools within the version skew",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.24.0",
+				LoadBalancerSKU: "Standard",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).KubernetesVersion).To(Equal(to.StringPtr("v1.24.0")))
+			},
+		},
+		{
+			name:     "managedcluster exists and the control plane upgrade exceeds the agent pool version skew",
+			existing: getExistingCluster(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag": "test-value",
+				},
+				Version:         "v1.25.0",
+				LoadBalancerSKU: "Standard",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "cannot upgrade managed cluster test-managedcluster: agent pool test-agentpool-1 at version v1.22.0 would be more than 2 minor versions older than version v1.25.0 of the control plane. Object will be requeued after 1m0s",
+		},
+		{
+			name: "managedcluster exists beyond the agent pool version skew and the version does not change",
+			existing: func() containerservice.ManagedCluster {
+				existing := getExistingCluster()
+				existing.KubernetesVersion = to.StringPtr("v1.25.0")
+				return existing
+			}(),
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Tags: map[string]string{
+					"test-tag":     "test-value",
+					"test-new-tag": "test-new-value",
+				},
+				Version:         "v1.25.0",
+				LoadBalancerSKU: "Standard",
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				g.Expect(result.(containerservice.ManagedCluster).KubernetesVersion).To(Equal(to.StringPtr("v1.25.0")))
+			},
+		},
+		{
+			name:     "managedcluster does not exist, with user-assigned control plane and kubelet identities",
+			existing: nil,
+			spec: &ManagedClusterSpec{
+				Name:          "test-managedcluster",
+				ResourceGroup: "test-rg",
+				Location:      "test-location",
+				Version:       "v1.22.0",
+				Identity: &Identity{
+					ControlPlaneIdentityResourceID: "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane",
+					KubeletIdentityResourceID:      "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kubelet",
+				},
+				GetAllAgentPools: func() ([]azure.ResourceSpecGetter, error) {
+					return []azure.ResourceSpecGetter{}, nil
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(containerservice.ManagedCluster{}))
+				mc := result.(containerservice.ManagedCluster)
+				g.Expect(mc.Identity).To(Equal(&containerservice.ManagedClusterIdentity{
+					Type: containerservice.ResourceIdentityTypeUserAssigned,
+					UserAssignedIdentities: map[string]*containerservice.ManagedClusterIdentityUserAssignedIdentitiesValue{
+						"/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane": {},
+					},
+				}))
+				g.Expect(mc.IdentityProfile).To(Equal(map[string]*containerservice.UserAssignedIdentity{
+					"kubeletidentity": {
+						ResourceID: to.StringPtr("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kubelet"),
+					},
+				}))
+			},
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
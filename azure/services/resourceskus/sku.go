@@ -17,11 +17,15 @@ limitations under the License.
 package resourceskus
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
 // SKU is a thin layer over the Azure resource SKU API to better introspect capabilities.
@@ -68,6 +72,12 @@ const (
 	MaximumPlatformFaultDomainCount = "MaximumPlatformFaultDomainCount"
 	// UltraSSDAvailable identifies the capability for the support of UltraSSD data disks.
 	UltraSSDAvailable = "UltraSSDAvailable"
+	// GPUs identifies the capability for the number of GPUs.
+	GPUs = "GPUs"
+	// CPUArchitectureType identifies the capability for the CPU architecture.
+	CPUArchitectureType = "CPUArchitectureType"
+	// DefaultMaxPodsPerNode is the maximum number of pods of a node when kubelet uses its default configuration.
+	DefaultMaxPodsPerNode = 110
 )
 
 // HasCapability return true for a capability which can be either
@@ -165,3 +175,62 @@ func (s SKU) HasLocationCapability(capabilityName, location, zone string) bool {
 	}
 	return false
 }
+
+// NodeCapacity returns the resource capacity of a node running on a VM of this SKU with a kubelet running at most
+// maxPods pods, as expected by the cluster autoscaler to scale a node group from zero. The ephemeral storage of the
+// node is the size of its OS disk, which is only known when osDiskSizeGB is set.
+func (s SKU) NodeCapacity(osDiskSizeGB *int32, maxPods int64) (corev1.ResourceList, error) {
+	capacity := corev1.ResourceList{
+		corev1.ResourcePods: *resource.NewQuantity(maxPods, resource.DecimalSI),
+	}
+
+	vCPUs, ok := s.GetCapability(VCPUs)
+	if !ok {
+		return nil, errors.Errorf("unable to get required VM SKU capability %s", VCPUs)
+	}
+	cpu, err := resource.ParseQuantity(vCPUs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s capability '%s'", VCPUs, vCPUs)
+	}
+	capacity[corev1.ResourceCPU] = cpu
+
+	memoryGB, ok := s.GetCapability(MemoryGB)
+	if !ok {
+		return nil, errors.Errorf("unable to get required VM SKU capability %s", MemoryGB)
+	}
+	memory, err := resource.ParseQuantity(fmt.Sprintf("%sGi", memoryGB))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s capability '%s'", MemoryGB, memoryGB)
+	}
+	capacity[corev1.ResourceMemory] = memory
+
+	if value, ok := s.GetCapability(GPUs); ok {
+		gpus, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s capability '%s'", GPUs, value)
+		}
+		if !gpus.IsZero() {
+			capacity["nvidia.com/gpu"] = gpus
+		}
+	}
+
+	if osDiskSizeGB != nil && *osDiskSizeGB > 0 {
+		capacity[corev1.ResourceEphemeralStorage] = resource.MustParse(fmt.Sprintf("%dGi", *osDiskSizeGB))
+	}
+
+	return capacity, nil
+}
+
+// NodeInfo returns the architecture and operating system of a node running on a VM of this SKU with an OS disk of
+// osType, as expected by the cluster autoscaler to scale a node group from zero.
+func (s SKU) NodeInfo(osType string) *infrav1.NodeInfo {
+	architecture := "amd64"
+	if value, ok := s.GetCapability(CPUArchitectureType); ok && strings.EqualFold(value, "Arm64") {
+		architecture = "arm64"
+	}
+
+	return &infrav1.NodeInfo{
+		Architecture:    architecture,
+		OperatingSystem: strings.ToLower(osType),
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceskus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func skuWithCapabilities(capabilities map[string]string) SKU {
+	var skuCapabilities []compute.ResourceSkuCapabilities
+	for name, value := range capabilities {
+		skuCapabilities = append(skuCapabilities, compute.ResourceSkuCapabilities{
+			Name:  to.StringPtr(name),
+			Value: to.StringPtr(value),
+		})
+	}
+	return SKU{Capabilities: &skuCapabilities}
+}
+
+func TestSKUNodeCapacity(t *testing.T) {
+	cases := map[string]struct {
+		capabilities map[string]string
+		osDiskSizeGB *int32
+		maxPods      int64
+		want         corev1.ResourceList
+		err          string
+	}{
+		"should return the cpu, memory and pods of the SKU": {
+			capabilities: map[string]string{VCPUs: "2", MemoryGB: "0.75", GPUs: "0"},
+			maxPods:      DefaultMaxPodsPerNode,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("768Mi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+		"should return the GPUs of the SKU and the size of the OS disk": {
+			capabilities: map[string]string{VCPUs: "6", MemoryGB: "112", GPUs: "1"},
+			osDiskSizeGB: to.Int32Ptr(128),
+			maxPods:      DefaultMaxPodsPerNode,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("6"),
+				corev1.ResourceMemory:           resource.MustParse("112Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("128Gi"),
+				corev1.ResourcePods:             resource.MustParse("110"),
+				"nvidia.com/gpu":                resource.MustParse("1"),
+			},
+		},
+		"should return the maximum number of pods of the kubelet": {
+			capabilities: map[string]string{VCPUs: "4", MemoryGB: "16"},
+			maxPods:      250,
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+				corev1.ResourcePods:   resource.MustParse("250"),
+			},
+		},
+		"should fail without vCPUs": {
+			capabilities: map[string]string{MemoryGB: "8"},
+			err:          "unable to get required VM SKU capability vCPUs",
+		},
+		"should fail with invalid memory": {
+			capabilities: map[string]string{VCPUs: "2", MemoryGB: "lots"},
+			err:          "failed to parse MemoryGB capability 'lots'",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := skuWithCapabilities(tc.capabilities).NodeCapacity(tc.osDiskSizeGB, tc.maxPods)
+			if tc.err != "" {
+				if err == nil {
+					t.Fatalf("expected NodeCapacity to fail with error %s, but actual error was nil", tc.err)
+				}
+				if !strings.HasPrefix(err.Error(), tc.err) {
+					t.Fatalf("expected NodeCapacity to fail with error %s, but actual error was %s", tc.err, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected NodeCapacity to succeed, but actual error was %s", err.Error())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected capacity (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSKUNodeInfo(t *testing.T) {
+	cases := map[string]struct {
+		capabilities map[string]string
+		osType       string
+		want         *infrav1.NodeInfo
+	}{
+		"should default to amd64": {
+			capabilities: map[string]string{},
+			osType:       "Linux",
+			want:         &infrav1.NodeInfo{Architecture: "amd64", OperatingSystem: "linux"},
+		},
+		"should return the architecture of the SKU": {
+			capabilities: map[string]string{CPUArchitectureType: "Arm64"},
+			osType:       "Linux",
+			want:         &infrav1.NodeInfo{Architecture: "arm64", OperatingSystem: "linux"},
+		},
+		"should return the windows operating system": {
+			capabilities: map[string]string{CPUArchitectureType: "x64"},
+			osType:       "Windows",
+			want:         &infrav1.NodeInfo{Architecture: "amd64", OperatingSystem: "windows"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := skuWithCapabilities(tc.capabilities).NodeInfo(tc.osType)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected node info (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
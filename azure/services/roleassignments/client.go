@@ -83,7 +83,11 @@ func (ac *azureClient) Result(ctx context.Context, futureData azureautorest.Futu
 	return nil, nil
 }
 
-// DeleteAsync is no-op for role assignments. It gets deleted as part of the VM deletion.
+// DeleteAsync deletes a role assignment.
+// Deleting a role assignment is not a long running operation, so we don't ever return a future.
 func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (azureautorest.FutureAPI, error) {
-	return nil, nil
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "roleassignments.AzureClient.Delete")
+	defer done()
+	_, err := ac.roleassignments.Delete(ctx, spec.OwnerResourceName(), spec.ResourceName())
+	return nil, err
 }
@@ -308,3 +308,308 @@ func (mr *MockRoleAssignmentScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockRoleAssignmentScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
+
+// MockUserAssignedIdentityRoleAssignmentScope is a mock of UserAssignedIdentityRoleAssignmentScope interface.
+type MockUserAssignedIdentityRoleAssignmentScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder
+}
+
+// MockUserAssignedIdentityRoleAssignmentScopeMockRecorder is the mock recorder for MockUserAssignedIdentityRoleAssignmentScope.
+type MockUserAssignedIdentityRoleAssignmentScopeMockRecorder struct {
+	mock *MockUserAssignedIdentityRoleAssignmentScope
+}
+
+// NewMockUserAssignedIdentityRoleAssignmentScope creates a new mock instance.
+func NewMockUserAssignedIdentityRoleAssignmentScope(ctrl *gomock.Controller) *MockUserAssignedIdentityRoleAssignmentScope {
+	mock := &MockUserAssignedIdentityRoleAssignmentScope{ctrl: ctrl}
+	mock.recorder = &MockUserAssignedIdentityRoleAssignmentScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) EXPECT() *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder {
+	return m.recorder
+}
+
+// Authorizer mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).Authorizer))
+}
+
+// BaseURI mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).BaseURI))
+}
+
+// ClientID mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).CloudEnvironment))
+}
+
+// DeleteLongRunningOperationState mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) DeleteLongRunningOperationState(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteLongRunningOperationState", arg0, arg1)
+}
+
+// DeleteLongRunningOperationState indicates an expected call of DeleteLongRunningOperationState.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) DeleteLongRunningOperationState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLongRunningOperationState", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).DeleteLongRunningOperationState), arg0, arg1)
+}
+
+// GetLongRunningOperationState mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) GetLongRunningOperationState(arg0, arg1 string) *v1beta1.Future {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLongRunningOperationState", arg0, arg1)
+	ret0, _ := ret[0].(*v1beta1.Future)
+	return ret0
+}
+
+// GetLongRunningOperationState indicates an expected call of GetLongRunningOperationState.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) GetLongRunningOperationState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLongRunningOperationState", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).GetLongRunningOperationState), arg0, arg1)
+}
+
+// HasSystemAssignedIdentity mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) HasSystemAssignedIdentity() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSystemAssignedIdentity")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasSystemAssignedIdentity indicates an expected call of HasSystemAssignedIdentity.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) HasSystemAssignedIdentity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSystemAssignedIdentity", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).HasSystemAssignedIdentity))
+}
+
+// HashKey mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).HashKey))
+}
+
+// Name mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).Name))
+}
+
+// OwnedRoleAssignments mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) OwnedRoleAssignments() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnedRoleAssignments")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OwnedRoleAssignments indicates an expected call of OwnedRoleAssignments.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) OwnedRoleAssignments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnedRoleAssignments", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).OwnedRoleAssignments))
+}
+
+// ResourceGroup mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) ResourceGroup() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceGroup")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceGroup indicates an expected call of ResourceGroup.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) ResourceGroup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroup", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).ResourceGroup))
+}
+
+// RoleAssignmentResourceType mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) RoleAssignmentResourceType() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleAssignmentResourceType")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RoleAssignmentResourceType indicates an expected call of RoleAssignmentResourceType.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) RoleAssignmentResourceType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleAssignmentResourceType", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).RoleAssignmentResourceType))
+}
+
+// RoleAssignmentSpecs mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) RoleAssignmentSpecs(principalID *string) []azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleAssignmentSpecs", principalID)
+	ret0, _ := ret[0].([]azure.ResourceSpecGetter)
+	return ret0
+}
+
+// RoleAssignmentSpecs indicates an expected call of RoleAssignmentSpecs.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) RoleAssignmentSpecs(principalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleAssignmentSpecs", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).RoleAssignmentSpecs), principalID)
+}
+
+// SetLongRunningOperationState mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) SetLongRunningOperationState(arg0 *v1beta1.Future) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLongRunningOperationState", arg0)
+}
+
+// SetLongRunningOperationState indicates an expected call of SetLongRunningOperationState.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) SetLongRunningOperationState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLongRunningOperationState", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).SetLongRunningOperationState), arg0)
+}
+
+// SetOwnedRoleAssignments mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) SetOwnedRoleAssignments(arg0 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOwnedRoleAssignments", arg0)
+}
+
+// SetOwnedRoleAssignments indicates an expected call of SetOwnedRoleAssignments.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) SetOwnedRoleAssignments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOwnedRoleAssignments", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).SetOwnedRoleAssignments), arg0)
+}
+
+// SubscriptionID mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).TenantID))
+}
+
+// UpdateDeleteStatus mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateDeleteStatus", arg0, arg1, arg2)
+}
+
+// UpdateDeleteStatus indicates an expected call of UpdateDeleteStatus.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) UpdateDeleteStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeleteStatus", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).UpdateDeleteStatus), arg0, arg1, arg2)
+}
+
+// UpdatePatchStatus mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) UpdatePatchStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePatchStatus", arg0, arg1, arg2)
+}
+
+// UpdatePatchStatus indicates an expected call of UpdatePatchStatus.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) UpdatePatchStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatchStatus", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).UpdatePatchStatus), arg0, arg1, arg2)
+}
+
+// UpdatePutStatus mocks base method.
+func (m *MockUserAssignedIdentityRoleAssignmentScope) UpdatePutStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePutStatus", arg0, arg1, arg2)
+}
+
+// UpdatePutStatus indicates an expected call of UpdatePutStatus.
+func (mr *MockUserAssignedIdentityRoleAssignmentScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockUserAssignedIdentityRoleAssignmentScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
+}
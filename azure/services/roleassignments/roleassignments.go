@@ -18,11 +18,14 @@ package roleassignments
 
 import (
 	"context"
+	"sort"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
@@ -42,12 +45,22 @@ type RoleAssignmentScope interface {
 	ResourceGroup() string
 }
 
+// UserAssignedIdentityRoleAssignmentScope defines the scope interface for the role assignments of user-assigned
+// identities, such as those of a managed cluster. It tracks the role assignments created by CAPZ so that they are
+// deleted when they are removed from the spec or when the resource is deleted.
+type UserAssignedIdentityRoleAssignmentScope interface {
+	RoleAssignmentScope
+	OwnedRoleAssignments() []string
+	SetOwnedRoleAssignments([]string)
+}
+
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope                 RoleAssignmentScope
 	virtualMachinesGetter async.Getter
 	async.Reconciler
 	virtualMachineScaleSetClient scalesets.Client
+	userAssignedIdentitiesClient identities.Client
 }
 
 // New creates a new service.
@@ -57,6 +70,7 @@ func New(scope RoleAssignmentScope) *Service {
 		Scope:                        scope,
 		virtualMachinesGetter:        virtualmachines.NewClient(scope),
 		virtualMachineScaleSetClient: scalesets.NewClient(scope),
+		userAssignedIdentitiesClient: identities.NewClient(scope),
 		Reconciler:                   async.New(scope, client, client),
 	}
 }
@@ -74,6 +88,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	defer cancel()
 	log.V(2).Info("reconciling role assignment")
 
+	resourceType := s.Scope.RoleAssignmentResourceType()
+	if resourceType == azure.ManagedCluster {
+		scope, ok := s.Scope.(UserAssignedIdentityRoleAssignmentScope)
+		if !ok {
+			return errors.Errorf("%T is not a UserAssignedIdentityRoleAssignmentScope", s.Scope)
+		}
+		return s.reconcileUserAssignedIdentities(ctx, scope)
+	}
+
 	// Return early if the identity is not system assigned as there will be no
 	// role assignment spec in this case.
 	if !s.Scope.HasSystemAssignedIdentity() {
@@ -82,7 +105,6 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	var principalID *string
-	resourceType := s.Scope.RoleAssignmentResourceType()
 	switch resourceType {
 	case azure.VirtualMachine:
 		ID, err := s.getVMPrincipalID(ctx)
@@ -112,6 +134,96 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return nil
 }
 
+// reconcileUserAssignedIdentities creates the role assignments of user-assigned identities, such as those of a managed
+// cluster, and deletes the role assignments created by CAPZ which were removed from the spec.
+func (s *Service) reconcileUserAssignedIdentities(ctx context.Context, scope UserAssignedIdentityRoleAssignmentScope) (err error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.reconcileUserAssignedIdentities")
+	defer done()
+
+	owned := make(map[string]bool)
+	for _, id := range scope.OwnedRoleAssignments() {
+		owned[id] = true
+	}
+	// Record the role assignments created so far, even if an error occurs.
+	defer func() {
+		scope.SetOwnedRoleAssignments(sortedIDs(owned))
+	}()
+
+	roleAssignmentSpecs := make([]*RoleAssignmentSpec, 0)
+	specified := make(map[string]bool)
+	for _, spec := range scope.RoleAssignmentSpecs(nil) {
+		roleAssignmentSpec, ok := spec.(*RoleAssignmentSpec)
+		if !ok {
+			return errors.Errorf("%T is not a *roleassignments.RoleAssignmentSpec", spec)
+		}
+		roleAssignmentSpecs = append(roleAssignmentSpecs, roleAssignmentSpec)
+		specified[roleAssignmentSpec.ResourceID()] = true
+	}
+
+	principalIDs := make(map[string]*string)
+	for _, roleAssignmentSpec := range roleAssignmentSpecs {
+		if roleAssignmentSpec.PrincipalID == nil {
+			principalID, ok := principalIDs[roleAssignmentSpec.UserAssignedIdentityID]
+			if !ok {
+				ID, err := s.userAssignedIdentitiesClient.GetPrincipalID(ctx, roleAssignmentSpec.UserAssignedIdentityID)
+				if err != nil {
+					return errors.Wrapf(err, "failed to get principal ID for user-assigned identity %s", roleAssignmentSpec.UserAssignedIdentityID)
+				}
+				principalID = to.StringPtr(ID)
+				principalIDs[roleAssignmentSpec.UserAssignedIdentityID] = principalID
+			}
+			roleAssignmentSpec.PrincipalID = principalID
+		}
+
+		log.V(2).Info("Creating role assignment", "scope", roleAssignmentSpec.Scope)
+		if _, err := s.CreateResource(ctx, roleAssignmentSpec, serviceName); err != nil {
+			return errors.Wrapf(err, "cannot assign role to user-assigned identity %s", roleAssignmentSpec.UserAssignedIdentityID)
+		}
+		owned[roleAssignmentSpec.ResourceID()] = true
+	}
+
+	for _, id := range sortedIDs(owned) {
+		if specified[id] {
+			continue
+		}
+		log.V(2).Info("Deleting role assignment removed from the spec", "roleAssignment", id)
+		if err := s.DeleteResource(ctx, roleAssignmentSpecFromID(id), serviceName); err != nil {
+			return errors.Wrapf(err, "failed to delete role assignment %s", id)
+		}
+		delete(owned, id)
+	}
+
+	return nil
+}
+
+// deleteUserAssignedIdentities deletes the role assignments of user-assigned identities created by CAPZ, including
+// those whose scope is outside of the resource groups deleted with the cluster.
+func (s *Service) deleteUserAssignedIdentities(ctx context.Context, scope UserAssignedIdentityRoleAssignmentScope) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.deleteUserAssignedIdentities")
+	defer done()
+
+	owned := scope.OwnedRoleAssignments()
+	for i, id := range owned {
+		log.V(2).Info("Deleting role assignment", "roleAssignment", id)
+		if err := s.DeleteResource(ctx, roleAssignmentSpecFromID(id), serviceName); err != nil {
+			scope.SetOwnedRoleAssignments(owned[i:])
+			return errors.Wrapf(err, "failed to delete role assignment %s", id)
+		}
+	}
+	scope.SetOwnedRoleAssignments(nil)
+	return nil
+}
+
+// sortedIDs returns the IDs of a set, sorted.
+func sortedIDs(set map[string]bool) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // getVMPrincipalID returns the VM principal ID.
 func (s *Service) getVMPrincipalID(ctx context.Context) (*string, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.getVMPrincipalID")
@@ -145,11 +257,20 @@ func (s *Service) getVMSSPrincipalID(ctx context.Context) (*string, error) {
 	return resultVMSS.Identity.PrincipalID, nil
 }
 
-// Delete is a no-op as the role assignments get deleted as part of VM deletion.
+// Delete deletes the role assignments of user-assigned identities created by CAPZ. It is a no-op for system-assigned
+// identities as their role assignments get deleted as part of VM deletion.
 func (s *Service) Delete(ctx context.Context) error {
-	_, _, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.Delete")
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.Delete")
 	defer done()
-	return nil
+
+	if s.Scope.RoleAssignmentResourceType() != azure.ManagedCluster {
+		return nil
+	}
+	scope, ok := s.Scope.(UserAssignedIdentityRoleAssignmentScope)
+	if !ok {
+		return errors.Errorf("%T is not a UserAssignedIdentityRoleAssignmentScope", s.Scope)
+	}
+	return s.deleteUserAssignedIdentities(ctx, scope)
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO role assignments.
@@ -29,6 +29,7 @@ import (
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities/mock_identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments/mock_roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets/mock_scalesets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
@@ -242,3 +243,222 @@ func TestReconcileRoleAssignmentsVMSS(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileRoleAssignmentsManagedCluster(t *testing.T) {
+	fakeIdentityID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"
+	fakeIdentityPrincipalID := "00000000-0000-0000-0000-000000000001"
+	newSpecs := func() []azure.ResourceSpecGetter {
+		return []azure.ResourceSpecGetter{
+			&RoleAssignmentSpec{
+				Name:                   "fake-role-assignment-1",
+				ResourceType:           azure.ManagedCluster,
+				Scope:                  "/subscriptions/123/resourceGroups/my-vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+				RoleDefinitionID:       "/subscriptions/123/providers/Microsoft.Authorization/roleDefinitions/4d97b98b-1d4f-4787-a291-c67834d212e7",
+				UserAssignedIdentityID: fakeIdentityID,
+			},
+			&RoleAssignmentSpec{
+				Name:                   "fake-role-assignment-2",
+				ResourceType:           azure.ManagedCluster,
+				Scope:                  "/subscriptions/123/resourceGroups/my-node-rg",
+				RoleDefinitionID:       "/subscriptions/123/providers/Microsoft.Authorization/roleDefinitions/b24988ac-6180-42a0-ab88-20f7382dd24c",
+				UserAssignedIdentityID: fakeIdentityID,
+			},
+		}
+	}
+
+	fakeRoleAssignmentID1 := "/subscriptions/123/resourceGroups/my-vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/providers/Microsoft.Authorization/roleAssignments/fake-role-assignment-1"
+	fakeRoleAssignmentID2 := "/subscriptions/123/resourceGroups/my-node-rg/providers/Microsoft.Authorization/roleAssignments/fake-role-assignment-2"
+	fakeRemovedRoleAssignmentID := "/subscriptions/123/resourceGroups/my-acr-rg/providers/Microsoft.ContainerRegistry/registries/myacr/providers/Microsoft.Authorization/roleAssignments/fake-removed-role-assignment"
+
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder, i *mock_identities.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "create role assignments for a user-assigned identity",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder,
+				i *mock_identities.MockClientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.RoleAssignmentResourceType().Return(azure.ManagedCluster)
+				s.OwnedRoleAssignments().Return(nil)
+				s.RoleAssignmentSpecs(nil).Return(newSpecs())
+				i.GetPrincipalID(gomockinternal.AContext(), fakeIdentityID).Return(fakeIdentityPrincipalID, nil)
+				r.CreateResource(gomockinternal.AContext(), gomock.Any(), serviceName).Times(2).DoAndReturn(
+					func(_ context.Context, spec azure.ResourceSpecGetter, _ string) (interface{}, error) {
+						g := NewWithT(t)
+						g.Expect(spec.(*RoleAssignmentSpec).PrincipalID).To(Equal(to.StringPtr(fakeIdentityPrincipalID)))
+						return nil, nil
+					})
+				s.SetOwnedRoleAssignments([]string{fakeRoleAssignmentID2, fakeRoleAssignmentID1})
+			},
+		},
+		{
+			name:          "delete the role assignments created by CAPZ which were removed from the spec",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder,
+				i *mock_identities.MockClientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.RoleAssignmentResourceType().Return(azure.ManagedCluster)
+				s.OwnedRoleAssignments().Return([]string{fakeRoleAssignmentID1, fakeRemovedRoleAssignmentID})
+				s.RoleAssignmentSpecs(nil).Return(newSpecs()[:1])
+				i.GetPrincipalID(gomockinternal.AContext(), fakeIdentityID).Return(fakeIdentityPrincipalID, nil)
+				r.CreateResource(gomockinternal.AContext(), gomock.Any(), serviceName).Return(nil, nil)
+				r.DeleteResource(gomockinternal.AContext(), &RoleAssignmentSpec{
+					Name:  "fake-removed-role-assignment",
+					Scope: "/subscriptions/123/resourceGroups/my-acr-rg/providers/Microsoft.ContainerRegistry/registries/myacr",
+				}, serviceName).Return(nil)
+				s.SetOwnedRoleAssignments([]string{fakeRoleAssignmentID1})
+			},
+		},
+		{
+			name:          "error deleting a role assignment removed from the spec",
+			expectedError: fmt.Sprintf("failed to delete role assignment %s: #: Internal Server Error: StatusCode=500", fakeRemovedRoleAssignmentID),
+			expect: func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder,
+				i *mock_identities.MockClientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.RoleAssignmentResourceType().Return(azure.ManagedCluster)
+				s.OwnedRoleAssignments().Return([]string{fakeRemovedRoleAssignmentID})
+				s.RoleAssignmentSpecs(nil).Return(newSpecs()[:1])
+				i.GetPrincipalID(gomockinternal.AContext(), fakeIdentityID).Return(fakeIdentityPrincipalID, nil)
+				r.CreateResource(gomockinternal.AContext(), gomock.Any(), serviceName).Return(nil, nil)
+				r.DeleteResource(gomockinternal.AContext(), gomock.Any(), serviceName).Return(
+					autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				s.SetOwnedRoleAssignments([]string{fakeRemovedRoleAssignmentID, fakeRoleAssignmentID1})
+			},
+		},
+		{
+			name:          "error getting the principal ID of a user-assigned identity",
+			expectedError: fmt.Sprintf("failed to get principal ID for user-assigned identity %s: #: Internal Server Error: StatusCode=500", fakeIdentityID),
+			expect: func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder,
+				i *mock_identities.MockClientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.RoleAssignmentResourceType().Return(azure.ManagedCluster)
+				s.OwnedRoleAssignments().Return(nil)
+				s.RoleAssignmentSpecs(nil).Return(newSpecs())
+				i.GetPrincipalID(gomockinternal.AContext(), fakeIdentityID).Return("", autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				s.SetOwnedRoleAssignments([]string{})
+			},
+		},
+		{
+			name:          "error creating a role assignment",
+			expectedError: fmt.Sprintf("cannot assign role to user-assigned identity %s: #: Internal Server Error: StatusCode=500", fakeIdentityID),
+			expect: func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder,
+				i *mock_identities.MockClientMockRecorder,
+				r *mock_async.MockReconcilerMockRecorder) {
+				s.RoleAssignmentResourceType().Return(azure.ManagedCluster)
+				s.OwnedRoleAssignments().Return(nil)
+				s.RoleAssignmentSpecs(nil).Return(newSpecs())
+				i.GetPrincipalID(gomockinternal.AContext(), fakeIdentityID).Return(fakeIdentityPrincipalID, nil)
+				r.CreateResource(gomockinternal.AContext(), gomock.Any(), serviceName).Return(nil,
+					autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				s.SetOwnedRoleAssignments([]string{})
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_roleassignments.NewMockUserAssignedIdentityRoleAssignmentScope(mockCtrl)
+			identitiesMock := mock_identities.NewMockClient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), identitiesMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:                        scopeMock,
+				Reconciler:                   asyncMock,
+				userAssignedIdentitiesClient: identitiesMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteRoleAssignments(t *testing.T) {
+	fakeRoleAssignmentID1 := "/subscriptions/123/resourceGroups/my-vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/providers/Microsoft.Authorization/roleAssignments/fake-role-assignment-1"
+	fakeRoleAssignmentID2 := "/subscriptions/123/resourceGroups/my-acr-rg/providers/Microsoft.ContainerRegistry/registries/myacr/providers/Microsoft.Authorization/roleAssignments/fake-role-assignment-2"
+
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "system-assigned identity role assignments are deleted with the VM",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.RoleAssignmentResourceType().Return(azure.VirtualMachine)
+			},
+		},
+		{
+			name:          "delete the role assignments created by CAPZ",
+			expectedError: "",
+			expect: func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.RoleAssignmentResourceType().Return(azure.ManagedCluster)
+				s.OwnedRoleAssignments().Return([]string{fakeRoleAssignmentID1, fakeRoleAssignmentID2})
+				r.DeleteResource(gomockinternal.AContext(), &RoleAssignmentSpec{
+					Name:  "fake-role-assignment-1",
+					Scope: "/subscriptions/123/resourceGroups/my-vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+				}, serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), &RoleAssignmentSpec{
+					Name:  "fake-role-assignment-2",
+					Scope: "/subscriptions/123/resourceGroups/my-acr-rg/providers/Microsoft.ContainerRegistry/registries/myacr",
+				}, serviceName).Return(nil)
+				s.SetOwnedRoleAssignments(nil)
+			},
+		},
+		{
+			name:          "error deleting a role assignment",
+			expectedError: fmt.Sprintf("failed to delete role assignment %s: #: Internal Server Error: StatusCode=500", fakeRoleAssignmentID2),
+			expect: func(s *mock_roleassignments.MockUserAssignedIdentityRoleAssignmentScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.RoleAssignmentResourceType().Return(azure.ManagedCluster)
+				s.OwnedRoleAssignments().Return([]string{fakeRoleAssignmentID1, fakeRoleAssignmentID2})
+				r.DeleteResource(gomockinternal.AContext(), gomock.Any(), serviceName).Return(nil)
+				r.DeleteResource(gomockinternal.AContext(), gomock.Any(), serviceName).Return(
+					autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error"))
+				s.SetOwnedRoleAssignments([]string{fakeRoleAssignmentID2})
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_roleassignments.NewMockUserAssignedIdentityRoleAssignmentScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package roleassignments
 
 import (
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/profiles/2019-03-01/authorization/mgmt/authorization"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
@@ -31,6 +33,29 @@ type RoleAssignmentSpec struct {
 	PrincipalID      *string
 	RoleDefinitionID string
 	Scope            string
+	// UserAssignedIdentityID is the resource ID of the user-assigned identity that is assigned the role.
+	// Its principal ID is resolved when PrincipalID is nil.
+	UserAssignedIdentityID string
+}
+
+// roleAssignmentsPath is the path of role assignments below their scope.
+const roleAssignmentsPath = "/providers/Microsoft.Authorization/roleAssignments/"
+
+// roleAssignmentSpecFromID returns the spec of a role assignment from its resource ID, to delete it.
+func roleAssignmentSpecFromID(id string) *RoleAssignmentSpec {
+	i := strings.LastIndex(strings.ToLower(id), strings.ToLower(roleAssignmentsPath))
+	if i < 0 {
+		return &RoleAssignmentSpec{Name: id}
+	}
+	return &RoleAssignmentSpec{
+		Name:  id[i+len(roleAssignmentsPath):],
+		Scope: id[:i],
+	}
+}
+
+// ResourceID returns the resource ID of the role assignment.
+func (s *RoleAssignmentSpec) ResourceID() string {
+	return s.Scope + roleAssignmentsPath + s.Name
 }
 
 // ResourceName returns the name of the role assignment.
@@ -39,6 +39,7 @@ import (
 type Client interface {
 	List(context.Context, string) ([]compute.VirtualMachineScaleSet, error)
 	ListInstances(context.Context, string, string) ([]compute.VirtualMachineScaleSetVM, error)
+	ListVMInstances(context.Context, string, string) ([]compute.VirtualMachine, error)
 	Get(context.Context, string, string) (compute.VirtualMachineScaleSet, error)
 	CreateOrUpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSet) (*infrav1.Future, error)
 	UpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error)
@@ -50,8 +51,9 @@ type Client interface {
 type (
 	// AzureClient contains the Azure go-sdk Client.
 	AzureClient struct {
-		scalesetvms compute.VirtualMachineScaleSetVMsClient
-		scalesets   compute.VirtualMachineScaleSetsClient
+		scalesetvms     compute.VirtualMachineScaleSetVMsClient
+		scalesets       compute.VirtualMachineScaleSetsClient
+		virtualmachines compute.VirtualMachinesClient
 	}
 
 	genericScaleSetFuture interface {
@@ -74,8 +76,9 @@ var _ Client = &AzureClient{}
 // NewClient creates a new VMSS client from subscription ID.
 func NewClient(auth azure.Authorizer) *AzureClient {
 	return &AzureClient{
-		scalesetvms: newVirtualMachineScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
-		scalesets:   newVirtualMachineScaleSetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		scalesetvms:     newVirtualMachineScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		scalesets:       newVirtualMachineScaleSetsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		virtualmachines: newVirtualMachinesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
 	}
 }
 
@@ -93,6 +96,13 @@ func newVirtualMachineScaleSetsClient(subscriptionID string, baseURI string, aut
 	return c
 }
 
+// newVirtualMachinesClient creates a new vm client from subscription ID.
+func newVirtualMachinesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachinesClient {
+	c := compute.NewVirtualMachinesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
 // ListInstances retrieves information about the model views of a virtual machine scale set.
 func (ac *AzureClient) ListInstances(ctx context.Context, resourceGroupName, vmssName string) ([]compute.VirtualMachineScaleSetVM, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.ListInstances")
@@ -114,6 +124,28 @@ func (ac *AzureClient) ListInstances(ctx context.Context, resourceGroupName, vms
 	return instances, nil
 }
 
+// ListVMInstances retrieves the virtual machines orchestrated by a virtual machine scale set in Flexible orchestration mode.
+func (ac *AzureClient) ListVMInstances(ctx context.Context, resourceGroupName, vmssID string) ([]compute.VirtualMachine, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.ListVMInstances")
+	defer done()
+
+	filter := fmt.Sprintf("'virtualMachineScaleSet/id' eq '%s'", vmssID)
+	itr, err := ac.virtualmachines.ListComplete(ctx, resourceGroupName, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances []compute.VirtualMachine
+	for ; itr.NotDone(); err = itr.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate virtual machines [%w]", err)
+		}
+		vm := itr.Value()
+		instances = append(instances, vm)
+	}
+	return instances, nil
+}
+
 // List returns all scale sets in a resource group.
 func (ac *AzureClient) List(ctx context.Context, resourceGroupName string) ([]compute.VirtualMachineScaleSet, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.List")
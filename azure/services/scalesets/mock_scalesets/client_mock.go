@@ -143,6 +143,21 @@ func (mr *MockClientMockRecorder) ListInstances(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstances", reflect.TypeOf((*MockClient)(nil).ListInstances), arg0, arg1, arg2)
 }
 
+// ListVMInstances mocks base method.
+func (m *MockClient) ListVMInstances(arg0 context.Context, arg1, arg2 string) ([]compute.VirtualMachine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVMInstances", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]compute.VirtualMachine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVMInstances indicates an expected call of ListVMInstances.
+func (mr *MockClientMockRecorder) ListVMInstances(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVMInstances", reflect.TypeOf((*MockClient)(nil).ListVMInstances), arg0, arg1, arg2)
+}
+
 // UpdateAsync mocks base method.
 func (m *MockClient) UpdateAsync(arg0 context.Context, arg1, arg2 string, arg3 compute.VirtualMachineScaleSetUpdate) (*v1beta1.Future, error) {
 	m.ctrl.T.Helper()
@@ -369,6 +369,18 @@ func (mr *MockScaleSetScopeMockRecorder) SetProviderID(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProviderID", reflect.TypeOf((*MockScaleSetScope)(nil).SetProviderID), arg0)
 }
 
+// SetSpotFallbackActive mocks base method.
+func (m *MockScaleSetScope) SetSpotFallbackActive(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSpotFallbackActive", arg0)
+}
+
+// SetSpotFallbackActive indicates an expected call of SetSpotFallbackActive.
+func (mr *MockScaleSetScopeMockRecorder) SetSpotFallbackActive(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSpotFallbackActive", reflect.TypeOf((*MockScaleSetScope)(nil).SetSpotFallbackActive), arg0)
+}
+
 // SetVMSSState mocks base method.
 func (m *MockScaleSetScope) SetVMSSState(arg0 *azure.VMSS) {
 	m.ctrl.T.Helper()
@@ -381,6 +393,18 @@ func (mr *MockScaleSetScopeMockRecorder) SetVMSSState(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVMSSState", reflect.TypeOf((*MockScaleSetScope)(nil).SetVMSSState), arg0)
 }
 
+// SetVMSizeFallback mocks base method.
+func (m *MockScaleSetScope) SetVMSizeFallback(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVMSizeFallback", arg0)
+}
+
+// SetVMSizeFallback indicates an expected call of SetVMSizeFallback.
+func (mr *MockScaleSetScopeMockRecorder) SetVMSizeFallback(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVMSizeFallback", reflect.TypeOf((*MockScaleSetScope)(nil).SetVMSizeFallback), arg0)
+}
+
 // SubscriptionID mocks base method.
 func (m *MockScaleSetScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -28,9 +28,11 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/generators"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/slice"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -51,22 +53,26 @@ type (
 		SetAnnotation(string, string)
 		SetProviderID(string)
 		SetVMSSState(*azure.VMSS)
+		SetSpotFallbackActive(bool)
+		SetVMSizeFallback(string)
 	}
 
 	// Service provides operations on Azure resources.
 	Service struct {
 		Scope ScaleSetScope
 		Client
-		resourceSKUCache *resourceskus.Cache
+		resourceSKUCache     *resourceskus.Cache
+		dedicatedHostsClient dedicatedhosts.Client
 	}
 )
 
 // New creates a new service.
 func New(scope ScaleSetScope, skuCache *resourceskus.Cache) *Service {
 	return &Service{
-		Client:           NewClient(scope),
-		Scope:            scope,
-		resourceSKUCache: skuCache,
+		Client:               NewClient(scope),
+		Scope:                scope,
+		resourceSKUCache:     skuCache,
+		dedicatedHostsClient: dedicatedhosts.NewClient(scope),
 	}
 }
 
@@ -115,10 +121,25 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 		}
 	}()
 
+	if future != nil && future.Type == infrav1.DeleteFuture {
+		// A Spot VMSS without allocated instances which could not be allocated, or an on-demand VMSS scaled to zero which
+		// returns to Spot VMs, is being deleted so that it can be recreated with the other priority.
+		if _, err := s.GetResultIfDone(ctx, future); err != nil {
+			return errors.Wrapf(err, "failed to delete VMSS %s before recreating it", scaleSetSpec.Name)
+		}
+		s.Scope.DeleteLongRunningOperationState(scaleSetSpec.Name, serviceName)
+		future = nil
+	}
+
 	if future == nil {
 		fetchedVMSS, err = s.getVirtualMachineScaleSet(ctx, scaleSetSpec.Name)
 	} else {
 		fetchedVMSS, err = s.getVirtualMachineScaleSetIfDone(ctx, future)
+		if err != nil {
+			if fallbackErr := s.fallback(ctx, future.Type, err); fallbackErr != nil {
+				return fallbackErr
+			}
+		}
 	}
 
 	switch {
@@ -129,14 +150,24 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 		// HTTP(404) resource was not found, so we need to create it with a PUT
 		future, err = s.createVMSS(ctx)
 		if err != nil {
+			if fallbackErr := s.fallback(ctx, infrav1.PutFuture, err); fallbackErr != nil {
+				return fallbackErr
+			}
 			return errors.Wrap(err, "failed to start creating VMSS")
 		}
 	case err == nil:
 		// HTTP(200)
+		if s.shouldReturnToSpot(fetchedVMSS) {
+			return s.returnToSpot(ctx)
+		}
+
 		// VMSS already exists and may have changes; update it with a PATCH
 		// we do this to avoid overwriting fields in networkProfile modified by cloud-provider
 		future, err = s.patchVMSSIfNeeded(ctx, fetchedVMSS)
 		if err != nil {
+			if fallbackErr := s.fallback(ctx, infrav1.PatchFuture, err); fallbackErr != nil {
+				return fallbackErr
+			}
 			return errors.Wrap(err, "failed to start updating VMSS")
 		}
 	}
@@ -146,6 +177,9 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 	if future != nil {
 		fetchedVMSS, err = s.getVirtualMachineScaleSetIfDone(ctx, future)
 		if err != nil {
+			if fallbackErr := s.fallback(ctx, future.Type, err); fallbackErr != nil {
+				return fallbackErr
+			}
 			return errors.Wrapf(err, "failed to get VMSS %s after create or update", scaleSetSpec.Name)
 		}
 	}
@@ -231,6 +265,12 @@ func (s *Service) createVMSS(ctx context.Context) (*infrav1.Future, error) {
 
 	spec := s.Scope.ScaleSetSpec()
 
+	if spec.DedicatedHost != nil {
+		if err := dedicatedhosts.ValidatePlacement(ctx, s.dedicatedHostsClient, spec.DedicatedHost, spec.Size, spec.FailureDomains); err != nil {
+			return nil, err
+		}
+	}
+
 	vmss, err := s.buildVMSSFromSpec(ctx, spec)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed building VMSS from spec")
@@ -246,6 +286,158 @@ func (s *Service) createVMSS(ctx context.Context) (*infrav1.Future, error) {
 	return future, err
 }
 
+// fallback falls back to the next available fallback VM size, or else to on-demand VMs, when Azure could not allocate
+// the capacity requested by a PUT or PATCH of the scale set. It returns nil when there is nothing to fall back to.
+func (s *Service) fallback(ctx context.Context, futureType string, cause error) error {
+	if !azure.IsCapacityError(cause) || (futureType != infrav1.PutFuture && futureType != infrav1.PatchFuture) {
+		return nil
+	}
+
+	size, err := s.nextVMSize(ctx)
+	if err != nil {
+		return err
+	}
+	if size != "" {
+		return s.fallbackToVMSize(ctx, size, cause)
+	}
+
+	if s.shouldFallbackToOnDemand(cause) {
+		return s.fallbackToOnDemand(ctx, cause)
+	}
+
+	return nil
+}
+
+// nextVMSize returns the first fallback VM size of the scale set which is available in its failure domains, or an empty
+// string if there is none.
+func (s *Service) nextVMSize(ctx context.Context) (string, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.nextVMSize")
+	defer done()
+
+	spec := s.Scope.ScaleSetSpec()
+	location := s.Scope.Location()
+	for _, size := range spec.FallbackSizes {
+		if _, err := s.resourceSKUCache.Get(ctx, size, resourceskus.VirtualMachines); err != nil {
+			log.V(2).Info("skipping fallback VM size which is not available", "size", size, "location", location)
+			continue
+		}
+
+		zones, err := s.resourceSKUCache.GetZonesWithVMSize(ctx, size, location)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get zones for VM type %s in location %s", size, location)
+		}
+		if !containsAll(zones, spec.FailureDomains) {
+			log.V(2).Info("skipping fallback VM size which is not available in all failure domains", "size", size, "failureDomains", spec.FailureDomains)
+			continue
+		}
+
+		return size, nil
+	}
+
+	return "", nil
+}
+
+// fallbackToVMSize records that the scale set must be created or updated with a fallback VM size because Azure could
+// not allocate enough capacity of its current VM size.
+func (s *Service) fallbackToVMSize(ctx context.Context, size string, cause error) error {
+	_, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.fallbackToVMSize")
+	defer done()
+
+	spec := s.Scope.ScaleSetSpec()
+	log.Info("VM size capacity is not available, falling back to the next VM size", "scale set", spec.Name, "size", spec.Size, "fallback size", size, "reason", cause.Error())
+
+	s.Scope.DeleteLongRunningOperationState(spec.Name, serviceName)
+	s.Scope.SetVMSizeFallback(size)
+
+	return azure.WithTransientError(errors.Wrapf(cause, "capacity is not available for VM size %s, falling back to VM size %s", spec.Size, size), reconciler.DefaultReconcilerRequeue)
+}
+
+// containsAll returns true if all the values are in the list.
+func containsAll(list []string, values []string) bool {
+	for _, value := range values {
+		if !slice.Contains(list, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// shouldFallbackToOnDemand returns true if the creation of a Spot VMSS failed because Azure could not allocate Spot capacity
+// and the scale set is allowed to fall back to on-demand VMs.
+func (s *Service) shouldFallbackToOnDemand(err error) bool {
+	spec := s.Scope.ScaleSetSpec()
+	return spec.SpotVMOptions != nil && spec.SpotFallbackToOnDemand && azure.IsCapacityError(err)
+}
+
+// fallbackToOnDemand starts deleting a Spot VMSS which could not be allocated and records that the scale set must be
+// recreated with on-demand VMs. A VMSS with allocated instances is not deleted, since their nodes would not be drained,
+// and the capacity error is reported as usual.
+func (s *Service) fallbackToOnDemand(ctx context.Context, cause error) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.fallbackToOnDemand")
+	defer done()
+
+	spec := s.Scope.ScaleSetSpec()
+	vmss, err := s.getVirtualMachineScaleSet(ctx, spec.Name)
+	if err != nil && !azure.ResourceNotFound(err) {
+		return errors.Wrapf(err, "failed to get VMSS %s before falling back to on-demand VMs", spec.Name)
+	}
+	if vmss != nil && hasAllocatedInstances(vmss) {
+		log.Info("Spot capacity is not available, not falling back to on-demand VMs since the scale set has allocated instances", "scale set", spec.Name, "reason", cause.Error())
+		return nil
+	}
+
+	log.Info("Spot capacity is not available, falling back to on-demand VMs", "scale set", spec.Name, "reason", cause.Error())
+
+	s.Scope.DeleteLongRunningOperationState(spec.Name, serviceName)
+	future, err := s.Client.DeleteAsync(ctx, s.Scope.ResourceGroup(), spec.Name)
+	if err != nil && !azure.ResourceNotFound(err) {
+		return errors.Wrapf(err, "failed to delete VMSS %s before falling back to on-demand VMs", spec.Name)
+	}
+	if future != nil {
+		s.Scope.SetLongRunningOperationState(future)
+	}
+	s.Scope.SetSpotFallbackActive(true)
+
+	return azure.WithTransientError(errors.Wrap(cause, "Spot capacity is not available, falling back to on-demand VMs"), reconciler.DefaultReconcilerRequeue)
+}
+
+// hasAllocatedInstances returns true if some instances of the VMSS were successfully allocated.
+func hasAllocatedInstances(vmss *azure.VMSS) bool {
+	for _, instance := range vmss.Instances {
+		if instance.State == infrav1.Succeeded {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldReturnToSpot returns true if the VMSS fell back to on-demand VMs and is scaled to zero, so that it can be
+// recreated with Spot VMs without disrupting any node.
+func (s *Service) shouldReturnToSpot(vmss *azure.VMSS) bool {
+	spec := s.Scope.ScaleSetSpec()
+	return spec.SpotFallbackActive && spec.Capacity == 0 && len(vmss.Instances) == 0
+}
+
+// returnToSpot starts deleting an empty on-demand VMSS and records that the scale set must be recreated with Spot VMs.
+func (s *Service) returnToSpot(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.returnToSpot")
+	defer done()
+
+	spec := s.Scope.ScaleSetSpec()
+	log.Info("On-demand scale set is scaled to zero, returning to Spot VMs", "scale set", spec.Name)
+
+	future, err := s.Client.DeleteAsync(ctx, s.Scope.ResourceGroup(), spec.Name)
+	if err != nil && !azure.ResourceNotFound(err) {
+		return errors.Wrapf(err, "failed to delete VMSS %s before returning to Spot VMs", spec.Name)
+	}
+	if future != nil {
+		s.Scope.SetLongRunningOperationState(future)
+	}
+	s.Scope.SetSpotFallbackActive(false)
+
+	return azure.WithTransientError(errors.Errorf("returning VMSS %s to Spot VMs", spec.Name), reconciler.DefaultReconcilerRequeue)
+}
+
 func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS) (*infrav1.Future, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.patchVMSSIfNeeded")
 	defer done()
@@ -262,6 +454,13 @@ func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS)
 		return nil, errors.Wrapf(err, "failed to generate vmss patch for %s", spec.Name)
 	}
 
+	// A patch without an automatic repairs policy leaves the existing one in place, so it has to be disabled explicitly.
+	if patch.AutomaticRepairsPolicy == nil && infraVMSS.AutomaticRepairsGracePeriod != "" {
+		patch.AutomaticRepairsPolicy = &compute.AutomaticRepairsPolicy{
+			Enabled: to.BoolPtr(false),
+		}
+	}
+
 	maxSurge, err := s.Scope.MaxSurge()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to calculate maxSurge")
@@ -451,14 +650,10 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 			},
 			Overprovision: to.BoolPtr(false),
 			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
-				OsProfile:       osProfile,
-				StorageProfile:  storageProfile,
-				SecurityProfile: securityProfile,
-				DiagnosticsProfile: &compute.DiagnosticsProfile{
-					BootDiagnostics: &compute.BootDiagnostics{
-						Enabled: to.BoolPtr(true),
-					},
-				},
+				OsProfile:          osProfile,
+				StorageProfile:     storageProfile,
+				SecurityProfile:    securityProfile,
+				DiagnosticsProfile: converters.GetDiagnosticsProfile(vmssSpec.Diagnostics),
 				NetworkProfile: &compute.VirtualMachineScaleSetNetworkProfile{
 					NetworkInterfaceConfigurations: &[]compute.VirtualMachineScaleSetNetworkConfiguration{
 						{
@@ -494,6 +689,34 @@ func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSet
 		},
 	}
 
+	if vmssSpec.OrchestrationMode == infrav1.FlexibleOrchestrationMode {
+		// Flexible scale sets create individual VMs with their own NICs, spread across as many fault domains as possible,
+		// and do not support an upgrade policy or overprovisioning.
+		vmss.VirtualMachineScaleSetProperties.OrchestrationMode = compute.OrchestrationModeFlexible
+		vmss.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = to.Int32Ptr(1)
+		vmss.VirtualMachineScaleSetProperties.UpgradePolicy = nil
+		vmss.VirtualMachineScaleSetProperties.Overprovision = nil
+		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkAPIVersion = compute.NetworkAPIVersionTwoZeroTwoZeroHyphenMinusOneOneHyphenMinusZeroOne
+	}
+
+	if vmssSpec.AutomaticRepairsGracePeriod != nil {
+		vmss.VirtualMachineScaleSetProperties.AutomaticRepairsPolicy = &compute.AutomaticRepairsPolicy{
+			Enabled:     to.BoolPtr(true),
+			GracePeriod: to.StringPtr(fmt.Sprintf("PT%dM", int(vmssSpec.AutomaticRepairsGracePeriod.Minutes()))),
+		}
+	}
+
+	// Azure rejects zoneBalance for a scale set which does not span more than one zone
+	if vmssSpec.ZoneBalance != nil && len(vmssSpec.FailureDomains) > 1 {
+		vmss.VirtualMachineScaleSetProperties.ZoneBalance = vmssSpec.ZoneBalance
+	}
+
+	if vmssSpec.DedicatedHost != nil {
+		vmss.VirtualMachineScaleSetProperties.HostGroup = &compute.SubResource{
+			ID: to.StringPtr(vmssSpec.DedicatedHost.HostGroupID),
+		}
+	}
+
 	// Assign Identity to VMSS
 	if vmssSpec.Identity == infrav1.VMIdentitySystemAssigned {
 		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
@@ -559,12 +782,7 @@ func (s *Service) getVirtualMachineScaleSet(ctx context.Context, vmssName string
 		return nil, errors.Wrap(err, "failed to get existing vmss")
 	}
 
-	vmssInstances, err := s.Client.ListInstances(ctx, s.Scope.ResourceGroup(), vmssName)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to list instances")
-	}
-
-	return converters.SDKToVMSS(vmss, vmssInstances), nil
+	return s.withInstances(ctx, vmss, s.Scope.ResourceGroup(), vmssName)
 }
 
 // getVirtualMachineScaleSetIfDone gets a Virtual Machine Scale Set and its instances from Azure if the future is completed.
@@ -577,7 +795,22 @@ func (s *Service) getVirtualMachineScaleSetIfDone(ctx context.Context, future *i
 		return nil, errors.Wrap(err, "failed to get result from future")
 	}
 
-	vmssInstances, err := s.Client.ListInstances(ctx, future.ResourceGroup, future.Name)
+	return s.withInstances(ctx, vmss, future.ResourceGroup, future.Name)
+}
+
+// withInstances lists the instances of a Virtual Machine Scale Set and converts both to an azure.VMSS. The instances of
+// a scale set in Flexible orchestration mode are regular virtual machines and are listed through the virtual machines API.
+func (s *Service) withInstances(ctx context.Context, vmss compute.VirtualMachineScaleSet, resourceGroup, vmssName string) (*azure.VMSS, error) {
+	if vmss.VirtualMachineScaleSetProperties != nil && vmss.OrchestrationMode == compute.OrchestrationModeFlexible {
+		vms, err := s.Client.ListVMInstances(ctx, resourceGroup, to.String(vmss.ID))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list instances")
+		}
+
+		return converters.SDKToFlexVMSS(vmss, vms), nil
+	}
+
+	vmssInstances, err := s.Client.ListInstances(ctx, resourceGroup, vmssName)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list instances")
 	}
@@ -20,9 +20,11 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -31,6 +33,7 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts/mock_dedicatedhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets/mock_scalesets"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -41,6 +44,7 @@ const (
 	defaultSubscriptionID = "123"
 	defaultResourceGroup  = "my-rg"
 	defaultVMSSName       = "my-vmss"
+	defaultHostGroupID    = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
 )
 
 func init() {
@@ -117,6 +121,53 @@ func TestGetExistingVMSS(t *testing.T) {
 				}, nil)
 			},
 		},
+		{
+			name:     "get existing vmss in flexible orchestration mode",
+			vmssName: "my-vmss",
+			result: &azure.VMSS{
+				ID:       "my-id",
+				Name:     "my-vmss",
+				State:    "Succeeded",
+				Sku:      "Standard_D2",
+				Capacity: int64(1),
+				Instances: []azure.VMSSVM{
+					{
+						ID:         "my-vm-id",
+						InstanceID: "my-vmss_1a2b3c4d",
+						Name:       "my-vmss1a2b3c",
+						State:      "Succeeded",
+					},
+				},
+			},
+			expectedError: "",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+				s.ResourceGroup().AnyTimes().Return("my-rg")
+				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{
+					ID:   to.StringPtr("my-id"),
+					Name: to.StringPtr("my-vmss"),
+					Sku: &compute.Sku{
+						Capacity: to.Int64Ptr(1),
+						Name:     to.StringPtr("Standard_D2"),
+					},
+					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+						OrchestrationMode: compute.OrchestrationModeFlexible,
+						ProvisioningState: to.StringPtr("Succeeded"),
+					},
+				}, nil)
+				m.ListVMInstances(gomockinternal.AContext(), "my-rg", "my-id").Return([]compute.VirtualMachine{
+					{
+						ID:   to.StringPtr("my-vm-id"),
+						Name: to.StringPtr("my-vmss_1a2b3c4d"),
+						VirtualMachineProperties: &compute.VirtualMachineProperties{
+							ProvisioningState: to.StringPtr("Succeeded"),
+							OsProfile: &compute.OSProfile{
+								ComputerName: to.StringPtr("my-vmss1a2b3c"),
+							},
+						},
+					},
+				}, nil)
+			},
+		},
 		{
 			name:          "list instances fails",
 			vmssName:      "my-vmss",
@@ -181,17 +232,23 @@ func TestReconcileVMSS(t *testing.T) {
 			ResourceGroup: defaultResourceGroup,
 			Name:          defaultVMSSName,
 		}
+
+		deleteFuture = &infrav1.Future{
+			Type:          infrav1.DeleteFuture,
+			ResourceGroup: defaultResourceGroup,
+			Name:          defaultVMSSName,
+		}
 	)
 
 	testcases := []struct {
 		name          string
-		expect        func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+		expect        func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder)
 		expectedError string
 	}{
 		{
 			name:          "should start creating a vmss",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				defaultSpec := newDefaultVMSSSpec()
 				defaultSpec.DataDisks = append(defaultSpec.DataDisks, infrav1.DataDisk{
 					NameSuffix: "my_disk_with_ultra_disks",
@@ -213,7 +270,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "should finish creating a vmss when long running operation is done",
 			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				defaultSpec := newDefaultVMSSSpec()
 				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
 				createdVMSS := newDefaultVMSS("VM_SIZE")
@@ -227,7 +284,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "Windows VMSS should not get patched",
 			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				defaultSpec := newWindowsVMSSSpec()
 				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
 				createdVMSS := newDefaultWindowsVMSS()
@@ -241,7 +298,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "should start creating vmss with defaulted accelerated networking when size allows",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Size = "VM_SIZE_AN"
 				s.ScaleSetSpec().Return(spec).AnyTimes()
@@ -258,7 +315,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "should start creating a vmss with spot vm",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
 				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
@@ -283,7 +340,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "should start creating a vmss with spot vm and delete evictionPolicy",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Size = "VM_SIZE_EPH"
 				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
@@ -303,10 +360,201 @@ func TestReconcileVMSS(t *testing.T) {
 				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EPH"), putFuture)
 			},
 		},
+		{
+			name:          "should fall back to on-demand VMs when spot capacity is not available on create",
+			expectedError: "Spot capacity is not available, falling back to on-demand VMs: cannot create VMSS: Code=\"SkuNotAvailable\" Message=\"The requested size is currently not available.\". Object will be requeued after 15s",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.SpotFallbackToOnDemand = true
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypesDeallocate
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(nil, &azureautorest.ServiceError{Code: "SkuNotAvailable", Message: "The requested size is currently not available."})
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName)
+				m.DeleteAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				s.SetSpotFallbackActive(true)
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")).Times(2)
+			},
+		},
+		{
+			name:          "should fall back to on-demand VMs when a spot vmss fails to allocate capacity",
+			expectedError: "Spot capacity is not available, falling back to on-demand VMs: failed to get result from future: Code=\"AllocationFailed\" Message=\"Allocation failed.\". Object will be requeued after 15s",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.SpotFallbackToOnDemand = true
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName).Return(putFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), putFuture).
+					Return(compute.VirtualMachineScaleSet{}, &azureautorest.ServiceError{Code: "AllocationFailed", Message: "Allocation failed."})
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName)
+				m.DeleteAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(deleteFuture, nil)
+				s.SetLongRunningOperationState(deleteFuture)
+				s.SetSpotFallbackActive(true)
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")).Times(2)
+			},
+		},
+		{
+			name:          "should fall back to on-demand VMs when an empty spot vmss fails to allocate capacity to scale out",
+			expectedError: "Spot capacity is not available, falling back to on-demand VMs: failed to get result from future: Code=\"AllocationFailed\" Message=\"Allocation failed.\". Object will be requeued after 15s",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.SpotFallbackToOnDemand = true
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName).Return(patchFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).
+					Return(compute.VirtualMachineScaleSet{}, &azureautorest.ServiceError{Code: "AllocationFailed", Message: "Allocation failed."})
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultExistingVMSS("VM_SIZE"), nil).Times(2)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(nil, nil).Times(2)
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName)
+				m.DeleteAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(deleteFuture, nil)
+				s.SetLongRunningOperationState(deleteFuture)
+				s.SetSpotFallbackActive(true)
+				s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetVMSSState(gomock.Any())
+			},
+		},
+		{
+			name:          "should not fall back to on-demand VMs when a spot vmss with allocated instances fails to allocate capacity",
+			expectedError: "failed to get VMSS my-vmss: failed to get result from future: Code=\"AllocationFailed\" Message=\"Allocation failed.\"",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.SpotFallbackToOnDemand = true
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName).Return(patchFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).
+					Return(compute.VirtualMachineScaleSet{}, &azureautorest.ServiceError{Code: "AllocationFailed", Message: "Allocation failed."})
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultExistingVMSS("VM_SIZE"), nil).Times(2)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil).Times(2)
+				s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetVMSSState(gomock.Any())
+			},
+		},
+		{
+			name:          "should return to spot VMs when an on-demand vmss which fell back is scaled to zero",
+			expectedError: "returning VMSS my-vmss to Spot VMs. Object will be requeued after 15s",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.SpotFallbackToOnDemand = true
+				spec.SpotFallbackActive = true
+				spec.Capacity = 0
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName).Return(nil)
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(nil, nil)
+				m.DeleteAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(deleteFuture, nil)
+				s.SetLongRunningOperationState(deleteFuture)
+				s.SetSpotFallbackActive(false)
+				s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetVMSSState(gomock.Any())
+			},
+		},
+		{
+			name:          "should fall back to the next available VM size when capacity is not available on create",
+			expectedError: "capacity is not available for VM size VM_SIZE, falling back to VM size VM_SIZE_EPH: cannot create VMSS: Code=\"SkuNotAvailable\" Message=\"The requested size is currently not available.\". Object will be requeued after 15s",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.FallbackSizes = []string{"VM_SIZE_UNKNOWN", "VM_SIZE_EPH"}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomock.Any()).
+					Return(nil, &azureautorest.ServiceError{Code: "SkuNotAvailable", Message: "The requested size is currently not available."})
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName)
+				s.SetVMSizeFallback("VM_SIZE_EPH")
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "should fail when capacity is not available on create and no fallback VM size is available",
+			expectedError: "failed to start creating VMSS: cannot create VMSS: Code=\"SkuNotAvailable\" Message=\"The requested size is currently not available.\"",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.FallbackSizes = []string{"VM_SIZE_UNKNOWN"}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomock.Any()).
+					Return(nil, &azureautorest.ServiceError{Code: "SkuNotAvailable", Message: "The requested size is currently not available."})
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "should fall back to the next VM size when a vmss fails to allocate capacity to scale out",
+			expectedError: "capacity is not available for VM size VM_SIZE, falling back to VM size VM_SIZE_EPH: failed to get result from future: Code=\"AllocationFailed\" Message=\"Allocation failed.\". Object will be requeued after 15s",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.FallbackSizes = []string{"VM_SIZE_EPH"}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName).Return(patchFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).
+					Return(compute.VirtualMachineScaleSet{}, &azureautorest.ServiceError{Code: "AllocationFailed", Message: "Allocation failed."})
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName)
+				s.SetVMSizeFallback("VM_SIZE_EPH")
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+			},
+		},
+		{
+			name:          "should start creating an on-demand vmss once the spot vmss which failed to allocate capacity is deleted",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.SpotFallbackToOnDemand = true
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSExpectations(s)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName).Return(deleteFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), deleteFuture).Return(compute.VirtualMachineScaleSet{}, nil)
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName)
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found"))
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			},
+		},
 		{
 			name:          "should start creating a vmss with spot vm and a maximum price",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				maxPrice := resource.MustParse("0.001")
 				spec.SpotVMOptions = &infrav1.SpotVMOptions{
@@ -337,7 +585,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "should start creating a vmss with encryption",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.OSDisk.ManagedDisk.DiskEncryptionSet = &infrav1.DiskEncryptionSetParameters{
 					ID: "my-diskencryptionset-id",
@@ -369,7 +617,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "can start creating a vmss with user assigned identity",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
 					NameSuffix: "my_disk_with_ultra_disks",
@@ -403,7 +651,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "should start creating a vmss with encryption at host enabled",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Size = "VM_SIZE_EAH"
 				spec.SecurityProfile = &infrav1.SecurityProfile{EncryptionAtHost: to.BoolPtr(true)}
@@ -422,7 +670,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "creating a vmss with encryption at host enabled for unsupported VM type fails",
 			expectedError: "reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type VM_SIZE. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:            defaultVMSSName,
 					Size:            "VM_SIZE",
@@ -435,7 +683,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "should start creating a vmss with ephemeral osdisk",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				defaultSpec := newDefaultVMSSSpec()
 				defaultSpec.Size = "VM_SIZE_EPH"
 				defaultSpec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
@@ -456,12 +704,190 @@ func TestReconcileVMSS(t *testing.T) {
 				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EPH"), putFuture)
 			},
 		},
+		{
+			name:          "should start creating a vmss in a dedicated host group",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.DedicatedHost = &infrav1.DedicatedHost{HostGroupID: defaultHostGroupID}
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				dh.GetHostGroup(gomockinternal.AContext(), defaultResourceGroup, "my-host-group").Return(compute.DedicatedHostGroup{
+					DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+						SupportAutomaticPlacement: to.BoolPtr(true),
+					},
+				}, nil)
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.HostGroup = &compute.SubResource{ID: to.StringPtr(defaultHostGroupID)}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			},
+		},
+		{
+			name:          "should start creating a vmss with automatic repairs",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				gracePeriod := 30 * time.Minute
+				spec.AutomaticRepairsGracePeriod = &gracePeriod
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.AutomaticRepairsPolicy = &compute.AutomaticRepairsPolicy{
+					Enabled:     to.BoolPtr(true),
+					GracePeriod: to.StringPtr("PT30M"),
+				}
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			},
+		},
+		{
+			name:          "should start creating a zone balanced vmss",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.ZoneBalance = to.BoolPtr(true)
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.ZoneBalance = to.BoolPtr(true)
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			},
+		},
+		{
+			name:          "should start creating a vmss in flexible orchestration mode",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.OrchestrationMode = infrav1.FlexibleOrchestrationMode
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				setupDefaultVMSSStartCreatingExpectations(s, m)
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.OrchestrationMode = compute.OrchestrationModeFlexible
+				vmss.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = to.Int32Ptr(1)
+				vmss.VirtualMachineScaleSetProperties.UpgradePolicy = nil
+				vmss.VirtualMachineScaleSetProperties.Overprovision = nil
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkAPIVersion = compute.NetworkAPIVersionTwoZeroTwoZeroHyphenMinusOneOneHyphenMinusZeroOne
+				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
+					Return(putFuture, nil)
+				existing := newDefaultExistingVMSS("VM_SIZE")
+				existing.VirtualMachineScaleSetProperties.OrchestrationMode = compute.OrchestrationModeFlexible
+				s.SetLongRunningOperationState(putFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), putFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(putFuture))
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existing, nil)
+				m.ListVMInstances(gomockinternal.AContext(), defaultResourceGroup, *existing.ID).Return([]compute.VirtualMachine{}, nil)
+				s.SetVMSSState(gomock.Any())
+				s.SetProviderID(azure.ProviderIDPrefix + *existing.ID)
+			},
+		},
+		{
+			name:          "creating a vmss in a dedicated host group without automatic placement fails",
+			expectedError: "failed to start creating VMSS: reconcile error that cannot be recovered occurred: dedicated host group my-host-group does not support automatic placement, a host ID must be set. Object will not be requeued",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.DedicatedHost = &infrav1.DedicatedHost{HostGroupID: defaultHostGroupID}
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName).Return(nil)
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
+					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 404}, "Not found")).Times(2)
+				dh.GetHostGroup(gomockinternal.AContext(), defaultResourceGroup, "my-host-group").Return(compute.DedicatedHostGroup{
+					DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+						SupportAutomaticPlacement: to.BoolPtr(false),
+					},
+				}, nil)
+			},
+		},
 		{
 			name:          "should start updating when scale set already exists and not currently in a long running operation",
 			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Capacity = 2
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+
+				setupDefaultVMSSUpdateExpectations(s)
+				existingVMSS := newDefaultExistingVMSS("VM_SIZE")
+				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				existingVMSS.Sku.Capacity = to.Int64Ptr(2)
+				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				instances := newDefaultInstances()
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+
+				clone := newDefaultExistingVMSS("VM_SIZE")
+				clone.Sku.Capacity = to.Int64Ptr(3)
+				clone.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+
+				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
+				g.Expect(err).NotTo(HaveOccurred())
+				patchVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				patchVMSS.VirtualMachineProfile.NetworkProfile = nil
+				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
+					Return(patchFuture, nil)
+				s.SetLongRunningOperationState(patchFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(patchFuture))
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(clone, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+			},
+		},
+		{
+			name:          "should start updating when automatic repairs are enabled on an existing scale set",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				spec.Capacity = 2
+				gracePeriod := 30 * time.Minute
+				spec.AutomaticRepairsGracePeriod = &gracePeriod
 				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
 					NameSuffix: "my_disk_with_ultra_disks",
 					DiskSizeGB: 128,
@@ -474,10 +900,65 @@ func TestReconcileVMSS(t *testing.T) {
 
 				setupDefaultVMSSUpdateExpectations(s)
 				existingVMSS := newDefaultExistingVMSS("VM_SIZE")
+				existingVMSS.Sku.Capacity = to.Int64Ptr(2)
 				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				existingVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				instances := newDefaultInstances()
+				for _, instance := range instances {
+					instance.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				}
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+
+				clone := newDefaultExistingVMSS("VM_SIZE")
+				clone.Sku.Capacity = to.Int64Ptr(3)
+				clone.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				clone.VirtualMachineScaleSetProperties.AutomaticRepairsPolicy = &compute.AutomaticRepairsPolicy{
+					Enabled:     to.BoolPtr(true),
+					GracePeriod: to.StringPtr("PT30M"),
+				}
+
+				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
+				g.Expect(err).NotTo(HaveOccurred())
+				patchVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				patchVMSS.VirtualMachineProfile.NetworkProfile = nil
+				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
+					Return(patchFuture, nil)
+				s.SetLongRunningOperationState(patchFuture)
+				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(patchFuture))
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(clone, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+			},
+		},
+		{
+			name:          "should disable automatic repairs when they are removed from the spec of an existing scale set",
+			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Capacity = 2
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+
+				setupDefaultVMSSUpdateExpectations(s)
+				existingVMSS := newDefaultExistingVMSS("VM_SIZE")
 				existingVMSS.Sku.Capacity = to.Int64Ptr(2)
 				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				existingVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				existingVMSS.VirtualMachineScaleSetProperties.AutomaticRepairsPolicy = &compute.AutomaticRepairsPolicy{
+					Enabled:     to.BoolPtr(true),
+					GracePeriod: to.StringPtr("PT30M"),
+				}
 				instances := newDefaultInstances()
+				for _, instance := range instances {
+					instance.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				}
 				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
 				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
 
@@ -489,6 +970,9 @@ func TestReconcileVMSS(t *testing.T) {
 				g.Expect(err).NotTo(HaveOccurred())
 				patchVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
 				patchVMSS.VirtualMachineProfile.NetworkProfile = nil
+				patchVMSS.AutomaticRepairsPolicy = &compute.AutomaticRepairsPolicy{
+					Enabled: to.BoolPtr(false),
+				}
 				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
 					Return(patchFuture, nil)
 				s.SetLongRunningOperationState(patchFuture)
@@ -497,10 +981,42 @@ func TestReconcileVMSS(t *testing.T) {
 				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
 			},
 		},
+		{
+			name:          "should not surge when the only instance without the latest model is protected from scale set actions",
+			expectedError: "",
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
+				spec := newDefaultVMSSSpec()
+				spec.Capacity = 2
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        to.Int32Ptr(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				s.ScaleSetSpec().Return(spec).AnyTimes()
+
+				setupDefaultVMSSUpdateExpectations(s)
+				existingVMSS := newDefaultExistingVMSS("VM_SIZE")
+				existingVMSS.Sku.Capacity = to.Int64Ptr(2)
+				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				existingVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				instances := newDefaultInstances()
+				instances[0].StorageProfile.ImageReference.Version = to.StringPtr("2.0")
+				instances[1].ProtectionPolicy = &compute.VirtualMachineScaleSetVMProtectionPolicy{
+					ProtectFromScaleSetActions: to.BoolPtr(true),
+				}
+				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+				s.DeleteLongRunningOperationState(defaultVMSSName, serviceName)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+			},
+		},
 		{
 			name:          "less than 2 vCPUs",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size should be bigger or equal to at least 2 vCPUs. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_1_CPU",
@@ -512,7 +1028,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "Memory is less than 2Gi",
 			expectedError: "reconcile error that cannot be recovered occurred: vm memory should be bigger or equal to at least 2Gi. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_1_MEM",
@@ -524,7 +1040,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "failed to get SKU",
 			expectedError: "failed to get SKU INVALID_VM_SIZE in compute api: reconcile error that cannot be recovered occurred: resource sku with name 'INVALID_VM_SIZE' and category 'virtualMachines' not found in location 'test-location'. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "INVALID_VM_SIZE",
@@ -536,7 +1052,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fails with internal error",
 			expectedError: "failed to start creating VMSS: cannot create VMSS: #: Internal error: StatusCode=500",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				spec := newDefaultVMSSSpec()
 				s.ScaleSetSpec().Return(spec).AnyTimes()
 				setupDefaultVMSSStartCreatingExpectations(s, m)
@@ -549,7 +1065,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with ultra disk implicitly enabled by data disk, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -569,7 +1085,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -585,7 +1101,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, dh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -617,13 +1133,15 @@ func TestReconcileVMSS(t *testing.T) {
 
 			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+			dedicatedHostsMock := mock_dedicatedhosts.NewMockClient(mockCtrl)
 
-			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT())
+			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT(), dedicatedHostsMock.EXPECT())
 
 			s := &Service{
-				Scope:            scopeMock,
-				Client:           clientMock,
-				resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
+				Scope:                scopeMock,
+				Client:               clientMock,
+				resourceSKUCache:     resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
+				dedicatedHostsClient: dedicatedHostsMock,
 			}
 
 			err := s.Reconcile(context.TODO())
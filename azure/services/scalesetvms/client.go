@@ -24,6 +24,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -37,12 +38,16 @@ type client interface {
 	Get(context.Context, string, string, string) (compute.VirtualMachineScaleSetVM, error)
 	GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachineScaleSetVM, error)
 	DeleteAsync(context.Context, string, string, string) (*infrav1.Future, error)
+	UpdateProtectionPolicyAsync(context.Context, string, string, string, azure.VMSSVMProtectionPolicy) (*infrav1.Future, error)
+	GetVM(context.Context, string, string) (compute.VirtualMachine, error)
+	DeleteVMAsync(context.Context, string, string) (*infrav1.Future, error)
 }
 
 type (
 	// azureClient contains the Azure go-sdk Client.
 	azureClient struct {
-		scalesetvms compute.VirtualMachineScaleSetVMsClient
+		scalesetvms     compute.VirtualMachineScaleSetVMsClient
+		virtualmachines compute.VirtualMachinesClient
 	}
 
 	genericScaleSetVMFuture interface {
@@ -50,6 +55,11 @@ type (
 		Result(client compute.VirtualMachineScaleSetVMsClient) (vmss compute.VirtualMachineScaleSetVM, err error)
 	}
 
+	genericScaleSetVMFutureImpl struct {
+		azureautorest.FutureAPI
+		result func(client compute.VirtualMachineScaleSetVMsClient) (vm compute.VirtualMachineScaleSetVM, err error)
+	}
+
 	deleteFutureAdapter struct {
 		compute.VirtualMachineScaleSetVMsDeleteFuture
 	}
@@ -59,8 +69,10 @@ var _ client = &azureClient{}
 
 // newClient creates a new VMSS client from subscription ID.
 func newClient(auth azure.Authorizer) *azureClient {
+	subscriptionID, baseURI, authorizer := auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()
 	return &azureClient{
-		scalesetvms: newVirtualMachineScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		scalesetvms:     newVirtualMachineScaleSetVMsClient(subscriptionID, baseURI, authorizer),
+		virtualmachines: newVirtualMachinesClient(subscriptionID, baseURI, authorizer),
 	}
 }
 
@@ -73,12 +85,29 @@ func newVirtualMachineScaleSetVMsClient(subscriptionID string, baseURI string, a
 	return c
 }
 
+// newVirtualMachinesClient creates a new VM client from subscription ID.
+func newVirtualMachinesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.VirtualMachinesClient {
+	c := compute.NewVirtualMachinesClientWithBaseURI(baseURI, subscriptionID)
+	c.Authorizer = authorizer
+	c.RetryAttempts = 1
+	_ = c.AddToUserAgent(azure.UserAgent()) // intentionally ignore error as it doesn't matter
+	return c
+}
+
 // Get retrieves the Virtual Machine Scale Set Virtual Machine.
 func (ac *azureClient) Get(ctx context.Context, resourceGroupName, vmssName, instanceID string) (compute.VirtualMachineScaleSetVM, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.Get")
 	defer done()
 
-	return ac.scalesetvms.Get(ctx, resourceGroupName, vmssName, instanceID, "")
+	return ac.scalesetvms.Get(ctx, resourceGroupName, vmssName, instanceID, compute.InstanceViewTypesInstanceView)
+}
+
+// GetVM retrieves a Virtual Machine orchestrated by a Virtual Machine Scale Set in Flexible orchestration mode.
+func (ac *azureClient) GetVM(ctx context.Context, resourceGroupName, vmName string) (compute.VirtualMachine, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.GetVM")
+	defer done()
+
+	return ac.virtualmachines.Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypesInstanceView)
 }
 
 // GetResultIfDone fetches the result of a long-running operation future if it is done.
@@ -93,7 +122,19 @@ func (ac *azureClient) GetResultIfDone(ctx context.Context, future *infrav1.Futu
 	}
 
 	switch future.Type {
+	case infrav1.PutFuture:
+		var future compute.VirtualMachineScaleSetVMsUpdateFuture
+		if err := json.Unmarshal(futureData, &future); err != nil {
+			return compute.VirtualMachineScaleSetVM{}, errors.Wrap(err, "failed to unmarshal future data")
+		}
+
+		genericFuture = &genericScaleSetVMFutureImpl{
+			FutureAPI: &future,
+			result:    future.Result,
+		}
 	case infrav1.DeleteFuture:
+		// the deletion of a virtual machine of a Flexible scale set is tracked by a future of the same shape, so it is
+		// decoded the same way; only the outcome of the deletion is relevant
 		var future compute.VirtualMachineScaleSetVMsDeleteFuture
 		if err := json.Unmarshal(futureData, &future); err != nil {
 			return compute.VirtualMachineScaleSetVM{}, errors.Wrap(err, "failed to unmarshal future data")
@@ -143,9 +184,69 @@ func (ac *azureClient) DeleteAsync(ctx context.Context, resourceGroupName, vmssN
 	return converters.SDKToFuture(&future, infrav1.DeleteFuture, serviceName, instanceID, resourceGroupName)
 }
 
+// UpdateProtectionPolicyAsync is the operation to update the protection policy of a virtual machine scale set instance
+// asynchronously. UpdateProtectionPolicyAsync fetches the instance and sends it back to Azure with the protection
+// policy in a PUT request, and if accepted without error, the func will return a Future which can be used to track
+// the ongoing progress of the operation.
+//
+// Parameters:
+//   resourceGroupName - the name of the resource group.
+//   vmssName - the name of the VM scale set.
+//   instanceID - the ID of the VM scale set VM.
+//   protectionPolicy - the protection policy of the VM scale set VM.
+func (ac *azureClient) UpdateProtectionPolicyAsync(ctx context.Context, resourceGroupName, vmssName, instanceID string, protectionPolicy azure.VMSSVMProtectionPolicy) (*infrav1.Future, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.UpdateProtectionPolicyAsync")
+	defer done()
+
+	instance, err := ac.scalesetvms.Get(ctx, resourceGroupName, vmssName, instanceID, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting instance %s of vmss named %q", instanceID, vmssName)
+	}
+
+	if instance.VirtualMachineScaleSetVMProperties == nil {
+		instance.VirtualMachineScaleSetVMProperties = &compute.VirtualMachineScaleSetVMProperties{}
+	}
+	instance.Resources = nil
+	instance.ProtectionPolicy = &compute.VirtualMachineScaleSetVMProtectionPolicy{
+		ProtectFromScaleIn:         to.BoolPtr(protectionPolicy.ProtectFromScaleIn),
+		ProtectFromScaleSetActions: to.BoolPtr(protectionPolicy.ProtectFromScaleSetActions),
+	}
+
+	future, err := ac.scalesetvms.Update(ctx, resourceGroupName, vmssName, instanceID, instance)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed updating instance %s of vmss named %q", instanceID, vmssName)
+	}
+
+	return converters.SDKToFuture(&future, infrav1.PutFuture, serviceName, instanceID, resourceGroupName)
+}
+
+// DeleteVMAsync is the operation to delete a virtual machine orchestrated by a virtual machine scale set in Flexible
+// orchestration mode asynchronously. DeleteVMAsync sends a DELETE request to Azure and if accepted without error, the
+// func will return a Future which can be used to track the ongoing progress of the operation.
+//
+// Parameters:
+//   resourceGroupName - the name of the resource group.
+//   vmName - the name of the virtual machine.
+func (ac *azureClient) DeleteVMAsync(ctx context.Context, resourceGroupName, vmName string) (*infrav1.Future, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.DeleteVMAsync")
+	defer done()
+
+	future, err := ac.virtualmachines.Delete(ctx, resourceGroupName, vmName, to.BoolPtr(false))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed deleting vm named %q", vmName)
+	}
+
+	return converters.SDKToFuture(&future, infrav1.DeleteFuture, serviceName, vmName, resourceGroupName)
+}
+
 // Result wraps the delete result so that we can treat it generically. The only thing we care about is if the delete
 // was successful. If it wasn't, an error will be returned.
 func (da *deleteFutureAdapter) Result(client compute.VirtualMachineScaleSetVMsClient) (compute.VirtualMachineScaleSetVM, error) {
 	_, err := da.VirtualMachineScaleSetVMsDeleteFuture.Result(client)
 	return compute.VirtualMachineScaleSetVM{}, err
 }
+
+// Result returns the result of the operation.
+func (g *genericScaleSetVMFutureImpl) Result(client compute.VirtualMachineScaleSetVMsClient) (compute.VirtualMachineScaleSetVM, error) {
+	return g.result(client)
+}
@@ -28,6 +28,7 @@ import (
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
 )
 
 // Mockclient is a mock of client interface.
@@ -68,6 +69,21 @@ func (mr *MockclientMockRecorder) DeleteAsync(arg0, arg1, arg2, arg3 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAsync", reflect.TypeOf((*Mockclient)(nil).DeleteAsync), arg0, arg1, arg2, arg3)
 }
 
+// DeleteVMAsync mocks base method.
+func (m *Mockclient) DeleteVMAsync(arg0 context.Context, arg1, arg2 string) (*v1beta1.Future, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVMAsync", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1beta1.Future)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVMAsync indicates an expected call of DeleteVMAsync.
+func (mr *MockclientMockRecorder) DeleteVMAsync(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVMAsync", reflect.TypeOf((*Mockclient)(nil).DeleteVMAsync), arg0, arg1, arg2)
+}
+
 // Get mocks base method.
 func (m *Mockclient) Get(arg0 context.Context, arg1, arg2, arg3 string) (compute.VirtualMachineScaleSetVM, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +114,36 @@ func (mr *MockclientMockRecorder) GetResultIfDone(ctx, future interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultIfDone", reflect.TypeOf((*Mockclient)(nil).GetResultIfDone), ctx, future)
 }
 
+// GetVM mocks base method.
+func (m *Mockclient) GetVM(arg0 context.Context, arg1, arg2 string) (compute.VirtualMachine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVM", arg0, arg1, arg2)
+	ret0, _ := ret[0].(compute.VirtualMachine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVM indicates an expected call of GetVM.
+func (mr *MockclientMockRecorder) GetVM(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVM", reflect.TypeOf((*Mockclient)(nil).GetVM), arg0, arg1, arg2)
+}
+
+// UpdateProtectionPolicyAsync mocks base method.
+func (m *Mockclient) UpdateProtectionPolicyAsync(arg0 context.Context, arg1, arg2, arg3 string, arg4 azure.VMSSVMProtectionPolicy) (*v1beta1.Future, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProtectionPolicyAsync", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*v1beta1.Future)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProtectionPolicyAsync indicates an expected call of UpdateProtectionPolicyAsync.
+func (mr *MockclientMockRecorder) UpdateProtectionPolicyAsync(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProtectionPolicyAsync", reflect.TypeOf((*Mockclient)(nil).UpdateProtectionPolicyAsync), arg0, arg1, arg2, arg3, arg4)
+}
+
 // MockgenericScaleSetVMFuture is a mock of genericScaleSetVMFuture interface.
 type MockgenericScaleSetVMFuture struct {
 	ctrl     *gomock.Controller
@@ -35,6 +35,8 @@ type (
 		azure.ClusterDescriber
 		azure.AsyncStatusUpdater
 		InstanceID() string
+		OrchestrationMode() infrav1.OrchestrationModeType
+		ProtectionPolicy() azure.VMSSVMProtectionPolicy
 		ScaleSetName() string
 		SetVMSSVM(vmssvm *azure.VMSSVM)
 	}
@@ -71,7 +73,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	)
 
 	// fetch the latest data about the instance -- model mutations are handled by the AzureMachinePoolReconciler
-	instance, err := s.Client.Get(ctx, resourceGroup, vmssName, instanceID)
+	instance, err := s.getInstance(ctx, resourceGroup, vmssName, instanceID)
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			return azure.WithTransientError(errors.New("instance does not exist yet"), 30*time.Second)
@@ -79,7 +81,54 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed getting instance")
 	}
 
-	s.Scope.SetVMSSVM(converters.SDKToVMSSVM(instance))
+	s.Scope.SetVMSSVM(instance)
+
+	// the instances of a scale set in Flexible orchestration mode have no protection policy
+	if s.Scope.OrchestrationMode() == infrav1.FlexibleOrchestrationMode {
+		return nil
+	}
+
+	return s.reconcileProtectionPolicy(ctx, resourceGroup, vmssName, instanceID, instance)
+}
+
+// reconcileProtectionPolicy updates the protection policy of the instance when it differs from the desired one.
+func (s *Service) reconcileProtectionPolicy(ctx context.Context, resourceGroup, vmssName, instanceID string, instance *azure.VMSSVM) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesetvms.Service.reconcileProtectionPolicy")
+	defer done()
+
+	future := s.Scope.GetLongRunningOperationState(instanceID, serviceName)
+	if future != nil {
+		if future.Type != infrav1.PutFuture {
+			return azure.WithTransientError(errors.New("attempting to update, non-update operation in progress"), 30*time.Second)
+		}
+
+		log.V(4).Info("checking if the protection policy of the instance is done updating")
+		if _, err := s.Client.GetResultIfDone(ctx, future); err != nil {
+			return errors.Wrap(err, "failed to get result of long running operation")
+		}
+
+		s.Scope.DeleteLongRunningOperationState(instanceID, serviceName)
+		return nil
+	}
+
+	protectionPolicy := s.Scope.ProtectionPolicy()
+	if instance.ProtectionPolicy == protectionPolicy {
+		return nil
+	}
+
+	log.V(2).Info("updating the protection policy of the instance", "protectFromScaleIn", protectionPolicy.ProtectFromScaleIn, "protectFromScaleSetActions", protectionPolicy.ProtectFromScaleSetActions)
+	future, err := s.Client.UpdateProtectionPolicyAsync(ctx, resourceGroup, vmssName, instanceID, protectionPolicy)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the protection policy of instance %s/%s", vmssName, instanceID)
+	}
+
+	s.Scope.SetLongRunningOperationState(future)
+
+	if _, err := s.Client.GetResultIfDone(ctx, future); err != nil {
+		return errors.Wrap(err, "failed to get result of long running operation")
+	}
+
+	s.Scope.DeleteLongRunningOperationState(instanceID, serviceName)
 	return nil
 }
 
@@ -101,14 +150,24 @@ func (s *Service) Delete(ctx context.Context) error {
 	defer done()
 
 	defer func() {
-		if instance, err := s.Client.Get(ctx, resourceGroup, vmssName, instanceID); err == nil && instance.VirtualMachineScaleSetVMProperties != nil {
-			log.V(4).Info("updating vmss vm state", "state", instance.ProvisioningState)
-			s.Scope.SetVMSSVM(converters.SDKToVMSSVM(instance))
+		if instance, err := s.getInstance(ctx, resourceGroup, vmssName, instanceID); err == nil && instance.State != "" {
+			log.V(4).Info("updating vmss vm state", "state", instance.State)
+			s.Scope.SetVMSSVM(instance)
 		}
 	}()
 
 	log.V(4).Info("entering delete")
 	future := s.Scope.GetLongRunningOperationState(instanceID, serviceName)
+	if future != nil && future.Type == infrav1.PutFuture {
+		// let the update of the protection policy of the instance complete before deleting it
+		if _, err := s.Client.GetResultIfDone(ctx, future); err != nil {
+			return errors.Wrap(err, "failed to get result of long running operation")
+		}
+
+		s.Scope.DeleteLongRunningOperationState(instanceID, serviceName)
+		future = nil
+	}
+
 	if future != nil {
 		if future.Type != infrav1.DeleteFuture {
 			return azure.WithTransientError(errors.New("attempting to delete, non-delete operation in progress"), 30*time.Second)
@@ -127,7 +186,7 @@ func (s *Service) Delete(ctx context.Context) error {
 	}
 
 	// since the future was nil, there is no ongoing activity; start deleting the instance
-	future, err := s.Client.DeleteAsync(ctx, resourceGroup, vmssName, instanceID)
+	future, err := s.deleteInstanceAsync(ctx, resourceGroup, vmssName, instanceID)
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			// already deleted
@@ -147,3 +206,30 @@ func (s *Service) Delete(ctx context.Context) error {
 	s.Scope.DeleteLongRunningOperationState(instanceID, serviceName)
 	return nil
 }
+
+// getInstance fetches an instance of the scale set. The instances of a scale set in Flexible orchestration mode are
+// regular virtual machines, identified by their name, and are fetched through the virtual machines API.
+func (s *Service) getInstance(ctx context.Context, resourceGroup, vmssName, instanceID string) (*azure.VMSSVM, error) {
+	if s.Scope.OrchestrationMode() == infrav1.FlexibleOrchestrationMode {
+		vm, err := s.Client.GetVM(ctx, resourceGroup, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		return converters.SDKVMToVMSSVM(vm), nil
+	}
+
+	instance, err := s.Client.Get(ctx, resourceGroup, vmssName, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return converters.SDKToVMSSVM(instance), nil
+}
+
+// deleteInstanceAsync starts deleting an instance of the scale set, deleting the virtual machine itself when the scale
+// set is in Flexible orchestration mode.
+func (s *Service) deleteInstanceAsync(ctx context.Context, resourceGroup, vmssName, instanceID string) (*infrav1.Future, error) {
+	if s.Scope.OrchestrationMode() == infrav1.FlexibleOrchestrationMode {
+		return s.Client.DeleteVMAsync(ctx, resourceGroup, instanceID)
+	}
+	return s.Client.DeleteAsync(ctx, resourceGroup, vmssName, instanceID)
+}
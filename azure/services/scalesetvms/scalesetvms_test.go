@@ -92,10 +92,11 @@ func TestNewService(t *testing.T) {
 
 func TestService_Reconcile(t *testing.T) {
 	cases := []struct {
-		Name       string
-		Setup      func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder)
-		Err        error
-		CheckIsErr bool
+		Name              string
+		OrchestrationMode infrav1.OrchestrationModeType
+		Setup             func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder)
+		Err               error
+		CheckIsErr        bool
 	}{
 		{
 			Name: "should reconcile successfully",
@@ -108,7 +109,74 @@ func TestService_Reconcile(t *testing.T) {
 				}
 				m.Get(gomock2.AContext(), "rg", "scaleset", "0").Return(vm, nil)
 				s.SetVMSSVM(converters.SDKToVMSSVM(vm))
+				s.GetLongRunningOperationState("0", serviceName).Return(nil)
+				s.ProtectionPolicy().Return(azure.VMSSVMProtectionPolicy{})
+			},
+		},
+		{
+			Name: "should update the protection policy of the instance",
+			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
+				s.ResourceGroup().Return("rg")
+				s.InstanceID().Return("0")
+				s.ScaleSetName().Return("scaleset")
+				vm := compute.VirtualMachineScaleSetVM{
+					InstanceID: to.StringPtr("0"),
+				}
+				m.Get(gomock2.AContext(), "rg", "scaleset", "0").Return(vm, nil)
+				s.SetVMSSVM(converters.SDKToVMSSVM(vm))
+				s.GetLongRunningOperationState("0", serviceName).Return(nil)
+				protectionPolicy := azure.VMSSVMProtectionPolicy{ProtectFromScaleIn: true}
+				s.ProtectionPolicy().Return(protectionPolicy)
+				future := &infrav1.Future{
+					Type: infrav1.PutFuture,
+				}
+				m.UpdateProtectionPolicyAsync(gomock2.AContext(), "rg", "scaleset", "0", protectionPolicy).Return(future, nil)
+				s.SetLongRunningOperationState(future)
+				m.GetResultIfDone(gomock2.AContext(), future).Return(compute.VirtualMachineScaleSetVM{}, nil)
+				s.DeleteLongRunningOperationState("0", serviceName)
+			},
+		},
+		{
+			Name: "should not update the protection policy of the instance when it is already applied",
+			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
+				s.ResourceGroup().Return("rg")
+				s.InstanceID().Return("0")
+				s.ScaleSetName().Return("scaleset")
+				vm := compute.VirtualMachineScaleSetVM{
+					InstanceID: to.StringPtr("0"),
+					VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+						ProtectionPolicy: &compute.VirtualMachineScaleSetVMProtectionPolicy{
+							ProtectFromScaleIn:         to.BoolPtr(true),
+							ProtectFromScaleSetActions: to.BoolPtr(false),
+						},
+					},
+				}
+				m.Get(gomock2.AContext(), "rg", "scaleset", "0").Return(vm, nil)
+				s.SetVMSSVM(converters.SDKToVMSSVM(vm))
+				s.GetLongRunningOperationState("0", serviceName).Return(nil)
+				s.ProtectionPolicy().Return(azure.VMSSVMProtectionPolicy{ProtectFromScaleIn: true})
+			},
+		},
+		{
+			Name: "should respond with a transient error while the protection policy of the instance is updating",
+			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
+				s.ResourceGroup().Return("rg")
+				s.InstanceID().Return("0")
+				s.ScaleSetName().Return("scaleset")
+				vm := compute.VirtualMachineScaleSetVM{
+					InstanceID: to.StringPtr("0"),
+				}
+				m.Get(gomock2.AContext(), "rg", "scaleset", "0").Return(vm, nil)
+				s.SetVMSSVM(converters.SDKToVMSSVM(vm))
+				future := &infrav1.Future{
+					Type: infrav1.PutFuture,
+				}
+				s.GetLongRunningOperationState("0", serviceName).Return(future)
+				m.GetResultIfDone(gomock2.AContext(), future).Return(compute.VirtualMachineScaleSetVM{}, azure.WithTransientError(azure.NewOperationNotDoneError(future), 15*time.Second))
 			},
+			Err: errors.Wrap(azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{
+				Type: infrav1.PutFuture,
+			}), 15*time.Second), "failed to get result of long running operation"),
 		},
 		{
 			Name: "if 404, then should respond with transient error",
@@ -121,6 +189,20 @@ func TestService_Reconcile(t *testing.T) {
 			Err:        azure.WithTransientError(errors.New("instance does not exist yet"), 30*time.Second),
 			CheckIsErr: true,
 		},
+		{
+			Name:              "should reconcile a virtual machine of a flexible scale set successfully",
+			OrchestrationMode: infrav1.FlexibleOrchestrationMode,
+			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
+				s.ResourceGroup().Return("rg")
+				s.InstanceID().Return("scaleset_1a2b3c4d")
+				s.ScaleSetName().Return("scaleset")
+				vm := compute.VirtualMachine{
+					Name: to.StringPtr("scaleset_1a2b3c4d"),
+				}
+				m.GetVM(gomock2.AContext(), "rg", "scaleset_1a2b3c4d").Return(vm, nil)
+				s.SetVMSSVM(converters.SDKVMToVMSSVM(vm))
+			},
+		},
 		{
 			Name: "if other error, then should respond with error",
 			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
@@ -146,6 +228,7 @@ func TestService_Reconcile(t *testing.T) {
 			scopeMock.EXPECT().SubscriptionID().Return("subID")
 			scopeMock.EXPECT().BaseURI().Return("https://localhost/")
 			scopeMock.EXPECT().Authorizer().Return(nil)
+			scopeMock.EXPECT().OrchestrationMode().Return(c.OrchestrationMode).AnyTimes()
 
 			service := NewService(scopeMock)
 			service.Client = clientMock
@@ -166,10 +249,11 @@ func TestService_Reconcile(t *testing.T) {
 
 func TestService_Delete(t *testing.T) {
 	cases := []struct {
-		Name       string
-		Setup      func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder)
-		Err        error
-		CheckIsErr bool
+		Name              string
+		OrchestrationMode infrav1.OrchestrationModeType
+		Setup             func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder)
+		Err               error
+		CheckIsErr        bool
 	}{
 		{
 			Name: "should start deleting successfully if no long running operation is active",
@@ -191,6 +275,24 @@ func TestService_Delete(t *testing.T) {
 				Type: infrav1.DeleteFuture,
 			}), 15*time.Second), "failed to get result of long running operation"),
 		},
+		{
+			Name:              "should start deleting a virtual machine of a flexible scale set",
+			OrchestrationMode: infrav1.FlexibleOrchestrationMode,
+			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
+				s.ResourceGroup().Return("rg")
+				s.InstanceID().Return("scaleset_1a2b3c4d")
+				s.ScaleSetName().Return("scaleset")
+				s.GetLongRunningOperationState("scaleset_1a2b3c4d", serviceName).Return(nil)
+				future := &infrav1.Future{
+					Type: infrav1.DeleteFuture,
+				}
+				m.DeleteVMAsync(gomock2.AContext(), "rg", "scaleset_1a2b3c4d").Return(future, nil)
+				s.SetLongRunningOperationState(future)
+				m.GetResultIfDone(gomock2.AContext(), future).Return(compute.VirtualMachineScaleSetVM{}, nil)
+				s.DeleteLongRunningOperationState("scaleset_1a2b3c4d", serviceName)
+				m.GetVM(gomock2.AContext(), "rg", "scaleset_1a2b3c4d").Return(compute.VirtualMachine{}, autorest404)
+			},
+		},
 		{
 			Name: "should finish deleting successfully when there's a long running operation that has completed",
 			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
@@ -259,6 +361,7 @@ func TestService_Delete(t *testing.T) {
 			scopeMock.EXPECT().SubscriptionID().Return("subID")
 			scopeMock.EXPECT().BaseURI().Return("https://localhost/")
 			scopeMock.EXPECT().Authorizer().Return(nil)
+			scopeMock.EXPECT().OrchestrationMode().Return(c.OrchestrationMode).AnyTimes()
 
 			service := NewService(scopeMock)
 			service.Client = clientMock
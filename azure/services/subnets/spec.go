@@ -37,6 +37,8 @@ type SubnetSpec struct {
 	SecurityGroupName string
 	Role              infrav1.SubnetRole
 	NatGatewayName    string
+	// CreateIfMissing creates the subnet when it does not exist even if the VNet is not managed.
+	CreateIfMissing bool
 }
 
 // ResourceName returns the name of the subnet.
@@ -65,7 +67,7 @@ func (s *SubnetSpec) Parameters(existing interface{}) (parameters interface{}, e
 		return nil, nil
 	}
 
-	if !s.IsVNetManaged {
+	if !s.IsVNetManaged && !s.CreateIfMissing {
 		// TODO: change this to terminal error once we add support for handling them
 		return nil, errors.Errorf("custom vnet was provided but subnet %s is missing", s.Name)
 	}
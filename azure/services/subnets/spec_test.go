@@ -138,6 +138,29 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "custom vnet was provided but subnet my-subnet-1 is missing",
 		},
+		{
+			name: "vnet is not managed and subnet is missing but may be created",
+			spec: &SubnetSpec{
+				Name:              "my-subnet-1",
+				ResourceGroup:     "my-rg",
+				SubscriptionID:    "123",
+				CIDRs:             []string{"10.0.0.0/16"},
+				IsVNetManaged:     false,
+				CreateIfMissing:   true,
+				VNetName:          "my-vnet",
+				VNetResourceGroup: "my-vnet-rg",
+				Role:              infrav1.SubnetNode,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix: to.StringPtr("10.0.0.0/16"),
+					},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "vnet is not managed and subnet is present",
 			spec:     &fakeSubnetSpecNotManaged,
@@ -54,7 +54,7 @@ func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Get")
 	defer done()
 
-	return ac.virtualmachines.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
+	return ac.virtualmachines.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), compute.InstanceViewTypesInstanceView)
 }
 
 // CreateOrUpdateAsync creates or updates a virtual machine asynchronously.
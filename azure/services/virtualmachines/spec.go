@@ -48,6 +48,8 @@ type VMSpec struct {
 	UserAssignedIdentities []infrav1.UserAssignedIdentity
 	SpotVMOptions          *infrav1.SpotVMOptions
 	SecurityProfile        *infrav1.SecurityProfile
+	DedicatedHost          *infrav1.DedicatedHost
+	Diagnostics            *infrav1.Diagnostics
 	AdditionalTags         infrav1.Tags
 	AdditionalCapabilities *infrav1.AdditionalCapabilities
 	SKU                    resourceskus.SKU
@@ -124,6 +126,8 @@ func (s *VMSpec) Parameters(existing interface{}) (params interface{}, err error
 		VirtualMachineProperties: &compute.VirtualMachineProperties{
 			AdditionalCapabilities: s.generateAdditionalCapabilities(),
 			AvailabilitySet:        s.getAvailabilitySet(),
+			Host:                   s.getHost(),
+			HostGroup:              s.getHostGroup(),
 			HardwareProfile: &compute.HardwareProfile{
 				VMSize: compute.VirtualMachineSizeTypes(s.Size),
 			},
@@ -133,14 +137,10 @@ func (s *VMSpec) Parameters(existing interface{}) (params interface{}, err error
 			NetworkProfile: &compute.NetworkProfile{
 				NetworkInterfaces: s.generateNICRefs(),
 			},
-			Priority:       priority,
-			EvictionPolicy: evictionPolicy,
-			BillingProfile: billingProfile,
-			DiagnosticsProfile: &compute.DiagnosticsProfile{
-				BootDiagnostics: &compute.BootDiagnostics{
-					Enabled: to.BoolPtr(true),
-				},
-			},
+			Priority:           priority,
+			EvictionPolicy:     evictionPolicy,
+			BillingProfile:     billingProfile,
+			DiagnosticsProfile: converters.GetDiagnosticsProfile(s.Diagnostics),
 		},
 		Identity: identity,
 		Zones:    s.getZones(),
@@ -341,6 +341,23 @@ func (s *VMSpec) getAvailabilitySet() *compute.SubResource {
 	return as
 }
 
+// getHost returns the dedicated host the VM should be placed on, if a specific host is requested.
+func (s *VMSpec) getHost() *compute.SubResource {
+	if s.DedicatedHost == nil || s.DedicatedHost.HostID == "" {
+		return nil
+	}
+	return &compute.SubResource{ID: to.StringPtr(s.DedicatedHost.HostID)}
+}
+
+// getHostGroup returns the dedicated host group the VM should be placed in using automatic placement.
+// Azure does not allow the host group to be set when a specific host is requested.
+func (s *VMSpec) getHostGroup() *compute.SubResource {
+	if s.DedicatedHost == nil || s.DedicatedHost.HostID != "" {
+		return nil
+	}
+	return &compute.SubResource{ID: to.StringPtr(s.DedicatedHost.HostGroupID)}
+}
+
 func (s *VMSpec) getZones() *[]string {
 	var zones *[]string
 	if s.Zone != "" {
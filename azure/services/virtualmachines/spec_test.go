@@ -391,6 +391,53 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a vm in a dedicated host group",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "",
+				Image:      &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SKU:        validSKU,
+				DedicatedHost: &infrav1.DedicatedHost{
+					HostGroupID: "fake-host-group-id",
+				},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).HostGroup.ID).To(Equal(to.StringPtr("fake-host-group-id")))
+				g.Expect(result.(compute.VirtualMachine).Host).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a vm on a specific dedicated host",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "",
+				Image:      &infrav1.Image{ID: to.StringPtr("fake-image-id")},
+				SKU:        validSKU,
+				DedicatedHost: &infrav1.DedicatedHost{
+					HostGroupID: "fake-host-group-id",
+					HostID:      "fake-host-group-id/hosts/fake-host",
+				},
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).Host.ID).To(Equal(to.StringPtr("fake-host-group-id/hosts/fake-host")))
+				g.Expect(result.(compute.VirtualMachine).HostGroup).To(BeNil())
+			},
+			expectedError: "",
+		},
 		{
 			name: "can create a vm with EphemeralOSDisk",
 			spec: &VMSpec{
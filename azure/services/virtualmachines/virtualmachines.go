@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
@@ -53,18 +54,20 @@ type VMScope interface {
 type Service struct {
 	Scope VMScope
 	async.Reconciler
-	interfacesGetter async.Getter
-	publicIPsGetter  async.Getter
+	interfacesGetter     async.Getter
+	publicIPsGetter      async.Getter
+	dedicatedHostsClient dedicatedhosts.Client
 }
 
 // New creates a new service.
 func New(scope VMScope) *Service {
 	Client := NewClient(scope)
 	return &Service{
-		Scope:            scope,
-		interfacesGetter: networkinterfaces.NewClient(scope),
-		publicIPsGetter:  publicips.NewClient(scope),
-		Reconciler:       async.New(scope, Client, Client),
+		Scope:                scope,
+		interfacesGetter:     networkinterfaces.NewClient(scope),
+		publicIPsGetter:      publicips.NewClient(scope),
+		dedicatedHostsClient: dedicatedhosts.NewClient(scope),
+		Reconciler:           async.New(scope, Client, Client),
 	}
 }
 
@@ -86,6 +89,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
+	if err := s.validateDedicatedHost(ctx, vmSpec); err != nil {
+		s.Scope.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, err)
+		return err
+	}
+
 	result, err := s.CreateResource(ctx, vmSpec, serviceName)
 	s.Scope.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, err)
 	// Set the DiskReady condition here since the disk gets created with the VM.
@@ -111,6 +119,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 		s.Scope.SetAddresses(addresses)
 		s.Scope.SetVMState(infraVM.State)
+
+		if spec, ok := vmSpec.(*VMSpec); ok && spec.SpotVMOptions != nil && infraVM.PowerState == azure.VMPowerStateDeallocated {
+			return azure.VMEvictedError{ProviderID: providerID}
+		}
 	}
 	return err
 }
@@ -138,6 +150,24 @@ func (s *Service) Delete(ctx context.Context) error {
 	return err
 }
 
+// validateDedicatedHost checks that a VM which has not been created yet can be placed on the requested dedicated host.
+func (s *Service) validateDedicatedHost(ctx context.Context, spec azure.ResourceSpecGetter) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.validateDedicatedHost")
+	defer done()
+
+	vmSpec, ok := spec.(*VMSpec)
+	if !ok || vmSpec.DedicatedHost == nil || vmSpec.ProviderID != "" {
+		return nil
+	}
+
+	var zones []string
+	if vmSpec.Zone != "" {
+		zones = []string{vmSpec.Zone}
+	}
+
+	return dedicatedhosts.ValidatePlacement(ctx, s.dedicatedHostsClient, vmSpec.DedicatedHost, vmSpec.Size, zones)
+}
+
 func (s *Service) getAddresses(ctx context.Context, vm compute.VirtualMachine, rgName string) ([]corev1.NodeAddress, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.getAddresses")
 	defer done()
@@ -30,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts/mock_dedicatedhosts"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines/mock_virtualmachines"
@@ -66,6 +67,26 @@ var (
 			},
 		},
 	}
+	fakeSpotVMSpec = VMSpec{
+		Name:          "test-vm",
+		ResourceGroup: "test-group",
+		Size:          "Standard_Fake_Size",
+		SpotVMOptions: &infrav1.SpotVMOptions{},
+	}
+	fakeEvictedVM = compute.VirtualMachine{
+		ID:   to.StringPtr("subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm"),
+		Name: to.StringPtr("test-vm-name"),
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			ProvisioningState: to.StringPtr("Succeeded"),
+			NetworkProfile:    &compute.NetworkProfile{},
+			InstanceView: &compute.VirtualMachineInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{
+					{Code: to.StringPtr("ProvisioningState/succeeded")},
+					{Code: to.StringPtr("PowerState/deallocated")},
+				},
+			},
+		},
+	}
 	fakeNetworkInterfaceGetterSpec = networkinterfaces.NICSpec{
 		Name:          "nic-1",
 		ResourceGroup: "test-group",
@@ -107,6 +128,31 @@ var (
 			Address: "10.0.0.6",
 		},
 	}
+	fakeDedicatedHostVMSpec = VMSpec{
+		Name:          "test-vm",
+		ResourceGroup: "test-group",
+		Size:          "Standard_Fake_Size",
+		DedicatedHost: &infrav1.DedicatedHost{
+			HostGroupID: "/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Compute/hostGroups/my-host-group",
+		},
+	}
+	fakeHostGroup = compute.DedicatedHostGroup{
+		DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+			SupportAutomaticPlacement: to.BoolPtr(true),
+			InstanceView: &compute.DedicatedHostGroupInstanceView{
+				Hosts: &[]compute.DedicatedHostInstanceViewWithName{
+					{
+						Name: to.StringPtr("my-host"),
+						AvailableCapacity: &compute.DedicatedHostAvailableCapacity{
+							AllocatableVMs: &[]compute.DedicatedHostAllocatableVM{
+								{VMSize: to.StringPtr("Standard_Other_Size"), Count: to.Float64Ptr(2)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: 500}, "Internal Server Error")
 )
 
@@ -114,19 +160,19 @@ func TestReconcileVM(t *testing.T) {
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, mdh *mock_dedicatedhosts.MockClientMockRecorder)
 	}{
 		{
 			name:          "noop if no vm spec is found",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, mdh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.VMSpec().Return(nil)
 			},
 		},
 		{
 			name:          "create vm succeeds",
 			expectedError: "",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, mdh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.VMSpec().Return(&fakeVMSpec)
 				r.CreateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
 				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
@@ -142,7 +188,7 @@ func TestReconcileVM(t *testing.T) {
 		{
 			name:          "creating vm fails",
 			expectedError: "#: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, mdh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.VMSpec().Return(&fakeVMSpec)
 				r.CreateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(nil, internalError)
 				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, internalError)
@@ -152,7 +198,7 @@ func TestReconcileVM(t *testing.T) {
 		{
 			name:          "create vm succeeds but failed to get network interfaces",
 			expectedError: "failed to fetch VM addresses: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, mdh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.VMSpec().Return(&fakeVMSpec)
 				r.CreateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
 				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
@@ -165,7 +211,7 @@ func TestReconcileVM(t *testing.T) {
 		{
 			name:          "create vm succeeds but failed to get public IPs",
 			expectedError: "failed to fetch VM addresses: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, mdh *mock_dedicatedhosts.MockClientMockRecorder) {
 				s.VMSpec().Return(&fakeVMSpec)
 				r.CreateResource(gomockinternal.AContext(), &fakeVMSpec, serviceName).Return(fakeExistingVM, nil)
 				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
@@ -176,6 +222,29 @@ func TestReconcileVM(t *testing.T) {
 				mpip.Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(network.PublicIPAddress{}, internalError)
 			},
 		},
+		{
+			name:          "spot vm has been evicted",
+			expectedError: "Spot VM with provider id \"azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm\" has been evicted",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, mdh *mock_dedicatedhosts.MockClientMockRecorder) {
+				s.VMSpec().Return(&fakeSpotVMSpec)
+				r.CreateResource(gomockinternal.AContext(), &fakeSpotVMSpec, serviceName).Return(fakeEvictedVM, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+				s.SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+				s.SetAnnotation("cluster-api-provider-azure", "true")
+				s.SetAddresses([]corev1.NodeAddress{{Type: corev1.NodeInternalDNS, Address: "test-vm-name"}})
+				s.SetVMState(infrav1.Succeeded)
+			},
+		},
+		{
+			name:          "vm size is not supported by the dedicated host group",
+			expectedError: "reconcile error that cannot be recovered occurred: vm size Standard_Fake_Size is not supported by the dedicated hosts in dedicated host group my-host-group. Object will not be requeued",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, mnic *mock_async.MockGetterMockRecorder, mpip *mock_async.MockGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder, mdh *mock_dedicatedhosts.MockClientMockRecorder) {
+				s.VMSpec().Return(&fakeDedicatedHostVMSpec)
+				mdh.GetHostGroup(gomockinternal.AContext(), "test-group", "my-host-group").Return(fakeHostGroup, nil)
+				s.UpdatePutStatus(infrav1.VMRunningCondition, serviceName, gomock.Any())
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -190,14 +259,16 @@ func TestReconcileVM(t *testing.T) {
 			interfaceMock := mock_async.NewMockGetter(mockCtrl)
 			publicIPMock := mock_async.NewMockGetter(mockCtrl)
 			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			dedicatedHostsMock := mock_dedicatedhosts.NewMockClient(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), interfaceMock.EXPECT(), publicIPMock.EXPECT(), asyncMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), interfaceMock.EXPECT(), publicIPMock.EXPECT(), asyncMock.EXPECT(), dedicatedHostsMock.EXPECT())
 
 			s := &Service{
-				Scope:            scopeMock,
-				interfacesGetter: interfaceMock,
-				publicIPsGetter:  publicIPMock,
-				Reconciler:       asyncMock,
+				Scope:                scopeMock,
+				interfacesGetter:     interfaceMock,
+				publicIPsGetter:      publicIPMock,
+				dedicatedHostsClient: dedicatedHostsMock,
+				Reconciler:           asyncMock,
 			}
 
 			err := s.Reconcile(context.TODO())
@@ -18,6 +18,7 @@ package azure
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -41,12 +42,16 @@ const (
 
 	// VirtualMachineScaleSet ...
 	VirtualMachineScaleSet = "VirtualMachineScaleSet"
+
+	// ManagedCluster ...
+	ManagedCluster = "ManagedCluster"
 )
 
 // ScaleSetSpec defines the specification for a Scale Set.
 type ScaleSetSpec struct {
 	Name                         string
 	Size                         string
+	FallbackSizes                []string
 	Capacity                     int64
 	SSHKeyData                   string
 	OSDisk                       infrav1.OSDisk
@@ -62,8 +67,15 @@ type ScaleSetSpec struct {
 	UserAssignedIdentities       []infrav1.UserAssignedIdentity
 	SecurityProfile              *infrav1.SecurityProfile
 	SpotVMOptions                *infrav1.SpotVMOptions
+	SpotFallbackToOnDemand       bool
+	SpotFallbackActive           bool
 	AdditionalCapabilities       *infrav1.AdditionalCapabilities
 	FailureDomains               []string
+	DedicatedHost                *infrav1.DedicatedHost
+	Diagnostics                  *infrav1.Diagnostics
+	OrchestrationMode            infrav1.OrchestrationModeType
+	AutomaticRepairsGracePeriod  *time.Duration
+	ZoneBalance                  *bool
 }
 
 // TagsSpec defines the specification for a set of tags.
@@ -82,9 +94,17 @@ type ExtensionSpec struct {
 	VMName            string
 	Publisher         string
 	Version           string
+	Settings          interface{}
 	ProtectedSettings map[string]string
 }
 
+// AgentPoolVersion defines the Kubernetes version and provisioning state of an agent pool of a managed cluster.
+type AgentPoolVersion struct {
+	Name              string
+	Version           string
+	ProvisioningState string
+}
+
 type (
 	// VMSSVM defines a VM in a virtual machine scale set.
 	VMSSVM struct {
@@ -94,6 +114,14 @@ type (
 		Name             string                    `json:"name,omitempty"`
 		AvailabilityZone string                    `json:"availabilityZone,omitempty"`
 		State            infrav1.ProvisioningState `json:"vmState,omitempty"`
+		PowerState       string                    `json:"powerState,omitempty"`
+		ProtectionPolicy VMSSVMProtectionPolicy    `json:"protectionPolicy,omitempty"`
+	}
+
+	// VMSSVMProtectionPolicy defines what a virtual machine scale set VM is protected from.
+	VMSSVMProtectionPolicy struct {
+		ProtectFromScaleIn         bool `json:"protectFromScaleIn,omitempty"`
+		ProtectFromScaleSetActions bool `json:"protectFromScaleSetActions,omitempty"`
 	}
 
 	// VMSS defines a virtual machine scale set.
@@ -108,6 +136,11 @@ type (
 		Identity  infrav1.VMIdentity        `json:"identity,omitempty"`
 		Tags      infrav1.Tags              `json:"tags,omitempty"`
 		Instances []VMSSVM                  `json:"instances,omitempty"`
+		// AutomaticRepairsGracePeriod is the grace period of the automatic repairs policy, or empty if automatic
+		// repairs are disabled.
+		AutomaticRepairsGracePeriod string `json:"automaticRepairsGracePeriod,omitempty"`
+		// Extensions are the sorted names of the extensions of the scale set model.
+		Extensions []string `json:"extensions,omitempty"`
 	}
 )
 
@@ -117,7 +150,9 @@ func (vmss VMSS) HasModelChanges(other VMSS) bool {
 		cmp.Equal(vmss.Identity, other.Identity) &&
 		cmp.Equal(vmss.Zones, other.Zones) &&
 		cmp.Equal(vmss.Tags, other.Tags) &&
-		cmp.Equal(vmss.Sku, other.Sku)
+		cmp.Equal(vmss.Sku, other.Sku) &&
+		cmp.Equal(vmss.AutomaticRepairsGracePeriod, other.AutomaticRepairsGracePeriod) &&
+		cmp.Equal(vmss.Extensions, other.Extensions)
 	return !equal
 }
 
@@ -136,10 +171,11 @@ func (vm VMSSVM) ProviderID() string {
 	return ProviderIDPrefix + vm.ID
 }
 
-// HasLatestModelAppliedToAll returns true if all VMSS instance have the latest model applied.
+// HasLatestModelAppliedToAll returns true if all VMSS instance have the latest model applied. Instances protected from
+// scale set actions are left out, as they are never replaced by an instance with the latest model.
 func (vmss VMSS) HasLatestModelAppliedToAll() bool {
 	for _, instance := range vmss.Instances {
-		if !vmss.HasLatestModelApplied(instance) {
+		if !vmss.HasLatestModelApplied(instance) && !instance.ProtectionPolicy.ProtectFromScaleSetActions {
 			return false
 		}
 	}
@@ -148,6 +184,8 @@ func (vmss VMSS) HasLatestModelAppliedToAll() bool {
 }
 
 // HasEnoughLatestModelOrNotMixedModel returns true if VMSS instance have the latest model applied to all or equal to the capacity.
+// Instances protected from scale set actions are counted as having the latest model, so that the scale set does not
+// surge to replace them.
 func (vmss VMSS) HasEnoughLatestModelOrNotMixedModel() bool {
 	if vmss.HasLatestModelAppliedToAll() {
 		return true
@@ -155,7 +193,7 @@ func (vmss VMSS) HasEnoughLatestModelOrNotMixedModel() bool {
 
 	counter := int64(0)
 	for _, instance := range vmss.Instances {
-		if vmss.HasLatestModelApplied(instance) {
+		if vmss.HasLatestModelApplied(instance) || instance.ProtectionPolicy.ProtectFromScaleSetActions {
 			counter++
 		}
 	}
@@ -136,6 +136,26 @@ func TestVMSS_HasModelChanges(t *testing.T) {
 			},
 			HasModelChanges: true,
 		},
+		{
+			Name: "with automatic repairs enabled",
+			Factory: func() (VMSS, VMSS) {
+				l := getDefaultVMSSForModelTesting()
+				l.AutomaticRepairsGracePeriod = "PT30M"
+				r := getDefaultVMSSForModelTesting()
+				return r, l
+			},
+			HasModelChanges: true,
+		},
+		{
+			Name: "with different extensions",
+			Factory: func() (VMSS, VMSS) {
+				l := getDefaultVMSSForModelTesting()
+				l.Extensions = []string{"ApplicationHealthLinux", "CAPZ.Linux.Bootstrapping"}
+				r := getDefaultVMSSForModelTesting()
+				return r, l
+			},
+			HasModelChanges: true,
+		},
 	}
 
 	for _, c := range cases {
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -28,6 +29,8 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootdiagnostics"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -51,21 +54,25 @@ type AzureMachineReconciler struct {
 	Recorder                  record.EventRecorder
 	ReconcileTimeout          time.Duration
 	WatchFilterValue          string
+	SerialConsoleLogCapture   SerialConsoleLogCapture
 	createAzureMachineService azureMachineServiceCreator
+	getBootDiagnosticsClient  BootDiagnosticsClientGetter
 }
 
 type azureMachineServiceCreator func(machineScope *scope.MachineScope) (*azureMachineService, error)
 
 // NewAzureMachineReconciler returns a new AzureMachineReconciler instance.
-func NewAzureMachineReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string) *AzureMachineReconciler {
+func NewAzureMachineReconciler(client client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string, serialConsoleLogCapture SerialConsoleLogCapture) *AzureMachineReconciler {
 	amr := &AzureMachineReconciler{
-		Client:           client,
-		Recorder:         recorder,
-		ReconcileTimeout: reconcileTimeout,
-		WatchFilterValue: watchFilterValue,
+		Client:                  client,
+		Recorder:                recorder,
+		ReconcileTimeout:        reconcileTimeout,
+		WatchFilterValue:        watchFilterValue,
+		SerialConsoleLogCapture: serialConsoleLogCapture,
 	}
 
 	amr.createAzureMachineService = newAzureMachineService
+	amr.getBootDiagnosticsClient = GetBootDiagnosticsClient
 
 	return amr
 }
@@ -131,6 +138,7 @@ func (amr *AzureMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 
 // Reconcile idempotently gets, creates, and updates a machine.
 func (amr *AzureMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -290,10 +298,20 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 	}
 
 	if err := ams.Reconcile(ctx); err != nil {
+		if captureErr := amr.captureSerialConsoleLog(ctx, machineScope); captureErr != nil {
+			log.Error(captureErr, "failed to capture the serial console log of the VM")
+		}
+
 		// This means that a VM was created and managed by this controller, but is not present anymore.
 		// In this case, we mark it as failed and leave it to MHC for remediation
 		if errors.As(err, &azure.VMDeletedError{}) {
-			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "VMDeleted", errors.Wrap(err, "failed to reconcile AzureMachine").Error())
+			if machineScope.AzureMachine.Spec.SpotVMOptions != nil {
+				// A Spot VM evicted with the Delete eviction policy is removed by Azure.
+				amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.SpotVMEvictedReason, errors.Wrap(err, "failed to reconcile AzureMachine").Error())
+				conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, infrav1.SpotVMEvictedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			} else {
+				amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, "VMDeleted", errors.Wrap(err, "failed to reconcile AzureMachine").Error())
+			}
 			machineScope.SetFailureReason(capierrors.UpdateMachineError)
 			machineScope.SetFailureMessage(err)
 			machineScope.SetNotReady()
@@ -301,6 +319,15 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 			return reconcile.Result{}, errors.Wrap(err, "failed to reconcile AzureMachine")
 		}
 
+		// This means that a Spot VM was evicted with the Deallocate eviction policy. The VM still exists and can be
+		// started again once capacity is available, so the machine is not marked as failed.
+		if errors.As(err, &azure.VMEvictedError{}) {
+			amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.SpotVMEvictedReason, err.Error())
+			conditions.MarkFalse(machineScope.AzureMachine, infrav1.VMRunningCondition, infrav1.SpotVMEvictedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			machineScope.SetNotReady()
+			return reconcile.Result{RequeueAfter: reconciler.DefaultReconcilerRequeue}, nil
+		}
+
 		// Handle transient and terminal errors
 		if errors.As(err, &reconcileError) {
 			if reconcileError.IsTerminal() {
@@ -328,9 +355,36 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 
 	machineScope.SetReady()
 
+	if feature.Gates.Enabled(feature.ScheduledEvents) {
+		return amr.reconcileScheduledEvents(ctx, machineScope)
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// reconcileScheduledEvents drains the node of the AzureMachine ahead of Azure Scheduled Events, and requeues to keep
+// watching for new events.
+func (amr *AzureMachineReconciler) reconcileScheduledEvents(ctx context.Context, machineScope *scope.MachineScope) (reconcile.Result, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.reconcileScheduledEvents")
+	defer done()
+
+	approved, err := machineScope.ReconcileScheduledEvents(ctx)
+	if err != nil {
+		var reconcileError azure.ReconcileError
+		if errors.As(err, &reconcileError) && reconcileError.IsTransient() {
+			log.V(2).Info(fmt.Sprintf("transient failure to drain node ahead of scheduled events, retrying: %s", reconcileError.Error()))
+			return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to handle the scheduled events of the node")
+	}
+
+	if len(approved) > 0 {
+		amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeNormal, "ScheduledEventsApproved", "Drained node ahead of Azure scheduled events %s", strings.Join(approved, ", "))
+	}
+
+	return reconcile.Result{RequeueAfter: scope.ScheduledEventsPollInterval}, nil
+}
+
 func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.reconcileDelete")
 	defer done()
@@ -375,3 +429,44 @@ func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineS
 
 	return reconcile.Result{}, nil
 }
+
+// captureSerialConsoleLog captures the tail of the serial console log of a VM which failed to bootstrap, once, and
+// attaches it to an event or stores it in a ConfigMap depending on the controller configuration.
+func (amr *AzureMachineReconciler) captureSerialConsoleLog(ctx context.Context, machineScope *scope.MachineScope) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.captureSerialConsoleLog")
+	defer done()
+
+	azureMachine := machineScope.AzureMachine
+	if !amr.SerialConsoleLogCapture.Enabled() || azureMachine.Status.BootDiagnostics != nil {
+		return nil
+	}
+
+	// The bootstrap extension reports BootstrapFailed, which is then reported as Failed by the extension service.
+	if reason := conditions.GetReason(azureMachine, infrav1.BootstrapSucceededCondition); reason != infrav1.BootstrapFailedReason && reason != infrav1.FailedReason {
+		return nil
+	}
+
+	// The serial console log can only be retrieved from the storage account managed by Azure.
+	if !bootdiagnostics.IsManaged(azureMachine.Spec.Diagnostics) {
+		log.V(2).Info("boot diagnostics are not stored in a managed storage account, not capturing the serial console log")
+		return nil
+	}
+
+	client, err := amr.getBootDiagnosticsClient(machineScope)
+	if err != nil {
+		return err
+	}
+
+	serialConsoleLog, err := bootdiagnostics.GetSerialConsoleLogTail(ctx, client, machineScope.ResourceGroup(), machineScope.Name(), amr.SerialConsoleLogCapture.MaxBytes())
+	if err != nil {
+		return err
+	}
+
+	status, err := RecordSerialConsoleLog(ctx, amr.Client, amr.Recorder, amr.SerialConsoleLogCapture, azureMachine, machineScope.ClusterName(), "VM failed to bootstrap", serialConsoleLog)
+	if err != nil {
+		return err
+	}
+	azureMachine.Status.BootDiagnostics = status
+
+	return nil
+}
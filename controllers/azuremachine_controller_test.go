@@ -20,18 +20,27 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootdiagnostics"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootdiagnostics/mock_bootdiagnostics"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -43,7 +52,7 @@ var _ = Describe("AzureMachineReconciler", func() {
 
 	Context("Reconcile an AzureMachine", func() {
 		It("should not error with minimal set up", func() {
-			reconciler := NewAzureMachineReconciler(testEnv, testEnv.GetEventRecorderFor("azuremachine-reconciler"), reconciler.DefaultLoopTimeout, "")
+			reconciler := NewAzureMachineReconciler(testEnv, testEnv.GetEventRecorderFor("azuremachine-reconciler"), reconciler.DefaultLoopTimeout, "", SerialConsoleLogCaptureNone)
 
 			By("Calling reconcile")
 			name := test.RandomName("foo", 10)
@@ -161,7 +170,7 @@ func TestConditions(t *testing.T) {
 			client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
 			recorder := record.NewFakeRecorder(10)
 
-			reconciler := NewAzureMachineReconciler(client, recorder, reconciler.DefaultLoopTimeout, "")
+			reconciler := NewAzureMachineReconciler(client, recorder, reconciler.DefaultLoopTimeout, "", SerialConsoleLogCaptureNone)
 
 			clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
 				AzureClients: scope.AzureClients{
@@ -193,6 +202,163 @@ func TestConditions(t *testing.T) {
 	}
 }
 
+func TestCaptureSerialConsoleLog(t *testing.T) {
+	scheme := setupScheme(NewWithT(t))
+	bootstrapFailed := func(m *infrav1.AzureMachine) {
+		conditions.MarkFalse(m, infrav1.BootstrapSucceededCondition, infrav1.BootstrapFailedReason, clusterv1.ConditionSeverityError, "")
+	}
+	testcases := []struct {
+		name          string
+		mode          SerialConsoleLogCapture
+		setup         func(m *infrav1.AzureMachine)
+		expect        func(c *mock_bootdiagnostics.MockClientMockRecorder)
+		expectCapture bool
+		expectLogRef  bool
+	}{
+		{
+			name:   "capture is disabled",
+			mode:   SerialConsoleLogCaptureNone,
+			setup:  bootstrapFailed,
+			expect: func(c *mock_bootdiagnostics.MockClientMockRecorder) {},
+		},
+		{
+			name: "bootstrap did not fail",
+			mode: SerialConsoleLogCaptureEvent,
+			setup: func(m *infrav1.AzureMachine) {
+				conditions.MarkFalse(m, infrav1.BootstrapSucceededCondition, infrav1.BootstrapInProgressReason, clusterv1.ConditionSeverityInfo, "")
+			},
+			expect: func(c *mock_bootdiagnostics.MockClientMockRecorder) {},
+		},
+		{
+			name: "serial console log was already captured",
+			mode: SerialConsoleLogCaptureEvent,
+			setup: func(m *infrav1.AzureMachine) {
+				bootstrapFailed(m)
+				m.Status.BootDiagnostics = &infrav1.BootDiagnosticsStatus{CaptureTime: metav1.Now()}
+			},
+			expect:        func(c *mock_bootdiagnostics.MockClientMockRecorder) {},
+			expectCapture: true,
+		},
+		{
+			name: "boot diagnostics are disabled",
+			mode: SerialConsoleLogCaptureEvent,
+			setup: func(m *infrav1.AzureMachine) {
+				bootstrapFailed(m)
+				m.Spec.Diagnostics = &infrav1.Diagnostics{
+					Boot: &infrav1.BootDiagnostics{StorageAccountType: infrav1.DisabledDiagnosticsStorage},
+				}
+			},
+			expect: func(c *mock_bootdiagnostics.MockClientMockRecorder) {},
+		},
+		{
+			name: "boot diagnostics are stored in a user managed storage account",
+			mode: SerialConsoleLogCaptureEvent,
+			setup: func(m *infrav1.AzureMachine) {
+				bootstrapFailed(m)
+				m.Spec.Diagnostics = &infrav1.Diagnostics{
+					Boot: &infrav1.BootDiagnostics{
+						StorageAccountType: infrav1.UserManagedDiagnosticsStorage,
+						UserManaged:        &infrav1.UserManagedBootDiagnostics{StorageAccountURI: "https://fake.blob.core.windows.net/"},
+					},
+				}
+			},
+			expect: func(c *mock_bootdiagnostics.MockClientMockRecorder) {},
+		},
+		{
+			name:  "capture the serial console log in an event",
+			mode:  SerialConsoleLogCaptureEvent,
+			setup: bootstrapFailed,
+			expect: func(c *mock_bootdiagnostics.MockClientMockRecorder) {
+				c.RetrieveBootDiagnosticsData(gomockinternal.AContext(), "my-rg", "my-machine").Return(compute.RetrieveBootDiagnosticsDataResult{
+					SerialConsoleLogBlobURI: to.StringPtr("https://serial-console-log"),
+				}, nil)
+				c.GetBlobTail(gomockinternal.AContext(), "https://serial-console-log", serialConsoleLogEventMaxBytes+1).Return([]byte("cloud-init failed\n"), nil)
+			},
+			expectCapture: true,
+		},
+		{
+			name:  "capture the serial console log in a ConfigMap",
+			mode:  SerialConsoleLogCaptureConfigMap,
+			setup: bootstrapFailed,
+			expect: func(c *mock_bootdiagnostics.MockClientMockRecorder) {
+				c.RetrieveBootDiagnosticsData(gomockinternal.AContext(), "my-rg", "my-machine").Return(compute.RetrieveBootDiagnosticsDataResult{
+					SerialConsoleLogBlobURI: to.StringPtr("https://serial-console-log"),
+				}, nil)
+				c.GetBlobTail(gomockinternal.AContext(), "https://serial-console-log", serialConsoleLogConfigMapMaxBytes+1).Return([]byte("cloud-init failed\n"), nil)
+			},
+			expectCapture: true,
+			expectLogRef:  true,
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}}
+			azureCluster := &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+					},
+				},
+			}
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "my-machine", Namespace: "default"}}
+			azureMachine := &infrav1.AzureMachine{ObjectMeta: metav1.ObjectMeta{Name: "my-machine", Namespace: "default"}}
+			tc.setup(azureMachine)
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster, azureCluster, machine, azureMachine).Build()
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewAzureMachineReconciler(client, recorder, reconciler.DefaultLoopTimeout, "", tc.mode)
+
+			clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+				AzureClients: scope.AzureClients{
+					Authorizer: autorest.NullAuthorizer{},
+				},
+				Client:       client,
+				Cluster:      cluster,
+				AzureCluster: azureCluster,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				ClusterScope: clusterScope,
+				Machine:      machine,
+				AzureMachine: azureMachine,
+				Cache:        &scope.MachineCache{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			bootDiagnosticsMock := mock_bootdiagnostics.NewMockClient(mockCtrl)
+			tc.expect(bootDiagnosticsMock.EXPECT())
+
+			reconciler.getBootDiagnosticsClient = func(azure.Authorizer) (bootdiagnostics.Client, error) {
+				return bootDiagnosticsMock, nil
+			}
+
+			g.Expect(reconciler.captureSerialConsoleLog(context.TODO(), machineScope)).To(Succeed())
+
+			if !tc.expectCapture {
+				g.Expect(azureMachine.Status.BootDiagnostics).To(BeNil())
+				return
+			}
+			g.Expect(azureMachine.Status.BootDiagnostics).NotTo(BeNil())
+			if !tc.expectLogRef {
+				g.Expect(azureMachine.Status.BootDiagnostics.SerialConsoleLogRef).To(BeNil())
+				return
+			}
+			g.Expect(azureMachine.Status.BootDiagnostics.SerialConsoleLogRef).NotTo(BeNil())
+			configMap := &corev1.ConfigMap{}
+			g.Expect(client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: azureMachine.Status.BootDiagnostics.SerialConsoleLogRef.Name}, configMap)).To(Succeed())
+			g.Expect(configMap.Data).To(HaveKeyWithValue("serial-console.log", "cloud-init failed\n"))
+		})
+	}
+}
+
 func conditionsMatch(i, j clusterv1.Condition) bool {
 	return i.Type == j.Type &&
 		i.Status == j.Status &&
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomega"
+)
+
+func TestReconcileNodeCapacity(t *testing.T) {
+	skuCache := resourceskus.NewStaticCache([]compute.ResourceSku{
+		{
+			Name: to.StringPtr("Standard_D2pls_v5"),
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: to.StringPtr(resourceskus.VCPUs), Value: to.StringPtr("2")},
+				{Name: to.StringPtr(resourceskus.MemoryGB), Value: to.StringPtr("4")},
+				{Name: to.StringPtr(resourceskus.CPUArchitectureType), Value: to.StringPtr("Arm64")},
+			},
+		},
+	}, "test-location")
+
+	cases := map[string]struct {
+		vmSize           string
+		annotations      map[string]string
+		expectedCapacity corev1.ResourceList
+		expectedNodeInfo *infrav1.NodeInfo
+		expectedEvent    string
+		expectedError    string
+	}{
+		"should set the capacity and node info of a known VM size": {
+			vmSize: "Standard_D2pls_v5",
+			expectedCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("2"),
+				corev1.ResourceMemory:           resource.MustParse("4Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("64Gi"),
+				corev1.ResourcePods:             resource.MustParse("110"),
+			},
+			expectedNodeInfo: &infrav1.NodeInfo{
+				Architecture:    "arm64",
+				OperatingSystem: "linux",
+				Labels:          map[string]string{corev1.LabelInstanceTypeStable: "Standard_D2pls_v5"},
+			},
+		},
+		"should set the maximum number of pods, labels and taints of the kubelet from the annotations": {
+			vmSize: "Standard_D2pls_v5",
+			annotations: map[string]string{
+				azure.MaxPodsAnnotation:    "30",
+				azure.NodeLabelsAnnotation: "workload=batch, tier=",
+				azure.NodeTaintsAnnotation: "dedicated=batch:NoSchedule,spot:PreferNoSchedule",
+			},
+			expectedCapacity: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("2"),
+				corev1.ResourceMemory:           resource.MustParse("4Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("64Gi"),
+				corev1.ResourcePods:             resource.MustParse("30"),
+			},
+			expectedNodeInfo: &infrav1.NodeInfo{
+				Architecture:    "arm64",
+				OperatingSystem: "linux",
+				Labels: map[string]string{
+					corev1.LabelInstanceTypeStable: "Standard_D2pls_v5",
+					"workload":                     "batch",
+					"tier":                         "",
+				},
+				Taints: []corev1.Taint{
+					{Key: "dedicated", Value: "batch", Effect: corev1.TaintEffectNoSchedule},
+					{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+				},
+			},
+		},
+		"should not publish the capacity with an invalid maximum number of pods": {
+			vmSize:        "Standard_D2pls_v5",
+			annotations:   map[string]string{azure.MaxPodsAnnotation: "lots"},
+			expectedEvent: "invalid sigs.k8s.io/cluster-api-provider-azure-max-pods annotation 'lots'",
+		},
+		"should not publish the capacity with an invalid label": {
+			vmSize:        "Standard_D2pls_v5",
+			annotations:   map[string]string{azure.NodeLabelsAnnotation: "workload"},
+			expectedEvent: "invalid label 'workload'",
+		},
+		"should not publish the capacity with a taint without effect": {
+			vmSize:        "Standard_D2pls_v5",
+			annotations:   map[string]string{azure.NodeTaintsAnnotation: "dedicated=batch"},
+			expectedEvent: "invalid taint 'dedicated=batch'",
+		},
+		"should not publish the capacity with a taint with an unsupported effect": {
+			vmSize:        "Standard_D2pls_v5",
+			annotations:   map[string]string{azure.NodeTaintsAnnotation: "dedicated=batch:Never"},
+			expectedEvent: "unsupported effect 'Never'",
+		},
+		"should fail with an unknown VM size": {
+			vmSize:        "Standard_Unknown",
+			expectedError: "failed to get SKU Standard_Unknown in compute api",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			azureMachineTemplate := &infrav1.AzureMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+				Spec: infrav1.AzureMachineTemplateSpec{
+					Template: infrav1.AzureMachineTemplateResource{
+						Spec: infrav1.AzureMachineSpec{
+							VMSize: tc.vmSize,
+							OSDisk: infrav1.OSDisk{
+								OSType:     "Linux",
+								DiskSizeGB: to.Int32Ptr(64),
+							},
+						},
+					},
+				},
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			err := reconcileNodeCapacity(context.TODO(), recorder, azureMachineTemplate, skuCache)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expectedEvent != "" {
+				g.Expect(recorder.Events).To(Receive(ContainSubstring(tc.expectedEvent)))
+			} else {
+				g.Expect(recorder.Events).NotTo(Receive())
+			}
+			g.Expect(azureMachineTemplate.Status.Capacity).To(gomega.DiffEq(tc.expectedCapacity))
+			g.Expect(azureMachineTemplate.Status.NodeInfo).To(Equal(tc.expectedNodeInfo))
+		})
+	}
+}
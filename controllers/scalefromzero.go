@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+)
+
+// ScaleFromZeroNodeCapacity returns the capacity and node info the cluster autoscaler uses to scale a group of machines
+// of a VM size from zero. The kubelet configuration comes from the bootstrap provider, which CAPZ does not know, so
+// the maximum number of pods, the labels and the taints of the nodes are taken from the annotations of obj.
+func ScaleFromZeroNodeCapacity(obj metav1.Object, sku resourceskus.SKU, vmSize string, osDisk infrav1.OSDisk) (corev1.ResourceList, *infrav1.NodeInfo, error) {
+	maxPods, labels, taints, err := parseNodeAnnotations(obj.GetAnnotations())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	capacity, err := sku.NodeCapacity(osDisk.DiskSizeGB, maxPods)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodeInfo := sku.NodeInfo(osDisk.OSType)
+	nodeInfo.Labels = map[string]string{
+		corev1.LabelInstanceTypeStable: vmSize,
+	}
+	for key, value := range labels {
+		nodeInfo.Labels[key] = value
+	}
+	nodeInfo.Taints = taints
+
+	return capacity, nodeInfo, nil
+}
+
+// ValidateNodeAnnotations returns an error if the maximum number of pods, the labels or the taints of the nodes in the
+// annotations of obj are malformed.
+func ValidateNodeAnnotations(obj metav1.Object) error {
+	_, _, _, err := parseNodeAnnotations(obj.GetAnnotations())
+	return err
+}
+
+// parseNodeAnnotations parses the maximum number of pods, the labels and the taints of the nodes in annotations.
+func parseNodeAnnotations(annotations map[string]string) (int64, map[string]string, []corev1.Taint, error) {
+	maxPods := int64(resourceskus.DefaultMaxPodsPerNode)
+	if value, ok := annotations[azure.MaxPodsAnnotation]; ok {
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || parsed <= 0 {
+			return 0, nil, nil, errors.Errorf("invalid %s annotation '%s', must be a positive integer", azure.MaxPodsAnnotation, value)
+		}
+		maxPods = parsed
+	}
+
+	labels := map[string]string{}
+	for _, label := range splitAnnotation(annotations[azure.NodeLabelsAnnotation]) {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			return 0, nil, nil, errors.Errorf("invalid label '%s' in %s annotation, must be key=value", label, azure.NodeLabelsAnnotation)
+		}
+		labels[key] = value
+	}
+
+	var taints []corev1.Taint
+	for _, taint := range splitAnnotation(annotations[azure.NodeTaintsAnnotation]) {
+		parsed, err := parseTaint(taint)
+		if err != nil {
+			return 0, nil, nil, errors.Wrapf(err, "invalid taint '%s' in %s annotation", taint, azure.NodeTaintsAnnotation)
+		}
+		taints = append(taints, parsed)
+	}
+
+	return maxPods, labels, taints, nil
+}
+
+// splitAnnotation returns the non-empty comma separated values of an annotation.
+func splitAnnotation(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseTaint parses a taint in the key=value:Effect or key:Effect format of the --register-with-taints flag of the
+// kubelet.
+func parseTaint(taint string) (corev1.Taint, error) {
+	i := strings.LastIndex(taint, ":")
+	if i < 0 {
+		return corev1.Taint{}, errors.New("must be key=value:Effect or key:Effect")
+	}
+
+	key, value, _ := strings.Cut(taint[:i], "=")
+	if key == "" {
+		return corev1.Taint{}, errors.New("the key must not be empty")
+	}
+
+	effect := corev1.TaintEffect(taint[i+1:])
+	switch effect {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return corev1.Taint{}, errors.Errorf("unsupported effect '%s'", effect)
+	}
+
+	return corev1.Taint{Key: key, Value: value, Effect: effect}, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootdiagnostics"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// SerialConsoleLogCapture defines where the tail of the serial console log of a VM is captured when bootstrapping fails.
+type SerialConsoleLogCapture string
+
+const (
+	// SerialConsoleLogCaptureNone disables the capture of the serial console log.
+	SerialConsoleLogCaptureNone SerialConsoleLogCapture = "None"
+	// SerialConsoleLogCaptureEvent attaches the tail of the serial console log to an event on the machine.
+	SerialConsoleLogCaptureEvent SerialConsoleLogCapture = "Event"
+	// SerialConsoleLogCaptureConfigMap stores the tail of the serial console log in a ConfigMap referenced by the machine status.
+	SerialConsoleLogCaptureConfigMap SerialConsoleLogCapture = "ConfigMap"
+)
+
+const (
+	// serialConsoleLogEventMaxBytes is the maximum size of the serial console log attached to an event.
+	serialConsoleLogEventMaxBytes = 1024
+	// serialConsoleLogConfigMapMaxBytes is the maximum size of the serial console log stored in a ConfigMap.
+	serialConsoleLogConfigMapMaxBytes = 64 * 1024
+	// serialConsoleLogConfigMapKey is the key of the serial console log in the ConfigMap.
+	serialConsoleLogConfigMapKey = "serial-console.log"
+)
+
+// BootDiagnosticsClientGetter returns a boot diagnostics client for the given Authorizer.
+type BootDiagnosticsClientGetter func(auth azure.Authorizer) (bootdiagnostics.Client, error)
+
+// GetBootDiagnosticsClient returns the boot diagnostics client cached for the given Authorizer.
+func GetBootDiagnosticsClient(auth azure.Authorizer) (bootdiagnostics.Client, error) {
+	return bootdiagnostics.GetClient(auth)
+}
+
+// Enabled returns true when the serial console log is captured.
+func (c SerialConsoleLogCapture) Enabled() bool {
+	return c != "" && c != SerialConsoleLogCaptureNone
+}
+
+// MaxBytes returns the maximum size of the serial console log captured in this mode.
+func (c SerialConsoleLogCapture) MaxBytes() int {
+	if c == SerialConsoleLogCaptureConfigMap {
+		return serialConsoleLogConfigMapMaxBytes
+	}
+	return serialConsoleLogEventMaxBytes
+}
+
+// RecordSerialConsoleLog attaches the tail of the serial console log of a VM to a warning event on obj, or stores it in a
+// ConfigMap owned by obj, depending on the capture mode. It returns the status describing the captured log.
+func RecordSerialConsoleLog(ctx context.Context, c client.Client, recorder record.EventRecorder, capture SerialConsoleLogCapture, obj client.Object, clusterName, summary, serialConsoleLog string) (*infrav1.BootDiagnosticsStatus, error) {
+	status := &infrav1.BootDiagnosticsStatus{CaptureTime: metav1.Now()}
+	switch capture {
+	case SerialConsoleLogCaptureEvent:
+		recorder.Eventf(obj, corev1.EventTypeWarning, infrav1.BootstrapFailedReason, "%s, serial console log tail:\n%s", summary, serialConsoleLog)
+	case SerialConsoleLogCaptureConfigMap:
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-serial-console-log", obj.GetName()),
+				Namespace: obj.GetNamespace(),
+			},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, c, configMap, func() error {
+			configMap.Labels = map[string]string{clusterv1.ClusterLabelName: clusterName}
+			configMap.Data = map[string]string{serialConsoleLogConfigMapKey: serialConsoleLog}
+			return controllerutil.SetOwnerReference(obj, configMap, c.Scheme())
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to store the serial console log in ConfigMap %s", configMap.Name)
+		}
+		status.SerialConsoleLogRef = &corev1.LocalObjectReference{Name: configMap.Name}
+		recorder.Eventf(obj, corev1.EventTypeWarning, infrav1.BootstrapFailedReason, "%s, serial console log tail stored in ConfigMap %s", summary, configMap.Name)
+	}
+	return status, nil
+}
@@ -55,6 +55,8 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		}
 
 		dst.Spec.Strategy.RollingUpdate.DeletePolicy = restored.Spec.Strategy.RollingUpdate.DeletePolicy
+		dst.Spec.Strategy.RollingUpdate.BalanceZones = restored.Spec.Strategy.RollingUpdate.BalanceZones
+		dst.Spec.Strategy.RollingUpdate.HealthCheck = restored.Spec.Strategy.RollingUpdate.HealthCheck
 	}
 
 	if restored.Spec.NodeDrainTimeout != nil {
@@ -75,6 +77,33 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Image.ComputeGallery = restored.Spec.Template.Image.ComputeGallery
 	}
 
+	if restored.Spec.Template.DedicatedHost != nil {
+		dst.Spec.Template.DedicatedHost = restored.Spec.Template.DedicatedHost
+	}
+
+	if restored.Spec.Template.Diagnostics != nil {
+		dst.Spec.Template.Diagnostics = restored.Spec.Template.Diagnostics
+	}
+
+	if restored.Spec.Template.SpotVMOptions != nil && dst.Spec.Template.SpotVMOptions != nil {
+		dst.Spec.Template.SpotVMOptions.EvictionPolicy = restored.Spec.Template.SpotVMOptions.EvictionPolicy
+	}
+
+	dst.Spec.SpotFallbackPolicy = restored.Spec.SpotFallbackPolicy
+	dst.Spec.OrchestrationMode = restored.Spec.OrchestrationMode
+	dst.Spec.RepairPolicy = restored.Spec.RepairPolicy
+	dst.Spec.ZoneBalance = restored.Spec.ZoneBalance
+	dst.Spec.NodeDrainOptions = restored.Spec.NodeDrainOptions
+	dst.Spec.Strategy.Canary = restored.Spec.Strategy.Canary
+	dst.Spec.Strategy.BlueGreen = restored.Spec.Strategy.BlueGreen
+	dst.Status.SpotFallbackActive = restored.Status.SpotFallbackActive
+	dst.Status.Deployment = restored.Status.Deployment
+	dst.Status.Zones = restored.Status.Zones
+	dst.Spec.Template.FallbackVMSizes = restored.Spec.Template.FallbackVMSizes
+	dst.Status.VMSizeFallback = restored.Status.VMSizeFallback
+	dst.Status.Capacity = restored.Status.Capacity
+	dst.Status.NodeInfo = restored.Status.NodeInfo
+
 	if len(dst.Annotations) == 0 {
 		dst.Annotations = nil
 	}
@@ -41,9 +41,31 @@ func (src *AzureManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.LoadBalancerProfile = restored.Spec.LoadBalancerProfile
 	dst.Spec.APIServerAccessProfile = restored.Spec.APIServerAccessProfile
 	dst.Spec.AddonProfiles = restored.Spec.AddonProfiles
+	dst.Spec.AutoScalerProfile = restored.Spec.AutoScalerProfile
+	dst.Spec.AutoUpgradeProfile = restored.Spec.AutoUpgradeProfile
+	dst.Spec.MaintenanceConfigurations = restored.Spec.MaintenanceConfigurations
+	dst.Spec.OIDCIssuerProfile = restored.Spec.OIDCIssuerProfile
+	dst.Spec.WorkloadIdentityProfile = restored.Spec.WorkloadIdentityProfile
+	dst.Spec.OutboundType = restored.Spec.OutboundType
+	dst.Spec.NatGatewayProfile = restored.Spec.NatGatewayProfile
+	dst.Spec.VirtualNetwork.Subnet.RouteTable = restored.Spec.VirtualNetwork.Subnet.RouteTable
+	dst.Spec.PodCIDR = restored.Spec.PodCIDR
+	dst.Spec.ServiceCIDR = restored.Spec.ServiceCIDR
+	dst.Spec.VirtualNetwork.ResourceGroup = restored.Spec.VirtualNetwork.ResourceGroup
+	dst.Spec.VirtualNetwork.AllowSubnetCreation = restored.Spec.VirtualNetwork.AllowSubnetCreation
+	dst.Spec.Identity = restored.Spec.Identity
+	dst.Spec.DisableLocalAccounts = restored.Spec.DisableLocalAccounts
+	dst.Spec.Kubeconfig = restored.Spec.Kubeconfig
+	dst.Spec.UpgradeOrchestration = restored.Spec.UpgradeOrchestration
+	dst.Spec.Addons = restored.Spec.Addons
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Status.OIDCIssuerProfile = restored.Status.OIDCIssuerProfile
+	dst.Status.AgentPoolUpgrades = restored.Status.AgentPoolUpgrades
+	dst.Status.Addons = restored.Status.Addons
+	dst.Status.MaintenanceConfigurations = restored.Status.MaintenanceConfigurations
+	dst.Status.RoleAssignments = restored.Status.RoleAssignments
 
 	return nil
 }
@@ -70,6 +92,16 @@ func Convert_v1beta1_AzureManagedControlPlaneStatus_To_v1alpha3_AzureManagedCont
 	return autoConvert_v1beta1_AzureManagedControlPlaneStatus_To_v1alpha3_AzureManagedControlPlaneStatus(in, out, s)
 }
 
+// Convert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedControlPlaneVirtualNetwork is an autogenerated conversion function.
+func Convert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedControlPlaneVirtualNetwork(in *infrav1exp.ManagedControlPlaneVirtualNetwork, out *ManagedControlPlaneVirtualNetwork, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedControlPlaneVirtualNetwork(in, out, s)
+}
+
+// Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet is an autogenerated conversion function.
+func Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(in *infrav1exp.ManagedControlPlaneSubnet, out *ManagedControlPlaneSubnet, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(in, out, s)
+}
+
 // ConvertTo converts this AzureManagedControlPlane to the Hub version (v1beta1).
 func (src *AzureManagedControlPlaneList) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1exp.AzureManagedControlPlaneList)
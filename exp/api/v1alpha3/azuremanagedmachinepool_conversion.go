@@ -45,6 +45,17 @@ func (src *AzureManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.OSType = restored.Spec.OSType
 	dst.Spec.NodeLabels = restored.Spec.NodeLabels
 	dst.Spec.EnableUltraSSD = restored.Spec.EnableUltraSSD
+	dst.Spec.OSSKU = restored.Spec.OSSKU
+	dst.Spec.EnableNodePublicIP = restored.Spec.EnableNodePublicIP
+	dst.Spec.NodePublicIPPrefixID = restored.Spec.NodePublicIPPrefixID
+	dst.Spec.ScaleSetPriority = restored.Spec.ScaleSetPriority
+	dst.Spec.ScaleDownMode = restored.Spec.ScaleDownMode
+	dst.Spec.UpgradeSettings = restored.Spec.UpgradeSettings
+	dst.Spec.KubeletConfig = restored.Spec.KubeletConfig
+	dst.Spec.LinuxOSConfig = restored.Spec.LinuxOSConfig
+	dst.Spec.PodSubnetID = restored.Spec.PodSubnetID
+	dst.Spec.PodSubnet = restored.Spec.PodSubnet
+	dst.Spec.Subnet = restored.Spec.Subnet
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
 	dst.Status.Conditions = restored.Status.Conditions
@@ -196,21 +196,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.ManagedControlPlaneSubnet)(nil), (*ManagedControlPlaneSubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(a.(*v1beta1.ManagedControlPlaneSubnet), b.(*ManagedControlPlaneSubnet), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ManagedControlPlaneVirtualNetwork)(nil), (*v1beta1.ManagedControlPlaneVirtualNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ManagedControlPlaneVirtualNetwork_To_v1beta1_ManagedControlPlaneVirtualNetwork(a.(*ManagedControlPlaneVirtualNetwork), b.(*v1beta1.ManagedControlPlaneVirtualNetwork), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.ManagedControlPlaneVirtualNetwork)(nil), (*ManagedControlPlaneVirtualNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedControlPlaneVirtualNetwork(a.(*v1beta1.ManagedControlPlaneVirtualNetwork), b.(*ManagedControlPlaneVirtualNetwork), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddConversionFunc((*apiv1alpha3.APIEndpoint)(nil), (*apiv1beta1.APIEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_APIEndpoint_To_v1beta1_APIEndpoint(a.(*apiv1alpha3.APIEndpoint), b.(*apiv1beta1.APIEndpoint), scope)
 	}); err != nil {
@@ -231,6 +221,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1alpha3.SpotVMOptions)(nil), (*clusterapiproviderazureapiv1beta1.SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(a.(*clusterapiproviderazureapiv1alpha3.SpotVMOptions), b.(*clusterapiproviderazureapiv1beta1.SpotVMOptions), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*apiv1beta1.APIEndpoint)(nil), (*apiv1alpha3.APIEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_APIEndpoint_To_v1alpha3_APIEndpoint(a.(*apiv1beta1.APIEndpoint), b.(*apiv1alpha3.APIEndpoint), scope)
 	}); err != nil {
@@ -276,11 +271,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.ManagedControlPlaneSubnet)(nil), (*ManagedControlPlaneSubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(a.(*v1beta1.ManagedControlPlaneSubnet), b.(*ManagedControlPlaneSubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.ManagedControlPlaneVirtualNetwork)(nil), (*ManagedControlPlaneVirtualNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedControlPlaneVirtualNetwork(a.(*v1beta1.ManagedControlPlaneVirtualNetwork), b.(*ManagedControlPlaneVirtualNetwork), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1beta1.OSDisk)(nil), (*clusterapiproviderazureapiv1alpha3.OSDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_OSDisk_To_v1alpha3_OSDisk(a.(*clusterapiproviderazureapiv1beta1.OSDisk), b.(*clusterapiproviderazureapiv1alpha3.OSDisk), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*clusterapiproviderazureapiv1beta1.SpotVMOptions)(nil), (*clusterapiproviderazureapiv1alpha3.SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(a.(*clusterapiproviderazureapiv1beta1.SpotVMOptions), b.(*clusterapiproviderazureapiv1alpha3.SpotVMOptions), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -429,7 +439,15 @@ func autoConvert_v1alpha3_AzureMachinePoolMachineTemplate_To_v1beta1_AzureMachin
 	out.AcceleratedNetworking = (*bool)(unsafe.Pointer(in.AcceleratedNetworking))
 	out.TerminateNotificationTimeout = (*int)(unsafe.Pointer(in.TerminateNotificationTimeout))
 	out.SecurityProfile = (*clusterapiproviderazureapiv1beta1.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
-	out.SpotVMOptions = (*clusterapiproviderazureapiv1beta1.SpotVMOptions)(unsafe.Pointer(in.SpotVMOptions))
+	if in.SpotVMOptions != nil {
+		in, out := &in.SpotVMOptions, &out.SpotVMOptions
+		*out = new(clusterapiproviderazureapiv1beta1.SpotVMOptions)
+		if err := Convert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SpotVMOptions = nil
+	}
 	return nil
 }
 
@@ -440,6 +458,7 @@ func Convert_v1alpha3_AzureMachinePoolMachineTemplate_To_v1beta1_AzureMachinePoo
 
 func autoConvert_v1beta1_AzureMachinePoolMachineTemplate_To_v1alpha3_AzureMachinePoolMachineTemplate(in *v1beta1.AzureMachinePoolMachineTemplate, out *AzureMachinePoolMachineTemplate, s conversion.Scope) error {
 	out.VMSize = in.VMSize
+	// WARNING: in.FallbackVMSizes requires manual conversion: does not exist in peer-type
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(clusterapiproviderazureapiv1alpha3.Image)
@@ -457,7 +476,17 @@ func autoConvert_v1beta1_AzureMachinePoolMachineTemplate_To_v1alpha3_AzureMachin
 	out.AcceleratedNetworking = (*bool)(unsafe.Pointer(in.AcceleratedNetworking))
 	out.TerminateNotificationTimeout = (*int)(unsafe.Pointer(in.TerminateNotificationTimeout))
 	out.SecurityProfile = (*clusterapiproviderazureapiv1alpha3.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
-	out.SpotVMOptions = (*clusterapiproviderazureapiv1alpha3.SpotVMOptions)(unsafe.Pointer(in.SpotVMOptions))
+	if in.SpotVMOptions != nil {
+		in, out := &in.SpotVMOptions, &out.SpotVMOptions
+		*out = new(clusterapiproviderazureapiv1alpha3.SpotVMOptions)
+		if err := Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SpotVMOptions = nil
+	}
+	// WARNING: in.DedicatedHost requires manual conversion: does not exist in peer-type
+	// WARNING: in.Diagnostics requires manual conversion: does not exist in peer-type
 	// WARNING: in.SubnetName requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -494,6 +523,11 @@ func autoConvert_v1beta1_AzureMachinePoolSpec_To_v1alpha3_AzureMachinePoolSpec(i
 	out.RoleAssignmentName = in.RoleAssignmentName
 	// WARNING: in.Strategy requires manual conversion: does not exist in peer-type
 	// WARNING: in.NodeDrainTimeout requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeDrainOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.SpotFallbackPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.OrchestrationMode requires manual conversion: does not exist in peer-type
+	// WARNING: in.ZoneBalance requires manual conversion: does not exist in peer-type
+	// WARNING: in.RepairPolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -514,9 +548,15 @@ func autoConvert_v1beta1_AzureMachinePoolStatus_To_v1alpha3_AzureMachinePoolStat
 	out.Ready = in.Ready
 	out.Replicas = in.Replicas
 	out.Instances = *(*[]*AzureMachinePoolInstanceStatus)(unsafe.Pointer(&in.Instances))
+	// WARNING: in.Zones requires manual conversion: does not exist in peer-type
+	// WARNING: in.Capacity requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeInfo requires manual conversion: does not exist in peer-type
 	// WARNING: in.Image requires manual conversion: does not exist in peer-type
 	out.Version = in.Version
 	out.ProvisioningState = (*clusterapiproviderazureapiv1alpha3.VMState)(unsafe.Pointer(in.ProvisioningState))
+	// WARNING: in.Deployment requires manual conversion: does not exist in peer-type
+	// WARNING: in.VMSizeFallback requires manual conversion: does not exist in peer-type
+	// WARNING: in.SpotFallbackActive requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	out.Conditions = *(*apiv1alpha3.Conditions)(unsafe.Pointer(&in.Conditions))
@@ -760,13 +800,27 @@ func autoConvert_v1beta1_AzureManagedControlPlaneSpec_To_v1alpha3_AzureManagedCo
 	out.NetworkPolicy = (*string)(unsafe.Pointer(in.NetworkPolicy))
 	out.SSHPublicKey = in.SSHPublicKey
 	out.DNSServiceIP = (*string)(unsafe.Pointer(in.DNSServiceIP))
+	// WARNING: in.PodCIDR requires manual conversion: does not exist in peer-type
+	// WARNING: in.ServiceCIDR requires manual conversion: does not exist in peer-type
 	out.LoadBalancerSKU = (*string)(unsafe.Pointer(in.LoadBalancerSKU))
 	// WARNING: in.IdentityRef requires manual conversion: does not exist in peer-type
 	out.AADProfile = (*AADProfile)(unsafe.Pointer(in.AADProfile))
 	// WARNING: in.AddonProfiles requires manual conversion: does not exist in peer-type
+	// WARNING: in.Addons requires manual conversion: does not exist in peer-type
 	// WARNING: in.SKU requires manual conversion: does not exist in peer-type
 	// WARNING: in.LoadBalancerProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.OutboundType requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayProfile requires manual conversion: does not exist in peer-type
 	// WARNING: in.APIServerAccessProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoScalerProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoUpgradeProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaintenanceConfigurations requires manual conversion: does not exist in peer-type
+	// WARNING: in.OIDCIssuerProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.WorkloadIdentityProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.Identity requires manual conversion: does not exist in peer-type
+	// WARNING: in.DisableLocalAccounts requires manual conversion: does not exist in peer-type
+	// WARNING: in.Kubeconfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpgradeOrchestration requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -786,6 +840,11 @@ func autoConvert_v1beta1_AzureManagedControlPlaneStatus_To_v1alpha3_AzureManaged
 	out.Initialized = in.Initialized
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.OIDCIssuerProfile requires manual conversion: does not exist in peer-type
+	// WARNING: in.AgentPoolUpgrades requires manual conversion: does not exist in peer-type
+	// WARNING: in.Addons requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaintenanceConfigurations requires manual conversion: does not exist in peer-type
+	// WARNING: in.RoleAssignments requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -890,6 +949,17 @@ func autoConvert_v1beta1_AzureManagedMachinePoolSpec_To_v1alpha3_AzureManagedMac
 	// WARNING: in.OsDiskType requires manual conversion: does not exist in peer-type
 	// WARNING: in.EnableUltraSSD requires manual conversion: does not exist in peer-type
 	// WARNING: in.OSType requires manual conversion: does not exist in peer-type
+	// WARNING: in.OSSKU requires manual conversion: does not exist in peer-type
+	// WARNING: in.EnableNodePublicIP requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodePublicIPPrefixID requires manual conversion: does not exist in peer-type
+	// WARNING: in.ScaleSetPriority requires manual conversion: does not exist in peer-type
+	// WARNING: in.ScaleDownMode requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpgradeSettings requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeletConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.LinuxOSConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnetID requires manual conversion: does not exist in peer-type
+	// WARNING: in.Subnet requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnet requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -930,14 +1000,10 @@ func Convert_v1alpha3_ManagedControlPlaneSubnet_To_v1beta1_ManagedControlPlaneSu
 func autoConvert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(in *v1beta1.ManagedControlPlaneSubnet, out *ManagedControlPlaneSubnet, s conversion.Scope) error {
 	out.Name = in.Name
 	out.CIDRBlock = in.CIDRBlock
+	// WARNING: in.RouteTable requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet is an autogenerated conversion function.
-func Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(in *v1beta1.ManagedControlPlaneSubnet, out *ManagedControlPlaneSubnet, s conversion.Scope) error {
-	return autoConvert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(in, out, s)
-}
-
 func autoConvert_v1alpha3_ManagedControlPlaneVirtualNetwork_To_v1beta1_ManagedControlPlaneVirtualNetwork(in *ManagedControlPlaneVirtualNetwork, out *v1beta1.ManagedControlPlaneVirtualNetwork, s conversion.Scope) error {
 	out.Name = in.Name
 	out.CIDRBlock = in.CIDRBlock
@@ -958,10 +1024,7 @@ func autoConvert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedCo
 	if err := Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha3_ManagedControlPlaneSubnet(&in.Subnet, &out.Subnet, s); err != nil {
 		return err
 	}
+	// WARNING: in.ResourceGroup requires manual conversion: does not exist in peer-type
+	// WARNING: in.AllowSubnetCreation requires manual conversion: does not exist in peer-type
 	return nil
 }
-
-// Convert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedControlPlaneVirtualNetwork is an autogenerated conversion function.
-func Convert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedControlPlaneVirtualNetwork(in *v1beta1.ManagedControlPlaneVirtualNetwork, out *ManagedControlPlaneVirtualNetwork, s conversion.Scope) error {
-	return autoConvert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha3_ManagedControlPlaneVirtualNetwork(in, out, s)
-}
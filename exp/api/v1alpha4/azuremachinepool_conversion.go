@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha4
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
@@ -43,6 +44,37 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.Image.ComputeGallery = restored.Status.Image.ComputeGallery
 	}
 
+	if restored.Spec.Template.DedicatedHost != nil {
+		dst.Spec.Template.DedicatedHost = restored.Spec.Template.DedicatedHost
+	}
+
+	if restored.Spec.Template.Diagnostics != nil {
+		dst.Spec.Template.Diagnostics = restored.Spec.Template.Diagnostics
+	}
+
+	if restored.Spec.Template.SpotVMOptions != nil && dst.Spec.Template.SpotVMOptions != nil {
+		dst.Spec.Template.SpotVMOptions.EvictionPolicy = restored.Spec.Template.SpotVMOptions.EvictionPolicy
+	}
+
+	dst.Spec.SpotFallbackPolicy = restored.Spec.SpotFallbackPolicy
+	dst.Spec.OrchestrationMode = restored.Spec.OrchestrationMode
+	dst.Spec.RepairPolicy = restored.Spec.RepairPolicy
+	dst.Spec.ZoneBalance = restored.Spec.ZoneBalance
+	dst.Spec.NodeDrainOptions = restored.Spec.NodeDrainOptions
+	dst.Spec.Strategy.Canary = restored.Spec.Strategy.Canary
+	dst.Spec.Strategy.BlueGreen = restored.Spec.Strategy.BlueGreen
+	if restored.Spec.Strategy.RollingUpdate != nil && dst.Spec.Strategy.RollingUpdate != nil {
+		dst.Spec.Strategy.RollingUpdate.BalanceZones = restored.Spec.Strategy.RollingUpdate.BalanceZones
+		dst.Spec.Strategy.RollingUpdate.HealthCheck = restored.Spec.Strategy.RollingUpdate.HealthCheck
+	}
+	dst.Status.SpotFallbackActive = restored.Status.SpotFallbackActive
+	dst.Status.Deployment = restored.Status.Deployment
+	dst.Status.Zones = restored.Status.Zones
+	dst.Spec.Template.FallbackVMSizes = restored.Spec.Template.FallbackVMSizes
+	dst.Status.VMSizeFallback = restored.Status.VMSizeFallback
+	dst.Status.Capacity = restored.Status.Capacity
+	dst.Status.NodeInfo = restored.Status.NodeInfo
+
 	return nil
 }
 
@@ -68,3 +100,28 @@ func (dst *AzureMachinePoolList) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1exp.AzureMachinePoolList)
 	return Convert_v1beta1_AzureMachinePoolList_To_v1alpha4_AzureMachinePoolList(src, dst, nil)
 }
+
+// Convert_v1beta1_AzureMachinePoolMachineTemplate_To_v1alpha4_AzureMachinePoolMachineTemplate converts an Azure Machine Pool Machine Template from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureMachinePoolMachineTemplate_To_v1alpha4_AzureMachinePoolMachineTemplate(in *infrav1exp.AzureMachinePoolMachineTemplate, out *AzureMachinePoolMachineTemplate, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachinePoolMachineTemplate_To_v1alpha4_AzureMachinePoolMachineTemplate(in, out, s)
+}
+
+// Convert_v1beta1_AzureMachinePoolSpec_To_v1alpha4_AzureMachinePoolSpec converts an AzureMachinePoolSpec from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureMachinePoolSpec_To_v1alpha4_AzureMachinePoolSpec(in *infrav1exp.AzureMachinePoolSpec, out *AzureMachinePoolSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachinePoolSpec_To_v1alpha4_AzureMachinePoolSpec(in, out, s)
+}
+
+// Convert_v1beta1_AzureMachinePoolDeploymentStrategy_To_v1alpha4_AzureMachinePoolDeploymentStrategy converts an AzureMachinePoolDeploymentStrategy from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureMachinePoolDeploymentStrategy_To_v1alpha4_AzureMachinePoolDeploymentStrategy(in *infrav1exp.AzureMachinePoolDeploymentStrategy, out *AzureMachinePoolDeploymentStrategy, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachinePoolDeploymentStrategy_To_v1alpha4_AzureMachinePoolDeploymentStrategy(in, out, s)
+}
+
+// Convert_v1beta1_MachineRollingUpdateDeployment_To_v1alpha4_MachineRollingUpdateDeployment converts a MachineRollingUpdateDeployment from v1beta1 to v1alpha4.
+func Convert_v1beta1_MachineRollingUpdateDeployment_To_v1alpha4_MachineRollingUpdateDeployment(in *infrav1exp.MachineRollingUpdateDeployment, out *MachineRollingUpdateDeployment, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_MachineRollingUpdateDeployment_To_v1alpha4_MachineRollingUpdateDeployment(in, out, s)
+}
+
+// Convert_v1beta1_AzureMachinePoolStatus_To_v1alpha4_AzureMachinePoolStatus converts an AzureMachinePoolStatus from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureMachinePoolStatus_To_v1alpha4_AzureMachinePoolStatus(in *infrav1exp.AzureMachinePoolStatus, out *AzureMachinePoolStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachinePoolStatus_To_v1alpha4_AzureMachinePoolStatus(in, out, s)
+}
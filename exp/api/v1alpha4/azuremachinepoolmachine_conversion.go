@@ -17,20 +17,40 @@ limitations under the License.
 package v1alpha4
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
 // ConvertTo converts this AzureMachinePoolMachine to the Hub version (v1beta1).
 func (src *AzureMachinePoolMachine) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1exp.AzureMachinePoolMachine)
-	return Convert_v1alpha4_AzureMachinePoolMachine_To_v1beta1_AzureMachinePoolMachine(src, dst, nil)
+	if err := Convert_v1alpha4_AzureMachinePoolMachine_To_v1beta1_AzureMachinePoolMachine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &infrav1exp.AzureMachinePoolMachine{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.ProtectionPolicy = restored.Spec.ProtectionPolicy
+	dst.Status.AvailabilityZone = restored.Status.AvailabilityZone
+	dst.Status.BootDiagnostics = restored.Status.BootDiagnostics
+	return nil
 }
 
 // ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *AzureMachinePoolMachine) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1exp.AzureMachinePoolMachine)
-	return Convert_v1beta1_AzureMachinePoolMachine_To_v1alpha4_AzureMachinePoolMachine(src, dst, nil)
+	if err := Convert_v1beta1_AzureMachinePoolMachine_To_v1alpha4_AzureMachinePoolMachine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion.
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this AzureMachinePoolMachineList to the Hub version (v1beta1).
@@ -44,3 +64,13 @@ func (dst *AzureMachinePoolMachineList) ConvertFrom(srcRaw conversion.Hub) error
 	src := srcRaw.(*infrav1exp.AzureMachinePoolMachineList)
 	return Convert_v1beta1_AzureMachinePoolMachineList_To_v1alpha4_AzureMachinePoolMachineList(src, dst, nil)
 }
+
+// Convert_v1beta1_AzureMachinePoolMachineStatus_To_v1alpha4_AzureMachinePoolMachineStatus converts an AzureMachinePoolMachineStatus from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureMachinePoolMachineStatus_To_v1alpha4_AzureMachinePoolMachineStatus(in *infrav1exp.AzureMachinePoolMachineStatus, out *AzureMachinePoolMachineStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachinePoolMachineStatus_To_v1alpha4_AzureMachinePoolMachineStatus(in, out, s)
+}
+
+// Convert_v1beta1_AzureMachinePoolMachineSpec_To_v1alpha4_AzureMachinePoolMachineSpec converts an AzureMachinePoolMachineSpec from v1beta1 to v1alpha4.
+func Convert_v1beta1_AzureMachinePoolMachineSpec_To_v1alpha4_AzureMachinePoolMachineSpec(in *infrav1exp.AzureMachinePoolMachineSpec, out *AzureMachinePoolMachineSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachinePoolMachineSpec_To_v1alpha4_AzureMachinePoolMachineSpec(in, out, s)
+}
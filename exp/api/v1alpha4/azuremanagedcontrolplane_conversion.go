@@ -37,7 +37,32 @@ func (src *AzureManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.AddonProfiles = restored.Spec.AddonProfiles
+	dst.Spec.AutoScalerProfile = restored.Spec.AutoScalerProfile
+	dst.Spec.AutoUpgradeProfile = restored.Spec.AutoUpgradeProfile
+	dst.Spec.MaintenanceConfigurations = restored.Spec.MaintenanceConfigurations
+	dst.Spec.OIDCIssuerProfile = restored.Spec.OIDCIssuerProfile
+	dst.Spec.WorkloadIdentityProfile = restored.Spec.WorkloadIdentityProfile
+	dst.Spec.OutboundType = restored.Spec.OutboundType
+	dst.Spec.NatGatewayProfile = restored.Spec.NatGatewayProfile
+	dst.Spec.VirtualNetwork.Subnet.RouteTable = restored.Spec.VirtualNetwork.Subnet.RouteTable
+	dst.Spec.PodCIDR = restored.Spec.PodCIDR
+	dst.Spec.ServiceCIDR = restored.Spec.ServiceCIDR
+	dst.Spec.VirtualNetwork.ResourceGroup = restored.Spec.VirtualNetwork.ResourceGroup
+	dst.Spec.VirtualNetwork.AllowSubnetCreation = restored.Spec.VirtualNetwork.AllowSubnetCreation
+	dst.Spec.Identity = restored.Spec.Identity
+	dst.Spec.DisableLocalAccounts = restored.Spec.DisableLocalAccounts
+	dst.Spec.Kubeconfig = restored.Spec.Kubeconfig
+	dst.Spec.UpgradeOrchestration = restored.Spec.UpgradeOrchestration
+	dst.Spec.Addons = restored.Spec.Addons
+	if restored.Spec.APIServerAccessProfile != nil && dst.Spec.APIServerAccessProfile != nil {
+		dst.Spec.APIServerAccessProfile.ManagedPrivateDNSZone = restored.Spec.APIServerAccessProfile.ManagedPrivateDNSZone
+	}
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Status.OIDCIssuerProfile = restored.Status.OIDCIssuerProfile
+	dst.Status.AgentPoolUpgrades = restored.Status.AgentPoolUpgrades
+	dst.Status.Addons = restored.Status.Addons
+	dst.Status.MaintenanceConfigurations = restored.Status.MaintenanceConfigurations
+	dst.Status.RoleAssignments = restored.Status.RoleAssignments
 
 	return nil
 }
@@ -63,6 +88,21 @@ func Convert_v1beta1_AzureManagedControlPlaneStatus_To_v1alpha4_AzureManagedCont
 	return autoConvert_v1beta1_AzureManagedControlPlaneStatus_To_v1alpha4_AzureManagedControlPlaneStatus(in, out, s)
 }
 
+// Convert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha4_ManagedControlPlaneVirtualNetwork is an autogenerated conversion function.
+func Convert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha4_ManagedControlPlaneVirtualNetwork(in *infrav1exp.ManagedControlPlaneVirtualNetwork, out *ManagedControlPlaneVirtualNetwork, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_ManagedControlPlaneVirtualNetwork_To_v1alpha4_ManagedControlPlaneVirtualNetwork(in, out, s)
+}
+
+// Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha4_ManagedControlPlaneSubnet is an autogenerated conversion function.
+func Convert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha4_ManagedControlPlaneSubnet(in *infrav1exp.ManagedControlPlaneSubnet, out *ManagedControlPlaneSubnet, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_ManagedControlPlaneSubnet_To_v1alpha4_ManagedControlPlaneSubnet(in, out, s)
+}
+
+// Convert_v1beta1_APIServerAccessProfile_To_v1alpha4_APIServerAccessProfile is an autogenerated conversion function.
+func Convert_v1beta1_APIServerAccessProfile_To_v1alpha4_APIServerAccessProfile(in *infrav1exp.APIServerAccessProfile, out *APIServerAccessProfile, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_APIServerAccessProfile_To_v1alpha4_APIServerAccessProfile(in, out, s)
+}
+
 // ConvertTo converts this AzureManagedControlPlaneList to the Hub version (v1beta1).
 func (src *AzureManagedControlPlaneList) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1exp.AzureManagedControlPlaneList)
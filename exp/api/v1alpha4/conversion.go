@@ -44,6 +44,16 @@ func Convert_v1beta1_Image_To_v1alpha4_Image(in *infrav1.Image, out *infrav1alph
 	return infrav1alpha4.Convert_v1beta1_Image_To_v1alpha4_Image(in, out, s)
 }
 
+// Convert_v1alpha4_SpotVMOptions_To_v1beta1_SpotVMOptions is a conversion function.
+func Convert_v1alpha4_SpotVMOptions_To_v1beta1_SpotVMOptions(in *infrav1alpha4.SpotVMOptions, out *infrav1.SpotVMOptions, s conversion.Scope) error {
+	return infrav1alpha4.Convert_v1alpha4_SpotVMOptions_To_v1beta1_SpotVMOptions(in, out, s)
+}
+
+// Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions is a conversion function.
+func Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in *infrav1.SpotVMOptions, out *infrav1alpha4.SpotVMOptions, s conversion.Scope) error {
+	return infrav1alpha4.Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in, out, s)
+}
+
 // Convert_v1alpha4_APIEndpoint_To_v1beta1_APIEndpoint is an autogenerated conversion function.
 func Convert_v1alpha4_APIEndpoint_To_v1beta1_APIEndpoint(in *clusterv1alpha4.APIEndpoint, out *clusterv1.APIEndpoint, s conversion.Scope) error {
 	return clusterv1alpha4.Convert_v1alpha4_APIEndpoint_To_v1beta1_APIEndpoint(in, out, s)
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -33,12 +34,35 @@ const (
 	// i.e. gradually scale down the old AzureMachinePoolMachines and scale up the new ones.
 	RollingUpdateAzureMachinePoolDeploymentStrategyType AzureMachinePoolDeploymentStrategyType = "RollingUpdate"
 
+	// CanaryAzureMachinePoolDeploymentStrategyType replaces a few AzureMachinePoolMachines with older models first,
+	// holds until the canaries based on the latest model have been healthy for a bake time, and then replaces the
+	// remaining ones.
+	CanaryAzureMachinePoolDeploymentStrategyType AzureMachinePoolDeploymentStrategyType = "Canary"
+
+	// BlueGreenAzureMachinePoolDeploymentStrategyType brings up a full set of AzureMachinePoolMachines based on the
+	// latest model before deleting the AzureMachinePoolMachines with older models.
+	BlueGreenAzureMachinePoolDeploymentStrategyType AzureMachinePoolDeploymentStrategyType = "BlueGreen"
+
+	// CompleteAzureMachinePoolDeploymentPhase means that all the AzureMachinePoolMachines run the latest model.
+	CompleteAzureMachinePoolDeploymentPhase AzureMachinePoolDeploymentPhase = "Complete"
+	// ProgressingAzureMachinePoolDeploymentPhase means that AzureMachinePoolMachines with older models are being replaced.
+	ProgressingAzureMachinePoolDeploymentPhase AzureMachinePoolDeploymentPhase = "Progressing"
+	// GatedAzureMachinePoolDeploymentPhase means that AzureMachinePoolMachines with older models are kept until enough
+	// AzureMachinePoolMachines based on the latest model have been healthy for the bake time.
+	GatedAzureMachinePoolDeploymentPhase AzureMachinePoolDeploymentPhase = "Gated"
+
 	// OldestDeletePolicyType will delete machines with the oldest creation date first.
 	OldestDeletePolicyType AzureMachinePoolDeletePolicyType = "Oldest"
 	// NewestDeletePolicyType will delete machines with the newest creation date first.
 	NewestDeletePolicyType AzureMachinePoolDeletePolicyType = "Newest"
 	// RandomDeletePolicyType will delete machines in random order.
 	RandomDeletePolicyType AzureMachinePoolDeletePolicyType = "Random"
+
+	// NoneSpotFallbackPolicyType keeps requesting Spot capacity when Azure cannot allocate it.
+	NoneSpotFallbackPolicyType SpotFallbackPolicyType = "None"
+	// OnDemandSpotFallbackPolicyType recreates the scale set with regular priority instances when Azure cannot
+	// allocate Spot capacity.
+	OnDemandSpotFallbackPolicyType SpotFallbackPolicyType = "OnDemand"
 )
 
 type (
@@ -48,6 +72,12 @@ type (
 		// See https://docs.microsoft.com/en-us/rest/api/compute/virtualmachines/createorupdate#virtualmachinesizetypes
 		VMSize string `json:"vmSize"`
 
+		// FallbackVMSizes is an ordered list of VM sizes the scale set falls back to, one after the other, when Azure
+		// cannot allocate enough capacity of its current VM size to create or scale it. The VM sizes which are not
+		// available in the failure domains of the MachinePool are skipped.
+		// +optional
+		FallbackVMSizes []string `json:"fallbackVMSizes,omitempty"`
+
 		// Image is used to provide details of an image to use during VM creation.
 		// If image details are omitted the image will default the Azure Marketplace "capi" offer,
 		// which is based on Ubuntu.
@@ -84,6 +114,15 @@ type (
 		// +optional
 		SpotVMOptions *infrav1.SpotVMOptions `json:"spotVMOptions,omitempty"`
 
+		// DedicatedHost places the VMSS instances in an Azure dedicated host group with automatic host placement.
+		// Specifying an individual host is not supported for scale sets.
+		// +optional
+		DedicatedHost *infrav1.DedicatedHost `json:"dedicatedHost,omitempty"`
+
+		// Diagnostics specifies the diagnostic settings of the VMSS instances.
+		// +optional
+		Diagnostics *infrav1.Diagnostics `json:"diagnostics,omitempty"`
+
 		// SubnetName selects the Subnet where the VMSS will be placed
 		// +optional
 		SubnetName string `json:"subnetName,omitempty"`
@@ -143,17 +182,136 @@ type (
 		// NOTE: NodeDrainTimeout is different from `kubectl drain --timeout`
 		// +optional
 		NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
+
+		// NodeDrainOptions customizes how the nodes of the AzureMachinePoolMachines are drained before they are deleted.
+		// +optional
+		NodeDrainOptions *NodeDrainOptions `json:"nodeDrainOptions,omitempty"`
+
+		// SpotFallbackPolicy defines what happens when Azure cannot allocate Spot capacity for the scale set.
+		// Valid values are "None" and "OnDemand". With "OnDemand", a scale set without allocated instances is recreated
+		// with regular priority instances once a Spot capacity error is observed, and recreated with Spot instances
+		// again once it is scaled to zero. Requires Template.SpotVMOptions to be set.
+		// When no value is supplied, the default is None.
+		// +optional
+		// +kubebuilder:validation:Enum=None;OnDemand
+		SpotFallbackPolicy SpotFallbackPolicyType `json:"spotFallbackPolicy,omitempty"`
+
+		// OrchestrationMode specifies the orchestration mode for the Virtual Machine Scale Set. With "Flexible", the
+		// scale set creates individual virtual machines with their own network interfaces, spread across fault domains,
+		// and each AzureMachinePoolMachine maps to one of those virtual machines. This field is immutable.
+		// +kubebuilder:default=Uniform
+		// +optional
+		OrchestrationMode infrav1.OrchestrationModeType `json:"orchestrationMode,omitempty"`
+
+		// ZoneBalance forces the scale set to keep the same number of instances in each of the failure domains of the
+		// MachinePool, give or take one. When false, Azure balances the instances on a best effort basis. Only applies
+		// when the MachinePool spans more than one failure domain.
+		// +optional
+		ZoneBalance *bool `json:"zoneBalance,omitempty"`
+
+		// RepairPolicy describes how the instances of the pool whose nodes are unhealthy are repaired. Unhealthy
+		// instances are cordoned, drained and deleted, and the scale set replaces them.
+		// +optional
+		RepairPolicy *AzureMachinePoolRepairPolicy `json:"repairPolicy,omitempty"`
+	}
+
+	// AzureMachinePoolRepairPolicy describes how the unhealthy instances of an AzureMachinePool are repaired.
+	AzureMachinePoolRepairPolicy struct {
+		// GracePeriod is the time a new instance has for its node to join the cluster before the instance is repaired.
+		// Defaults to 10 minutes.
+		// +optional
+		GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+		// UnhealthyConditions are the node conditions which make an instance unhealthy once they lasted for their
+		// timeout. When empty, an instance is unhealthy when the Ready condition of its node is False or Unknown for
+		// 5 minutes.
+		// +optional
+		UnhealthyConditions []clusterv1.UnhealthyCondition `json:"unhealthyConditions,omitempty"`
+
+		// MaxConcurrentRepairs is the number of instances of the pool which can be repaired at the same time.
+		// Value can be an absolute number (ex: 5) or a percentage of the desired replicas (ex: 10%).
+		// Absolute number is calculated from percentage by rounding down, but is at least 1.
+		// Defaults to 1.
+		// +optional
+		MaxConcurrentRepairs *intstr.IntOrString `json:"maxConcurrentRepairs,omitempty"`
+
+		// AutomaticRepairs enables the automatic repairs of the scale set, which replaces the instances reported as
+		// unhealthy by an application health extension.
+		// +optional
+		AutomaticRepairs *AutomaticRepairs `json:"automaticRepairs,omitempty"`
+	}
+
+	// AutomaticRepairs describes the automatic repairs of a scale set.
+	AutomaticRepairs struct {
+		// GracePeriod is the time for which automatic repairs are suspended after a state change of an instance.
+		// Must be between 10 and 90 minutes. Defaults to 30 minutes.
+		// +optional
+		GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+		// HealthProbe is the endpoint of the instances the application health extension probes.
+		HealthProbe ApplicationHealthProbe `json:"healthProbe"`
+	}
+
+	// ApplicationHealthProbe describes the endpoint of an instance which reports the health of the instance.
+	ApplicationHealthProbe struct {
+		// Protocol is the protocol used to probe the endpoint. Valid values are "http", "https" and "tcp".
+		// +kubebuilder:validation:Enum=http;https;tcp
+		// +kubebuilder:default=http
+		// +optional
+		Protocol string `json:"protocol,omitempty"`
+
+		// Port is the port of the endpoint.
+		// +kubebuilder:validation:Minimum=1
+		// +kubebuilder:validation:Maximum=65535
+		Port int32 `json:"port"`
+
+		// RequestPath is the path of the endpoint probed with the http or https protocol.
+		// +optional
+		RequestPath string `json:"requestPath,omitempty"`
 	}
 
+	// NodeDrainOptions customizes how a node is drained.
+	NodeDrainOptions struct {
+		// PodDisruptionBudgetTimeout is how long the drain of a node respects PodDisruptionBudgets. Once it has passed,
+		// the remaining pods are deleted instead of evicted, ignoring their PodDisruptionBudgets. By default,
+		// PodDisruptionBudgets are always respected.
+		// +optional
+		PodDisruptionBudgetTimeout *metav1.Duration `json:"podDisruptionBudgetTimeout,omitempty"`
+
+		// DeleteEmptyDirData deletes the pods using emptyDir volumes, whose data is lost. When false, the drain does
+		// not complete while such pods run on the node. Defaults to true.
+		// +optional
+		DeleteEmptyDirData *bool `json:"deleteEmptyDirData,omitempty"`
+
+		// IgnoreDaemonSets leaves the pods managed by DaemonSets on the node. When false, the drain does not complete
+		// while such pods run on the node. Defaults to true.
+		// +optional
+		IgnoreDaemonSets *bool `json:"ignoreDaemonSets,omitempty"`
+
+		// ExcludePodSelector selects the pods which are left on the node when it is drained.
+		// +optional
+		ExcludePodSelector *metav1.LabelSelector `json:"excludePodSelector,omitempty"`
+
+		// GracePeriodSeconds overrides the termination grace period of the pods evicted from the node. By default, the
+		// termination grace period of each pod is used.
+		// +kubebuilder:validation:Minimum=0
+		// +optional
+		GracePeriodSeconds *int32 `json:"gracePeriodSeconds,omitempty"`
+	}
+
+	// SpotFallbackPolicyType is the type of fallback employed when Spot capacity cannot be allocated for an
+	// AzureMachinePool.
+	SpotFallbackPolicyType string
+
 	// AzureMachinePoolDeploymentStrategyType is the type of deployment strategy employed to rollout a new version of
 	// the AzureMachinePool.
 	AzureMachinePoolDeploymentStrategyType string
 
 	// AzureMachinePoolDeploymentStrategy describes how to replace existing machines with new ones.
 	AzureMachinePoolDeploymentStrategy struct {
-		// Type of deployment. Currently the only supported strategy is RollingUpdate
+		// Type of deployment. Valid values are "RollingUpdate", "Canary" and "BlueGreen".
 		// +optional
-		// +kubebuilder:validation:Enum=RollingUpdate
+		// +kubebuilder:validation:Enum=RollingUpdate;Canary;BlueGreen
 		// +optional
 		// +kubebuilder:default=RollingUpdate
 		Type AzureMachinePoolDeploymentStrategyType `json:"type,omitempty"`
@@ -162,6 +320,95 @@ type (
 		// MachineDeploymentStrategyType = RollingUpdate.
 		// +optional
 		RollingUpdate *MachineRollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+
+		// Canary config params. Present only if
+		// MachineDeploymentStrategyType = Canary.
+		// +optional
+		Canary *MachineCanaryDeployment `json:"canary,omitempty"`
+
+		// Blue/green config params. Present only if
+		// MachineDeploymentStrategyType = BlueGreen.
+		// +optional
+		BlueGreen *MachineBlueGreenDeployment `json:"blueGreen,omitempty"`
+	}
+
+	// MachineCanaryDeployment is used to control the desired behavior of a canary deployment.
+	MachineCanaryDeployment struct {
+		// Replicas is the number of machines that are replaced in the canary step, and in each of the steps
+		// following it.
+		// Value can be an absolute number (ex: 5) or a percentage of desired
+		// machines (ex: 10%).
+		// Absolute number is calculated from percentage by rounding up.
+		// Defaults to 1.
+		// +optional
+		// +kubebuilder:default:=1
+		Replicas *intstr.IntOrString `json:"replicas,omitempty"`
+
+		// BakeTime is how long the canaries must have been healthy before the remaining machines are replaced.
+		// The default value is 0, meaning that the remaining machines are replaced as soon as the canaries are healthy.
+		// +optional
+		BakeTime *metav1.Duration `json:"bakeTime,omitempty"`
+
+		// DeletePolicy defines the policy used to identify nodes to delete when downscaling.
+		// Valid values are "Random, "Newest", "Oldest"
+		// When no value is supplied, the default is Oldest
+		// +optional
+		// +kubebuilder:validation:Enum=Random;Newest;Oldest
+		// +kubebuilder:default:=Oldest
+		DeletePolicy AzureMachinePoolDeletePolicyType `json:"deletePolicy,omitempty"`
+	}
+
+	// MachineBlueGreenDeployment is used to control the desired behavior of a blue/green deployment.
+	MachineBlueGreenDeployment struct {
+		// BakeTime is how long all the machines based on the latest model must have been healthy before the machines
+		// with older models are deleted.
+		// The default value is 0, meaning that the machines with older models are deleted as soon as the machines
+		// based on the latest model are healthy.
+		// +optional
+		BakeTime *metav1.Duration `json:"bakeTime,omitempty"`
+	}
+
+	// AzureMachinePoolDeploymentPhase is the phase of the rollout of the latest model of an AzureMachinePool.
+	AzureMachinePoolDeploymentPhase string
+
+	// AzureMachinePoolDeploymentStatus describes the progress of the rollout of the latest model of an AzureMachinePool.
+	AzureMachinePoolDeploymentStatus struct {
+		// Type is the type of deployment strategy used for the rollout.
+		Type AzureMachinePoolDeploymentStrategyType `json:"type"`
+
+		// Phase is the phase of the rollout.
+		Phase AzureMachinePoolDeploymentPhase `json:"phase"`
+
+		// UpdatedReplicas is the number of machines based on the latest model.
+		// +optional
+		UpdatedReplicas int32 `json:"updatedReplicas"`
+
+		// HealthyUpdatedReplicas is the number of healthy machines based on the latest model.
+		// +optional
+		HealthyUpdatedReplicas int32 `json:"healthyUpdatedReplicas"`
+
+		// OutdatedReplicas is the number of machines with older models.
+		// +optional
+		OutdatedReplicas int32 `json:"outdatedReplicas"`
+
+		// BakeUntil is the time at which enough machines based on the latest model will have been healthy for the
+		// bake time for the rollout to continue. Only set in the Gated phase.
+		// +optional
+		BakeUntil *metav1.Time `json:"bakeUntil,omitempty"`
+	}
+
+	// AzureMachinePoolVMSizeFallback describes the fallback of the scale set of an AzureMachinePool to another VM size.
+	AzureMachinePoolVMSizeFallback struct {
+		// VMSize is the VM size the scale set fell back to.
+		VMSize string `json:"vmSize"`
+
+		// PrimaryVMSize is the VM size of the template the scale set fell back from. The fallback is discarded when
+		// the VM size of the template changes.
+		PrimaryVMSize string `json:"primaryVMSize"`
+
+		// FallbackTime is the time at which the scale set fell back to the VM size. The primary VM size is tried again
+		// once the MachinePool is scaled to zero.
+		FallbackTime metav1.Time `json:"fallbackTime"`
 	}
 
 	// AzureMachinePoolDeletePolicyType is the type of DeletePolicy employed to select machines to be deleted during an
@@ -210,6 +457,35 @@ type (
 		// +kubebuilder:validation:Enum=Random;Newest;Oldest
 		// +kubebuilder:default:=Oldest
 		DeletePolicy AzureMachinePoolDeletePolicyType `json:"deletePolicy,omitempty"`
+
+		// BalanceZones deletes machines from the availability zones with the most ready machines first when the
+		// machines span more than one availability zone, and the DeletePolicy then orders the machines of each zone.
+		// When false, machines are deleted in the order of the DeletePolicy only.
+		// +optional
+		BalanceZones bool `json:"balanceZones,omitempty"`
+
+		// HealthCheck pauses the rollout when the nodes of machines based on the latest model do not become ready.
+		// When not set, the rollout only considers the provisioning state of the machines.
+		// +optional
+		HealthCheck *MachineRolloutHealthCheck `json:"healthCheck,omitempty"`
+	}
+
+	// MachineRolloutHealthCheck describes when the rollout of the latest model is paused because the machines based on
+	// it are unhealthy.
+	MachineRolloutHealthCheck struct {
+		// NodeReadyTimeout is the time the node of a machine based on the latest model has to become ready, counted
+		// from the creation of the machine. The rollout is paused when a node does not become ready in time.
+		// Defaults to 10 minutes.
+		// +optional
+		NodeReadyTimeout *metav1.Duration `json:"nodeReadyTimeout,omitempty"`
+
+		// MaxUnhealthy is the number of machines based on the latest model which can be unhealthy before the rollout
+		// is paused. A machine is unhealthy when it failed to provision or when its node is not ready.
+		// Value can be an absolute number (ex: 5) or a percentage of the machines based on the latest model (ex: 10%).
+		// Absolute number is calculated from percentage by rounding down.
+		// Defaults to 0.
+		// +optional
+		MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
 	}
 
 	// AzureMachinePoolStatus defines the observed state of AzureMachinePool.
@@ -226,6 +502,20 @@ type (
 		// +optional
 		Instances []*AzureMachinePoolInstanceStatus `json:"instances,omitempty"`
 
+		// Zones is the number of replicas in each availability zone of the scale set.
+		// +optional
+		Zones []AzureMachinePoolZoneStatus `json:"zones,omitempty"`
+
+		// Capacity is the resource capacity of the nodes of the AzureMachinePool, derived from the capabilities of its
+		// VM size. The cluster autoscaler uses it to scale the MachinePool from zero.
+		// +optional
+		Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+		// NodeInfo describes the nodes of the AzureMachinePool for the cluster autoscaler to scale the MachinePool
+		// from zero.
+		// +optional
+		NodeInfo *infrav1.NodeInfo `json:"nodeInfo,omitempty"`
+
 		// Image is the current image used in the AzureMachinePool. When the spec image is nil, this image is populated
 		// with the details of the defaulted Azure Marketplace "capi" offer.
 		// +optional
@@ -239,6 +529,21 @@ type (
 		// +optional
 		ProvisioningState *infrav1.ProvisioningState `json:"provisioningState,omitempty"`
 
+		// Deployment is the progress of the rollout of the latest model of the scale set.
+		// +optional
+		Deployment *AzureMachinePoolDeploymentStatus `json:"deployment,omitempty"`
+
+		// VMSizeFallback is set when the scale set fell back to one of the Template.FallbackVMSizes because Azure could
+		// not allocate enough capacity of its VM size.
+		// +optional
+		VMSizeFallback *AzureMachinePoolVMSizeFallback `json:"vmSizeFallback,omitempty"`
+
+		// SpotFallbackActive is true when the scale set runs regular priority instances instead of Spot instances
+		// because Azure could not allocate Spot capacity and the SpotFallbackPolicy is OnDemand. It is reset once the
+		// scale set is scaled to zero and recreated with Spot instances.
+		// +optional
+		SpotFallbackActive bool `json:"spotFallbackActive,omitempty"`
+
 		// FailureReason will be set in the event that there is a terminal problem
 		// reconciling the MachinePool and will contain a succinct value suitable
 		// for machine interpretation.
@@ -287,6 +592,20 @@ type (
 		LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
 	}
 
+	// AzureMachinePoolZoneStatus provides the number of replicas in an availability zone of the VMSS.
+	AzureMachinePoolZoneStatus struct {
+		// Zone is the availability zone.
+		Zone string `json:"zone"`
+
+		// Replicas is the number of instances in the zone.
+		// +optional
+		Replicas int32 `json:"replicas"`
+
+		// ReadyReplicas is the number of ready instances in the zone.
+		// +optional
+		ReadyReplicas int32 `json:"readyReplicas"`
+	}
+
 	// AzureMachinePoolInstanceStatus provides status information for each instance in the VMSS.
 	AzureMachinePoolInstanceStatus struct {
 		// Version defines the Kubernetes version for the VM Instance
@@ -20,10 +20,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
@@ -88,6 +91,13 @@ func (amp *AzureMachinePool) Validate(old runtime.Object) error {
 		amp.ValidateUserAssignedIdentity,
 		amp.ValidateStrategy(),
 		amp.ValidateSystemAssignedIdentity(old),
+		amp.ValidateDedicatedHost(old),
+		amp.ValidateSpotVMOptions,
+		amp.ValidateDiagnostics,
+		amp.ValidateOrchestrationMode(old),
+		amp.ValidateRepairPolicy,
+		amp.ValidateFallbackVMSizes,
+		amp.ValidateNodeDrainOptions,
 	}
 
 	var errs []error
@@ -159,10 +169,54 @@ func (amp *AzureMachinePool) ValidateStrategy() func() error {
 			rollingUpdateStrategy := amp.Spec.Strategy.RollingUpdate
 			maxSurge := rollingUpdateStrategy.MaxSurge
 			maxUnavailable := rollingUpdateStrategy.MaxUnavailable
-			if maxSurge.Type == intstr.Int && maxSurge.IntVal == 0 &&
+			if maxSurge != nil && maxUnavailable != nil &&
+				maxSurge.Type == intstr.Int && maxSurge.IntVal == 0 &&
 				maxUnavailable.Type == intstr.Int && maxUnavailable.IntVal == 0 {
 				return errors.New("rolling update strategy MaxUnavailable must not be 0 if MaxSurge is 0")
 			}
+
+			if healthCheck := rollingUpdateStrategy.HealthCheck; healthCheck != nil {
+				if healthCheck.NodeReadyTimeout != nil && healthCheck.NodeReadyTimeout.Duration <= 0 {
+					return errors.New("rolling update strategy health check NodeReadyTimeout must be greater than 0")
+				}
+				if healthCheck.MaxUnhealthy != nil {
+					maxUnhealthy, err := intstr.GetScaledValueFromIntOrPercent(healthCheck.MaxUnhealthy, 100, false)
+					if err != nil {
+						return fmt.Errorf("invalid rolling update strategy health check MaxUnhealthy: %w", err)
+					}
+					if maxUnhealthy < 0 {
+						return errors.New("rolling update strategy health check MaxUnhealthy must not be negative")
+					}
+				}
+			}
+		}
+
+		if canary := amp.Spec.Strategy.Canary; canary != nil {
+			if amp.Spec.Strategy.Type != CanaryAzureMachinePoolDeploymentStrategyType {
+				return errors.New("canary strategy parameters can only be set when the strategy type is Canary")
+			}
+			if canary.Replicas != nil {
+				// Scaled against 100 replicas, a percentage is 0 only if it is 0%.
+				replicas, err := intstr.GetScaledValueFromIntOrPercent(canary.Replicas, 100, true)
+				if err != nil {
+					return fmt.Errorf("invalid canary strategy Replicas: %w", err)
+				}
+				if replicas <= 0 {
+					return errors.New("canary strategy Replicas must be greater than 0")
+				}
+			}
+			if canary.BakeTime != nil && canary.BakeTime.Duration < 0 {
+				return errors.New("canary strategy BakeTime must not be negative")
+			}
+		}
+
+		if blueGreen := amp.Spec.Strategy.BlueGreen; blueGreen != nil {
+			if amp.Spec.Strategy.Type != BlueGreenAzureMachinePoolDeploymentStrategyType {
+				return errors.New("blue/green strategy parameters can only be set when the strategy type is BlueGreen")
+			}
+			if blueGreen.BakeTime != nil && blueGreen.BakeTime.Duration < 0 {
+				return errors.New("blue/green strategy BakeTime must not be negative")
+			}
 		}
 
 		return nil
@@ -190,3 +244,168 @@ func (amp *AzureMachinePool) ValidateSystemAssignedIdentity(old runtime.Object)
 		return nil
 	}
 }
+
+// ValidateDedicatedHost validates the dedicated host group placement of the scale set.
+func (amp *AzureMachinePool) ValidateDedicatedHost(old runtime.Object) func() error {
+	return func() error {
+		fldPath := field.NewPath("spec", "template", "dedicatedHost")
+		if old != nil {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			if !reflect.DeepEqual(amp.Spec.Template.DedicatedHost, oldMachinePool.Spec.Template.DedicatedHost) {
+				return field.Invalid(fldPath, amp.Spec.Template.DedicatedHost, "field is immutable")
+			}
+		}
+
+		if errs := infrav1.ValidateDedicatedHost(amp.Spec.Template.DedicatedHost, amp.Spec.Template.SpotVMOptions, fldPath); len(errs) > 0 {
+			return kerrors.NewAggregate(errs.ToAggregate().Errors())
+		}
+
+		if amp.Spec.Template.DedicatedHost != nil && amp.Spec.Template.DedicatedHost.HostID != "" {
+			return field.Forbidden(fldPath.Child("hostID"), "placing a scale set on a specific dedicated host is not supported, use automatic placement in the host group instead")
+		}
+
+		return nil
+	}
+}
+
+// ValidateSpotVMOptions validates the Spot VM options and the Spot fallback policy of the scale set.
+func (amp *AzureMachinePool) ValidateSpotVMOptions() error {
+	if errs := infrav1.ValidateSpotVMOptions(amp.Spec.Template.SpotVMOptions, amp.Spec.Template.OSDisk, field.NewPath("spec", "template", "spotVMOptions")); len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+
+	if amp.Spec.SpotFallbackPolicy == OnDemandSpotFallbackPolicyType && amp.Spec.Template.SpotVMOptions == nil {
+		return field.Invalid(field.NewPath("spec", "spotFallbackPolicy"), amp.Spec.SpotFallbackPolicy, "a Spot fallback policy can only be set when spotVMOptions is set")
+	}
+
+	return nil
+}
+
+// ValidateDiagnostics validates the diagnostic settings of the AzureMachinePool.
+func (amp *AzureMachinePool) ValidateDiagnostics() error {
+	if errs := infrav1.ValidateDiagnostics(amp.Spec.Template.Diagnostics, field.NewPath("spec", "template", "diagnostics")); len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// ValidateOrchestrationMode validates that the orchestration mode of the scale set is not changed.
+func (amp *AzureMachinePool) ValidateOrchestrationMode(old runtime.Object) func() error {
+	return func() error {
+		if old == nil {
+			return nil
+		}
+
+		oldMachinePool, ok := old.(*AzureMachinePool)
+		if !ok {
+			return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+				"AzureMachinePool", reflect.TypeOf(old))
+		}
+
+		if orchestrationMode(amp.Spec.OrchestrationMode) != orchestrationMode(oldMachinePool.Spec.OrchestrationMode) {
+			return field.Invalid(field.NewPath("spec", "orchestrationMode"), amp.Spec.OrchestrationMode, "field is immutable")
+		}
+
+		return nil
+	}
+}
+
+// ValidateRepairPolicy validates the repair policy of the pool and of its scale set.
+func (amp *AzureMachinePool) ValidateRepairPolicy() error {
+	repairPolicy := amp.Spec.RepairPolicy
+	if repairPolicy == nil {
+		return nil
+	}
+
+	fldPath := field.NewPath("spec", "repairPolicy")
+	var allErrs field.ErrorList
+	if repairPolicy.GracePeriod != nil && repairPolicy.GracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("gracePeriod"), repairPolicy.GracePeriod.Duration.String(), "must not be negative"))
+	}
+
+	for i, condition := range repairPolicy.UnhealthyConditions {
+		if condition.Timeout.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("unhealthyConditions").Index(i).Child("timeout"), condition.Timeout.Duration.String(), "must not be negative"))
+		}
+	}
+
+	if repairPolicy.MaxConcurrentRepairs != nil {
+		// Scaled against 100 replicas, a percentage is negative only if it is negative.
+		maxConcurrentRepairs, err := intstr.GetScaledValueFromIntOrPercent(repairPolicy.MaxConcurrentRepairs, 100, false)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrentRepairs"), repairPolicy.MaxConcurrentRepairs.String(), err.Error()))
+		} else if maxConcurrentRepairs < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrentRepairs"), repairPolicy.MaxConcurrentRepairs.String(), "must not be negative"))
+		}
+	}
+
+	if automaticRepairs := repairPolicy.AutomaticRepairs; automaticRepairs != nil {
+		if gracePeriod := automaticRepairs.GracePeriod; gracePeriod != nil &&
+			(gracePeriod.Duration < 10*time.Minute || gracePeriod.Duration > 90*time.Minute || gracePeriod.Duration%time.Minute != 0) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("automaticRepairs", "gracePeriod"), gracePeriod.Duration.String(), "must be a whole number of minutes between 10 and 90 minutes"))
+		}
+
+		if probe := automaticRepairs.HealthProbe; probe.Protocol == "tcp" && probe.RequestPath != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("automaticRepairs", "healthProbe", "requestPath"), probe.RequestPath, "must not be set for the tcp protocol"))
+		}
+	}
+
+	return allErrs.ToAggregate()
+}
+
+// ValidateFallbackVMSizes validates the fallback VM sizes of the AzureMachinePool.
+func (amp *AzureMachinePool) ValidateFallbackVMSizes() error {
+	fldPath := field.NewPath("spec", "template", "fallbackVMSizes")
+	var allErrs field.ErrorList
+	sizes := sets.NewString(amp.Spec.Template.VMSize)
+	for i, size := range amp.Spec.Template.FallbackVMSizes {
+		switch {
+		case size == "":
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "must not be empty"))
+		case sizes.Has(size):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), size))
+		}
+		sizes.Insert(size)
+	}
+
+	return allErrs.ToAggregate()
+}
+
+// ValidateNodeDrainOptions validates the node drain options of the AzureMachinePool.
+func (amp *AzureMachinePool) ValidateNodeDrainOptions() error {
+	options := amp.Spec.NodeDrainOptions
+	if options == nil {
+		return nil
+	}
+
+	fldPath := field.NewPath("spec", "nodeDrainOptions")
+	var allErrs field.ErrorList
+	if options.PodDisruptionBudgetTimeout != nil && options.PodDisruptionBudgetTimeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podDisruptionBudgetTimeout"), options.PodDisruptionBudgetTimeout.Duration.String(), "must not be negative"))
+	}
+
+	if options.ExcludePodSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(options.ExcludePodSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("excludePodSelector"), options.ExcludePodSelector, err.Error()))
+		}
+	}
+
+	if options.GracePeriodSeconds != nil && *options.GracePeriodSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("gracePeriodSeconds"), *options.GracePeriodSeconds, "must not be negative"))
+	}
+
+	return allErrs.ToAggregate()
+}
+
+// orchestrationMode returns the orchestration mode, treating an unset mode as Uniform.
+func orchestrationMode(mode infrav1.OrchestrationModeType) infrav1.OrchestrationModeType {
+	if mode == "" {
+		return infrav1.UniformOrchestrationMode
+	}
+	return mode
+}
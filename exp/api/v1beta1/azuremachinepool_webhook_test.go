@@ -21,11 +21,13 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	guuid "github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"golang.org/x/crypto/ssh"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	utilfeature "k8s.io/component-base/featuregate/testing"
@@ -46,8 +48,9 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 	g := NewWithT(t)
 
 	var (
-		zero = intstr.FromInt(0)
-		one  = intstr.FromInt(1)
+		zero          = intstr.FromInt(0)
+		one           = intstr.FromInt(1)
+		twentyPercent = intstr.FromString("20%")
 	)
 
 	tests := []struct {
@@ -142,6 +145,208 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name: "azuremachinepool with valid rolling upgrade health check",
+			amp: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{
+				Type: RollingUpdateAzureMachinePoolDeploymentStrategyType,
+				RollingUpdate: &MachineRollingUpdateDeployment{
+					HealthCheck: &MachineRolloutHealthCheck{
+						NodeReadyTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+						MaxUnhealthy:     &twentyPercent,
+					},
+				},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with non-positive rolling upgrade health check NodeReadyTimeout",
+			amp: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{
+				Type: RollingUpdateAzureMachinePoolDeploymentStrategyType,
+				RollingUpdate: &MachineRollingUpdateDeployment{
+					HealthCheck: &MachineRolloutHealthCheck{
+						NodeReadyTimeout: &metav1.Duration{},
+					},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with invalid rolling upgrade health check MaxUnhealthy",
+			amp: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{
+				Type: RollingUpdateAzureMachinePoolDeploymentStrategyType,
+				RollingUpdate: &MachineRollingUpdateDeployment{
+					HealthCheck: &MachineRolloutHealthCheck{
+						MaxUnhealthy: &intstr.IntOrString{Type: intstr.String, StrVal: "twenty"},
+					},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with valid canary configuration",
+			amp: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{
+				Type: CanaryAzureMachinePoolDeploymentStrategyType,
+				Canary: &MachineCanaryDeployment{
+					Replicas: &twentyPercent,
+					BakeTime: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with 0 canary replicas",
+			amp: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{
+				Type: CanaryAzureMachinePoolDeploymentStrategyType,
+				Canary: &MachineCanaryDeployment{
+					Replicas: &zero,
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with canary configuration for a blue/green strategy",
+			amp: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{
+				Type:   BlueGreenAzureMachinePoolDeploymentStrategyType,
+				Canary: &MachineCanaryDeployment{},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with negative blue/green bake time",
+			amp: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{
+				Type: BlueGreenAzureMachinePoolDeploymentStrategyType,
+				BlueGreen: &MachineBlueGreenDeployment{
+					BakeTime: &metav1.Duration{Duration: -time.Minute},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with dedicated host group",
+			amp:     createMachinePoolWithDedicatedHost(&infrav1.DedicatedHost{HostGroupID: testHostGroupID}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with specific dedicated host",
+			amp:     createMachinePoolWithDedicatedHost(&infrav1.DedicatedHost{HostGroupID: testHostGroupID, HostID: testHostGroupID + "/hosts/my-host"}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with spot fallback to on-demand",
+			amp:     createMachinePoolWithSpotFallback(&infrav1.SpotVMOptions{}, OnDemandSpotFallbackPolicyType),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with spot fallback policy but no spot vm options",
+			amp:     createMachinePoolWithSpotFallback(nil, OnDemandSpotFallbackPolicyType),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool without spot fallback and no spot vm options",
+			amp:     createMachinePoolWithSpotFallback(nil, NoneSpotFallbackPolicyType),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with user managed boot diagnostics",
+			amp: createMachinePoolWithDiagnostics(&infrav1.Diagnostics{Boot: &infrav1.BootDiagnostics{
+				StorageAccountType: infrav1.UserManagedDiagnosticsStorage,
+				UserManaged:        &infrav1.UserManagedBootDiagnostics{StorageAccountURI: "https://fake.blob.core.windows.net/"},
+			}}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with user managed boot diagnostics but no storage account",
+			amp: createMachinePoolWithDiagnostics(&infrav1.Diagnostics{Boot: &infrav1.BootDiagnostics{
+				StorageAccountType: infrav1.UserManagedDiagnosticsStorage,
+			}}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with repair policy and automatic repairs",
+			amp: createMachinePoolWithRepairPolicy(&AzureMachinePoolRepairPolicy{
+				GracePeriod:          &metav1.Duration{Duration: 15 * time.Minute},
+				MaxConcurrentRepairs: &twentyPercent,
+				AutomaticRepairs: &AutomaticRepairs{
+					GracePeriod: &metav1.Duration{Duration: 30 * time.Minute},
+					HealthProbe: ApplicationHealthProbe{Protocol: "http", Port: 8080, RequestPath: "/healthz"},
+				},
+			}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with negative repair grace period",
+			amp: createMachinePoolWithRepairPolicy(&AzureMachinePoolRepairPolicy{
+				GracePeriod: &metav1.Duration{Duration: -time.Minute},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with negative repair max concurrent repairs",
+			amp: createMachinePoolWithRepairPolicy(&AzureMachinePoolRepairPolicy{
+				MaxConcurrentRepairs: &intstr.IntOrString{Type: intstr.Int, IntVal: -1},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with automatic repairs grace period shorter than 10 minutes",
+			amp: createMachinePoolWithRepairPolicy(&AzureMachinePoolRepairPolicy{
+				AutomaticRepairs: &AutomaticRepairs{
+					GracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+					HealthProbe: ApplicationHealthProbe{Port: 8080},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with node drain options",
+			amp: createMachinePoolWithNodeDrainOptions(&NodeDrainOptions{
+				PodDisruptionBudgetTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+				DeleteEmptyDirData:         to.BoolPtr(false),
+				ExcludePodSelector:         &metav1.LabelSelector{MatchLabels: map[string]string{"app": "stateful"}},
+				GracePeriodSeconds:         to.Int32Ptr(30),
+			}),
+			wantErr: false,
+		},
+		{
+			name: "azuremachinepool with negative pod disruption budget timeout",
+			amp: createMachinePoolWithNodeDrainOptions(&NodeDrainOptions{
+				PodDisruptionBudgetTimeout: &metav1.Duration{Duration: -time.Minute},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with invalid exclude pod selector",
+			amp: createMachinePoolWithNodeDrainOptions(&NodeDrainOptions{
+				ExcludePodSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+				},
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with fallback VM sizes",
+			amp:     createMachinePoolWithFallbackVMSizes("Standard_D2s_v3", "Standard_D2s_v4", "Standard_D2as_v4"),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with an empty fallback VM size",
+			amp:     createMachinePoolWithFallbackVMSizes("Standard_D2s_v3", ""),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with a fallback VM size equal to its VM size",
+			amp:     createMachinePoolWithFallbackVMSizes("Standard_D2s_v3", "Standard_D2s_v4", "Standard_D2s_v3"),
+			wantErr: true,
+		},
+		{
+			name: "azuremachinepool with automatic repairs tcp health probe with a request path",
+			amp: createMachinePoolWithRepairPolicy(&AzureMachinePoolRepairPolicy{
+				AutomaticRepairs: &AutomaticRepairs{
+					HealthProbe: ApplicationHealthProbe{Protocol: "tcp", Port: 8080, RequestPath: "/healthz"},
+				},
+			}),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -221,6 +426,30 @@ func TestAzureMachinePool_ValidateUpdate(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name:    "azuremachinepool with dedicated host group unchanged",
+			oldAMP:  createMachinePoolWithDedicatedHost(&infrav1.DedicatedHost{HostGroupID: testHostGroupID}),
+			amp:     createMachinePoolWithDedicatedHost(&infrav1.DedicatedHost{HostGroupID: testHostGroupID}),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with dedicated host group changed",
+			oldAMP:  createMachinePoolWithDedicatedHost(nil),
+			amp:     createMachinePoolWithDedicatedHost(&infrav1.DedicatedHost{HostGroupID: testHostGroupID}),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with orchestration mode defaulted to uniform",
+			oldAMP:  createMachinePoolWithOrchestrationMode(""),
+			amp:     createMachinePoolWithOrchestrationMode(infrav1.UniformOrchestrationMode),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with orchestration mode changed",
+			oldAMP:  createMachinePoolWithOrchestrationMode(infrav1.UniformOrchestrationMode),
+			amp:     createMachinePoolWithOrchestrationMode(infrav1.FlexibleOrchestrationMode),
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -378,3 +607,71 @@ func createMachinePoolWithStrategy(strategy AzureMachinePoolDeploymentStrategy)
 		},
 	}
 }
+
+const testHostGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+
+func createMachinePoolWithDedicatedHost(dedicatedHost *infrav1.DedicatedHost) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				DedicatedHost: dedicatedHost,
+			},
+		},
+	}
+}
+
+func createMachinePoolWithOrchestrationMode(mode infrav1.OrchestrationModeType) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			OrchestrationMode: mode,
+		},
+	}
+}
+
+func createMachinePoolWithSpotFallback(spotVMOptions *infrav1.SpotVMOptions, policy SpotFallbackPolicyType) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				SpotVMOptions: spotVMOptions,
+			},
+			SpotFallbackPolicy: policy,
+		},
+	}
+}
+
+func createMachinePoolWithDiagnostics(diagnostics *infrav1.Diagnostics) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				Diagnostics: diagnostics,
+			},
+		},
+	}
+}
+
+func createMachinePoolWithRepairPolicy(repairPolicy *AzureMachinePoolRepairPolicy) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			RepairPolicy: repairPolicy,
+		},
+	}
+}
+
+func createMachinePoolWithFallbackVMSizes(vmSize string, fallbackVMSizes ...string) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			Template: AzureMachinePoolMachineTemplate{
+				VMSize:          vmSize,
+				FallbackVMSizes: fallbackVMSizes,
+			},
+		},
+	}
+}
+
+func createMachinePoolWithNodeDrainOptions(options *NodeDrainOptions) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			NodeDrainOptions: options,
+		},
+	}
+}
@@ -38,6 +38,23 @@ type (
 
 		// InstanceID is the identification of the Machine Instance within the VMSS
 		InstanceID string `json:"instanceID"`
+
+		// ProtectionPolicy protects the instance from scale-in and from the actions of the scale set, such as the
+		// rollout of a new model. Not supported by scale sets in Flexible orchestration mode.
+		// +optional
+		ProtectionPolicy *AzureMachinePoolMachineProtectionPolicy `json:"protectionPolicy,omitempty"`
+	}
+
+	// AzureMachinePoolMachineProtectionPolicy describes what an instance of the scale set is protected from.
+	AzureMachinePoolMachineProtectionPolicy struct {
+		// ProtectFromScaleIn keeps the instance from being deleted when the pool is scaled in.
+		// +optional
+		ProtectFromScaleIn bool `json:"protectFromScaleIn,omitempty"`
+
+		// ProtectFromScaleSetActions keeps the instance from being updated to the latest model of the scale set and
+		// from being deleted when the pool is scaled in.
+		// +optional
+		ProtectFromScaleSetActions bool `json:"protectFromScaleSetActions,omitempty"`
 	}
 
 	// AzureMachinePoolMachineStatus defines the observed state of AzureMachinePoolMachine.
@@ -92,9 +109,17 @@ type (
 		// may not be running the version of Kubernetes the Machine Pool has specified and needs to be updated.
 		LatestModelApplied bool `json:"latestModelApplied"`
 
+		// AvailabilityZone is the availability zone of the instance, if any.
+		// +optional
+		AvailabilityZone string `json:"availabilityZone,omitempty"`
+
 		// Ready is true when the provider resource is ready.
 		// +optional
 		Ready bool `json:"ready"`
+
+		// BootDiagnostics describes the serial console log captured when the instance ended in the Failed state.
+		// +optional
+		BootDiagnostics *infrav1.BootDiagnosticsStatus `json:"bootDiagnostics,omitempty"`
 	}
 
 	// +kubebuilder:object:root=true
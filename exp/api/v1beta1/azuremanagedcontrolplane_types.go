@@ -84,6 +84,17 @@ type AzureManagedControlPlaneSpec struct {
 	// +optional
 	DNSServiceIP *string `json:"dnsServiceIP,omitempty"`
 
+	// PodCIDR is the CIDR notation IP range from which to assign pod IPs when kubenet is used.
+	// If omitted, the single pod CIDR block of the owner Cluster's clusterNetwork is used.
+	// +optional
+	PodCIDR *string `json:"podCidr,omitempty"`
+
+	// ServiceCIDR is the CIDR notation IP range from which to assign service cluster IPs.
+	// It must not overlap with any subnet IP ranges.
+	// If omitted, the single service CIDR block of the owner Cluster's clusterNetwork is used.
+	// +optional
+	ServiceCIDR *string `json:"serviceCidr,omitempty"`
+
 	// LoadBalancerSKU is the SKU of the loadBalancer to be provisioned.
 	// +kubebuilder:validation:Enum=Basic;Standard
 	// +optional
@@ -101,17 +112,77 @@ type AzureManagedControlPlaneSpec struct {
 	// +optional
 	AddonProfiles []AddonProfile `json:"addonProfiles,omitempty"`
 
+	// Addons configures commonly used managed cluster add-ons with typed settings.
+	// An add-on configured here cannot also be listed in AddonProfiles.
+	// +optional
+	Addons *ManagedControlPlaneAddons `json:"addons,omitempty"`
+
 	// SKU is the SKU of the AKS to be provisioned.
 	// +optional
 	SKU *SKU `json:"sku,omitempty"`
 
 	// LoadBalancerProfile is the profile of the cluster load balancer.
+	// Only allowed when OutboundType is loadBalancer.
 	// +optional
 	LoadBalancerProfile *LoadBalancerProfile `json:"loadBalancerProfile,omitempty"`
 
+	// OutboundType is the outbound (egress) routing method of the cluster. The default is loadBalancer.
+	// See https://docs.microsoft.com/azure/aks/egress-outboundtype for more details.
+	// +optional
+	OutboundType *ManagedControlPlaneOutboundType `json:"outboundType,omitempty"`
+
+	// NatGatewayProfile is the profile of the cluster NAT gateway.
+	// Only allowed when OutboundType is managedNATGateway.
+	// +optional
+	NatGatewayProfile *NatGatewayProfile `json:"natGatewayProfile,omitempty"`
+
 	// APIServerAccessProfile is the access profile for AKS API server.
 	// +optional
 	APIServerAccessProfile *APIServerAccessProfile `json:"apiServerAccessProfile,omitempty"`
+
+	// AutoScalerProfile is the parameters to be applied to the cluster-autoscaler when autoscaling is enabled on an agent pool.
+	// +optional
+	AutoScalerProfile *AutoScalerProfile `json:"autoScalerProfile,omitempty"`
+
+	// AutoUpgradeProfile is the automatic upgrade configuration of the cluster.
+	// +optional
+	AutoUpgradeProfile *ManagedClusterAutoUpgradeProfile `json:"autoUpgradeProfile,omitempty"`
+
+	// MaintenanceConfigurations are the planned maintenance configurations of the cluster.
+	// AKS only performs planned maintenance, such as automatic upgrades, in the windows allowed by the 'default' configuration.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	MaintenanceConfigurations []MaintenanceConfiguration `json:"maintenanceConfigurations,omitempty"`
+
+	// OIDCIssuerProfile is the OIDC issuer profile of the Managed Cluster.
+	// The OIDC issuer cannot be disabled once it has been enabled.
+	// +optional
+	OIDCIssuerProfile *OIDCIssuerProfile `json:"oidcIssuerProfile,omitempty"`
+
+	// WorkloadIdentityProfile is the workload identity profile of the Managed Cluster.
+	// Workload identity requires the OIDC issuer to be enabled.
+	// +optional
+	WorkloadIdentityProfile *WorkloadIdentityProfile `json:"workloadIdentityProfile,omitempty"`
+
+	// Identity configures the user-assigned identities of the cluster and their role assignments.
+	// If omitted, the control plane uses a system-assigned identity.
+	// +optional
+	Identity *ManagedControlPlaneIdentity `json:"identity,omitempty"`
+
+	// DisableLocalAccounts disables the static admin and user credentials of the cluster.
+	// It requires AAD integration and the exec or aadToken kubeconfig credential kind.
+	// +optional
+	DisableLocalAccounts *bool `json:"disableLocalAccounts,omitempty"`
+
+	// Kubeconfig configures the kubeconfig of the cluster that is stored in the kubeconfig secret used by Cluster API.
+	// +optional
+	Kubeconfig *ManagedControlPlaneKubeconfig `json:"kubeconfig,omitempty"`
+
+	// UpgradeOrchestration rolls out Kubernetes version upgrades to the control plane first and then to the agent pools,
+	// one group of agent pools at a time. When unset, agent pools are upgraded as soon as their version changes.
+	// +optional
+	UpgradeOrchestration *UpgradeOrchestration `json:"upgradeOrchestration,omitempty"`
 }
 
 // AADProfile - AAD integration managed by AKS.
@@ -138,6 +209,127 @@ type AddonProfile struct {
 	Enabled bool `json:"enabled"`
 }
 
+// Names of the managed cluster add-ons that can be configured with typed settings.
+const (
+	// MonitoringAddonName is the name of the Container Insights monitoring add-on.
+	MonitoringAddonName = "omsagent"
+
+	// AzurePolicyAddonName is the name of the Azure Policy add-on.
+	AzurePolicyAddonName = "azurepolicy"
+
+	// KeyVaultSecretsProviderAddonName is the name of the Azure Key Vault secrets provider add-on.
+	KeyVaultSecretsProviderAddonName = "azureKeyvaultSecretsProvider"
+
+	// IngressApplicationGatewayAddonName is the name of the application gateway ingress controller add-on.
+	IngressApplicationGatewayAddonName = "ingressApplicationGateway"
+)
+
+// ManagedControlPlaneAddons configures managed cluster add-ons with typed settings.
+type ManagedControlPlaneAddons struct {
+	// Monitoring configures the Container Insights monitoring add-on.
+	// +optional
+	Monitoring *MonitoringAddon `json:"monitoring,omitempty"`
+
+	// AzurePolicy configures the Azure Policy add-on.
+	// +optional
+	AzurePolicy *AzurePolicyAddon `json:"azurePolicy,omitempty"`
+
+	// KeyVaultSecretsProvider configures the Azure Key Vault secrets provider add-on.
+	// +optional
+	KeyVaultSecretsProvider *KeyVaultSecretsProviderAddon `json:"keyVaultSecretsProvider,omitempty"`
+
+	// IngressApplicationGateway configures the application gateway ingress controller add-on.
+	// +optional
+	IngressApplicationGateway *IngressApplicationGatewayAddon `json:"ingressApplicationGateway,omitempty"`
+}
+
+// MonitoringAddon configures the Container Insights monitoring add-on.
+type MonitoringAddon struct {
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+
+	// LogAnalyticsWorkspaceResourceID is the resource ID of the Log Analytics workspace the monitoring data is sent to.
+	// Required when the add-on is enabled.
+	// +optional
+	LogAnalyticsWorkspaceResourceID string `json:"logAnalyticsWorkspaceResourceID,omitempty"`
+}
+
+// AzurePolicyAddon configures the Azure Policy add-on.
+type AzurePolicyAddon struct {
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+}
+
+// KeyVaultSecretsProviderAddon configures the Azure Key Vault secrets provider add-on.
+type KeyVaultSecretsProviderAddon struct {
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+
+	// EnableSecretRotation periodically updates the mounted secrets from Key Vault.
+	// +optional
+	EnableSecretRotation *bool `json:"enableSecretRotation,omitempty"`
+
+	// RotationPollInterval is how often the secrets are updated when secret rotation is enabled. AKS defaults it to 2m.
+	// +optional
+	RotationPollInterval *metav1.Duration `json:"rotationPollInterval,omitempty"`
+}
+
+// IngressApplicationGatewayAddon configures the application gateway ingress controller add-on.
+// Either an existing application gateway is used, or AKS creates one with the given name in a new or existing subnet.
+type IngressApplicationGatewayAddon struct {
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+
+	// ApplicationGatewayID is the resource ID of an existing application gateway.
+	// +optional
+	ApplicationGatewayID *string `json:"applicationGatewayID,omitempty"`
+
+	// ApplicationGatewayName is the name of the application gateway AKS creates.
+	// +optional
+	ApplicationGatewayName *string `json:"applicationGatewayName,omitempty"`
+
+	// SubnetCIDR is the CIDR block of the subnet AKS creates for the application gateway.
+	// +optional
+	SubnetCIDR *string `json:"subnetCIDR,omitempty"`
+
+	// SubnetID is the resource ID of an existing subnet for the application gateway that AKS creates.
+	// +optional
+	SubnetID *string `json:"subnetID,omitempty"`
+}
+
+// AddonStatus is the observed state of a managed cluster add-on.
+type AddonStatus struct {
+	// Name is the name of the add-on.
+	Name string `json:"name"`
+
+	// Enabled - Whether the add-on is enabled or not.
+	Enabled bool `json:"enabled"`
+
+	// Config is the configuration of the add-on reported by AKS, including the IDs of resources AKS generated for it
+	// such as effectiveApplicationGatewayId.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// Identity is the managed identity AKS created for the add-on.
+	// +optional
+	Identity *AddonIdentity `json:"identity,omitempty"`
+}
+
+// AddonIdentity is the user-assigned identity of a managed cluster add-on.
+type AddonIdentity struct {
+	// ResourceID is the resource ID of the user-assigned identity.
+	// +optional
+	ResourceID string `json:"resourceID,omitempty"`
+
+	// ClientID is the client ID of the user-assigned identity.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+
+	// ObjectID is the object ID of the user-assigned identity.
+	// +optional
+	ObjectID string `json:"objectID,omitempty"`
+}
+
 // AzureManagedControlPlaneSkuTier - Tier of a managed cluster SKU.
 // +kubebuilder:validation:Enum=Free;Paid
 type AzureManagedControlPlaneSkuTier string
@@ -183,6 +375,37 @@ type LoadBalancerProfile struct {
 	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
 }
 
+// ManagedControlPlaneOutboundType enumerates the values for the managed control plane outbound type.
+// +kubebuilder:validation:Enum=loadBalancer;userDefinedRouting;managedNATGateway;userAssignedNATGateway
+type ManagedControlPlaneOutboundType string
+
+const (
+	// ManagedControlPlaneOutboundTypeLoadBalancer uses the cluster load balancer for egress through an AKS assigned public IP.
+	ManagedControlPlaneOutboundTypeLoadBalancer ManagedControlPlaneOutboundType = "loadBalancer"
+	// ManagedControlPlaneOutboundTypeUserDefinedRouting uses the egress paths defined by the route table of the cluster subnet.
+	ManagedControlPlaneOutboundTypeUserDefinedRouting ManagedControlPlaneOutboundType = "userDefinedRouting"
+	// ManagedControlPlaneOutboundTypeManagedNATGateway uses an AKS-managed NAT gateway for egress.
+	ManagedControlPlaneOutboundTypeManagedNATGateway ManagedControlPlaneOutboundType = "managedNATGateway"
+	// ManagedControlPlaneOutboundTypeUserAssignedNATGateway uses the NAT gateway associated to the cluster subnet for egress.
+	ManagedControlPlaneOutboundTypeUserAssignedNATGateway ManagedControlPlaneOutboundType = "userAssignedNATGateway"
+)
+
+// NatGatewayProfile - Profile of the managed cluster NAT gateway.
+type NatGatewayProfile struct {
+	// ManagedOutboundIPs - Desired number of outbound IPs created and managed by Azure for the cluster NAT gateway.
+	// Allowed values must be in the range of 1 to 16 (inclusive). The default value is 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	// +optional
+	ManagedOutboundIPs *int32 `json:"managedOutboundIPs,omitempty"`
+
+	// IdleTimeoutInMinutes - Desired outbound flow idle timeout in minutes. Allowed values must be in the range of 4 to 120 (inclusive). The default value is 4 minutes.
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+}
+
 // APIServerAccessProfile - access profile for AKS API server.
 type APIServerAccessProfile struct {
 	// AuthorizedIPRanges - Authorized IP Ranges to kubernetes API server.
@@ -195,23 +418,372 @@ type APIServerAccessProfile struct {
 	// +kubebuilder:validation:Enum=System;None
 	// +optional
 	PrivateDNSZone *string `json:"privateDNSZone,omitempty"`
+	// ManagedPrivateDNSZone - Private DNS zone for private cluster that is created, linked to virtual networks
+	// and deleted along with the cluster. It cannot be combined with PrivateDNSZone.
+	// +optional
+	ManagedPrivateDNSZone *ManagedPrivateDNSZone `json:"managedPrivateDNSZone,omitempty"`
 	// EnablePrivateClusterPublicFQDN - Whether to create additional public FQDN for private cluster or not.
 	// +optional
 	EnablePrivateClusterPublicFQDN *bool `json:"enablePrivateClusterPublicFQDN,omitempty"`
 }
 
+// ManagedPrivateDNSZone describes a private DNS zone of a private AKS cluster whose lifecycle is managed by CAPZ.
+// The zone is created in the resource group of the cluster, and the control plane identity is granted the
+// Private DNS Zone Contributor role on it.
+type ManagedPrivateDNSZone struct {
+	// Name is the name of the private DNS zone. It must be either privatelink.<location>.azmk8s.io or
+	// <subzone>.privatelink.<location>.azmk8s.io. Immutable.
+	Name string `json:"name"`
+
+	// AdditionalVNetLinks are virtual networks, such as the hub virtual networks of a hub-and-spoke topology,
+	// that the private DNS zone is linked to. AKS links the zone to the virtual network of the cluster itself.
+	// +optional
+	AdditionalVNetLinks []PrivateDNSZoneVNetLink `json:"additionalVNetLinks,omitempty"`
+}
+
+// PrivateDNSZoneVNetLink describes a link of a private DNS zone to a virtual network.
+type PrivateDNSZoneVNetLink struct {
+	// VNetResourceID is the resource ID of the virtual network.
+	VNetResourceID string `json:"vnetResourceID"`
+}
+
+// AutoScalerProfile is the parameters to be applied to the cluster-autoscaler.
+// See https://docs.microsoft.com/azure/aks/cluster-autoscaler#using-the-autoscaler-profile for more details.
+type AutoScalerProfile struct {
+	// BalanceSimilarNodeGroups - Valid values are 'true' and 'false'. The default is false.
+	// +kubebuilder:validation:Enum="true";"false"
+	// +optional
+	BalanceSimilarNodeGroups *string `json:"balanceSimilarNodeGroups,omitempty"`
+
+	// Expander - The expander to use when scaling up. Possible values include: 'least-waste', 'most-pods', 'priority', 'random'. The default is 'random'.
+	// +kubebuilder:validation:Enum=least-waste;most-pods;priority;random
+	// +optional
+	Expander *string `json:"expander,omitempty"`
+
+	// MaxEmptyBulkDelete - The maximum number of empty nodes that can be deleted at the same time. The default is 10.
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	// +optional
+	MaxEmptyBulkDelete *string `json:"maxEmptyBulkDelete,omitempty"`
+
+	// MaxGracefulTerminationSec - The maximum number of seconds the cluster autoscaler waits for pod termination when trying to scale down a node. The default is 600.
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	// +optional
+	MaxGracefulTerminationSec *string `json:"maxGracefulTerminationSec,omitempty"`
+
+	// MaxNodeProvisionTime - The maximum time the autoscaler waits for a node to be provisioned. The default is '15m'. Values must be an integer followed by an 'm'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+m$`
+	// +optional
+	MaxNodeProvisionTime *string `json:"maxNodeProvisionTime,omitempty"`
+
+	// MaxTotalUnreadyPercentage - The maximum percentage of unready nodes in the cluster, after which the cluster autoscaler halts operations. The default is 45.
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	// +optional
+	MaxTotalUnreadyPercentage *string `json:"maxTotalUnreadyPercentage,omitempty"`
+
+	// NewPodScaleUpDelay - Ignore unscheduled pods before they're a certain age, e.g. '0s', '10s' or '1m'. The default is '0s'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smh]$`
+	// +optional
+	NewPodScaleUpDelay *string `json:"newPodScaleUpDelay,omitempty"`
+
+	// OkTotalUnreadyCount - The number of allowed unready nodes, irrespective of max-total-unready-percentage. The default is 3.
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	// +optional
+	OkTotalUnreadyCount *string `json:"okTotalUnreadyCount,omitempty"`
+
+	// ScanInterval - How often the cluster is reevaluated for scale up or down, e.g. '10s'. The default is '10s'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+s$`
+	// +optional
+	ScanInterval *string `json:"scanInterval,omitempty"`
+
+	// ScaleDownDelayAfterAdd - How long after scale up that scale down evaluation resumes. The default is '10m'. Values must be an integer followed by an 'm'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+m$`
+	// +optional
+	ScaleDownDelayAfterAdd *string `json:"scaleDownDelayAfterAdd,omitempty"`
+
+	// ScaleDownDelayAfterDelete - How long after node deletion that scale down evaluation resumes, e.g. '10s'. The default is the scan-interval.
+	// +kubebuilder:validation:Pattern=`^[0-9]+s$`
+	// +optional
+	ScaleDownDelayAfterDelete *string `json:"scaleDownDelayAfterDelete,omitempty"`
+
+	// ScaleDownDelayAfterFailure - How long after scale down failure that scale down evaluation resumes. The default is '3m'. Values must be an integer followed by an 'm'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+m$`
+	// +optional
+	ScaleDownDelayAfterFailure *string `json:"scaleDownDelayAfterFailure,omitempty"`
+
+	// ScaleDownUnneededTime - How long a node should be unneeded before it is eligible for scale down. The default is '10m'. Values must be an integer followed by an 'm'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+m$`
+	// +optional
+	ScaleDownUnneededTime *string `json:"scaleDownUnneededTime,omitempty"`
+
+	// ScaleDownUnreadyTime - How long an unready node should be unneeded before it is eligible for scale down. The default is '20m'. Values must be an integer followed by an 'm'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+m$`
+	// +optional
+	ScaleDownUnreadyTime *string `json:"scaleDownUnreadyTime,omitempty"`
+
+	// ScaleDownUtilizationThreshold - Node utilization level, defined as sum of requested resources divided by capacity, below which a node can be considered for scale down. The default is '0.5'.
+	// +kubebuilder:validation:Pattern=`^(0\.[0-9]+|1(\.0+)?)$`
+	// +optional
+	ScaleDownUtilizationThreshold *string `json:"scaleDownUtilizationThreshold,omitempty"`
+
+	// SkipNodesWithLocalStorage - If cluster autoscaler will skip deleting nodes with pods with local storage, for example, EmptyDir or HostPath. The default is true.
+	// +kubebuilder:validation:Enum="true";"false"
+	// +optional
+	SkipNodesWithLocalStorage *string `json:"skipNodesWithLocalStorage,omitempty"`
+
+	// SkipNodesWithSystemPods - If cluster autoscaler will skip deleting nodes with pods from kube-system (except for DaemonSet or mirror pods). The default is true.
+	// +kubebuilder:validation:Enum="true";"false"
+	// +optional
+	SkipNodesWithSystemPods *string `json:"skipNodesWithSystemPods,omitempty"`
+}
+
+// UpgradeChannel - The channel used to automatically upgrade a cluster.
+// +kubebuilder:validation:Enum=node-image;none;patch;rapid;stable
+type UpgradeChannel string
+
+const (
+	// UpgradeChannelNodeImage automatically upgrades the node image to the latest version available.
+	UpgradeChannelNodeImage UpgradeChannel = "node-image"
+	// UpgradeChannelNone disables automatic upgrades.
+	UpgradeChannelNone UpgradeChannel = "none"
+	// UpgradeChannelPatch automatically upgrades the cluster to the latest supported patch version of its minor version.
+	UpgradeChannelPatch UpgradeChannel = "patch"
+	// UpgradeChannelRapid automatically upgrades the cluster to the latest supported patch release on the latest supported minor version.
+	UpgradeChannelRapid UpgradeChannel = "rapid"
+	// UpgradeChannelStable automatically upgrades the cluster to the latest supported patch release on minor version N-1.
+	UpgradeChannelStable UpgradeChannel = "stable"
+)
+
+// ManagedClusterAutoUpgradeProfile - Auto upgrade profile for a managed cluster.
+type ManagedClusterAutoUpgradeProfile struct {
+	// UpgradeChannel - The channel used to automatically upgrade the cluster. The default is 'none'.
+	// When the Kubernetes version is upgraded automatically, the upgrade is not reverted to the version of the spec.
+	// +optional
+	UpgradeChannel *UpgradeChannel `json:"upgradeChannel,omitempty"`
+}
+
+// MaintenanceConfiguration - A planned maintenance configuration of an AKS cluster.
+// See https://docs.microsoft.com/azure/aks/planned-maintenance for more details.
+type MaintenanceConfiguration struct {
+	// Name - The name of the maintenance configuration.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// TimeInWeek - The days of the week and the hours of those days when maintenance is allowed.
+	// +optional
+	TimeInWeek []TimeInWeek `json:"timeInWeek,omitempty"`
+
+	// NotAllowedTime - The time spans during which maintenance is not allowed.
+	// +optional
+	NotAllowedTime []TimeSpan `json:"notAllowedTime,omitempty"`
+}
+
+// TimeInWeek - The days of the week and the hours of those days when maintenance is allowed.
+type TimeInWeek struct {
+	// Day - The day of the week. Possible values include: 'Sunday', 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday'.
+	// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+	Day string `json:"day"`
+
+	// HourSlots - The hours of the day when maintenance is allowed, in UTC. Each slot is one hour long, e.g. 1 means 01:00 to 02:00.
+	// +optional
+	HourSlots []int32 `json:"hourSlots,omitempty"`
+}
+
+// TimeSpan - A time span during which maintenance is not allowed.
+type TimeSpan struct {
+	// Start - The start of the time span.
+	Start metav1.Time `json:"start"`
+
+	// End - The end of the time span.
+	End metav1.Time `json:"end"`
+}
+
+// OIDCIssuerProfile is the OIDC issuer profile of the Managed Cluster.
+type OIDCIssuerProfile struct {
+	// Enabled is whether the OIDC issuer is enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// WorkloadIdentityProfile is the workload identity profile of the Managed Cluster.
+// See https://azure.github.io/azure-workload-identity/docs/ for more details.
+type WorkloadIdentityProfile struct {
+	// Enabled is whether workload identity is enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// OIDCIssuerStatus is the OIDC issuer status of the Managed Cluster.
+type OIDCIssuerStatus struct {
+	// IssuerURL is the OIDC issuer URL of the Managed Cluster.
+	// +optional
+	IssuerURL *string `json:"issuerURL,omitempty"`
+}
+
+// ManagedIdentityKind selects one of the user-assigned identities of an AKS cluster.
+type ManagedIdentityKind string
+
+const (
+	// ManagedIdentityKindControlPlane is the user-assigned identity of the control plane.
+	ManagedIdentityKindControlPlane ManagedIdentityKind = "controlPlane"
+
+	// ManagedIdentityKindKubelet is the user-assigned identity of the kubelet.
+	ManagedIdentityKindKubelet ManagedIdentityKind = "kubelet"
+)
+
+const (
+	// RoleAssignmentScopeVirtualNetwork scopes a role assignment to the virtual network of the cluster.
+	RoleAssignmentScopeVirtualNetwork = "VirtualNetwork"
+
+	// RoleAssignmentScopeNodeResourceGroup scopes a role assignment to the node resource group of the cluster.
+	RoleAssignmentScopeNodeResourceGroup = "NodeResourceGroup"
+)
+
+// ManagedControlPlaneIdentity describes the user-assigned identities of an AKS cluster.
+type ManagedControlPlaneIdentity struct {
+	// ControlPlaneIdentityResourceID is the resource ID of the user-assigned identity of the control plane.
+	// If omitted, the control plane uses a system-assigned identity.
+	// +optional
+	ControlPlaneIdentityResourceID *string `json:"controlPlaneIdentityResourceID,omitempty"`
+
+	// KubeletIdentityResourceID is the resource ID of the user-assigned identity of the kubelet.
+	// It requires a user-assigned control plane identity that holds the Managed Identity Operator role on the kubelet identity.
+	// +optional
+	KubeletIdentityResourceID *string `json:"kubeletIdentityResourceID,omitempty"`
+
+	// RoleAssignments are the role assignments of the user-assigned identities.
+	// +optional
+	RoleAssignments []ManagedIdentityRoleAssignment `json:"roleAssignments,omitempty"`
+}
+
+// ManagedIdentityRoleAssignment describes a role assignment of a user-assigned identity of an AKS cluster.
+type ManagedIdentityRoleAssignment struct {
+	// Identity is the user-assigned identity that is assigned the role.
+	// +kubebuilder:validation:Enum=controlPlane;kubelet
+	Identity ManagedIdentityKind `json:"identity"`
+
+	// RoleDefinitionID is either the name of a role definition, such as 4d97b98b-1d4f-4787-a291-c67834d212e7 for
+	// the built-in Network Contributor role, or the resource ID of a role definition.
+	RoleDefinitionID string `json:"roleDefinitionID"`
+
+	// Scope is the scope of the role assignment: VirtualNetwork for the virtual network of the cluster,
+	// NodeResourceGroup for the node resource group of the cluster, or the resource ID of any other Azure resource,
+	// such as a container registry.
+	Scope string `json:"scope"`
+}
+
+// KubeconfigCredentialKind is the kind of credentials of the kubeconfig of an AKS cluster.
+type KubeconfigCredentialKind string
+
+const (
+	// KubeconfigCredentialKindAdmin uses the static admin credentials of the cluster.
+	KubeconfigCredentialKindAdmin KubeconfigCredentialKind = "admin"
+
+	// KubeconfigCredentialKindUser uses the user credentials of the cluster.
+	KubeconfigCredentialKindUser KubeconfigCredentialKind = "user"
+
+	// KubeconfigCredentialKindExec uses the kubelogin exec plugin, which logs in with a service principal.
+	KubeconfigCredentialKindExec KubeconfigCredentialKind = "exec"
+
+	// KubeconfigCredentialKindAADToken uses AAD tokens of a service principal in place of the kubelogin exec plugin.
+	KubeconfigCredentialKindAADToken KubeconfigCredentialKind = "aadToken"
+)
+
+// ManagedControlPlaneKubeconfig describes the kubeconfig of an AKS cluster.
+type ManagedControlPlaneKubeconfig struct {
+	// CredentialKind is the kind of credentials of the kubeconfig: admin, user, exec or aadToken. The default is admin.
+	// The exec kind configures the kubelogin exec plugin of the AAD kubeconfig to log in with the service principal,
+	// so the kubeconfig holds the service principal secret and requires the kubelogin binary.
+	// The aadToken kind replaces the kubelogin exec plugin of the AAD kubeconfig with AAD access tokens that CAPZ
+	// obtains with the service principal. The tokens are typically valid for 60 to 90 minutes. CAPZ replaces them in the
+	// kubeconfig secret at least every 10 minutes and before they expire, so clients must read the secret again to
+	// pick up new tokens.
+	// +kubebuilder:validation:Enum=admin;user;exec;aadToken
+	// +optional
+	CredentialKind *KubeconfigCredentialKind `json:"credentialKind,omitempty"`
+
+	// ServicePrincipalSecretRef references a Secret in the namespace of the AzureManagedControlPlane that holds the
+	// clientID and clientSecret keys of the service principal that logs in to the cluster.
+	// Required for the exec and aadToken credential kinds.
+	// +optional
+	ServicePrincipalSecretRef *corev1.LocalObjectReference `json:"servicePrincipalSecretRef,omitempty"`
+}
+
+// UpgradeOrchestration describes how Kubernetes version upgrades are rolled out to the agent pools of an AKS cluster.
+type UpgradeOrchestration struct {
+	// AgentPoolOrder is the names of the agent pools in the order they are upgraded. Agent pools that are not listed
+	// are upgraded after the listed ones, System mode agent pools first and then by name.
+	// +optional
+	AgentPoolOrder []string `json:"agentPoolOrder,omitempty"`
+
+	// MaxParallelAgentPools is the maximum number of agent pools that are upgraded at the same time. The default is 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxParallelAgentPools *int32 `json:"maxParallelAgentPools,omitempty"`
+}
+
+// AgentPoolUpgradePhase is the phase of the Kubernetes version upgrade of an agent pool.
+type AgentPoolUpgradePhase string
+
+const (
+	// AgentPoolUpgradePhaseUpToDate means the agent pool runs its desired Kubernetes version.
+	AgentPoolUpgradePhaseUpToDate AgentPoolUpgradePhase = "UpToDate"
+
+	// AgentPoolUpgradePhasePending means the agent pool waits for the control plane or for its turn to be upgraded.
+	AgentPoolUpgradePhasePending AgentPoolUpgradePhase = "Pending"
+
+	// AgentPoolUpgradePhaseUpgrading means the agent pool is being upgraded.
+	AgentPoolUpgradePhaseUpgrading AgentPoolUpgradePhase = "Upgrading"
+
+	// AgentPoolUpgradePhaseFailed means the upgrade of the agent pool failed. No other agent pool is upgraded until it
+	// recovers.
+	AgentPoolUpgradePhaseFailed AgentPoolUpgradePhase = "Failed"
+)
+
+// AgentPoolUpgradeStatus is the Kubernetes version upgrade progress of an agent pool.
+type AgentPoolUpgradeStatus struct {
+	// Name is the name of the agent pool.
+	Name string `json:"name"`
+
+	// CurrentVersion is the Kubernetes version the agent pool runs.
+	// +optional
+	CurrentVersion string `json:"currentVersion,omitempty"`
+
+	// TargetVersion is the desired Kubernetes version of the agent pool.
+	// +optional
+	TargetVersion string `json:"targetVersion,omitempty"`
+
+	// Phase is the phase of the upgrade.
+	Phase AgentPoolUpgradePhase `json:"phase"`
+}
+
 // ManagedControlPlaneVirtualNetwork describes a virtual network required to provision AKS clusters.
 type ManagedControlPlaneVirtualNetwork struct {
 	Name      string `json:"name"`
 	CIDRBlock string `json:"cidrBlock"`
 	// +optional
 	Subnet ManagedControlPlaneSubnet `json:"subnet,omitempty"`
+
+	// ResourceGroup is the name of the resource group of the virtual network.
+	// It must already exist and defaults to the resource group of the AzureManagedControlPlane.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
+	// AllowSubnetCreation allows CAPZ to create missing subnets in a virtual network that it does not manage.
+	// The virtual network itself is never modified, and subnets created this way are not deleted with the cluster.
+	// +optional
+	AllowSubnetCreation *bool `json:"allowSubnetCreation,omitempty"`
 }
 
 // ManagedControlPlaneSubnet describes a subnet for an AKS cluster.
 type ManagedControlPlaneSubnet struct {
 	Name      string `json:"name"`
 	CIDRBlock string `json:"cidrBlock"`
+
+	// RouteTable is the name of an existing route table in the resource group of the virtual network associated with the
+	// subnet when CAPZ creates it. The subnet must be associated with a route table when the OutboundType is
+	// userDefinedRouting.
+	// +optional
+	RouteTable string `json:"routeTable,omitempty"`
 }
 
 // AzureManagedControlPlaneStatus defines the observed state of AzureManagedControlPlane.
@@ -234,6 +806,30 @@ type AzureManagedControlPlaneStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OIDCIssuerProfile is the OIDC issuer status of the Managed Cluster, set when the OIDC issuer is enabled.
+	// +optional
+	OIDCIssuerProfile *OIDCIssuerStatus `json:"oidcIssuerProfile,omitempty"`
+
+	// AgentPoolUpgrades is the Kubernetes version upgrade progress of each agent pool, set when UpgradeOrchestration
+	// is enabled.
+	// +optional
+	AgentPoolUpgrades []AgentPoolUpgradeStatus `json:"agentPoolUpgrades,omitempty"`
+
+	// Addons is the observed state of the add-ons of the managed cluster.
+	// +optional
+	Addons []AddonStatus `json:"addons,omitempty"`
+
+	// MaintenanceConfigurations are the names of the planned maintenance configurations created by CAPZ. Only these
+	// are deleted when they are removed from the spec.
+	// +optional
+	MaintenanceConfigurations []string `json:"maintenanceConfigurations,omitempty"`
+
+	// RoleAssignments are the resource IDs of the role assignments of the user-assigned identities created by CAPZ.
+	// They are deleted when they are removed from the spec or when the cluster is deleted, including those whose scope
+	// is outside of the resource group of the cluster.
+	// +optional
+	RoleAssignments []string `json:"roleAssignments,omitempty"`
 }
 
 // +kubebuilder:object:root=true
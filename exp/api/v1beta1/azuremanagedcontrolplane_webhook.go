@@ -25,12 +25,16 @@ import (
 	"regexp"
 	"strings"
 
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -80,6 +84,11 @@ func (m *AzureManagedControlPlane) Default(_ client.Client) {
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (m *AzureManagedControlPlane) ValidateCreate(client client.Client) error {
+	// The outbound type is immutable, so its network prerequisites are only validated when the cluster is created.
+	if allErrs := m.validateOutboundTypeNetwork(); len(allErrs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedControlPlane").GroupKind(), m.Name, allErrs)
+	}
+
 	return m.Validate(client)
 }
 
@@ -120,6 +129,14 @@ func (m *AzureManagedControlPlane) ValidateUpdate(oldRaw runtime.Object, client
 				"field is immutable"))
 	}
 
+	if old.Spec.VirtualNetwork.ResourceGroup != "" && m.Spec.VirtualNetwork.ResourceGroup != old.Spec.VirtualNetwork.ResourceGroup {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "VirtualNetwork", "ResourceGroup"),
+				m.Spec.VirtualNetwork.ResourceGroup,
+				"field is immutable"))
+	}
+
 	if m.Spec.Location != old.Spec.Location {
 		allErrs = append(allErrs,
 			field.Invalid(
@@ -196,6 +213,44 @@ func (m *AzureManagedControlPlane) ValidateUpdate(oldRaw runtime.Object, client
 		}
 	}
 
+	if old.Spec.PodCIDR != nil {
+		// Prevent PodCIDR modification if it was already set to some value
+		if m.Spec.PodCIDR == nil {
+			// unsetting the field is not allowed
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("Spec", "PodCIDR"),
+					m.Spec.PodCIDR,
+					"field is immutable, unsetting is not allowed"))
+		} else if *m.Spec.PodCIDR != *old.Spec.PodCIDR {
+			// changing the field is not allowed
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("Spec", "PodCIDR"),
+					*m.Spec.PodCIDR,
+					"field is immutable"))
+		}
+	}
+
+	if old.Spec.ServiceCIDR != nil {
+		// Prevent ServiceCIDR modification if it was already set to some value
+		if m.Spec.ServiceCIDR == nil {
+			// unsetting the field is not allowed
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("Spec", "ServiceCIDR"),
+					m.Spec.ServiceCIDR,
+					"field is immutable, unsetting is not allowed"))
+		} else if *m.Spec.ServiceCIDR != *old.Spec.ServiceCIDR {
+			// changing the field is not allowed
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("Spec", "ServiceCIDR"),
+					*m.Spec.ServiceCIDR,
+					"field is immutable"))
+		}
+	}
+
 	if old.Spec.LoadBalancerSKU != nil {
 		// Prevent LoadBalancerSKU modification if it was already set to some value
 		if m.Spec.LoadBalancerSKU == nil {
@@ -244,6 +299,33 @@ func (m *AzureManagedControlPlane) ValidateUpdate(oldRaw runtime.Object, client
 		allErrs = append(allErrs, errs...)
 	}
 
+	if m.outboundType() != old.outboundType() {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "OutboundType"),
+				m.Spec.OutboundType,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.controlPlaneIdentityResourceIDs(), old.controlPlaneIdentityResourceIDs()) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "Identity"),
+				m.Spec.Identity,
+				"user-assigned identities are immutable, only role assignments can be changed"))
+	}
+
+	if old.isOIDCIssuerEnabled() && !m.isOIDCIssuerEnabled() {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				field.NewPath("Spec", "OIDCIssuerProfile", "Enabled"),
+				"OIDC issuer cannot be disabled once it has been enabled"))
+	}
+
+	if errs := m.validateImportAnnotationUpdate(old); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return m.Validate(client)
 	}
@@ -266,6 +348,14 @@ func (m *AzureManagedControlPlane) Validate(cli client.Client) error {
 		m.validateLoadBalancerProfile,
 		m.validateAPIServerAccessProfile,
 		m.validateManagedClusterNetwork,
+		m.validateMaintenanceConfigurations,
+		m.validateWorkloadIdentityProfile,
+		m.validateOutboundType,
+		m.validateIdentity,
+		m.validateKubeconfig,
+		m.validateImportAnnotation,
+		m.validateUpgradeOrchestration,
+		m.validateAddons,
 	}
 
 	var errs []error
@@ -368,6 +458,7 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfile(_ client.Clien
 				allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "APIServerAccessProfile", "AuthorizedIPRanges"), ipRange, "invalid CIDR format"))
 			}
 		}
+		allErrs = append(allErrs, m.validateManagedPrivateDNSZone()...)
 		if len(allErrs) > 0 {
 			return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
 		}
@@ -375,63 +466,315 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfile(_ client.Clien
 	return nil
 }
 
+// validateManagedPrivateDNSZone validates the private DNS zone managed by CAPZ.
+func (m *AzureManagedControlPlane) validateManagedPrivateDNSZone() field.ErrorList {
+	profile := m.Spec.APIServerAccessProfile
+	zone := profile.ManagedPrivateDNSZone
+	if zone == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	zonePath := field.NewPath("Spec", "APIServerAccessProfile", "ManagedPrivateDNSZone")
+
+	if !to.Bool(profile.EnablePrivateCluster) {
+		allErrs = append(allErrs, field.Forbidden(zonePath, "a managed private DNS zone requires EnablePrivateCluster to be true"))
+	}
+	if profile.PrivateDNSZone != nil {
+		allErrs = append(allErrs, field.Forbidden(zonePath, "a managed private DNS zone cannot be combined with PrivateDNSZone"))
+	}
+	if m.Spec.Identity == nil || m.Spec.Identity.ControlPlaneIdentityResourceID == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("Spec", "Identity", "ControlPlaneIdentityResourceID"),
+			"a managed private DNS zone requires a user-assigned control plane identity"))
+	}
+
+	suffix := fmt.Sprintf("privatelink.%s.azmk8s.io", strings.ToLower(m.Spec.Location))
+	if zone.Name != suffix && !strings.HasSuffix(zone.Name, "."+suffix) {
+		allErrs = append(allErrs, field.Invalid(zonePath.Child("Name"), zone.Name,
+			fmt.Sprintf("must be either %[1]s or <subzone>.%[1]s", suffix)))
+	}
+
+	vnetNames := map[string]bool{}
+	for i, link := range zone.AdditionalVNetLinks {
+		vnetPath := zonePath.Child("AdditionalVNetLinks").Index(i).Child("VNetResourceID")
+		if err := validateResourceID(link.VNetResourceID, "Microsoft.Network", "virtualNetworks"); err != nil {
+			allErrs = append(allErrs, field.Invalid(vnetPath, link.VNetResourceID, err.Error()))
+			continue
+		}
+		// Virtual network links are named after their virtual network.
+		vnet, _ := azureautorest.ParseResourceID(link.VNetResourceID)
+		if vnetNames[strings.ToLower(vnet.ResourceName)] {
+			allErrs = append(allErrs, field.Duplicate(vnetPath, link.VNetResourceID))
+		}
+		vnetNames[strings.ToLower(vnet.ResourceName)] = true
+	}
+
+	return allErrs
+}
+
 // validateManagedClusterNetwork validates the Cluster network values.
 func (m *AzureManagedControlPlane) validateManagedClusterNetwork(cli client.Client) error {
 	ctx := context.Background()
 
+	var (
+		allErrs         field.ErrorList
+		serviceCIDR     string
+		serviceCIDRPath *field.Path
+	)
+
+	if m.Spec.PodCIDR != nil {
+		if _, _, err := net.ParseCIDR(*m.Spec.PodCIDR); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "PodCIDR"), *m.Spec.PodCIDR, fmt.Sprintf("failed to parse pod cidr: %v", err)))
+		}
+	}
+
+	if m.Spec.ServiceCIDR != nil {
+		serviceCIDR = *m.Spec.ServiceCIDR
+		serviceCIDRPath = field.NewPath("Spec", "ServiceCIDR")
+	}
+
 	// Fetch the Cluster.
-	clusterName, ok := m.Labels[clusterv1.ClusterLabelName]
-	if !ok {
-		return nil
+	if clusterName, ok := m.Labels[clusterv1.ClusterLabelName]; ok {
+		ownerCluster := &clusterv1.Cluster{}
+		key := client.ObjectKey{
+			Namespace: m.Namespace,
+			Name:      clusterName,
+		}
+
+		if err := cli.Get(ctx, key, ownerCluster); err != nil {
+			return err
+		}
+
+		if clusterNetwork := ownerCluster.Spec.ClusterNetwork; clusterNetwork != nil {
+			if clusterNetwork.Services != nil {
+				servicesPath := field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks")
+				// A user may provide zero or one CIDR blocks. If they provide an empty array,
+				// we ignore it and use the default. AKS doesn't support > 1 Service/Pod CIDR.
+				if len(clusterNetwork.Services.CIDRBlocks) > 1 {
+					allErrs = append(allErrs, field.TooMany(servicesPath, len(clusterNetwork.Services.CIDRBlocks), 1))
+				}
+				if len(clusterNetwork.Services.CIDRBlocks) == 1 {
+					clusterServiceCIDR := clusterNetwork.Services.CIDRBlocks[0]
+					if m.Spec.ServiceCIDR != nil && *m.Spec.ServiceCIDR != clusterServiceCIDR {
+						allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "ServiceCIDR"), *m.Spec.ServiceCIDR, fmt.Sprintf("must match the cluster service cidr %s when both are specified", clusterServiceCIDR)))
+					}
+					if serviceCIDR == "" {
+						serviceCIDR = clusterServiceCIDR
+						serviceCIDRPath = servicesPath
+					}
+				}
+			}
+			if clusterNetwork.Pods != nil {
+				// A user may provide zero or one CIDR blocks. If they provide an empty array,
+				// we ignore it and use the default. AKS doesn't support > 1 Service/Pod CIDR.
+				if len(clusterNetwork.Pods.CIDRBlocks) > 1 {
+					allErrs = append(allErrs, field.TooMany(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Pods", "CIDRBlocks"), len(clusterNetwork.Pods.CIDRBlocks), 1))
+				}
+				if len(clusterNetwork.Pods.CIDRBlocks) == 1 && m.Spec.PodCIDR != nil && *m.Spec.PodCIDR != clusterNetwork.Pods.CIDRBlocks[0] {
+					allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "PodCIDR"), *m.Spec.PodCIDR, fmt.Sprintf("must match the cluster pod cidr %s when both are specified", clusterNetwork.Pods.CIDRBlocks[0])))
+				}
+			}
+		}
+
+		if m.Spec.DNSServiceIP != nil && serviceCIDR == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks"), "service CIDR must be specified if specifying DNSServiceIP"))
+		}
 	}
 
-	ownerCluster := &clusterv1.Cluster{}
-	key := client.ObjectKey{
-		Namespace: m.Namespace,
-		Name:      clusterName,
+	if serviceCIDR != "" {
+		_, cidr, err := net.ParseCIDR(serviceCIDR)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(serviceCIDRPath, serviceCIDR, fmt.Sprintf("failed to parse cluster service cidr: %v", err)))
+		} else if m.Spec.DNSServiceIP != nil && !cidr.Contains(net.ParseIP(*m.Spec.DNSServiceIP)) {
+			allErrs = append(allErrs, field.Invalid(serviceCIDRPath, serviceCIDR, "DNSServiceIP must reside within the associated cluster serviceCIDR"))
+		}
 	}
 
-	if err := cli.Get(ctx, key, ownerCluster); err != nil {
-		return err
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
 	}
+	return nil
+}
 
-	var (
-		allErrs     field.ErrorList
-		serviceCIDR string
-	)
+// validateMaintenanceConfigurations validates the MaintenanceConfigurations.
+func (m *AzureManagedControlPlane) validateMaintenanceConfigurations(_ client.Client) error {
+	var allErrs field.ErrorList
+	names := make(map[string]struct{}, len(m.Spec.MaintenanceConfigurations))
+	for i, config := range m.Spec.MaintenanceConfigurations {
+		configPath := field.NewPath("Spec", "MaintenanceConfigurations").Index(i)
+		if _, ok := names[config.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(configPath.Child("Name"), config.Name))
+		}
+		names[config.Name] = struct{}{}
 
-	if clusterNetwork := ownerCluster.Spec.ClusterNetwork; clusterNetwork != nil {
-		if clusterNetwork.Services != nil {
-			// A user may provide zero or one CIDR blocks. If they provide an empty array,
-			// we ignore it and use the default. AKS doesn't support > 1 Service/Pod CIDR.
-			if len(clusterNetwork.Services.CIDRBlocks) > 1 {
-				allErrs = append(allErrs, field.TooMany(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks"), len(clusterNetwork.Services.CIDRBlocks), 1))
+		for j, timeInWeek := range config.TimeInWeek {
+			for k, hourSlot := range timeInWeek.HourSlots {
+				if hourSlot < 0 || hourSlot > 23 {
+					allErrs = append(allErrs, field.Invalid(configPath.Child("TimeInWeek").Index(j).Child("HourSlots").Index(k), hourSlot, "value should be in between 0 and 23"))
+				}
 			}
-			if len(clusterNetwork.Services.CIDRBlocks) == 1 {
-				serviceCIDR = clusterNetwork.Services.CIDRBlocks[0]
+		}
+
+		for j, notAllowedTime := range config.NotAllowedTime {
+			if !notAllowedTime.End.After(notAllowedTime.Start.Time) {
+				allErrs = append(allErrs, field.Invalid(configPath.Child("NotAllowedTime").Index(j).Child("End"), notAllowedTime.End, "end must be after start"))
 			}
 		}
-		if clusterNetwork.Pods != nil {
-			// A user may provide zero or one CIDR blocks. If they provide an empty array,
-			// we ignore it and use the default. AKS doesn't support > 1 Service/Pod CIDR.
-			if len(clusterNetwork.Pods.CIDRBlocks) > 1 {
-				allErrs = append(allErrs, field.TooMany(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Pods", "CIDRBlocks"), len(clusterNetwork.Pods.CIDRBlocks), 1))
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateOutboundType validates the OutboundType and the egress profiles that depend on it.
+// The route table or NAT gateway of the subnet required by some outbound types can be associated outside of CAPZ, so
+// it is checked when the managed cluster is created and reported in the ManagedClusterRunning condition instead.
+func (m *AzureManagedControlPlane) validateOutboundType(_ client.Client) error {
+	var allErrs field.ErrorList
+	outboundType := m.outboundType()
+
+	if m.Spec.LoadBalancerProfile != nil && outboundType != ManagedControlPlaneOutboundTypeLoadBalancer {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "LoadBalancerProfile"),
+			fmt.Sprintf("load balancer profile is only allowed when outbound type is %s", ManagedControlPlaneOutboundTypeLoadBalancer)))
+	}
+
+	if m.Spec.NatGatewayProfile != nil && outboundType != ManagedControlPlaneOutboundTypeManagedNATGateway {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "NatGatewayProfile"),
+			fmt.Sprintf("NAT gateway profile is only allowed when outbound type is %s", ManagedControlPlaneOutboundTypeManagedNATGateway)))
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateOutboundTypeNetwork validates that the subnet of the cluster can provide the egress path required by the
+// OutboundType. A virtual network created by CAPZ is only associated with a route table when
+// VirtualNetwork.Subnet.RouteTable is set, and never with a NAT gateway, so these outbound types otherwise require a
+// virtual network brought by the user, in another resource group than the cluster.
+func (m *AzureManagedControlPlane) validateOutboundTypeNetwork() field.ErrorList {
+	var allErrs field.ErrorList
+	byoVirtualNetwork := m.Spec.VirtualNetwork.ResourceGroup != "" && m.Spec.VirtualNetwork.ResourceGroup != m.Spec.ResourceGroupName
+
+	switch m.outboundType() {
+	case ManagedControlPlaneOutboundTypeUserDefinedRouting:
+		if !byoVirtualNetwork && m.Spec.VirtualNetwork.Subnet.RouteTable == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("Spec", "VirtualNetwork", "Subnet", "RouteTable"),
+				fmt.Sprintf("a route table or an existing virtual network in another resource group is required when outbound type is %s", ManagedControlPlaneOutboundTypeUserDefinedRouting)))
+		}
+	case ManagedControlPlaneOutboundTypeUserAssignedNATGateway:
+		if !byoVirtualNetwork {
+			allErrs = append(allErrs, field.Required(field.NewPath("Spec", "VirtualNetwork", "ResourceGroup"),
+				fmt.Sprintf("an existing virtual network in another resource group is required when outbound type is %s", ManagedControlPlaneOutboundTypeUserAssignedNATGateway)))
+		}
+	}
+
+	return allErrs
+}
+
+// outboundType returns the OutboundType, or its default value if it is not set.
+func (m *AzureManagedControlPlane) outboundType() ManagedControlPlaneOutboundType {
+	if m.Spec.OutboundType == nil {
+		return ManagedControlPlaneOutboundTypeLoadBalancer
+	}
+	return *m.Spec.OutboundType
+}
+
+// validateWorkloadIdentityProfile validates the WorkloadIdentityProfile.
+func (m *AzureManagedControlPlane) validateWorkloadIdentityProfile(_ client.Client) error {
+	if m.Spec.WorkloadIdentityProfile != nil && to.Bool(m.Spec.WorkloadIdentityProfile.Enabled) && !m.isOIDCIssuerEnabled() {
+		return field.Invalid(field.NewPath("Spec", "WorkloadIdentityProfile", "Enabled"), m.Spec.WorkloadIdentityProfile.Enabled,
+			"workload identity requires the OIDC issuer to be enabled")
+	}
+	return nil
+}
+
+// validateIdentity validates the Identity.
+func (m *AzureManagedControlPlane) validateIdentity(_ client.Client) error {
+	if m.Spec.Identity == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	identityPath := field.NewPath("Spec", "Identity")
+	identityIDs := map[ManagedIdentityKind]*string{
+		ManagedIdentityKindControlPlane: m.Spec.Identity.ControlPlaneIdentityResourceID,
+		ManagedIdentityKindKubelet:      m.Spec.Identity.KubeletIdentityResourceID,
+	}
+
+	if m.Spec.Identity.ControlPlaneIdentityResourceID != nil {
+		if _, err := azureautorest.ParseResourceID(*m.Spec.Identity.ControlPlaneIdentityResourceID); err != nil {
+			allErrs = append(allErrs, field.Invalid(identityPath.Child("ControlPlaneIdentityResourceID"), *m.Spec.Identity.ControlPlaneIdentityResourceID, err.Error()))
+		}
+	}
+
+	if m.Spec.Identity.KubeletIdentityResourceID != nil {
+		if _, err := azureautorest.ParseResourceID(*m.Spec.Identity.KubeletIdentityResourceID); err != nil {
+			allErrs = append(allErrs, field.Invalid(identityPath.Child("KubeletIdentityResourceID"), *m.Spec.Identity.KubeletIdentityResourceID, err.Error()))
+		}
+		if m.Spec.Identity.ControlPlaneIdentityResourceID == nil {
+			allErrs = append(allErrs, field.Required(identityPath.Child("ControlPlaneIdentityResourceID"),
+				"a user-assigned control plane identity is required when specifying a kubelet identity"))
+		}
+	}
+
+	for i, roleAssignment := range m.Spec.Identity.RoleAssignments {
+		roleAssignmentPath := identityPath.Child("RoleAssignments").Index(i)
+		if identityIDs[roleAssignment.Identity] == nil {
+			allErrs = append(allErrs, field.Invalid(roleAssignmentPath.Child("Identity"), roleAssignment.Identity,
+				"role assignments require the identity to be user-assigned"))
+		}
+		if roleAssignment.RoleDefinitionID == "" {
+			allErrs = append(allErrs, field.Required(roleAssignmentPath.Child("RoleDefinitionID"), "role definition ID must be specified"))
+		}
+		switch roleAssignment.Scope {
+		case RoleAssignmentScopeVirtualNetwork, RoleAssignmentScopeNodeResourceGroup:
+		default:
+			if !strings.HasPrefix(strings.ToLower(roleAssignment.Scope), "/subscriptions/") {
+				allErrs = append(allErrs, field.Invalid(roleAssignmentPath.Child("Scope"), roleAssignment.Scope,
+					fmt.Sprintf("scope must be %s, %s or an Azure resource ID", RoleAssignmentScopeVirtualNetwork, RoleAssignmentScopeNodeResourceGroup)))
 			}
 		}
 	}
 
-	if m.Spec.DNSServiceIP != nil {
-		if serviceCIDR == "" {
-			allErrs = append(allErrs, field.Required(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks"), "service CIDR must be specified if specifying DNSServiceIP"))
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateKubeconfig validates the Kubeconfig and DisableLocalAccounts.
+func (m *AzureManagedControlPlane) validateKubeconfig(_ client.Client) error {
+	var allErrs field.ErrorList
+	credentialKind := m.kubeconfigCredentialKind()
+	servicePrincipalLogin := credentialKind == KubeconfigCredentialKindExec || credentialKind == KubeconfigCredentialKindAADToken
+
+	if to.Bool(m.Spec.DisableLocalAccounts) {
+		if m.Spec.AADProfile == nil {
+			allErrs = append(allErrs, field.Required(field.NewPath("Spec", "AADProfile"),
+				"local accounts can only be disabled for AAD-enabled clusters"))
 		}
-		_, cidr, err := net.ParseCIDR(serviceCIDR)
-		if err != nil {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks"), serviceCIDR, fmt.Sprintf("failed to parse cluster service cidr: %v", err)))
+		if !servicePrincipalLogin {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "Kubeconfig", "CredentialKind"), credentialKind,
+				fmt.Sprintf("credential kind must be %s or %s when local accounts are disabled", KubeconfigCredentialKindExec, KubeconfigCredentialKindAADToken)))
+		}
+	}
+
+	if servicePrincipalLogin {
+		if m.Spec.AADProfile == nil {
+			allErrs = append(allErrs, field.Required(field.NewPath("Spec", "AADProfile"),
+				fmt.Sprintf("credential kind %s requires an AAD-enabled cluster", credentialKind)))
 		}
-		ip := net.ParseIP(*m.Spec.DNSServiceIP)
-		if !cidr.Contains(ip) {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("Cluster", "Spec", "ClusterNetwork", "Services", "CIDRBlocks"), serviceCIDR, "DNSServiceIP must reside within the associated cluster serviceCIDR"))
+		if m.Spec.Kubeconfig.ServicePrincipalSecretRef == nil || m.Spec.Kubeconfig.ServicePrincipalSecretRef.Name == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("Spec", "Kubeconfig", "ServicePrincipalSecretRef"),
+				fmt.Sprintf("a service principal secret is required for credential kind %s", credentialKind)))
 		}
+	} else if m.Spec.Kubeconfig != nil && m.Spec.Kubeconfig.ServicePrincipalSecretRef != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "Kubeconfig", "ServicePrincipalSecretRef"),
+			fmt.Sprintf("a service principal secret is only allowed for credential kinds %s and %s", KubeconfigCredentialKindExec, KubeconfigCredentialKindAADToken)))
 	}
 
 	if len(allErrs) > 0 {
@@ -440,6 +783,202 @@ func (m *AzureManagedControlPlane) validateManagedClusterNetwork(cli client.Clie
 	return nil
 }
 
+// validateUpgradeOrchestration validates the UpgradeOrchestration.
+func (m *AzureManagedControlPlane) validateUpgradeOrchestration(_ client.Client) error {
+	if m.Spec.UpgradeOrchestration == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	orderPath := field.NewPath("Spec", "UpgradeOrchestration", "AgentPoolOrder")
+	seen := make(map[string]bool, len(m.Spec.UpgradeOrchestration.AgentPoolOrder))
+	for i, name := range m.Spec.UpgradeOrchestration.AgentPoolOrder {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(orderPath.Index(i), "agent pool name must not be empty"))
+		} else if seen[name] {
+			allErrs = append(allErrs, field.Duplicate(orderPath.Index(i), name))
+		}
+		seen[name] = true
+	}
+
+	if maxParallel := m.Spec.UpgradeOrchestration.MaxParallelAgentPools; maxParallel != nil && *maxParallel < 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("Spec", "UpgradeOrchestration", "MaxParallelAgentPools"), *maxParallel,
+			"must be at least 1"))
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validateAddons validates the Addons.
+func (m *AzureManagedControlPlane) validateAddons(_ client.Client) error {
+	addons := m.Spec.Addons
+	if addons == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	addonsPath := field.NewPath("Spec", "Addons")
+
+	typedNames := map[string]bool{}
+	if addons.Monitoring != nil {
+		typedNames[strings.ToLower(MonitoringAddonName)] = true
+	}
+	if addons.AzurePolicy != nil {
+		typedNames[strings.ToLower(AzurePolicyAddonName)] = true
+	}
+	if addons.KeyVaultSecretsProvider != nil {
+		typedNames[strings.ToLower(KeyVaultSecretsProviderAddonName)] = true
+	}
+	if addons.IngressApplicationGateway != nil {
+		typedNames[strings.ToLower(IngressApplicationGatewayAddonName)] = true
+	}
+	for i, profile := range m.Spec.AddonProfiles {
+		if typedNames[strings.ToLower(profile.Name)] {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("Spec", "AddonProfiles").Index(i).Child("Name"),
+				fmt.Sprintf("add-on %s is already configured in Addons", profile.Name)))
+		}
+	}
+
+	if monitoring := addons.Monitoring; monitoring != nil && monitoring.Enabled {
+		workspacePath := addonsPath.Child("Monitoring", "LogAnalyticsWorkspaceResourceID")
+		if monitoring.LogAnalyticsWorkspaceResourceID == "" {
+			allErrs = append(allErrs, field.Required(workspacePath, "a Log Analytics workspace is required when monitoring is enabled"))
+		} else if err := validateResourceID(monitoring.LogAnalyticsWorkspaceResourceID, "Microsoft.OperationalInsights", "workspaces"); err != nil {
+			allErrs = append(allErrs, field.Invalid(workspacePath, monitoring.LogAnalyticsWorkspaceResourceID, err.Error()))
+		}
+	}
+
+	if secretsProvider := addons.KeyVaultSecretsProvider; secretsProvider != nil && secretsProvider.RotationPollInterval != nil {
+		intervalPath := addonsPath.Child("KeyVaultSecretsProvider", "RotationPollInterval")
+		if !to.Bool(secretsProvider.EnableSecretRotation) {
+			allErrs = append(allErrs, field.Forbidden(intervalPath, "a rotation poll interval requires secret rotation to be enabled"))
+		}
+		if secretsProvider.RotationPollInterval.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(intervalPath, secretsProvider.RotationPollInterval.Duration.String(), "must be positive"))
+		}
+	}
+
+	if ingress := addons.IngressApplicationGateway; ingress != nil && ingress.Enabled {
+		allErrs = append(allErrs, ingress.validate(addonsPath.Child("IngressApplicationGateway"))...)
+	}
+
+	if len(allErrs) > 0 {
+		return kerrors.NewAggregate(allErrs.ToAggregate().Errors())
+	}
+	return nil
+}
+
+// validate validates that the add-on either uses an existing application gateway, or has AKS create one in exactly
+// one new or existing subnet.
+func (a *IngressApplicationGatewayAddon) validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if a.ApplicationGatewayID != nil {
+		if err := validateResourceID(*a.ApplicationGatewayID, "Microsoft.Network", "applicationGateways"); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ApplicationGatewayID"), *a.ApplicationGatewayID, err.Error()))
+		}
+		if a.ApplicationGatewayName != nil || a.SubnetCIDR != nil || a.SubnetID != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ApplicationGatewayID"),
+				"an existing application gateway is not allowed with an application gateway name, subnet CIDR or subnet ID"))
+		}
+		return allErrs
+	}
+
+	switch {
+	case a.SubnetCIDR == nil && a.SubnetID == nil:
+		allErrs = append(allErrs, field.Required(fldPath.Child("SubnetCIDR"),
+			"either an existing application gateway, a subnet CIDR or a subnet ID is required"))
+	case a.SubnetCIDR != nil && a.SubnetID != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("SubnetID"), "not allowed with a subnet CIDR"))
+	case a.SubnetCIDR != nil:
+		if _, _, err := net.ParseCIDR(*a.SubnetCIDR); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("SubnetCIDR"), *a.SubnetCIDR, "invalid CIDR format"))
+		}
+	default:
+		if err := validateResourceID(*a.SubnetID, "Microsoft.Network", "virtualNetworks"); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("SubnetID"), *a.SubnetID, err.Error()))
+		} else if !strings.Contains(strings.ToLower(*a.SubnetID), "/subnets/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("SubnetID"), *a.SubnetID, "must be the resource ID of a subnet"))
+		}
+	}
+	return allErrs
+}
+
+// validateResourceID returns an error if the resource ID is invalid or does not belong to the given provider and resource type.
+func validateResourceID(resourceID, provider, resourceType string) error {
+	resource, err := azureautorest.ParseResourceID(resourceID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(resource.Provider, provider) || !strings.EqualFold(resource.ResourceType, resourceType) {
+		return fmt.Errorf("must be the resource ID of a %s/%s resource", provider, resourceType)
+	}
+	return nil
+}
+
+// kubeconfigCredentialKind returns the kubeconfig CredentialKind, or its default value if it is not set.
+func (m *AzureManagedControlPlane) kubeconfigCredentialKind() KubeconfigCredentialKind {
+	if m.Spec.Kubeconfig == nil || m.Spec.Kubeconfig.CredentialKind == nil {
+		return KubeconfigCredentialKindAdmin
+	}
+	return *m.Spec.Kubeconfig.CredentialKind
+}
+
+// validateImportAnnotation validates the value of the import annotation.
+func (m *AzureManagedControlPlane) validateImportAnnotation(_ client.Client) error {
+	value, ok := m.Annotations[azure.ManagedClusterImportAnnotation]
+	if !ok || value == azure.ManagedClusterImportPending || value == azure.ManagedClusterImportConfirmed {
+		return nil
+	}
+	return field.NotSupported(field.NewPath("ObjectMeta", "Annotations").Key(azure.ManagedClusterImportAnnotation), value,
+		[]string{azure.ManagedClusterImportPending, azure.ManagedClusterImportConfirmed})
+}
+
+// validateImportAnnotationUpdate validates update to the import annotation.
+func (m *AzureManagedControlPlane) validateImportAnnotationUpdate(old *AzureManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+	annotationPath := field.NewPath("ObjectMeta", "Annotations").Key(azure.ManagedClusterImportAnnotation)
+	oldValue, oldOK := old.Annotations[azure.ManagedClusterImportAnnotation]
+	value, ok := m.Annotations[azure.ManagedClusterImportAnnotation]
+
+	if ok && !oldOK {
+		allErrs = append(allErrs, field.Forbidden(annotationPath, "an existing managed cluster can only be imported when the AzureManagedControlPlane is created"))
+	}
+	if oldValue == azure.ManagedClusterImportConfirmed && value == azure.ManagedClusterImportPending {
+		allErrs = append(allErrs, field.Forbidden(annotationPath, "a confirmed import cannot be reverted to pending"))
+	}
+	if oldValue == azure.ManagedClusterImportPending && value == azure.ManagedClusterImportConfirmed &&
+		conditions.GetReason(old, infrav1.ManagedClusterImportedCondition) == infrav1.ManagedClusterImportConflictReason {
+		allErrs = append(allErrs, field.Forbidden(annotationPath, "an import cannot be confirmed while it has conflicts, see the ManagedClusterImported condition"))
+	}
+
+	return allErrs
+}
+
+// controlPlaneIdentityResourceIDs returns the resource IDs of the user-assigned identities of the control plane and the kubelet.
+func (m *AzureManagedControlPlane) controlPlaneIdentityResourceIDs() []*string {
+	if m.Spec.Identity == nil {
+		return []*string{nil, nil}
+	}
+	return []*string{m.Spec.Identity.ControlPlaneIdentityResourceID, m.Spec.Identity.KubeletIdentityResourceID}
+}
+
+// managedPrivateDNSZoneName returns the name of the private DNS zone managed by CAPZ, or an empty string.
+func (m *AzureManagedControlPlane) managedPrivateDNSZoneName() string {
+	if m.Spec.APIServerAccessProfile == nil || m.Spec.APIServerAccessProfile.ManagedPrivateDNSZone == nil {
+		return ""
+	}
+	return m.Spec.APIServerAccessProfile.ManagedPrivateDNSZone.Name
+}
+
+// isOIDCIssuerEnabled returns true if the OIDC issuer is enabled.
+func (m *AzureManagedControlPlane) isOIDCIssuerEnabled() bool {
+	return m.Spec.OIDCIssuerProfile != nil && to.Bool(m.Spec.OIDCIssuerProfile.Enabled)
+}
+
 // validateAPIServerAccessProfileUpdate validates update to APIServerAccessProfile.
 func (m *AzureManagedControlPlane) validateAPIServerAccessProfileUpdate(old *AzureManagedControlPlane) field.ErrorList {
 	var allErrs field.ErrorList
@@ -461,10 +1000,17 @@ func (m *AzureManagedControlPlane) validateAPIServerAccessProfileUpdate(old *Azu
 		}
 	}
 
+	if name := m.managedPrivateDNSZoneName(); name != "" {
+		newAPIServerAccessProfileNormalized.ManagedPrivateDNSZone = &ManagedPrivateDNSZone{Name: name}
+	}
+	if name := old.managedPrivateDNSZoneName(); name != "" {
+		oldAPIServerAccessProfileNormalized.ManagedPrivateDNSZone = &ManagedPrivateDNSZone{Name: name}
+	}
+
 	if !reflect.DeepEqual(newAPIServerAccessProfileNormalized, oldAPIServerAccessProfileNormalized) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("Spec", "APIServerAccessProfile"),
-				m.Spec.APIServerAccessProfile, "fields (except for AuthorizedIPRanges) are immutable"),
+				m.Spec.APIServerAccessProfile, "fields (except for AuthorizedIPRanges and the virtual network links of ManagedPrivateDNSZone) are immutable"),
 		)
 	}
 
@@ -18,11 +18,16 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestDefaultingWebhook(t *testing.T) {
@@ -47,6 +52,7 @@ func TestDefaultingWebhook(t *testing.T) {
 	g.Expect(amcp.Spec.SSHPublicKey).NotTo(BeEmpty())
 	g.Expect(amcp.Spec.NodeResourceGroupName).To(Equal("MC_fooRg_fooName_fooLocation"))
 	g.Expect(amcp.Spec.VirtualNetwork.Name).To(Equal("fooName"))
+	g.Expect(amcp.Spec.VirtualNetwork.ResourceGroup).To(Equal("fooRg"))
 	g.Expect(amcp.Spec.VirtualNetwork.Subnet.Name).To(Equal("fooName"))
 	g.Expect(amcp.Spec.SKU.Tier).To(Equal(FreeManagedControlPlaneTier))
 
@@ -61,6 +67,7 @@ func TestDefaultingWebhook(t *testing.T) {
 	amcp.Spec.SSHPublicKey = ""
 	amcp.Spec.NodeResourceGroupName = "fooNodeRg"
 	amcp.Spec.VirtualNetwork.Name = "fooVnetName"
+	amcp.Spec.VirtualNetwork.ResourceGroup = "fooVnetRg"
 	amcp.Spec.VirtualNetwork.Subnet.Name = "fooSubnetName"
 	amcp.Spec.SKU.Tier = PaidManagedControlPlaneTier
 
@@ -72,6 +79,7 @@ func TestDefaultingWebhook(t *testing.T) {
 	g.Expect(amcp.Spec.SSHPublicKey).NotTo(BeEmpty())
 	g.Expect(amcp.Spec.NodeResourceGroupName).To(Equal("fooNodeRg"))
 	g.Expect(amcp.Spec.VirtualNetwork.Name).To(Equal("fooVnetName"))
+	g.Expect(amcp.Spec.VirtualNetwork.ResourceGroup).To(Equal("fooVnetRg"))
 	g.Expect(amcp.Spec.VirtualNetwork.Subnet.Name).To(Equal("fooSubnetName"))
 	g.Expect(amcp.Spec.SKU.Tier).To(Equal(PaidManagedControlPlaneTier))
 }
@@ -102,6 +110,512 @@ func TestValidatingWebhook(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "Testing valid user-assigned identities with role assignments",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+						KubeletIdentityResourceID:      pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kubelet"),
+						RoleAssignments: []ManagedIdentityRoleAssignment{
+							{
+								Identity:         ManagedIdentityKindControlPlane,
+								RoleDefinitionID: "4d97b98b-1d4f-4787-a291-c67834d212e7",
+								Scope:            RoleAssignmentScopeVirtualNetwork,
+							},
+							{
+								Identity:         ManagedIdentityKindKubelet,
+								RoleDefinitionID: "7f951dda-4ed3-4680-a7ca-43fe172d538d",
+								Scope:            "/subscriptions/123/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/acr",
+							},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing kubelet identity without control plane identity",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Identity: &ManagedControlPlaneIdentity{
+						KubeletIdentityResourceID: pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kubelet"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing role assignment for a system-assigned identity",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Identity: &ManagedControlPlaneIdentity{
+						RoleAssignments: []ManagedIdentityRoleAssignment{
+							{
+								Identity:         ManagedIdentityKindControlPlane,
+								RoleDefinitionID: "4d97b98b-1d4f-4787-a291-c67834d212e7",
+								Scope:            RoleAssignmentScopeVirtualNetwork,
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing role assignment with invalid scope",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+						RoleAssignments: []ManagedIdentityRoleAssignment{
+							{
+								Identity:         ManagedIdentityKindControlPlane,
+								RoleDefinitionID: "4d97b98b-1d4f-4787-a291-c67834d212e7",
+								Scope:            "Subnet",
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing local accounts disabled with aadToken credentials",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:              "v1.17.8",
+					AADProfile:           &AADProfile{Managed: true, AdminGroupObjectIDs: []string{"616077a8-5db7-4c98-b856-b34619afg75h"}},
+					DisableLocalAccounts: pointer.BoolPtr(true),
+					Kubeconfig: &ManagedControlPlaneKubeconfig{
+						CredentialKind:            credentialKindPtr(KubeconfigCredentialKindAADToken),
+						ServicePrincipalSecretRef: &corev1.LocalObjectReference{Name: "kubelogin-sp"},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing local accounts disabled with exec credentials",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:              "v1.17.8",
+					AADProfile:           &AADProfile{Managed: true, AdminGroupObjectIDs: []string{"616077a8-5db7-4c98-b856-b34619afg75h"}},
+					DisableLocalAccounts: pointer.BoolPtr(true),
+					Kubeconfig: &ManagedControlPlaneKubeconfig{
+						CredentialKind:            credentialKindPtr(KubeconfigCredentialKindExec),
+						ServicePrincipalSecretRef: &corev1.LocalObjectReference{Name: "kubelogin-sp"},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing exec credentials without a service principal secret",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:    "v1.17.8",
+					AADProfile: &AADProfile{Managed: true, AdminGroupObjectIDs: []string{"616077a8-5db7-4c98-b856-b34619afg75h"}},
+					Kubeconfig: &ManagedControlPlaneKubeconfig{
+						CredentialKind: credentialKindPtr(KubeconfigCredentialKindExec),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing local accounts disabled with admin credentials",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:              "v1.17.8",
+					AADProfile:           &AADProfile{Managed: true, AdminGroupObjectIDs: []string{"616077a8-5db7-4c98-b856-b34619afg75h"}},
+					DisableLocalAccounts: pointer.BoolPtr(true),
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing aadToken credentials without AAD",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Kubeconfig: &ManagedControlPlaneKubeconfig{
+						CredentialKind:            credentialKindPtr(KubeconfigCredentialKindAADToken),
+						ServicePrincipalSecretRef: &corev1.LocalObjectReference{Name: "kubelogin-sp"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing aadToken credentials without a service principal secret",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:    "v1.17.8",
+					AADProfile: &AADProfile{Managed: true, AdminGroupObjectIDs: []string{"616077a8-5db7-4c98-b856-b34619afg75h"}},
+					Kubeconfig: &ManagedControlPlaneKubeconfig{
+						CredentialKind: credentialKindPtr(KubeconfigCredentialKindAADToken),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing user credentials with a service principal secret",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Kubeconfig: &ManagedControlPlaneKubeconfig{
+						CredentialKind:            credentialKindPtr(KubeconfigCredentialKindUser),
+						ServicePrincipalSecretRef: &corev1.LocalObjectReference{Name: "kubelogin-sp"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing pending import annotation",
+			amcp: AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: azure.ManagedClusterImportPending},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing invalid import annotation",
+			amcp: AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: "true"},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing valid UpgradeOrchestration",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					UpgradeOrchestration: &UpgradeOrchestration{
+						AgentPoolOrder:        []string{"pool0", "pool1"},
+						MaxParallelAgentPools: pointer.Int32(2),
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing UpgradeOrchestration with a duplicate agent pool",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					UpgradeOrchestration: &UpgradeOrchestration{
+						AgentPoolOrder: []string{"pool0", "pool0"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing UpgradeOrchestration with no parallel agent pools",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					UpgradeOrchestration: &UpgradeOrchestration{
+						MaxParallelAgentPools: pointer.Int32(0),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing valid typed add-ons",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Addons: &ManagedControlPlaneAddons{
+						Monitoring: &MonitoringAddon{
+							Enabled:                         true,
+							LogAnalyticsWorkspaceResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.OperationalInsights/workspaces/ws1",
+						},
+						AzurePolicy: &AzurePolicyAddon{Enabled: true},
+						KeyVaultSecretsProvider: &KeyVaultSecretsProviderAddon{
+							Enabled:              true,
+							EnableSecretRotation: pointer.Bool(true),
+							RotationPollInterval: &metav1.Duration{Duration: 5 * time.Minute},
+						},
+						IngressApplicationGateway: &IngressApplicationGatewayAddon{
+							Enabled:  true,
+							SubnetID: pointer.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/appgw"),
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing typed add-on also in AddonProfiles",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					AddonProfiles: []AddonProfile{
+						{Name: "azurePolicy", Enabled: true},
+					},
+					Addons: &ManagedControlPlaneAddons{
+						AzurePolicy: &AzurePolicyAddon{Enabled: true},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing monitoring add-on without a workspace",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Addons: &ManagedControlPlaneAddons{
+						Monitoring: &MonitoringAddon{Enabled: true},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing monitoring add-on with a workspace of the wrong resource type",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Addons: &ManagedControlPlaneAddons{
+						Monitoring: &MonitoringAddon{
+							Enabled:                         true,
+							LogAnalyticsWorkspaceResourceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/sa1",
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing rotation poll interval without secret rotation",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Addons: &ManagedControlPlaneAddons{
+						KeyVaultSecretsProvider: &KeyVaultSecretsProviderAddon{
+							Enabled:              true,
+							RotationPollInterval: &metav1.Duration{Duration: 5 * time.Minute},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing ingress application gateway add-on with an existing gateway and a subnet",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Addons: &ManagedControlPlaneAddons{
+						IngressApplicationGateway: &IngressApplicationGatewayAddon{
+							Enabled:              true,
+							ApplicationGatewayID: pointer.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/applicationGateways/appgw1"),
+							SubnetCIDR:           pointer.String("10.225.0.0/16"),
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing ingress application gateway add-on without a gateway or subnet",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.17.8",
+					Addons: &ManagedControlPlaneAddons{
+						IngressApplicationGateway: &IngressApplicationGatewayAddon{
+							Enabled:                true,
+							ApplicationGatewayName: pointer.String("appgw1"),
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing managed private DNS zone linked to a hub virtual network",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.17.8",
+					Location: "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "cluster.privatelink.eastus.azmk8s.io",
+							AdditionalVNetLinks: []PrivateDNSZoneVNetLink{
+								{VNetResourceID: "/subscriptions/456/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet"},
+							},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing managed private DNS zone for another location",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.17.8",
+					Location: "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "privatelink.westus.azmk8s.io",
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing managed private DNS zone without a user-assigned control plane identity",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.17.8",
+					Location: "eastus",
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "privatelink.eastus.azmk8s.io",
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing managed private DNS zone combined with PrivateDNSZone",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.17.8",
+					Location: "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						PrivateDNSZone:       pointer.String("System"),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "privatelink.eastus.azmk8s.io",
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing managed private DNS zone with duplicate virtual network names",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.17.8",
+					Location: "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "privatelink.eastus.azmk8s.io",
+							AdditionalVNetLinks: []PrivateDNSZoneVNetLink{
+								{VNetResourceID: "/subscriptions/456/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet"},
+								{VNetResourceID: "/subscriptions/789/resourceGroups/other-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet"},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing managed private DNS zone with an invalid virtual network ID",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:  "v1.17.8",
+					Location: "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: pointer.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: pointer.Bool(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "privatelink.eastus.azmk8s.io",
+							AdditionalVNetLinks: []PrivateDNSZoneVNetLink{
+								{VNetResourceID: "/subscriptions/456/resourceGroups/hub-rg/providers/Microsoft.Network/networkSecurityGroups/hub-nsg"},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing DNSServiceIP within ServiceCIDR",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.StringPtr("10.0.0.10"),
+					ServiceCIDR:  pointer.StringPtr("10.0.0.0/16"),
+					PodCIDR:      pointer.StringPtr("10.244.0.0/16"),
+					Version:      "v1.17.8",
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Testing DNSServiceIP outside ServiceCIDR",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: pointer.StringPtr("192.168.0.10"),
+					ServiceCIDR:  pointer.StringPtr("10.0.0.0/16"),
+					Version:      "v1.17.8",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing invalid ServiceCIDR",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					ServiceCIDR: pointer.StringPtr("10.0.0.0/33"),
+					Version:     "v1.17.8",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Testing invalid PodCIDR",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					PodCIDR: pointer.StringPtr("10.244.0.0"),
+					Version: "v1.17.8",
+				},
+			},
+			expectErr: true,
+		},
 		{
 			name: "Invalid Version",
 			amcp: AzureManagedControlPlane{
@@ -223,12 +737,229 @@ func TestValidatingWebhook(t *testing.T) {
 			expectErr: true,
 		},
 		{
-			name: "Invalid CIDR for AuthorizedIPRanges",
+			name: "Invalid CIDR for AuthorizedIPRanges",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					APIServerAccessProfile: &APIServerAccessProfile{
+						AuthorizedIPRanges: []string{"1.2.3.400/32"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "userDefinedRouting OutboundType with a route table",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.21.2",
+					OutboundType: outboundTypePtr(ManagedControlPlaneOutboundTypeUserDefinedRouting),
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Name:      "my-vnet",
+						CIDRBlock: "10.0.0.0/8",
+						Subnet: ManagedControlPlaneSubnet{
+							Name:       "my-subnet",
+							CIDRBlock:  "10.240.0.0/16",
+							RouteTable: "my-route-table",
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "userDefinedRouting OutboundType with a route table associated outside of CAPZ",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:           "v1.21.2",
+					ResourceGroupName: "my-cluster-rg",
+					OutboundType:      outboundTypePtr(ManagedControlPlaneOutboundTypeUserDefinedRouting),
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Name:          "my-vnet",
+						CIDRBlock:     "10.0.0.0/8",
+						ResourceGroup: "my-network-rg",
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "userDefinedRouting OutboundType with a CAPZ-managed virtual network without a route table",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:           "v1.21.2",
+					ResourceGroupName: "my-cluster-rg",
+					OutboundType:      outboundTypePtr(ManagedControlPlaneOutboundTypeUserDefinedRouting),
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Name:          "my-vnet",
+						CIDRBlock:     "10.0.0.0/8",
+						ResourceGroup: "my-cluster-rg",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "userAssignedNATGateway OutboundType with an existing virtual network",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:           "v1.21.2",
+					ResourceGroupName: "my-cluster-rg",
+					OutboundType:      outboundTypePtr(ManagedControlPlaneOutboundTypeUserAssignedNATGateway),
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						Name:          "my-vnet",
+						CIDRBlock:     "10.0.0.0/8",
+						ResourceGroup: "my-network-rg",
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "userAssignedNATGateway OutboundType with a CAPZ-managed virtual network",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:           "v1.21.2",
+					ResourceGroupName: "my-cluster-rg",
+					OutboundType:      outboundTypePtr(ManagedControlPlaneOutboundTypeUserAssignedNATGateway),
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "LoadBalancerProfile with a managedNATGateway OutboundType",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.21.2",
+					OutboundType: outboundTypePtr(ManagedControlPlaneOutboundTypeManagedNATGateway),
+					LoadBalancerProfile: &LoadBalancerProfile{
+						ManagedOutboundIPs: to.Int32Ptr(2),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "NatGatewayProfile with a managedNATGateway OutboundType",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.21.2",
+					OutboundType: outboundTypePtr(ManagedControlPlaneOutboundTypeManagedNATGateway),
+					NatGatewayProfile: &NatGatewayProfile{
+						ManagedOutboundIPs:   to.Int32Ptr(2),
+						IdleTimeoutInMinutes: to.Int32Ptr(10),
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "NatGatewayProfile without a managedNATGateway OutboundType",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					NatGatewayProfile: &NatGatewayProfile{
+						ManagedOutboundIPs: to.Int32Ptr(2),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "WorkloadIdentityProfile enabled without the OIDC issuer",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					WorkloadIdentityProfile: &WorkloadIdentityProfile{
+						Enabled: to.BoolPtr(true),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "WorkloadIdentityProfile enabled with the OIDC issuer",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					OIDCIssuerProfile: &OIDCIssuerProfile{
+						Enabled: to.BoolPtr(true),
+					},
+					WorkloadIdentityProfile: &WorkloadIdentityProfile{
+						Enabled: to.BoolPtr(true),
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "Valid MaintenanceConfigurations",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{
+							Name: "default",
+							TimeInWeek: []TimeInWeek{
+								{Day: "Saturday", HourSlots: []int32{0, 1, 23}},
+							},
+							NotAllowedTime: []TimeSpan{
+								{
+									Start: metav1.NewTime(time.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC)),
+									End:   metav1.NewTime(time.Date(2022, time.December, 26, 0, 0, 0, 0, time.UTC)),
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "MaintenanceConfigurations with duplicate names",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{Name: "default"},
+						{Name: "default"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "MaintenanceConfigurations with invalid hour slot",
+			amcp: AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.21.2",
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{
+							Name: "default",
+							TimeInWeek: []TimeInWeek{
+								{Day: "Monday", HourSlots: []int32{24}},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "MaintenanceConfigurations with not allowed time ending before it starts",
 			amcp: AzureManagedControlPlane{
 				Spec: AzureManagedControlPlaneSpec{
 					Version: "v1.21.2",
-					APIServerAccessProfile: &APIServerAccessProfile{
-						AuthorizedIPRanges: []string{"1.2.3.400/32"},
+					MaintenanceConfigurations: []MaintenanceConfiguration{
+						{
+							Name: "default",
+							NotAllowedTime: []TimeSpan{
+								{
+									Start: metav1.NewTime(time.Date(2022, time.December, 26, 0, 0, 0, 0, time.UTC)),
+									End:   metav1.NewTime(time.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC)),
+								},
+							},
+						},
 					},
 				},
 			},
@@ -377,6 +1108,162 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 			amcp:    createAzureManagedControlPlane("192.168.0.0", "1.999.9", generateSSHPublicKey(true)),
 			wantErr: true,
 		},
+		{
+			name: "AzureManagedControlPlane import can be confirmed",
+			oldAMCP: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: azure.ManagedClusterImportPending},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: azure.ManagedClusterImportConfirmed},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane import cannot be confirmed while it has conflicts",
+			oldAMCP: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: azure.ManagedClusterImportPending},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+				Status: AzureManagedControlPlaneStatus{
+					Conditions: clusterv1.Conditions{
+						{
+							Type:   infrav1.ManagedClusterImportedCondition,
+							Status: corev1.ConditionFalse,
+							Reason: infrav1.ManagedClusterImportConflictReason,
+						},
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: azure.ManagedClusterImportConfirmed},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane confirmed import cannot be reverted",
+			oldAMCP: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: azure.ManagedClusterImportConfirmed},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: azure.ManagedClusterImportPending},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane import annotation cannot be added after creation",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{azure.ManagedClusterImportAnnotation: azure.ManagedClusterImportPending},
+				},
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane OutboundType is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.18.0",
+					OutboundType: outboundTypePtr(ManagedControlPlaneOutboundTypeManagedNATGateway),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane OutboundType set to its default value",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version:      "v1.18.0",
+					OutboundType: outboundTypePtr(ManagedControlPlaneOutboundTypeLoadBalancer),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane OIDC issuer can be enabled",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+					OIDCIssuerProfile: &OIDCIssuerProfile{
+						Enabled: to.BoolPtr(true),
+					},
+					WorkloadIdentityProfile: &WorkloadIdentityProfile{
+						Enabled: to.BoolPtr(true),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane OIDC issuer cannot be disabled",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+					OIDCIssuerProfile: &OIDCIssuerProfile{
+						Enabled: to.BoolPtr(true),
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+					OIDCIssuerProfile: &OIDCIssuerProfile{
+						Enabled: to.BoolPtr(false),
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "AzureManagedControlPlane SubscriptionID is immutable",
 			oldAMCP: &AzureManagedControlPlane{
@@ -533,6 +1420,101 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "AzureManagedControlPlane VirtualNetwork ResourceGroup is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						ResourceGroup: "network-rg",
+					},
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					VirtualNetwork: ManagedControlPlaneVirtualNetwork{
+						ResourceGroup: "other-network-rg",
+					},
+					Version: "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane control plane identity is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: to.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane identity role assignments are mutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: to.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: to.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+						RoleAssignments: []ManagedIdentityRoleAssignment{
+							{
+								Identity:         ManagedIdentityKindControlPlane,
+								RoleDefinitionID: "4d97b98b-1d4f-4787-a291-c67834d212e7",
+								Scope:            RoleAssignmentScopeVirtualNetwork,
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "AzureManagedControlPlane ServiceCIDR is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					ServiceCIDR: to.StringPtr("10.0.0.0/16"),
+					Version:     "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					ServiceCIDR: to.StringPtr("10.1.0.0/16"),
+					Version:     "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane PodCIDR is immutable, unsetting is not allowed",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					PodCIDR: to.StringPtr("10.244.0.0/16"),
+					Version: "v1.18.0",
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					Version: "v1.18.0",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "AzureManagedControlPlane NetworkPolicy is immutable",
 			oldAMCP: &AzureManagedControlPlane{
@@ -752,6 +1734,81 @@ func TestAzureManagedControlPlane_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "AzureManagedControlPlane ManagedPrivateDNSZone Name is immutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: to.StringPtr("192.168.0.0"),
+					Version:      "v1.18.0",
+					Location:     "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: to.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: to.BoolPtr(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "privatelink.eastus.azmk8s.io",
+						},
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: to.StringPtr("192.168.0.0"),
+					Version:      "v1.18.0",
+					Location:     "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: to.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: to.BoolPtr(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "cluster.privatelink.eastus.azmk8s.io",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AzureManagedControlPlane ManagedPrivateDNSZone AdditionalVNetLinks is mutable",
+			oldAMCP: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: to.StringPtr("192.168.0.0"),
+					Version:      "v1.18.0",
+					Location:     "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: to.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: to.BoolPtr(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "privatelink.eastus.azmk8s.io",
+						},
+					},
+				},
+			},
+			amcp: &AzureManagedControlPlane{
+				Spec: AzureManagedControlPlaneSpec{
+					DNSServiceIP: to.StringPtr("192.168.0.0"),
+					Version:      "v1.18.0",
+					Location:     "eastus",
+					Identity: &ManagedControlPlaneIdentity{
+						ControlPlaneIdentityResourceID: to.StringPtr("/subscriptions/123/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/control-plane"),
+					},
+					APIServerAccessProfile: &APIServerAccessProfile{
+						EnablePrivateCluster: to.BoolPtr(true),
+						ManagedPrivateDNSZone: &ManagedPrivateDNSZone{
+							Name: "privatelink.eastus.azmk8s.io",
+							AdditionalVNetLinks: []PrivateDNSZoneVNetLink{
+								{VNetResourceID: "/subscriptions/456/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "AzureManagedControlPlane Name is mutable",
 			oldAMCP: &AzureManagedControlPlane{
@@ -799,3 +1856,11 @@ func createAzureManagedControlPlane(serviceIP, version, sshKey string) *AzureMan
 		},
 	}
 }
+
+func outboundTypePtr(outboundType ManagedControlPlaneOutboundType) *ManagedControlPlaneOutboundType {
+	return &outboundType
+}
+
+func credentialKindPtr(credentialKind KubeconfigCredentialKind) *KubeconfigCredentialKind {
+	return &credentialKind
+}
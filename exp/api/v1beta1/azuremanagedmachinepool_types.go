@@ -36,8 +36,17 @@ const (
 
 	// DefaultOSType represents the default operating system for azmachinepool.
 	DefaultOSType string = azure.LinuxOS
+
+	// ScaleSetPriorityRegular represents the regular priority of the VMs of an agent pool.
+	ScaleSetPriorityRegular ScaleSetPriority = "Regular"
+
+	// ScaleSetPrioritySpot represents the Spot priority of the VMs of an agent pool.
+	ScaleSetPrioritySpot ScaleSetPriority = "Spot"
 )
 
+// ScaleSetPriority enumerates the values for the priority of the VMs of an agent pool.
+type ScaleSetPriority string
+
 // NodePoolMode enumerates the values for agent pool mode.
 type NodePoolMode string
 
@@ -98,6 +107,61 @@ type AzureManagedMachinePoolSpec struct {
 	// +kubebuilder:validation:Enum=Linux;Windows
 	// +optional
 	OSType *string `json:"osType,omitempty"`
+
+	// OSSKU specifies the OS SKU used by the agent pool. Possible values include: 'Ubuntu', 'CBLMariner'.
+	// Defaults to Ubuntu for Linux agent pools.
+	// +kubebuilder:validation:Enum=Ubuntu;CBLMariner
+	// +optional
+	OSSKU *string `json:"osSKU,omitempty"`
+
+	// EnableNodePublicIP controls whether or not nodes in the pool each have a public IP address.
+	// +optional
+	EnableNodePublicIP *bool `json:"enableNodePublicIP,omitempty"`
+
+	// NodePublicIPPrefixID specifies the public IP prefix resource ID which VM nodes should use IPs from.
+	// Requires EnableNodePublicIP to be true.
+	// +optional
+	NodePublicIPPrefixID *string `json:"nodePublicIPPrefixID,omitempty"`
+
+	// ScaleSetPriority specifies the ScaleSetPriority value. Default to Regular. Possible values include: 'Regular', 'Spot'.
+	// Spot priority is not supported for system node pools.
+	// +kubebuilder:validation:Enum=Regular;Spot
+	// +optional
+	ScaleSetPriority *string `json:"scaleSetPriority,omitempty"`
+
+	// ScaleDownMode affects the cluster autoscaler behavior. Default to Delete. Possible values include: 'Deallocate', 'Delete'.
+	// +kubebuilder:validation:Enum=Deallocate;Delete
+	// +optional
+	ScaleDownMode *string `json:"scaleDownMode,omitempty"`
+
+	// UpgradeSettings specifies the settings used when upgrading the nodes of the agent pool.
+	// +optional
+	UpgradeSettings *AgentPoolUpgradeSettings `json:"upgradeSettings,omitempty"`
+
+	// KubeletConfig specifies the kubelet configuration for the nodes of the agent pool.
+	// +optional
+	KubeletConfig *KubeletConfig `json:"kubeletConfig,omitempty"`
+
+	// LinuxOSConfig specifies the OS configuration for the Linux nodes of the agent pool.
+	// +optional
+	LinuxOSConfig *LinuxOSConfig `json:"linuxOSConfig,omitempty"`
+
+	// PodSubnetID is the Azure Resource ID of the subnet from which pod IPs are dynamically allocated.
+	// If omitted, pod IPs are statically assigned on the node subnet.
+	// +optional
+	PodSubnetID *string `json:"podSubnetID,omitempty"`
+
+	// Subnet describes the subnet of the nodes of this pool.
+	// The subnet is created in the virtual network of the AzureManagedControlPlane.
+	// If omitted, the nodes are placed in the subnet of the AzureManagedControlPlane.
+	// +optional
+	Subnet *ManagedControlPlaneSubnet `json:"subnet,omitempty"`
+
+	// PodSubnet describes a subnet from which pod IPs are dynamically allocated.
+	// The subnet is created in the virtual network of the AzureManagedControlPlane alongside the node subnet.
+	// Mutually exclusive with PodSubnetID.
+	// +optional
+	PodSubnet *ManagedControlPlaneSubnet `json:"podSubnet,omitempty"`
 }
 
 // ManagedMachinePoolScaling specifies scaling options.
@@ -123,6 +187,213 @@ type Taint struct {
 // Taints is an array of Taints.
 type Taints []Taint
 
+// AgentPoolUpgradeSettings specifies the settings used when upgrading the nodes of an agent pool.
+type AgentPoolUpgradeSettings struct {
+	// MaxSurge is the maximum number or percentage of nodes that are surged during an upgrade, e.g. '5' or '33%'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+%?$`
+	// +optional
+	MaxSurge *string `json:"maxSurge,omitempty"`
+}
+
+// KubeletConfig defines the supported subset of kubelet configurations for the nodes of an agent pool.
+// See https://docs.microsoft.com/azure/aks/custom-node-configuration for more details.
+type KubeletConfig struct {
+	// CPUManagerPolicy is the CPU Manager policy to use. Possible values include: 'none', 'static'. Default is 'none'.
+	// +kubebuilder:validation:Enum=none;static
+	// +optional
+	CPUManagerPolicy *string `json:"cpuManagerPolicy,omitempty"`
+
+	// CPUCfsQuota enables CPU CFS quota enforcement for containers that specify CPU limits. Default is true.
+	// +optional
+	CPUCfsQuota *bool `json:"cpuCfsQuota,omitempty"`
+
+	// CPUCfsQuotaPeriod is the CPU CFS quota period value, e.g. '100ms'. Default is '100ms'.
+	// +optional
+	CPUCfsQuotaPeriod *string `json:"cpuCfsQuotaPeriod,omitempty"`
+
+	// ImageGcHighThreshold is the percent of disk usage after which image garbage collection is always run. Default is 85.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImageGcHighThreshold *int32 `json:"imageGcHighThreshold,omitempty"`
+
+	// ImageGcLowThreshold is the percent of disk usage before which image garbage collection is never run. Default is 80.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImageGcLowThreshold *int32 `json:"imageGcLowThreshold,omitempty"`
+
+	// TopologyManagerPolicy is the Topology Manager policy to use.
+	// Possible values include: 'none', 'best-effort', 'restricted', 'single-numa-node'. Default is 'none'.
+	// +kubebuilder:validation:Enum=none;best-effort;restricted;single-numa-node
+	// +optional
+	TopologyManagerPolicy *string `json:"topologyManagerPolicy,omitempty"`
+
+	// AllowedUnsafeSysctls is the list of allowed unsafe sysctls or unsafe sysctl patterns (ending in '*').
+	// +optional
+	AllowedUnsafeSysctls []string `json:"allowedUnsafeSysctls,omitempty"`
+
+	// FailSwapOn makes the kubelet fail to start if swap is enabled on the node. Default is true.
+	// +optional
+	FailSwapOn *bool `json:"failSwapOn,omitempty"`
+
+	// ContainerLogMaxSizeMB is the maximum size in MB of a container log file before it is rotated.
+	// +optional
+	ContainerLogMaxSizeMB *int32 `json:"containerLogMaxSizeMB,omitempty"`
+
+	// ContainerLogMaxFiles is the maximum number of container log files that can be present for a container.
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	ContainerLogMaxFiles *int32 `json:"containerLogMaxFiles,omitempty"`
+
+	// PodMaxPids is the maximum number of processes per pod.
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	PodMaxPids *int32 `json:"podMaxPids,omitempty"`
+}
+
+// LinuxOSConfig defines the OS configuration for the Linux nodes of an agent pool.
+// See https://docs.microsoft.com/azure/aks/custom-node-configuration for more details.
+type LinuxOSConfig struct {
+	// SwapFileSizeMB is the size in MB of a swap file that will be created on each node.
+	// Requires KubeletConfig.FailSwapOn to be false.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SwapFileSizeMB *int32 `json:"swapFileSizeMB,omitempty"`
+
+	// Sysctls specifies the sysctl settings for the Linux nodes.
+	// +optional
+	Sysctls *SysctlConfig `json:"sysctls,omitempty"`
+
+	// TransparentHugePageDefrag specifies whether the kernel should make aggressive use of memory compaction to make more hugepages available.
+	// Possible values include: 'always', 'defer', 'defer+madvise', 'madvise', 'never'. Default is 'madvise'.
+	// +kubebuilder:validation:Enum=always;defer;defer+madvise;madvise;never
+	// +optional
+	TransparentHugePageDefrag *string `json:"transparentHugePageDefrag,omitempty"`
+
+	// TransparentHugePageEnabled specifies whether transparent hugepages are enabled.
+	// Possible values include: 'always', 'madvise', 'never'. Default is 'always'.
+	// +kubebuilder:validation:Enum=always;madvise;never
+	// +optional
+	TransparentHugePageEnabled *string `json:"transparentHugePageEnabled,omitempty"`
+}
+
+// SysctlConfig specifies the sysctl settings for the Linux nodes of an agent pool.
+type SysctlConfig struct {
+	// FsAioMaxNr specifies the sysctl setting fs.aio-max-nr.
+	// +optional
+	FsAioMaxNr *int32 `json:"fsAioMaxNr,omitempty"`
+
+	// FsFileMax specifies the sysctl setting fs.file-max.
+	// +optional
+	FsFileMax *int32 `json:"fsFileMax,omitempty"`
+
+	// FsInotifyMaxUserWatches specifies the sysctl setting fs.inotify.max_user_watches.
+	// +optional
+	FsInotifyMaxUserWatches *int32 `json:"fsInotifyMaxUserWatches,omitempty"`
+
+	// FsNrOpen specifies the sysctl setting fs.nr_open.
+	// +optional
+	FsNrOpen *int32 `json:"fsNrOpen,omitempty"`
+
+	// KernelThreadsMax specifies the sysctl setting kernel.threads-max.
+	// +optional
+	KernelThreadsMax *int32 `json:"kernelThreadsMax,omitempty"`
+
+	// NetCoreNetdevMaxBacklog specifies the sysctl setting net.core.netdev_max_backlog.
+	// +optional
+	NetCoreNetdevMaxBacklog *int32 `json:"netCoreNetdevMaxBacklog,omitempty"`
+
+	// NetCoreOptmemMax specifies the sysctl setting net.core.optmem_max.
+	// +optional
+	NetCoreOptmemMax *int32 `json:"netCoreOptmemMax,omitempty"`
+
+	// NetCoreRmemDefault specifies the sysctl setting net.core.rmem_default.
+	// +optional
+	NetCoreRmemDefault *int32 `json:"netCoreRmemDefault,omitempty"`
+
+	// NetCoreRmemMax specifies the sysctl setting net.core.rmem_max.
+	// +optional
+	NetCoreRmemMax *int32 `json:"netCoreRmemMax,omitempty"`
+
+	// NetCoreSomaxconn specifies the sysctl setting net.core.somaxconn.
+	// +optional
+	NetCoreSomaxconn *int32 `json:"netCoreSomaxconn,omitempty"`
+
+	// NetCoreWmemDefault specifies the sysctl setting net.core.wmem_default.
+	// +optional
+	NetCoreWmemDefault *int32 `json:"netCoreWmemDefault,omitempty"`
+
+	// NetCoreWmemMax specifies the sysctl setting net.core.wmem_max.
+	// +optional
+	NetCoreWmemMax *int32 `json:"netCoreWmemMax,omitempty"`
+
+	// NetIpv4IPLocalPortRange specifies the sysctl setting net.ipv4.ip_local_port_range, e.g. '32768 60999'.
+	// +kubebuilder:validation:Pattern=`^[0-9]+ [0-9]+$`
+	// +optional
+	NetIpv4IPLocalPortRange *string `json:"netIpv4IPLocalPortRange,omitempty"`
+
+	// NetIpv4NeighDefaultGcThresh1 specifies the sysctl setting net.ipv4.neigh.default.gc_thresh1.
+	// +optional
+	NetIpv4NeighDefaultGcThresh1 *int32 `json:"netIpv4NeighDefaultGcThresh1,omitempty"`
+
+	// NetIpv4NeighDefaultGcThresh2 specifies the sysctl setting net.ipv4.neigh.default.gc_thresh2.
+	// +optional
+	NetIpv4NeighDefaultGcThresh2 *int32 `json:"netIpv4NeighDefaultGcThresh2,omitempty"`
+
+	// NetIpv4NeighDefaultGcThresh3 specifies the sysctl setting net.ipv4.neigh.default.gc_thresh3.
+	// +optional
+	NetIpv4NeighDefaultGcThresh3 *int32 `json:"netIpv4NeighDefaultGcThresh3,omitempty"`
+
+	// NetIpv4TCPFinTimeout specifies the sysctl setting net.ipv4.tcp_fin_timeout.
+	// +optional
+	NetIpv4TCPFinTimeout *int32 `json:"netIpv4TCPFinTimeout,omitempty"`
+
+	// NetIpv4TCPKeepaliveProbes specifies the sysctl setting net.ipv4.tcp_keepalive_probes.
+	// +optional
+	NetIpv4TCPKeepaliveProbes *int32 `json:"netIpv4TCPKeepaliveProbes,omitempty"`
+
+	// NetIpv4TCPKeepaliveTime specifies the sysctl setting net.ipv4.tcp_keepalive_time.
+	// +optional
+	NetIpv4TCPKeepaliveTime *int32 `json:"netIpv4TCPKeepaliveTime,omitempty"`
+
+	// NetIpv4TCPMaxSynBacklog specifies the sysctl setting net.ipv4.tcp_max_syn_backlog.
+	// +optional
+	NetIpv4TCPMaxSynBacklog *int32 `json:"netIpv4TCPMaxSynBacklog,omitempty"`
+
+	// NetIpv4TCPMaxTwBuckets specifies the sysctl setting net.ipv4.tcp_max_tw_buckets.
+	// +optional
+	NetIpv4TCPMaxTwBuckets *int32 `json:"netIpv4TCPMaxTwBuckets,omitempty"`
+
+	// NetIpv4TCPTwReuse specifies the sysctl setting net.ipv4.tcp_tw_reuse.
+	// +optional
+	NetIpv4TCPTwReuse *bool `json:"netIpv4TCPTwReuse,omitempty"`
+
+	// NetIpv4TCPkeepaliveIntvl specifies the sysctl setting net.ipv4.tcp_keepalive_intvl.
+	// +optional
+	NetIpv4TCPkeepaliveIntvl *int32 `json:"netIpv4TCPkeepaliveIntvl,omitempty"`
+
+	// NetNetfilterNfConntrackBuckets specifies the sysctl setting net.netfilter.nf_conntrack_buckets.
+	// +optional
+	NetNetfilterNfConntrackBuckets *int32 `json:"netNetfilterNfConntrackBuckets,omitempty"`
+
+	// NetNetfilterNfConntrackMax specifies the sysctl setting net.netfilter.nf_conntrack_max.
+	// +optional
+	NetNetfilterNfConntrackMax *int32 `json:"netNetfilterNfConntrackMax,omitempty"`
+
+	// VMMaxMapCount specifies the sysctl setting vm.max_map_count.
+	// +optional
+	VMMaxMapCount *int32 `json:"vmMaxMapCount,omitempty"`
+
+	// VMSwappiness specifies the sysctl setting vm.swappiness.
+	// +optional
+	VMSwappiness *int32 `json:"vmSwappiness,omitempty"`
+
+	// VMVfsCachePressure specifies the sysctl setting vm.vfs_cache_pressure.
+	// +optional
+	VMVfsCachePressure *int32 `json:"vmVfsCachePressure,omitempty"`
+}
+
 // AzureManagedMachinePoolStatus defines the observed state of AzureManagedMachinePool.
 type AzureManagedMachinePoolStatus struct {
 	// Ready is true when the provider resource is ready.
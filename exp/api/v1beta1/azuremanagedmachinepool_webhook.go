@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 
 	"github.com/Azure/go-autorest/autorest/to"
@@ -59,6 +60,11 @@ func (m *AzureManagedMachinePool) ValidateCreate(client client.Client) error {
 		m.validateMaxPods,
 		m.validateOSType,
 		m.validateName,
+		m.validateNodePublicIP,
+		m.validateScaleSetPriority,
+		m.validateKubeletConfig,
+		m.validateLinuxOSConfig,
+		m.validateSubnets,
 	}
 
 	var errs []error
@@ -223,6 +229,78 @@ func (m *AzureManagedMachinePool) ValidateUpdate(oldRaw runtime.Object, client c
 					"field is immutable, unsetting is not allowed"))
 		}
 	}
+	if !reflect.DeepEqual(m.Spec.OSSKU, old.Spec.OSSKU) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "OSSKU"),
+				m.Spec.OSSKU,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.Spec.EnableNodePublicIP, old.Spec.EnableNodePublicIP) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "EnableNodePublicIP"),
+				m.Spec.EnableNodePublicIP,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.Spec.NodePublicIPPrefixID, old.Spec.NodePublicIPPrefixID) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "NodePublicIPPrefixID"),
+				m.Spec.NodePublicIPPrefixID,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.Spec.ScaleSetPriority, old.Spec.ScaleSetPriority) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "ScaleSetPriority"),
+				m.Spec.ScaleSetPriority,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.Spec.KubeletConfig, old.Spec.KubeletConfig) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "KubeletConfig"),
+				m.Spec.KubeletConfig,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.Spec.LinuxOSConfig, old.Spec.LinuxOSConfig) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "LinuxOSConfig"),
+				m.Spec.LinuxOSConfig,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.Spec.PodSubnetID, old.Spec.PodSubnetID) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "PodSubnetID"),
+				m.Spec.PodSubnetID,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.Spec.Subnet, old.Spec.Subnet) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "Subnet"),
+				m.Spec.Subnet,
+				"field is immutable"))
+	}
+
+	if !reflect.DeepEqual(m.Spec.PodSubnet, old.Spec.PodSubnet) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				field.NewPath("Spec", "PodSubnet"),
+				m.Spec.PodSubnet,
+				"field is immutable"))
+	}
+
 	if len(allErrs) != 0 {
 		return apierrors.NewInvalid(GroupVersion.WithKind("AzureManagedMachinePool").GroupKind(), m.Name, allErrs)
 	}
@@ -319,6 +397,108 @@ func (m *AzureManagedMachinePool) validateName() error {
 	return nil
 }
 
+func (m *AzureManagedMachinePool) validateNodePublicIP() error {
+	if m.Spec.NodePublicIPPrefixID != nil && !to.Bool(m.Spec.EnableNodePublicIP) {
+		return field.Invalid(
+			field.NewPath("Spec", "NodePublicIPPrefixID"),
+			m.Spec.NodePublicIPPrefixID,
+			"NodePublicIPPrefixID requires EnableNodePublicIP to be true")
+	}
+
+	return nil
+}
+
+func (m *AzureManagedMachinePool) validateScaleSetPriority() error {
+	if m.Spec.Mode == string(NodePoolModeSystem) && to.String(m.Spec.ScaleSetPriority) == string(ScaleSetPrioritySpot) {
+		return field.Forbidden(
+			field.NewPath("Spec", "ScaleSetPriority"),
+			"System node pool cannot use Spot priority")
+	}
+
+	return nil
+}
+
+func (m *AzureManagedMachinePool) validateKubeletConfig() error {
+	if m.Spec.KubeletConfig == nil {
+		return nil
+	}
+
+	high, low := m.Spec.KubeletConfig.ImageGcHighThreshold, m.Spec.KubeletConfig.ImageGcLowThreshold
+	if high != nil && low != nil && *low > *high {
+		return field.Invalid(
+			field.NewPath("Spec", "KubeletConfig", "ImageGcLowThreshold"),
+			*low,
+			fmt.Sprintf("ImageGcLowThreshold must not be greater than ImageGcHighThreshold (%d)", *high))
+	}
+
+	return nil
+}
+
+func (m *AzureManagedMachinePool) validateLinuxOSConfig() error {
+	if m.Spec.LinuxOSConfig == nil {
+		return nil
+	}
+
+	if m.Spec.OSType != nil && *m.Spec.OSType != azure.LinuxOS {
+		return field.Forbidden(
+			field.NewPath("Spec", "LinuxOSConfig"),
+			"LinuxOSConfig can only be set for Linux node pools")
+	}
+
+	if m.Spec.LinuxOSConfig.SwapFileSizeMB != nil && (m.Spec.KubeletConfig == nil || m.Spec.KubeletConfig.FailSwapOn == nil || *m.Spec.KubeletConfig.FailSwapOn) {
+		return field.Invalid(
+			field.NewPath("Spec", "LinuxOSConfig", "SwapFileSizeMB"),
+			*m.Spec.LinuxOSConfig.SwapFileSizeMB,
+			"SwapFileSizeMB requires KubeletConfig.FailSwapOn to be false")
+	}
+
+	return nil
+}
+
+func (m *AzureManagedMachinePool) validateSubnets() error {
+	if m.Spec.Subnet != nil {
+		if err := validateManagedMachinePoolSubnet(field.NewPath("Spec", "Subnet"), m.Spec.Subnet); err != nil {
+			return err
+		}
+	}
+
+	if m.Spec.PodSubnet == nil {
+		return nil
+	}
+
+	if m.Spec.PodSubnetID != nil {
+		return field.Forbidden(
+			field.NewPath("Spec", "PodSubnet"),
+			"PodSubnet and PodSubnetID are mutually exclusive")
+	}
+
+	if m.Spec.Subnet != nil && m.Spec.Subnet.Name == m.Spec.PodSubnet.Name {
+		return field.Invalid(
+			field.NewPath("Spec", "PodSubnet", "Name"),
+			m.Spec.PodSubnet.Name,
+			"PodSubnet must be different from Subnet")
+	}
+
+	return validateManagedMachinePoolSubnet(field.NewPath("Spec", "PodSubnet"), m.Spec.PodSubnet)
+}
+
+func validateManagedMachinePoolSubnet(fldPath *field.Path, subnet *ManagedControlPlaneSubnet) error {
+	if subnet.Name == "" {
+		return field.Required(
+			fldPath.Child("Name"),
+			"subnet must have a name")
+	}
+
+	if _, _, err := net.ParseCIDR(subnet.CIDRBlock); err != nil {
+		return field.Invalid(
+			fldPath.Child("CIDRBlock"),
+			subnet.CIDRBlock,
+			fmt.Sprintf("failed to parse subnet cidr: %v", err))
+	}
+
+	return nil
+}
+
 func ensureStringSlicesAreEqual(a []string, b []string) bool {
 	if len(a) != len(b) {
 		return false
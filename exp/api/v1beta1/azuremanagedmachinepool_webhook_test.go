@@ -19,7 +19,7 @@ package v1beta1
 import (
 	"testing"
 
-	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2021-05-01/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/preview/containerservice/mgmt/2022-03-02-preview/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -463,6 +463,61 @@ func TestAzureManagedMachinePoolUpdatingWebhook(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Cannot change OSSKU of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					OSSKU: to.StringPtr("CBLMariner"),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					OSSKU: to.StringPtr("Ubuntu"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot change KubeletConfig of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{CPUManagerPolicy: to.StringPtr("static")},
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{CPUManagerPolicy: to.StringPtr("none")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Cannot enable node public IPs of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					EnableNodePublicIP: to.BoolPtr(true),
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Can change ScaleDownMode and UpgradeSettings of the agentpool",
+			new: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ScaleDownMode:   to.StringPtr("Deallocate"),
+					UpgradeSettings: &AgentPoolUpgradeSettings{MaxSurge: to.StringPtr("33%")},
+				},
+			},
+			old: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					ScaleDownMode: to.StringPtr("Delete"),
+				},
+			},
+			wantErr: false,
+		},
 	}
 	var client client.Client
 	for _, tc := range tests {
@@ -581,6 +636,171 @@ func TestAzureManagedMachinePool_ValidateCreate(t *testing.T) {
 			wantErr:  true,
 			errorLen: 1,
 		},
+		{
+			name: "node public IP prefix without node public IPs is not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					NodePublicIPPrefixID: to.StringPtr("public-ip-prefix-id"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "node public IP prefix with node public IPs",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					EnableNodePublicIP:   to.BoolPtr(true),
+					NodePublicIPPrefixID: to.StringPtr("public-ip-prefix-id"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Spot priority with System mode not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "System",
+					ScaleSetPriority: to.StringPtr("Spot"),
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "Spot priority with User mode",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:             "User",
+					ScaleSetPriority: to.StringPtr("Spot"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "image GC low threshold greater than high threshold not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{
+						ImageGcHighThreshold: to.Int32Ptr(70),
+						ImageGcLowThreshold:  to.Int32Ptr(80),
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "swap file without disabling failSwapOn not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					LinuxOSConfig: &LinuxOSConfig{
+						SwapFileSizeMB: to.Int32Ptr(1500),
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "swap file with failSwapOn disabled",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					KubeletConfig: &KubeletConfig{
+						FailSwapOn: to.BoolPtr(false),
+					},
+					LinuxOSConfig: &LinuxOSConfig{
+						SwapFileSizeMB: to.Int32Ptr(1500),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "LinuxOSConfig with Windows node pool not allowed",
+			ammp: &AzureManagedMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pool0",
+				},
+				Spec: AzureManagedMachinePoolSpec{
+					Mode:   "User",
+					OSType: to.StringPtr(azure.WindowsOS),
+					LinuxOSConfig: &LinuxOSConfig{
+						TransparentHugePageEnabled: to.StringPtr("never"),
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "valid pod subnet",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					PodSubnet: &ManagedControlPlaneSubnet{
+						Name:      "pool0-pods",
+						CIDRBlock: "10.241.0.0/16",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "pod subnet with pod subnet ID not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					PodSubnetID: to.StringPtr("fake-pod-subnet-id"),
+					PodSubnet: &ManagedControlPlaneSubnet{
+						Name:      "pool0-pods",
+						CIDRBlock: "10.241.0.0/16",
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "pod subnet same as node subnet not allowed",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Subnet: &ManagedControlPlaneSubnet{
+						Name:      "pool0",
+						CIDRBlock: "10.240.0.0/16",
+					},
+					PodSubnet: &ManagedControlPlaneSubnet{
+						Name:      "pool0",
+						CIDRBlock: "10.241.0.0/16",
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "node subnet without name",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					Subnet: &ManagedControlPlaneSubnet{
+						CIDRBlock: "10.240.0.0/16",
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
+		{
+			name: "pod subnet with invalid CIDR block",
+			ammp: &AzureManagedMachinePool{
+				Spec: AzureManagedMachinePoolSpec{
+					PodSubnet: &ManagedControlPlaneSubnet{
+						Name:      "pool0-pods",
+						CIDRBlock: "10.241.0.0",
+					},
+				},
+			},
+			wantErr:  true,
+			errorLen: 1,
+		},
 	}
 	var client client.Client
 	for _, tc := range tests {
@@ -69,6 +69,11 @@ func (in *APIServerAccessProfile) DeepCopyInto(out *APIServerAccessProfile) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ManagedPrivateDNSZone != nil {
+		in, out := &in.ManagedPrivateDNSZone, &out.ManagedPrivateDNSZone
+		*out = new(ManagedPrivateDNSZone)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnablePrivateClusterPublicFQDN != nil {
 		in, out := &in.EnablePrivateClusterPublicFQDN, &out.EnablePrivateClusterPublicFQDN
 		*out = new(bool)
@@ -86,6 +91,21 @@ func (in *APIServerAccessProfile) DeepCopy() *APIServerAccessProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonIdentity) DeepCopyInto(out *AddonIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonIdentity.
+func (in *AddonIdentity) DeepCopy() *AddonIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonProfile) DeepCopyInto(out *AddonProfile) {
 	*out = *in
@@ -108,6 +128,204 @@ func (in *AddonProfile) DeepCopy() *AddonProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(AddonIdentity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
+func (in *AddonStatus) DeepCopy() *AddonStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPoolUpgradeSettings) DeepCopyInto(out *AgentPoolUpgradeSettings) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPoolUpgradeSettings.
+func (in *AgentPoolUpgradeSettings) DeepCopy() *AgentPoolUpgradeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPoolUpgradeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPoolUpgradeStatus) DeepCopyInto(out *AgentPoolUpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPoolUpgradeStatus.
+func (in *AgentPoolUpgradeStatus) DeepCopy() *AgentPoolUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPoolUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationHealthProbe) DeepCopyInto(out *ApplicationHealthProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationHealthProbe.
+func (in *ApplicationHealthProbe) DeepCopy() *ApplicationHealthProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationHealthProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoScalerProfile) DeepCopyInto(out *AutoScalerProfile) {
+	*out = *in
+	if in.BalanceSimilarNodeGroups != nil {
+		in, out := &in.BalanceSimilarNodeGroups, &out.BalanceSimilarNodeGroups
+		*out = new(string)
+		**out = **in
+	}
+	if in.Expander != nil {
+		in, out := &in.Expander, &out.Expander
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxEmptyBulkDelete != nil {
+		in, out := &in.MaxEmptyBulkDelete, &out.MaxEmptyBulkDelete
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxGracefulTerminationSec != nil {
+		in, out := &in.MaxGracefulTerminationSec, &out.MaxGracefulTerminationSec
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxNodeProvisionTime != nil {
+		in, out := &in.MaxNodeProvisionTime, &out.MaxNodeProvisionTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxTotalUnreadyPercentage != nil {
+		in, out := &in.MaxTotalUnreadyPercentage, &out.MaxTotalUnreadyPercentage
+		*out = new(string)
+		**out = **in
+	}
+	if in.NewPodScaleUpDelay != nil {
+		in, out := &in.NewPodScaleUpDelay, &out.NewPodScaleUpDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.OkTotalUnreadyCount != nil {
+		in, out := &in.OkTotalUnreadyCount, &out.OkTotalUnreadyCount
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScanInterval != nil {
+		in, out := &in.ScanInterval, &out.ScanInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownDelayAfterAdd != nil {
+		in, out := &in.ScaleDownDelayAfterAdd, &out.ScaleDownDelayAfterAdd
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownDelayAfterDelete != nil {
+		in, out := &in.ScaleDownDelayAfterDelete, &out.ScaleDownDelayAfterDelete
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownDelayAfterFailure != nil {
+		in, out := &in.ScaleDownDelayAfterFailure, &out.ScaleDownDelayAfterFailure
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownUnneededTime != nil {
+		in, out := &in.ScaleDownUnneededTime, &out.ScaleDownUnneededTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownUnreadyTime != nil {
+		in, out := &in.ScaleDownUnreadyTime, &out.ScaleDownUnreadyTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownUtilizationThreshold != nil {
+		in, out := &in.ScaleDownUtilizationThreshold, &out.ScaleDownUtilizationThreshold
+		*out = new(string)
+		**out = **in
+	}
+	if in.SkipNodesWithLocalStorage != nil {
+		in, out := &in.SkipNodesWithLocalStorage, &out.SkipNodesWithLocalStorage
+		*out = new(string)
+		**out = **in
+	}
+	if in.SkipNodesWithSystemPods != nil {
+		in, out := &in.SkipNodesWithSystemPods, &out.SkipNodesWithSystemPods
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoScalerProfile.
+func (in *AutoScalerProfile) DeepCopy() *AutoScalerProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoScalerProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutomaticRepairs) DeepCopyInto(out *AutomaticRepairs) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.HealthProbe = in.HealthProbe
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticRepairs.
+func (in *AutomaticRepairs) DeepCopy() *AutomaticRepairs {
+	if in == nil {
+		return nil
+	}
+	out := new(AutomaticRepairs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePool) DeepCopyInto(out *AzureMachinePool) {
 	*out = *in
@@ -135,6 +353,25 @@ func (in *AzureMachinePool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolDeploymentStatus) DeepCopyInto(out *AzureMachinePoolDeploymentStatus) {
+	*out = *in
+	if in.BakeUntil != nil {
+		in, out := &in.BakeUntil, &out.BakeUntil
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolDeploymentStatus.
+func (in *AzureMachinePoolDeploymentStatus) DeepCopy() *AzureMachinePoolDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePoolDeploymentStrategy) DeepCopyInto(out *AzureMachinePoolDeploymentStrategy) {
 	*out = *in
@@ -143,6 +380,16 @@ func (in *AzureMachinePoolDeploymentStrategy) DeepCopyInto(out *AzureMachinePool
 		*out = new(MachineRollingUpdateDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(MachineCanaryDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlueGreen != nil {
+		in, out := &in.BlueGreen, &out.BlueGreen
+		*out = new(MachineBlueGreenDeployment)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolDeploymentStrategy.
@@ -212,7 +459,7 @@ func (in *AzureMachinePoolMachine) DeepCopyInto(out *AzureMachinePoolMachine) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -266,9 +513,29 @@ func (in *AzureMachinePoolMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolMachineProtectionPolicy) DeepCopyInto(out *AzureMachinePoolMachineProtectionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolMachineProtectionPolicy.
+func (in *AzureMachinePoolMachineProtectionPolicy) DeepCopy() *AzureMachinePoolMachineProtectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolMachineProtectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePoolMachineSpec) DeepCopyInto(out *AzureMachinePoolMachineSpec) {
 	*out = *in
+	if in.ProtectionPolicy != nil {
+		in, out := &in.ProtectionPolicy, &out.ProtectionPolicy
+		*out = new(AzureMachinePoolMachineProtectionPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolMachineSpec.
@@ -316,6 +583,11 @@ func (in *AzureMachinePoolMachineStatus) DeepCopyInto(out *AzureMachinePoolMachi
 		*out = make(apiv1beta1.Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.BootDiagnostics != nil {
+		in, out := &in.BootDiagnostics, &out.BootDiagnostics
+		*out = new(apiv1beta1.BootDiagnosticsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolMachineStatus.
@@ -331,6 +603,11 @@ func (in *AzureMachinePoolMachineStatus) DeepCopy() *AzureMachinePoolMachineStat
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePoolMachineTemplate) DeepCopyInto(out *AzureMachinePoolMachineTemplate) {
 	*out = *in
+	if in.FallbackVMSizes != nil {
+		in, out := &in.FallbackVMSizes, &out.FallbackVMSizes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(apiv1beta1.Image)
@@ -364,6 +641,16 @@ func (in *AzureMachinePoolMachineTemplate) DeepCopyInto(out *AzureMachinePoolMac
 		*out = new(apiv1beta1.SpotVMOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DedicatedHost != nil {
+		in, out := &in.DedicatedHost, &out.DedicatedHost
+		*out = new(apiv1beta1.DedicatedHost)
+		**out = **in
+	}
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = new(apiv1beta1.Diagnostics)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolMachineTemplate.
@@ -376,6 +663,41 @@ func (in *AzureMachinePoolMachineTemplate) DeepCopy() *AzureMachinePoolMachineTe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolRepairPolicy) DeepCopyInto(out *AzureMachinePoolRepairPolicy) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UnhealthyConditions != nil {
+		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
+		*out = make([]cluster_apiapiv1beta1.UnhealthyCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConcurrentRepairs != nil {
+		in, out := &in.MaxConcurrentRepairs, &out.MaxConcurrentRepairs
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.AutomaticRepairs != nil {
+		in, out := &in.AutomaticRepairs, &out.AutomaticRepairs
+		*out = new(AutomaticRepairs)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolRepairPolicy.
+func (in *AzureMachinePoolRepairPolicy) DeepCopy() *AzureMachinePoolRepairPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolRepairPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachinePoolSpec) DeepCopyInto(out *AzureMachinePoolSpec) {
 	*out = *in
@@ -403,6 +725,21 @@ func (in *AzureMachinePoolSpec) DeepCopyInto(out *AzureMachinePoolSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.NodeDrainOptions != nil {
+		in, out := &in.NodeDrainOptions, &out.NodeDrainOptions
+		*out = new(NodeDrainOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneBalance != nil {
+		in, out := &in.ZoneBalance, &out.ZoneBalance
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RepairPolicy != nil {
+		in, out := &in.RepairPolicy, &out.RepairPolicy
+		*out = new(AzureMachinePoolRepairPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolSpec.
@@ -429,6 +766,23 @@ func (in *AzureMachinePoolStatus) DeepCopyInto(out *AzureMachinePoolStatus) {
 			}
 		}
 	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]AzureMachinePoolZoneStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.NodeInfo != nil {
+		in, out := &in.NodeInfo, &out.NodeInfo
+		*out = new(apiv1beta1.NodeInfo)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(apiv1beta1.Image)
@@ -439,6 +793,16 @@ func (in *AzureMachinePoolStatus) DeepCopyInto(out *AzureMachinePoolStatus) {
 		*out = new(apiv1beta1.ProvisioningState)
 		**out = **in
 	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(AzureMachinePoolDeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMSizeFallback != nil {
+		in, out := &in.VMSizeFallback, &out.VMSizeFallback
+		*out = new(AzureMachinePoolVMSizeFallback)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -473,6 +837,37 @@ func (in *AzureMachinePoolStatus) DeepCopy() *AzureMachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolVMSizeFallback) DeepCopyInto(out *AzureMachinePoolVMSizeFallback) {
+	*out = *in
+	in.FallbackTime.DeepCopyInto(&out.FallbackTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolVMSizeFallback.
+func (in *AzureMachinePoolVMSizeFallback) DeepCopy() *AzureMachinePoolVMSizeFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolVMSizeFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureMachinePoolZoneStatus) DeepCopyInto(out *AzureMachinePoolZoneStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachinePoolZoneStatus.
+func (in *AzureMachinePoolZoneStatus) DeepCopy() *AzureMachinePoolZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureMachinePoolZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureManagedCluster) DeepCopyInto(out *AzureManagedCluster) {
 	*out = *in
@@ -625,7 +1020,7 @@ func (in *AzureManagedControlPlaneList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPlaneSpec) {
 	*out = *in
-	out.VirtualNetwork = in.VirtualNetwork
+	in.VirtualNetwork.DeepCopyInto(&out.VirtualNetwork)
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
 	if in.AdditionalTags != nil {
 		in, out := &in.AdditionalTags, &out.AdditionalTags
@@ -649,6 +1044,16 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(string)
 		**out = **in
 	}
+	if in.PodCIDR != nil {
+		in, out := &in.PodCIDR, &out.PodCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceCIDR != nil {
+		in, out := &in.ServiceCIDR, &out.ServiceCIDR
+		*out = new(string)
+		**out = **in
+	}
 	if in.LoadBalancerSKU != nil {
 		in, out := &in.LoadBalancerSKU, &out.LoadBalancerSKU
 		*out = new(string)
@@ -671,6 +1076,11 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(ManagedControlPlaneAddons)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SKU != nil {
 		in, out := &in.SKU, &out.SKU
 		*out = new(SKU)
@@ -681,11 +1091,68 @@ func (in *AzureManagedControlPlaneSpec) DeepCopyInto(out *AzureManagedControlPla
 		*out = new(LoadBalancerProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OutboundType != nil {
+		in, out := &in.OutboundType, &out.OutboundType
+		*out = new(ManagedControlPlaneOutboundType)
+		**out = **in
+	}
+	if in.NatGatewayProfile != nil {
+		in, out := &in.NatGatewayProfile, &out.NatGatewayProfile
+		*out = new(NatGatewayProfile)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.APIServerAccessProfile != nil {
 		in, out := &in.APIServerAccessProfile, &out.APIServerAccessProfile
 		*out = new(APIServerAccessProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AutoScalerProfile != nil {
+		in, out := &in.AutoScalerProfile, &out.AutoScalerProfile
+		*out = new(AutoScalerProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoUpgradeProfile != nil {
+		in, out := &in.AutoUpgradeProfile, &out.AutoUpgradeProfile
+		*out = new(ManagedClusterAutoUpgradeProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceConfigurations != nil {
+		in, out := &in.MaintenanceConfigurations, &out.MaintenanceConfigurations
+		*out = make([]MaintenanceConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OIDCIssuerProfile != nil {
+		in, out := &in.OIDCIssuerProfile, &out.OIDCIssuerProfile
+		*out = new(OIDCIssuerProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadIdentityProfile != nil {
+		in, out := &in.WorkloadIdentityProfile, &out.WorkloadIdentityProfile
+		*out = new(WorkloadIdentityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(ManagedControlPlaneIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisableLocalAccounts != nil {
+		in, out := &in.DisableLocalAccounts, &out.DisableLocalAccounts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Kubeconfig != nil {
+		in, out := &in.Kubeconfig, &out.Kubeconfig
+		*out = new(ManagedControlPlaneKubeconfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeOrchestration != nil {
+		in, out := &in.UpgradeOrchestration, &out.UpgradeOrchestration
+		*out = new(UpgradeOrchestration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneSpec.
@@ -713,6 +1180,33 @@ func (in *AzureManagedControlPlaneStatus) DeepCopyInto(out *AzureManagedControlP
 		*out = make(apiv1beta1.Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.OIDCIssuerProfile != nil {
+		in, out := &in.OIDCIssuerProfile, &out.OIDCIssuerProfile
+		*out = new(OIDCIssuerStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentPoolUpgrades != nil {
+		in, out := &in.AgentPoolUpgrades, &out.AgentPoolUpgrades
+		*out = make([]AgentPoolUpgradeStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]AddonStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceConfigurations != nil {
+		in, out := &in.MaintenanceConfigurations, &out.MaintenanceConfigurations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoleAssignments != nil {
+		in, out := &in.RoleAssignments, &out.RoleAssignments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneStatus.
@@ -844,6 +1338,61 @@ func (in *AzureManagedMachinePoolSpec) DeepCopyInto(out *AzureManagedMachinePool
 		*out = new(string)
 		**out = **in
 	}
+	if in.OSSKU != nil {
+		in, out := &in.OSSKU, &out.OSSKU
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableNodePublicIP != nil {
+		in, out := &in.EnableNodePublicIP, &out.EnableNodePublicIP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodePublicIPPrefixID != nil {
+		in, out := &in.NodePublicIPPrefixID, &out.NodePublicIPPrefixID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleSetPriority != nil {
+		in, out := &in.ScaleSetPriority, &out.ScaleSetPriority
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownMode != nil {
+		in, out := &in.ScaleDownMode, &out.ScaleDownMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.UpgradeSettings != nil {
+		in, out := &in.UpgradeSettings, &out.UpgradeSettings
+		*out = new(AgentPoolUpgradeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletConfig != nil {
+		in, out := &in.KubeletConfig, &out.KubeletConfig
+		*out = new(KubeletConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LinuxOSConfig != nil {
+		in, out := &in.LinuxOSConfig, &out.LinuxOSConfig
+		*out = new(LinuxOSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSubnetID != nil {
+		in, out := &in.PodSubnetID, &out.PodSubnetID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(ManagedControlPlaneSubnet)
+		**out = **in
+	}
+	if in.PodSubnet != nil {
+		in, out := &in.PodSubnet, &out.PodSubnet
+		*out = new(ManagedControlPlaneSubnet)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolSpec.
@@ -893,6 +1442,186 @@ func (in *AzureManagedMachinePoolStatus) DeepCopy() *AzureManagedMachinePoolStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzurePolicyAddon) DeepCopyInto(out *AzurePolicyAddon) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePolicyAddon.
+func (in *AzurePolicyAddon) DeepCopy() *AzurePolicyAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(AzurePolicyAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressApplicationGatewayAddon) DeepCopyInto(out *IngressApplicationGatewayAddon) {
+	*out = *in
+	if in.ApplicationGatewayID != nil {
+		in, out := &in.ApplicationGatewayID, &out.ApplicationGatewayID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApplicationGatewayName != nil {
+		in, out := &in.ApplicationGatewayName, &out.ApplicationGatewayName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetCIDR != nil {
+		in, out := &in.SubnetCIDR, &out.SubnetCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetID != nil {
+		in, out := &in.SubnetID, &out.SubnetID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressApplicationGatewayAddon.
+func (in *IngressApplicationGatewayAddon) DeepCopy() *IngressApplicationGatewayAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressApplicationGatewayAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultSecretsProviderAddon) DeepCopyInto(out *KeyVaultSecretsProviderAddon) {
+	*out = *in
+	if in.EnableSecretRotation != nil {
+		in, out := &in.EnableSecretRotation, &out.EnableSecretRotation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RotationPollInterval != nil {
+		in, out := &in.RotationPollInterval, &out.RotationPollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultSecretsProviderAddon.
+func (in *KeyVaultSecretsProviderAddon) DeepCopy() *KeyVaultSecretsProviderAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultSecretsProviderAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
+	*out = *in
+	if in.CPUManagerPolicy != nil {
+		in, out := &in.CPUManagerPolicy, &out.CPUManagerPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.CPUCfsQuota != nil {
+		in, out := &in.CPUCfsQuota, &out.CPUCfsQuota
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CPUCfsQuotaPeriod != nil {
+		in, out := &in.CPUCfsQuotaPeriod, &out.CPUCfsQuotaPeriod
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageGcHighThreshold != nil {
+		in, out := &in.ImageGcHighThreshold, &out.ImageGcHighThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImageGcLowThreshold != nil {
+		in, out := &in.ImageGcLowThreshold, &out.ImageGcLowThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TopologyManagerPolicy != nil {
+		in, out := &in.TopologyManagerPolicy, &out.TopologyManagerPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedUnsafeSysctls != nil {
+		in, out := &in.AllowedUnsafeSysctls, &out.AllowedUnsafeSysctls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailSwapOn != nil {
+		in, out := &in.FailSwapOn, &out.FailSwapOn
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ContainerLogMaxSizeMB != nil {
+		in, out := &in.ContainerLogMaxSizeMB, &out.ContainerLogMaxSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContainerLogMaxFiles != nil {
+		in, out := &in.ContainerLogMaxFiles, &out.ContainerLogMaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodMaxPids != nil {
+		in, out := &in.PodMaxPids, &out.PodMaxPids
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfig.
+func (in *KubeletConfig) DeepCopy() *KubeletConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinuxOSConfig) DeepCopyInto(out *LinuxOSConfig) {
+	*out = *in
+	if in.SwapFileSizeMB != nil {
+		in, out := &in.SwapFileSizeMB, &out.SwapFileSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = new(SysctlConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransparentHugePageDefrag != nil {
+		in, out := &in.TransparentHugePageDefrag, &out.TransparentHugePageDefrag
+		*out = new(string)
+		**out = **in
+	}
+	if in.TransparentHugePageEnabled != nil {
+		in, out := &in.TransparentHugePageEnabled, &out.TransparentHugePageEnabled
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinuxOSConfig.
+func (in *LinuxOSConfig) DeepCopy() *LinuxOSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LinuxOSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerProfile) DeepCopyInto(out *LoadBalancerProfile) {
 	*out = *in
@@ -934,107 +1663,651 @@ func (in *LoadBalancerProfile) DeepCopy() *LoadBalancerProfile {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachineRollingUpdateDeployment) DeepCopyInto(out *MachineRollingUpdateDeployment) {
+func (in *MachineBlueGreenDeployment) DeepCopyInto(out *MachineBlueGreenDeployment) {
 	*out = *in
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
-		**out = **in
-	}
-	if in.MaxSurge != nil {
-		in, out := &in.MaxSurge, &out.MaxSurge
-		*out = new(intstr.IntOrString)
+	if in.BakeTime != nil {
+		in, out := &in.BakeTime, &out.BakeTime
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineRollingUpdateDeployment.
-func (in *MachineRollingUpdateDeployment) DeepCopy() *MachineRollingUpdateDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineBlueGreenDeployment.
+func (in *MachineBlueGreenDeployment) DeepCopy() *MachineBlueGreenDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(MachineRollingUpdateDeployment)
+	out := new(MachineBlueGreenDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedControlPlaneSubnet) DeepCopyInto(out *ManagedControlPlaneSubnet) {
+func (in *MachineCanaryDeployment) DeepCopyInto(out *MachineCanaryDeployment) {
 	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.BakeTime != nil {
+		in, out := &in.BakeTime, &out.BakeTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneSubnet.
-func (in *ManagedControlPlaneSubnet) DeepCopy() *ManagedControlPlaneSubnet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineCanaryDeployment.
+func (in *MachineCanaryDeployment) DeepCopy() *MachineCanaryDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedControlPlaneSubnet)
+	out := new(MachineCanaryDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedControlPlaneVirtualNetwork) DeepCopyInto(out *ManagedControlPlaneVirtualNetwork) {
+func (in *MachineRollingUpdateDeployment) DeepCopyInto(out *MachineRollingUpdateDeployment) {
 	*out = *in
-	out.Subnet = in.Subnet
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(MachineRolloutHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneVirtualNetwork.
-func (in *ManagedControlPlaneVirtualNetwork) DeepCopy() *ManagedControlPlaneVirtualNetwork {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineRollingUpdateDeployment.
+func (in *MachineRollingUpdateDeployment) DeepCopy() *MachineRollingUpdateDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedControlPlaneVirtualNetwork)
+	out := new(MachineRollingUpdateDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedMachinePoolScaling) DeepCopyInto(out *ManagedMachinePoolScaling) {
+func (in *MachineRolloutHealthCheck) DeepCopyInto(out *MachineRolloutHealthCheck) {
 	*out = *in
-	if in.MinSize != nil {
-		in, out := &in.MinSize, &out.MinSize
-		*out = new(int32)
+	if in.NodeReadyTimeout != nil {
+		in, out := &in.NodeReadyTimeout, &out.NodeReadyTimeout
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.MaxSize != nil {
-		in, out := &in.MaxSize, &out.MaxSize
-		*out = new(int32)
+	if in.MaxUnhealthy != nil {
+		in, out := &in.MaxUnhealthy, &out.MaxUnhealthy
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedMachinePoolScaling.
-func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineRolloutHealthCheck.
+func (in *MachineRolloutHealthCheck) DeepCopy() *MachineRolloutHealthCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedMachinePoolScaling)
+	out := new(MachineRolloutHealthCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SKU) DeepCopyInto(out *SKU) {
+func (in *MaintenanceConfiguration) DeepCopyInto(out *MaintenanceConfiguration) {
 	*out = *in
+	if in.TimeInWeek != nil {
+		in, out := &in.TimeInWeek, &out.TimeInWeek
+		*out = make([]TimeInWeek, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NotAllowedTime != nil {
+		in, out := &in.NotAllowedTime, &out.NotAllowedTime
+		*out = make([]TimeSpan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SKU.
-func (in *SKU) DeepCopy() *SKU {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceConfiguration.
+func (in *MaintenanceConfiguration) DeepCopy() *MaintenanceConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(SKU)
+	out := new(MaintenanceConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Taint) DeepCopyInto(out *Taint) {
+func (in *ManagedClusterAutoUpgradeProfile) DeepCopyInto(out *ManagedClusterAutoUpgradeProfile) {
 	*out = *in
+	if in.UpgradeChannel != nil {
+		in, out := &in.UpgradeChannel, &out.UpgradeChannel
+		*out = new(UpgradeChannel)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Taint.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterAutoUpgradeProfile.
+func (in *ManagedClusterAutoUpgradeProfile) DeepCopy() *ManagedClusterAutoUpgradeProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterAutoUpgradeProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneAddons) DeepCopyInto(out *ManagedControlPlaneAddons) {
+	*out = *in
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringAddon)
+		**out = **in
+	}
+	if in.AzurePolicy != nil {
+		in, out := &in.AzurePolicy, &out.AzurePolicy
+		*out = new(AzurePolicyAddon)
+		**out = **in
+	}
+	if in.KeyVaultSecretsProvider != nil {
+		in, out := &in.KeyVaultSecretsProvider, &out.KeyVaultSecretsProvider
+		*out = new(KeyVaultSecretsProviderAddon)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressApplicationGateway != nil {
+		in, out := &in.IngressApplicationGateway, &out.IngressApplicationGateway
+		*out = new(IngressApplicationGatewayAddon)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneAddons.
+func (in *ManagedControlPlaneAddons) DeepCopy() *ManagedControlPlaneAddons {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneAddons)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneIdentity) DeepCopyInto(out *ManagedControlPlaneIdentity) {
+	*out = *in
+	if in.ControlPlaneIdentityResourceID != nil {
+		in, out := &in.ControlPlaneIdentityResourceID, &out.ControlPlaneIdentityResourceID
+		*out = new(string)
+		**out = **in
+	}
+	if in.KubeletIdentityResourceID != nil {
+		in, out := &in.KubeletIdentityResourceID, &out.KubeletIdentityResourceID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleAssignments != nil {
+		in, out := &in.RoleAssignments, &out.RoleAssignments
+		*out = make([]ManagedIdentityRoleAssignment, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneIdentity.
+func (in *ManagedControlPlaneIdentity) DeepCopy() *ManagedControlPlaneIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneKubeconfig) DeepCopyInto(out *ManagedControlPlaneKubeconfig) {
+	*out = *in
+	if in.CredentialKind != nil {
+		in, out := &in.CredentialKind, &out.CredentialKind
+		*out = new(KubeconfigCredentialKind)
+		**out = **in
+	}
+	if in.ServicePrincipalSecretRef != nil {
+		in, out := &in.ServicePrincipalSecretRef, &out.ServicePrincipalSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneKubeconfig.
+func (in *ManagedControlPlaneKubeconfig) DeepCopy() *ManagedControlPlaneKubeconfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneKubeconfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneSubnet) DeepCopyInto(out *ManagedControlPlaneSubnet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneSubnet.
+func (in *ManagedControlPlaneSubnet) DeepCopy() *ManagedControlPlaneSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedControlPlaneVirtualNetwork) DeepCopyInto(out *ManagedControlPlaneVirtualNetwork) {
+	*out = *in
+	out.Subnet = in.Subnet
+	if in.AllowSubnetCreation != nil {
+		in, out := &in.AllowSubnetCreation, &out.AllowSubnetCreation
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedControlPlaneVirtualNetwork.
+func (in *ManagedControlPlaneVirtualNetwork) DeepCopy() *ManagedControlPlaneVirtualNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedControlPlaneVirtualNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedIdentityRoleAssignment) DeepCopyInto(out *ManagedIdentityRoleAssignment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedIdentityRoleAssignment.
+func (in *ManagedIdentityRoleAssignment) DeepCopy() *ManagedIdentityRoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedIdentityRoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedMachinePoolScaling) DeepCopyInto(out *ManagedMachinePoolScaling) {
+	*out = *in
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedMachinePoolScaling.
+func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedMachinePoolScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedPrivateDNSZone) DeepCopyInto(out *ManagedPrivateDNSZone) {
+	*out = *in
+	if in.AdditionalVNetLinks != nil {
+		in, out := &in.AdditionalVNetLinks, &out.AdditionalVNetLinks
+		*out = make([]PrivateDNSZoneVNetLink, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedPrivateDNSZone.
+func (in *ManagedPrivateDNSZone) DeepCopy() *ManagedPrivateDNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedPrivateDNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAddon) DeepCopyInto(out *MonitoringAddon) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAddon.
+func (in *MonitoringAddon) DeepCopy() *MonitoringAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatGatewayProfile) DeepCopyInto(out *NatGatewayProfile) {
+	*out = *in
+	if in.ManagedOutboundIPs != nil {
+		in, out := &in.ManagedOutboundIPs, &out.ManagedOutboundIPs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeoutInMinutes != nil {
+		in, out := &in.IdleTimeoutInMinutes, &out.IdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatGatewayProfile.
+func (in *NatGatewayProfile) DeepCopy() *NatGatewayProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NatGatewayProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainOptions) DeepCopyInto(out *NodeDrainOptions) {
+	*out = *in
+	if in.PodDisruptionBudgetTimeout != nil {
+		in, out := &in.PodDisruptionBudgetTimeout, &out.PodDisruptionBudgetTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DeleteEmptyDirData != nil {
+		in, out := &in.DeleteEmptyDirData, &out.DeleteEmptyDirData
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreDaemonSets != nil {
+		in, out := &in.IgnoreDaemonSets, &out.IgnoreDaemonSets
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExcludePodSelector != nil {
+		in, out := &in.ExcludePodSelector, &out.ExcludePodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainOptions.
+func (in *NodeDrainOptions) DeepCopy() *NodeDrainOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCIssuerProfile) DeepCopyInto(out *OIDCIssuerProfile) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCIssuerProfile.
+func (in *OIDCIssuerProfile) DeepCopy() *OIDCIssuerProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCIssuerProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCIssuerStatus) DeepCopyInto(out *OIDCIssuerStatus) {
+	*out = *in
+	if in.IssuerURL != nil {
+		in, out := &in.IssuerURL, &out.IssuerURL
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCIssuerStatus.
+func (in *OIDCIssuerStatus) DeepCopy() *OIDCIssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCIssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateDNSZoneVNetLink) DeepCopyInto(out *PrivateDNSZoneVNetLink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateDNSZoneVNetLink.
+func (in *PrivateDNSZoneVNetLink) DeepCopy() *PrivateDNSZoneVNetLink {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateDNSZoneVNetLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SKU) DeepCopyInto(out *SKU) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SKU.
+func (in *SKU) DeepCopy() *SKU {
+	if in == nil {
+		return nil
+	}
+	out := new(SKU)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SysctlConfig) DeepCopyInto(out *SysctlConfig) {
+	*out = *in
+	if in.FsAioMaxNr != nil {
+		in, out := &in.FsAioMaxNr, &out.FsAioMaxNr
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FsFileMax != nil {
+		in, out := &in.FsFileMax, &out.FsFileMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FsInotifyMaxUserWatches != nil {
+		in, out := &in.FsInotifyMaxUserWatches, &out.FsInotifyMaxUserWatches
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FsNrOpen != nil {
+		in, out := &in.FsNrOpen, &out.FsNrOpen
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KernelThreadsMax != nil {
+		in, out := &in.KernelThreadsMax, &out.KernelThreadsMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreNetdevMaxBacklog != nil {
+		in, out := &in.NetCoreNetdevMaxBacklog, &out.NetCoreNetdevMaxBacklog
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreOptmemMax != nil {
+		in, out := &in.NetCoreOptmemMax, &out.NetCoreOptmemMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreRmemDefault != nil {
+		in, out := &in.NetCoreRmemDefault, &out.NetCoreRmemDefault
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreRmemMax != nil {
+		in, out := &in.NetCoreRmemMax, &out.NetCoreRmemMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreSomaxconn != nil {
+		in, out := &in.NetCoreSomaxconn, &out.NetCoreSomaxconn
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreWmemDefault != nil {
+		in, out := &in.NetCoreWmemDefault, &out.NetCoreWmemDefault
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetCoreWmemMax != nil {
+		in, out := &in.NetCoreWmemMax, &out.NetCoreWmemMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4IPLocalPortRange != nil {
+		in, out := &in.NetIpv4IPLocalPortRange, &out.NetIpv4IPLocalPortRange
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetIpv4NeighDefaultGcThresh1 != nil {
+		in, out := &in.NetIpv4NeighDefaultGcThresh1, &out.NetIpv4NeighDefaultGcThresh1
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4NeighDefaultGcThresh2 != nil {
+		in, out := &in.NetIpv4NeighDefaultGcThresh2, &out.NetIpv4NeighDefaultGcThresh2
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4NeighDefaultGcThresh3 != nil {
+		in, out := &in.NetIpv4NeighDefaultGcThresh3, &out.NetIpv4NeighDefaultGcThresh3
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPFinTimeout != nil {
+		in, out := &in.NetIpv4TCPFinTimeout, &out.NetIpv4TCPFinTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPKeepaliveProbes != nil {
+		in, out := &in.NetIpv4TCPKeepaliveProbes, &out.NetIpv4TCPKeepaliveProbes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPKeepaliveTime != nil {
+		in, out := &in.NetIpv4TCPKeepaliveTime, &out.NetIpv4TCPKeepaliveTime
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPMaxSynBacklog != nil {
+		in, out := &in.NetIpv4TCPMaxSynBacklog, &out.NetIpv4TCPMaxSynBacklog
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPMaxTwBuckets != nil {
+		in, out := &in.NetIpv4TCPMaxTwBuckets, &out.NetIpv4TCPMaxTwBuckets
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetIpv4TCPTwReuse != nil {
+		in, out := &in.NetIpv4TCPTwReuse, &out.NetIpv4TCPTwReuse
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetIpv4TCPkeepaliveIntvl != nil {
+		in, out := &in.NetIpv4TCPkeepaliveIntvl, &out.NetIpv4TCPkeepaliveIntvl
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetNetfilterNfConntrackBuckets != nil {
+		in, out := &in.NetNetfilterNfConntrackBuckets, &out.NetNetfilterNfConntrackBuckets
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetNetfilterNfConntrackMax != nil {
+		in, out := &in.NetNetfilterNfConntrackMax, &out.NetNetfilterNfConntrackMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VMMaxMapCount != nil {
+		in, out := &in.VMMaxMapCount, &out.VMMaxMapCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VMSwappiness != nil {
+		in, out := &in.VMSwappiness, &out.VMSwappiness
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VMVfsCachePressure != nil {
+		in, out := &in.VMVfsCachePressure, &out.VMVfsCachePressure
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysctlConfig.
+func (in *SysctlConfig) DeepCopy() *SysctlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SysctlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Taint) DeepCopyInto(out *Taint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Taint.
 func (in *Taint) DeepCopy() *Taint {
 	if in == nil {
 		return nil
@@ -1062,3 +2335,85 @@ func (in Taints) DeepCopy() Taints {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeInWeek) DeepCopyInto(out *TimeInWeek) {
+	*out = *in
+	if in.HourSlots != nil {
+		in, out := &in.HourSlots, &out.HourSlots
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeInWeek.
+func (in *TimeInWeek) DeepCopy() *TimeInWeek {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeInWeek)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeSpan) DeepCopyInto(out *TimeSpan) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSpan.
+func (in *TimeSpan) DeepCopy() *TimeSpan {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSpan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeOrchestration) DeepCopyInto(out *UpgradeOrchestration) {
+	*out = *in
+	if in.AgentPoolOrder != nil {
+		in, out := &in.AgentPoolOrder, &out.AgentPoolOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxParallelAgentPools != nil {
+		in, out := &in.MaxParallelAgentPools, &out.MaxParallelAgentPools
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeOrchestration.
+func (in *UpgradeOrchestration) DeepCopy() *UpgradeOrchestration {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeOrchestration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityProfile) DeepCopyInto(out *WorkloadIdentityProfile) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityProfile.
+func (in *WorkloadIdentityProfile) DeepCopy() *WorkloadIdentityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -37,6 +37,7 @@ import (
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -239,9 +240,14 @@ func (ampr *AzureMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	// Always close the scope when exiting this function so we can persist any AzureMachine changes.
 	defer func() {
+		wasRolloutPaused := conditions.IsTrue(azMachinePool, infrav1.RolloutPausedCondition)
 		if err := machinePoolScope.Close(ctx); err != nil && reterr == nil {
 			reterr = err
 		}
+
+		if !wasRolloutPaused && conditions.IsTrue(azMachinePool, infrav1.RolloutPausedCondition) {
+			ampr.Recorder.Event(azMachinePool, corev1.EventTypeWarning, "RolloutPaused", conditions.GetMessage(azMachinePool, infrav1.RolloutPausedCondition))
+		}
 	}()
 
 	// Handle deleted machine pools
@@ -310,6 +316,10 @@ func (ampr *AzureMachinePoolReconciler) reconcileNormal(ctx context.Context, mac
 	log.V(2).Info("Scale Set reconciled", "id",
 		machinePoolScope.ProviderID(), "state", machinePoolScope.ProvisioningState())
 
+	if err := infracontroller.ValidateNodeAnnotations(machinePoolScope.AzureMachinePool); err != nil {
+		ampr.Recorder.Eventf(machinePoolScope.AzureMachinePool, corev1.EventTypeWarning, "InvalidNodeAnnotations", "Node capacity is not published: %s", err)
+	}
+
 	switch machinePoolScope.ProvisioningState() {
 	case infrav1.Deleting:
 		log.Info("Unexpected scale set deletion", "id", machinePoolScope.ProviderID())
@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets"
+	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -67,6 +68,38 @@ func (s *azureMachinePoolService) Reconcile(ctx context.Context) error {
 		}
 	}
 
+	if err := s.reconcileNodeCapacity(ctx); err != nil {
+		return errors.Wrap(err, "failed to reconcile AzureMachinePool node capacity")
+	}
+
+	return nil
+}
+
+// reconcileNodeCapacity publishes the capacity of the nodes of the AzureMachinePool, derived from its VM size and the
+// kubelet configuration in its annotations, for the cluster autoscaler to scale the MachinePool from zero. The capacity
+// is not published while the annotations are malformed, which the controller reports in a warning event.
+func (s *azureMachinePoolService) reconcileNodeCapacity(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureMachinePoolService.reconcileNodeCapacity")
+	defer done()
+
+	if err := infracontroller.ValidateNodeAnnotations(s.scope.AzureMachinePool); err != nil {
+		log.V(2).Info("not publishing the node capacity of the AzureMachinePool", "reason", err.Error())
+		s.scope.SetNodeCapacity(nil, nil)
+		return nil
+	}
+
+	vmSize := s.scope.VMSize()
+	sku, err := s.skuCache.Get(ctx, vmSize, resourceskus.VirtualMachines)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get SKU %s in compute api", vmSize)
+	}
+
+	capacity, nodeInfo, err := infracontroller.ScaleFromZeroNodeCapacity(s.scope.AzureMachinePool, sku, vmSize, s.scope.AzureMachinePool.Spec.Template.OSDisk)
+	if err != nil {
+		return err
+	}
+
+	s.scope.SetNodeCapacity(capacity, nodeInfo)
 	return nil
 }
 
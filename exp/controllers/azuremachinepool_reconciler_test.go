@@ -22,14 +22,18 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomega"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -85,6 +89,11 @@ func TestAzureMachinePoolServiceReconcile(t *testing.T) {
 						Spec: infrav1exp.AzureMachinePoolSpec{
 							Template: infrav1exp.AzureMachinePoolMachineTemplate{
 								SubnetName: "test-subnet",
+								VMSize:     "Standard_D2s_v3",
+								OSDisk: infrav1.OSDisk{
+									OSType:     "Linux",
+									DiskSizeGB: to.Int32Ptr(30),
+								},
 							},
 						},
 					},
@@ -94,7 +103,15 @@ func TestAzureMachinePoolServiceReconcile(t *testing.T) {
 					svcTwoMock,
 					svcThreeMock,
 				},
-				skuCache: resourceskus.NewStaticCache([]compute.ResourceSku{}, ""),
+				skuCache: resourceskus.NewStaticCache([]compute.ResourceSku{
+					{
+						Name: to.StringPtr("Standard_D2s_v3"),
+						Capabilities: &[]compute.ResourceSkuCapabilities{
+							{Name: to.StringPtr(resourceskus.VCPUs), Value: to.StringPtr("2")},
+							{Name: to.StringPtr(resourceskus.MemoryGB), Value: to.StringPtr("8")},
+						},
+					},
+				}, ""),
 			}
 
 			err := s.Reconcile(context.TODO())
@@ -103,6 +120,17 @@ func TestAzureMachinePoolServiceReconcile(t *testing.T) {
 				g.Expect(err).To(MatchError(tc.expectedError))
 			} else {
 				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(s.scope.AzureMachinePool.Status.Capacity).To(gomega.DiffEq(corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("2"),
+					corev1.ResourceMemory:           resource.MustParse("8Gi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("30Gi"),
+					corev1.ResourcePods:             resource.MustParse("110"),
+				}))
+				g.Expect(s.scope.AzureMachinePool.Status.NodeInfo).To(Equal(&infrav1.NodeInfo{
+					Architecture:    "amd64",
+					OperatingSystem: "linux",
+					Labels:          map[string]string{corev1.LabelInstanceTypeStable: "Standard_D2s_v3"},
+				}))
 			}
 		})
 	}
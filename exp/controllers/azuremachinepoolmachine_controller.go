@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -29,12 +30,15 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootdiagnostics"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesetvms"
 	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -53,11 +57,13 @@ type (
 	// AzureMachinePoolMachineController handles Kubernetes change events for AzureMachinePoolMachine resources.
 	AzureMachinePoolMachineController struct {
 		client.Client
-		Scheme            *runtime.Scheme
-		Recorder          record.EventRecorder
-		ReconcileTimeout  time.Duration
-		WatchFilterValue  string
-		reconcilerFactory azureMachinePoolMachineReconcilerFactory
+		Scheme                   *runtime.Scheme
+		Recorder                 record.EventRecorder
+		ReconcileTimeout         time.Duration
+		WatchFilterValue         string
+		SerialConsoleLogCapture  infracontroller.SerialConsoleLogCapture
+		reconcilerFactory        azureMachinePoolMachineReconcilerFactory
+		getBootDiagnosticsClient infracontroller.BootDiagnosticsClientGetter
 	}
 
 	azureMachinePoolMachineReconciler struct {
@@ -67,13 +73,15 @@ type (
 )
 
 // NewAzureMachinePoolMachineController creates a new AzureMachinePoolMachineController to handle updates to Azure Machine Pool Machines.
-func NewAzureMachinePoolMachineController(c client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string) *AzureMachinePoolMachineController {
+func NewAzureMachinePoolMachineController(c client.Client, recorder record.EventRecorder, reconcileTimeout time.Duration, watchFilterValue string, serialConsoleLogCapture infracontroller.SerialConsoleLogCapture) *AzureMachinePoolMachineController {
 	return &AzureMachinePoolMachineController{
-		Client:            c,
-		Recorder:          recorder,
-		ReconcileTimeout:  reconcileTimeout,
-		WatchFilterValue:  watchFilterValue,
-		reconcilerFactory: newAzureMachinePoolMachineReconciler,
+		Client:                   c,
+		Recorder:                 recorder,
+		ReconcileTimeout:         reconcileTimeout,
+		WatchFilterValue:         watchFilterValue,
+		SerialConsoleLogCapture:  serialConsoleLogCapture,
+		reconcilerFactory:        newAzureMachinePoolMachineReconciler,
+		getBootDiagnosticsClient: infracontroller.GetBootDiagnosticsClient,
 	}
 }
 
@@ -277,12 +285,25 @@ func (ampmr *AzureMachinePoolMachineController) reconcileNormal(ctx context.Cont
 	switch state {
 	case infrav1.Failed:
 		ampmr.Recorder.Eventf(machineScope.AzureMachinePoolMachine, corev1.EventTypeWarning, "FailedVMState", "Azure scale set VM is in failed state")
+		if err := ampmr.captureSerialConsoleLog(ctx, machineScope); err != nil {
+			log.Error(err, "failed to capture the serial console log of the scale set VM")
+		}
 		machineScope.SetFailureReason(capierrors.UpdateMachineError)
 		machineScope.SetFailureMessage(errors.Errorf("Azure VM state is %s", state))
 	case infrav1.Deleting:
 		if err := ampmr.Client.Delete(ctx, machineScope.AzureMachinePoolMachine); err != nil {
 			return reconcile.Result{}, errors.Wrap(err, "machine pool machine failed to be deleted when deleting")
 		}
+	default:
+		if repairing, err := ampmr.reconcileRepair(ctx, machineScope); err != nil || repairing {
+			return reconcile.Result{}, err
+		}
+
+		if feature.Gates.Enabled(feature.ScheduledEvents) {
+			if result, err := ampmr.reconcileScheduledEvents(ctx, machineScope); err != nil || !result.IsZero() {
+				return result, err
+			}
+		}
 	}
 
 	log.V(2).Info(fmt.Sprintf("Scale Set VM is %s", state), "id", machineScope.ProviderID())
@@ -295,9 +316,98 @@ func (ampmr *AzureMachinePoolMachineController) reconcileNormal(ctx context.Cont
 		}, nil
 	}
 
+	if feature.Gates.Enabled(feature.ScheduledEvents) {
+		return reconcile.Result{RequeueAfter: scope.ScheduledEventsPollInterval}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileScheduledEvents drains the node of the AzureMachinePoolMachine ahead of Azure Scheduled Events. It returns a
+// non-zero result when the drain has to be retried.
+func (ampmr *AzureMachinePoolMachineController) reconcileScheduledEvents(ctx context.Context, machineScope *scope.MachinePoolMachineScope) (reconcile.Result, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachinePoolMachineController.reconcileScheduledEvents")
+	defer done()
+
+	approved, err := machineScope.ReconcileScheduledEvents(ctx)
+	if err != nil {
+		var reconcileError azure.ReconcileError
+		if errors.As(err, &reconcileError) && reconcileError.IsTransient() {
+			log.V(4).Info("failed to drain node ahead of scheduled events", "name", machineScope.Name(), "transient_error", err)
+			return reconcile.Result{RequeueAfter: reconcileError.RequeueAfter()}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, "failed to handle the scheduled events of the node")
+	}
+
+	if len(approved) > 0 {
+		ampmr.Recorder.Eventf(machineScope.AzureMachinePoolMachine, corev1.EventTypeNormal, "ScheduledEventsApproved", "Drained node ahead of Azure scheduled events %s", strings.Join(approved, ", "))
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// captureSerialConsoleLog captures the tail of the serial console log of a scale set VM in the Failed state, which
+// usually means that its bootstrap extension failed, once, and attaches it to an event or stores it in a ConfigMap
+// depending on the controller configuration.
+func (ampmr *AzureMachinePoolMachineController) captureSerialConsoleLog(ctx context.Context, machineScope *scope.MachinePoolMachineScope) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachinePoolMachineController.captureSerialConsoleLog")
+	defer done()
+
+	ampm := machineScope.AzureMachinePoolMachine
+	if !ampmr.SerialConsoleLogCapture.Enabled() || ampm.Status.BootDiagnostics != nil {
+		return nil
+	}
+
+	// The serial console log can only be retrieved from the storage account managed by Azure.
+	if !bootdiagnostics.IsManaged(machineScope.AzureMachinePool.Spec.Template.Diagnostics) {
+		log.V(2).Info("boot diagnostics are not stored in a managed storage account, not capturing the serial console log")
+		return nil
+	}
+
+	client, err := ampmr.getBootDiagnosticsClient(machineScope)
+	if err != nil {
+		return err
+	}
+
+	serialConsoleLog, err := bootdiagnostics.GetScaleSetVMSerialConsoleLogTail(ctx, client, machineScope.ResourceGroup(), machineScope.ScaleSetName(), machineScope.InstanceID(), ampmr.SerialConsoleLogCapture.MaxBytes())
+	if err != nil {
+		return err
+	}
+
+	status, err := infracontroller.RecordSerialConsoleLog(ctx, ampmr.Client, ampmr.Recorder, ampmr.SerialConsoleLogCapture, ampm, machineScope.ClusterName(), "Azure scale set VM is in failed state", serialConsoleLog)
+	if err != nil {
+		return err
+	}
+	ampm.Status.BootDiagnostics = status
+
+	return nil
+}
+
+// reconcileRepair deletes the AzureMachinePoolMachine when its instance needs to be repaired according to the repair
+// policy of the pool. Deleting the AzureMachinePoolMachine cordons and drains the node and deletes the instance, which
+// the scale set then replaces.
+func (ampmr *AzureMachinePoolMachineController) reconcileRepair(ctx context.Context, machineScope *scope.MachinePoolMachineScope) (bool, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachinePoolMachineController.reconcileRepair")
+	defer done()
+
+	needsRepair, reason, err := machineScope.NeedsRepair(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to determine whether the instance needs to be repaired")
+	}
+
+	if !needsRepair {
+		return false, nil
+	}
+
+	log.Info("Repairing unhealthy instance", "reason", reason)
+	ampmr.Recorder.Eventf(machineScope.AzureMachinePoolMachine, corev1.EventTypeWarning, "RepairingInstance", "Repairing unhealthy Azure scale set VM: %s", reason)
+	if err := ampmr.Client.Delete(ctx, machineScope.AzureMachinePoolMachine); err != nil {
+		return false, errors.Wrap(err, "machine pool machine failed to be deleted when repairing")
+	}
+
+	return true, nil
+}
+
 func (ampmr *AzureMachinePoolMachineController) reconcileDelete(ctx context.Context, machineScope *scope.MachinePoolMachineScope) (_ reconcile.Result, reterr error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachinePoolMachineController.reconcileDelete")
 	defer done()
@@ -381,11 +491,22 @@ func (r *azureMachinePoolMachineReconciler) Delete(ctx context.Context) error {
 		}
 	}()
 
+	// the removal of a hook annotation triggers a new reconciliation
+	if r.Scope.WaitForDeleteHook(clusterv1.PreDrainDeleteHookAnnotationPrefix, clusterv1.PreDrainDeleteHookSucceededCondition) {
+		log.Info("Waiting for pre-drain hooks to succeed")
+		return nil
+	}
+
 	// cordon and drain stuff
 	if err := r.Scope.CordonAndDrain(ctx); err != nil {
 		return errors.Wrap(err, "failed to cordon and drain the scalesetVMs")
 	}
 
+	if r.Scope.WaitForDeleteHook(clusterv1.PreTerminateDeleteHookAnnotationPrefix, clusterv1.PreTerminateDeleteHookSucceededCondition) {
+		log.Info("Waiting for pre-terminate hooks to succeed")
+		return nil
+	}
+
 	if err := r.scalesetVMsService.Delete(ctx); err != nil {
 		return errors.Wrap(err, "failed to reconcile scalesetVMs")
 	}
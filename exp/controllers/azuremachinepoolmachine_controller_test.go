@@ -22,22 +22,29 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootdiagnostics"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bootdiagnostics/mock_bootdiagnostics"
+	infracontroller "sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	gomock2 "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -103,7 +110,7 @@ func TestAzureMachinePoolMachineReconciler_Reconcile(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			c.Setup(cb, reconciler.EXPECT())
-			controller := NewAzureMachinePoolMachineController(cb.Build(), nil, 30*time.Second, "foo")
+			controller := NewAzureMachinePoolMachineController(cb.Build(), nil, 30*time.Second, "foo", infracontroller.SerialConsoleLogCaptureNone)
 			controller.reconcilerFactory = func(_ *scope.MachinePoolMachineScope) azure.Reconciler {
 				return reconciler
 			}
@@ -118,6 +125,70 @@ func TestAzureMachinePoolMachineReconciler_Reconcile(t *testing.T) {
 	}
 }
 
+func TestAzureMachinePoolMachineReconciler_CaptureSerialConsoleLog(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	scheme := runtime.NewScheme()
+	for _, addTo := range []func(s *runtime.Scheme) error{
+		clusterv1.AddToScheme,
+		expv1.AddToScheme,
+		infrav1.AddToScheme,
+		infrav1exp.AddToScheme,
+		corev1.AddToScheme,
+	} {
+		g.Expect(addTo(scheme)).To(Succeed())
+	}
+
+	os.Setenv(auth.ClientID, "fooClient")
+	os.Setenv(auth.ClientSecret, "fooSecret")
+	os.Setenv(auth.TenantID, "fooTenant")
+
+	cluster, azCluster, mp, amp, ampm := getAReadyMachinePoolMachineCluster()
+	amp.Spec.Template.Diagnostics = &infrav1.Diagnostics{
+		Boot: &infrav1.BootDiagnostics{StorageAccountType: infrav1.ManagedDiagnosticsStorage},
+	}
+	ampm.Spec.InstanceID = "2"
+	failed := infrav1.Failed
+	ampm.Status.ProvisioningState = &failed
+
+	reconciler := mock_azure.NewMockReconciler(mockCtrl)
+	reconciler.EXPECT().Reconcile(gomock2.AContext()).Return(nil)
+
+	bootDiagnosticsMock := mock_bootdiagnostics.NewMockClient(mockCtrl)
+	bootDiagnosticsMock.EXPECT().RetrieveScaleSetVMBootDiagnosticsData(gomock2.AContext(), gomock.Any(), amp.Name, "2").Return(compute.RetrieveBootDiagnosticsDataResult{
+		SerialConsoleLogBlobURI: to.StringPtr("https://serial-console-log"),
+	}, nil)
+	bootDiagnosticsMock.EXPECT().GetBlobTail(gomock2.AContext(), "https://serial-console-log", infracontroller.SerialConsoleLogCaptureConfigMap.MaxBytes()+1).Return([]byte("cloud-init failed\n"), nil)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, azCluster, mp, amp, ampm).Build()
+	controller := NewAzureMachinePoolMachineController(c, record.NewFakeRecorder(10), 30*time.Second, "foo", infracontroller.SerialConsoleLogCaptureConfigMap)
+	controller.reconcilerFactory = func(_ *scope.MachinePoolMachineScope) azure.Reconciler {
+		return reconciler
+	}
+	controller.getBootDiagnosticsClient = func(azure.Authorizer) (bootdiagnostics.Client, error) {
+		return bootDiagnosticsMock, nil
+	}
+
+	_, err := controller.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      ampm.Name,
+			Namespace: ampm.Namespace,
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	updated := &infrav1exp.AzureMachinePoolMachine{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(ampm), updated)).To(Succeed())
+	g.Expect(updated.Status.BootDiagnostics).NotTo(BeNil())
+	g.Expect(updated.Status.BootDiagnostics.SerialConsoleLogRef).NotTo(BeNil())
+
+	configMap := &corev1.ConfigMap{}
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: ampm.Namespace, Name: updated.Status.BootDiagnostics.SerialConsoleLogRef.Name}, configMap)).To(Succeed())
+	g.Expect(configMap.Data).To(HaveKeyWithValue("serial-console.log", "cloud-init failed\n"))
+}
+
 func getAReadyMachinePoolMachineCluster() (*clusterv1.Cluster, *infrav1.AzureCluster, *expv1.MachinePool, *infrav1exp.AzureMachinePool, *infrav1exp.AzureMachinePoolMachine) {
 	azCluster := &infrav1.AzureCluster{
 		ObjectMeta: metav1.ObjectMeta{
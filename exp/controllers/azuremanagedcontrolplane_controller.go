@@ -47,6 +47,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// agentPoolUpgradeRequeueInterval is how often the progress of orchestrated agent pool upgrades is checked.
+const agentPoolUpgradeRequeueInterval = 30 * time.Second
+
 // AzureManagedControlPlaneReconciler reconciles an AzureManagedControlPlane object.
 type AzureManagedControlPlaneReconciler struct {
 	client.Client
@@ -236,7 +239,7 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 		return reconcile.Result{}, err
 	}
 
-	if err := newAzureManagedControlPlaneReconciler(scope).Reconcile(ctx); err != nil {
+	if err := newAzureManagedControlPlaneReconciler(scope, amcpr.Recorder).Reconcile(ctx); err != nil {
 		// Handle transient and terminal errors
 		log := log.WithValues("name", scope.ControlPlane.Name, "namespace", scope.ControlPlane.Namespace)
 		var reconcileError azure.ReconcileError
@@ -261,7 +264,15 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 	scope.ControlPlane.Status.Ready = true
 	scope.ControlPlane.Status.Initialized = true
 	amcpr.Recorder.Event(scope.ControlPlane, corev1.EventTypeNormal, "AzureManagedControlPlane available", "successfully reconciled")
-	return reconcile.Result{}, nil
+
+	// Renew the AAD tokens of the kubeconfig before they expire.
+	requeueAfter := kubeconfigRefreshAfter(scope.KubeConfigTokenExpiry())
+
+	// Not every machine pool is watched, so check on orchestrated agent pool upgrades until they complete.
+	if scope.AgentPoolUpgradesInProgress() && (requeueAfter == 0 || agentPoolUpgradeRequeueInterval < requeueAfter) {
+		requeueAfter = agentPoolUpgradeRequeueInterval
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (amcpr *AzureManagedControlPlaneReconciler) reconcileDelete(ctx context.Context, scope *scope.ManagedControlPlaneScope) (reconcile.Result, error) {
@@ -270,7 +281,7 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileDelete(ctx context.Con
 
 	log.Info("Reconciling AzureManagedControlPlane delete")
 
-	if err := newAzureManagedControlPlaneReconciler(scope).Delete(ctx); err != nil {
+	if err := newAzureManagedControlPlaneReconciler(scope, amcpr.Recorder).Delete(ctx); err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "error deleting AzureManagedControlPlane %s/%s", scope.ControlPlane.Namespace, scope.ControlPlane.Name)
 	}
 
@@ -17,39 +17,71 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/maintenanceconfigurations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/managedclusters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/roleassignments"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/secret"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+const (
+	// kubeconfigRefreshInterval is how often the AAD tokens of the kubeconfig secret are refreshed at most, so tokens
+	// revoked early are replaced without a change to the AzureManagedControlPlane. Other credentials are only refreshed
+	// on the periodic resync of the controller.
+	kubeconfigRefreshInterval = 10 * time.Minute
+
+	// kubeconfigTokenRefreshMargin is how long before they expire the AAD tokens of the kubeconfig are refreshed.
+	kubeconfigTokenRefreshMargin = 5 * time.Minute
+
+	// minKubeconfigRefreshInterval bounds the refresh interval of AAD tokens whose lifetime is shorter than the margin.
+	minKubeconfigRefreshInterval = time.Minute
+)
+
 // azureManagedControlPlaneService contains the services required by the cluster controller.
 type azureManagedControlPlaneService struct {
-	kubeclient client.Client
-	scope      managedclusters.ManagedClusterScope
-	services   []azure.ServiceReconciler
+	kubeclient      client.Client
+	recorder        record.EventRecorder
+	scope           *scope.ManagedControlPlaneScope
+	managedClusters *managedclusters.Service
+	services        []azure.ServiceReconciler
 }
 
 // newAzureManagedControlPlaneReconciler populates all the services based on input scope.
-func newAzureManagedControlPlaneReconciler(scope *scope.ManagedControlPlaneScope) *azureManagedControlPlaneService {
+func newAzureManagedControlPlaneReconciler(scope *scope.ManagedControlPlaneScope, recorder record.EventRecorder) *azureManagedControlPlaneService {
+	managedClustersSvc := managedclusters.New(scope)
 	return &azureManagedControlPlaneService{
-		kubeclient: scope.Client,
-		scope:      scope,
+		kubeclient:      scope.Client,
+		recorder:        recorder,
+		scope:           scope,
+		managedClusters: managedClustersSvc,
 		services: []azure.ServiceReconciler{
 			groups.New(scope),
 			virtualnetworks.New(scope),
 			subnets.New(scope),
-			managedclusters.New(scope),
+			privatedns.New(scope),
+			roleassignments.New(scope),
+			managedClustersSvc,
+			maintenanceconfigurations.New(scope),
 			tags.New(scope),
 		},
 	}
@@ -60,12 +92,21 @@ func (r *azureManagedControlPlaneService) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.Reconcile")
 	defer done()
 
+	// Nothing is changed in Azure until the import of an existing managed cluster is confirmed.
+	if r.scope.ManagedClusterImportState() == azure.ManagedClusterImportPending {
+		return r.managedClusters.ReconcileImport(ctx)
+	}
+
 	for _, service := range r.services {
 		if err := service.Reconcile(ctx); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureManagedControlPlane service %s", service.Name())
 		}
 	}
 
+	if r.scope.ManagedClusterImportState() == azure.ManagedClusterImportConfirmed {
+		conditions.MarkTrue(r.scope.ControlPlane, infrav1.ManagedClusterImportedCondition)
+	}
+
 	if err := r.reconcileKubeconfig(ctx); err != nil {
 		return errors.Wrap(err, "failed to reconcile kubeconfig secret")
 	}
@@ -78,6 +119,11 @@ func (r *azureManagedControlPlaneService) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.Delete")
 	defer done()
 
+	// A managed cluster whose import was never confirmed is not owned by CAPZ, so leave it and its resources alone.
+	if r.scope.ManagedClusterImportState() == azure.ManagedClusterImportPending {
+		return nil
+	}
+
 	// Delete services in reverse order of creation.
 	for i := len(r.services) - 1; i >= 0; i-- {
 		if err := r.services[i].Delete(ctx); err != nil {
@@ -89,7 +135,7 @@ func (r *azureManagedControlPlaneService) Delete(ctx context.Context) error {
 }
 
 func (r *azureManagedControlPlaneService) reconcileKubeconfig(ctx context.Context) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.reconcileKubeconfig")
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.azureManagedControlPlaneService.reconcileKubeconfig")
 	defer done()
 
 	kubeConfigData := r.scope.GetKubeConfigData()
@@ -99,7 +145,10 @@ func (r *azureManagedControlPlaneService) reconcileKubeconfig(ctx context.Contex
 	kubeConfigSecret := r.scope.MakeEmptyKubeConfigSecret()
 
 	// Always update credentials in case of rotation
+	rotated := false
 	if _, err := controllerutil.CreateOrUpdate(ctx, r.kubeclient, &kubeConfigSecret, func() error {
+		existingData := kubeConfigSecret.Data[secret.KubeconfigDataName]
+		rotated = len(existingData) > 0 && kubeconfigRotated(existingData, kubeConfigData, r.scope.KubeconfigCredentialKind())
 		kubeConfigSecret.Data = map[string][]byte{
 			secret.KubeconfigDataName: kubeConfigData,
 		}
@@ -108,5 +157,54 @@ func (r *azureManagedControlPlaneService) reconcileKubeconfig(ctx context.Contex
 		return errors.Wrap(err, "failed to kubeconfig secret for cluster")
 	}
 
+	if rotated {
+		log.V(2).Info("kubeconfig credentials changed", "secret", kubeConfigSecret.Name)
+		r.recorder.Eventf(r.scope.ControlPlane, corev1.EventTypeNormal, "KubeconfigRotated",
+			"credentials of kubeconfig secret %s changed", kubeConfigSecret.Name)
+	}
+
 	return nil
 }
+
+// kubeconfigRotated returns whether the credentials of a kubeconfig changed. The AAD tokens of an aadToken kubeconfig
+// are refreshed by CAPZ before they expire, so new tokens alone are not a rotation.
+func kubeconfigRotated(existingData, kubeConfigData []byte, kind infrav1exp.KubeconfigCredentialKind) bool {
+	if kind == infrav1exp.KubeconfigCredentialKindAADToken {
+		return !bytes.Equal(withoutTokens(existingData), withoutTokens(kubeConfigData))
+	}
+	return !bytes.Equal(existingData, kubeConfigData)
+}
+
+// withoutTokens returns a kubeconfig without the tokens of its users, or the kubeconfig itself if it cannot be parsed.
+func withoutTokens(kubeConfigData []byte) []byte {
+	kubeConfig, err := clientcmd.Load(kubeConfigData)
+	if err != nil {
+		return kubeConfigData
+	}
+	for _, authInfo := range kubeConfig.AuthInfos {
+		authInfo.Token = ""
+	}
+	data, err := clientcmd.Write(*kubeConfig)
+	if err != nil {
+		return kubeConfigData
+	}
+	return data
+}
+
+// kubeconfigRefreshAfter returns how long to wait before the kubeconfig secret is refreshed, given when the earliest
+// AAD token of the kubeconfig expires. The zero time means the kubeconfig has no AAD tokens, which do not need to be
+// refreshed, and zero is returned.
+func kubeconfigRefreshAfter(tokenExpiry time.Time) time.Duration {
+	if tokenExpiry.IsZero() {
+		return 0
+	}
+	refreshAfter := time.Until(tokenExpiry) - kubeconfigTokenRefreshMargin
+	switch {
+	case refreshAfter > kubeconfigRefreshInterval:
+		return kubeconfigRefreshInterval
+	case refreshAfter < minKubeconfigRefreshInterval:
+		return minKubeconfigRefreshInterval
+	default:
+		return refreshAfter
+	}
+}
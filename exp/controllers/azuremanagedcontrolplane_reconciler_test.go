@@ -0,0 +1,177 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAzureManagedControlPlaneService_ReconcileKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+
+	sch := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(sch)).To(Succeed())
+	g.Expect(infrav1exp.AddToScheme(sch)).To(Succeed())
+	kubeclient := fake.NewClientBuilder().WithScheme(sch).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	mcpScope := &scope.ManagedControlPlaneScope{
+		Client: kubeclient,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		},
+		ControlPlane: &infrav1exp.AzureManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-control-plane", Namespace: "default"},
+		},
+	}
+	r := &azureManagedControlPlaneService{
+		kubeclient: kubeclient,
+		recorder:   recorder,
+		scope:      mcpScope,
+	}
+	key := client.ObjectKey{Name: secret.Name("my-cluster", secret.Kubeconfig), Namespace: "default"}
+
+	// The first kubeconfig is not a rotation.
+	mcpScope.SetKubeConfigData([]byte("credentials"))
+	g.Expect(r.reconcileKubeconfig(context.TODO())).To(Succeed())
+	kubeConfigSecret := &corev1.Secret{}
+	g.Expect(kubeclient.Get(context.TODO(), key, kubeConfigSecret)).To(Succeed())
+	g.Expect(kubeConfigSecret.Data[secret.KubeconfigDataName]).To(Equal([]byte("credentials")))
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	// Unchanged credentials are not a rotation either.
+	g.Expect(r.reconcileKubeconfig(context.TODO())).To(Succeed())
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	mcpScope.SetKubeConfigData([]byte("rotated-credentials"))
+	g.Expect(r.reconcileKubeconfig(context.TODO())).To(Succeed())
+	g.Expect(kubeclient.Get(context.TODO(), key, kubeConfigSecret)).To(Succeed())
+	g.Expect(kubeConfigSecret.Data[secret.KubeconfigDataName]).To(Equal([]byte("rotated-credentials")))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("KubeconfigRotated")))
+}
+
+func TestKubeconfigRotated(t *testing.T) {
+	kubeconfigWithToken := func(server, token string) []byte {
+		return []byte(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: ` + server + `
+  name: my-cluster
+users:
+- name: clusterUser
+  user:
+    token: ` + token + `
+`)
+	}
+
+	tests := []struct {
+		name     string
+		existing []byte
+		updated  []byte
+		kind     infrav1exp.KubeconfigCredentialKind
+		expected bool
+	}{
+		{
+			name:     "unchanged admin credentials",
+			existing: kubeconfigWithToken("https://my-cluster:443", "admin-token"),
+			updated:  kubeconfigWithToken("https://my-cluster:443", "admin-token"),
+			kind:     infrav1exp.KubeconfigCredentialKindAdmin,
+			expected: false,
+		},
+		{
+			name:     "rotated admin credentials",
+			existing: kubeconfigWithToken("https://my-cluster:443", "admin-token"),
+			updated:  kubeconfigWithToken("https://my-cluster:443", "rotated-admin-token"),
+			kind:     infrav1exp.KubeconfigCredentialKindAdmin,
+			expected: true,
+		},
+		{
+			name:     "refreshed AAD token",
+			existing: kubeconfigWithToken("https://my-cluster:443", "aad-token"),
+			updated:  kubeconfigWithToken("https://my-cluster:443", "refreshed-aad-token"),
+			kind:     infrav1exp.KubeconfigCredentialKindAADToken,
+			expected: false,
+		},
+		{
+			name:     "changed aadToken kubeconfig",
+			existing: kubeconfigWithToken("https://my-cluster:443", "aad-token"),
+			updated:  kubeconfigWithToken("https://my-new-cluster:443", "refreshed-aad-token"),
+			kind:     infrav1exp.KubeconfigCredentialKindAADToken,
+			expected: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(kubeconfigRotated(tc.existing, tc.updated, tc.kind)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestKubeconfigRefreshAfter(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenExpiry time.Time
+		expected    time.Duration
+		tolerance   time.Duration
+	}{
+		{
+			name:     "no AAD tokens",
+			expected: 0,
+		},
+		{
+			name:        "AAD token expiring after the refresh interval",
+			tokenExpiry: time.Now().Add(time.Hour),
+			expected:    kubeconfigRefreshInterval,
+		},
+		{
+			name:        "AAD token expiring before the refresh interval",
+			tokenExpiry: time.Now().Add(10 * time.Minute),
+			expected:    5 * time.Minute,
+			tolerance:   time.Second,
+		},
+		{
+			name:        "expired AAD token",
+			tokenExpiry: time.Now().Add(-time.Minute),
+			expected:    minKubeconfigRefreshInterval,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(kubeconfigRefreshAfter(tc.tokenExpiry)).To(BeNumerically("~", tc.expected, tc.tolerance))
+		})
+	}
+}
@@ -98,6 +98,7 @@ func (s *azureManagedMachinePoolService) Reconcile(ctx context.Context) error {
 	log.Info("reconciling managed machine pool")
 	agentPoolName := s.scope.Name()
 
+	s.scope.SetAgentPoolUpgradeCondition()
 	if err := s.agentPoolsSvc.Reconcile(ctx); err != nil {
 		return errors.Wrapf(err, "failed to reconcile machine pool %s", agentPoolName)
 	}
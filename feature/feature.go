@@ -34,6 +34,10 @@ const (
 	// owner: @alexeldeib
 	// alpha: v0.4
 	AKS featuregate.Feature = "AKS"
+
+	// ScheduledEvents is the feature gate for draining nodes ahead of Azure Scheduled Events.
+	// alpha: v1.5
+	ScheduledEvents featuregate.Feature = "ScheduledEvents"
 )
 
 func init() {
@@ -44,5 +48,6 @@ func init() {
 // To add a new feature, define a key for it above and add it here.
 var defaultCAPZFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	// Every feature should be initiated here:
-	AKS: {Default: false, PreRelease: featuregate.Alpha},
+	AKS:             {Default: false, PreRelease: featuregate.Alpha},
+	ScheduledEvents: {Default: false, PreRelease: featuregate.Alpha},
 }
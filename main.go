@@ -115,6 +115,7 @@ var (
 	webhookPort                        int
 	reconcileTimeout                   time.Duration
 	enableTracing                      bool
+	serialConsoleLogCapture            string
 )
 
 // InitFlags initializes all command-line flags.
@@ -241,6 +242,14 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Enable tracing to the opentelemetry-collector service in the same namespace.",
 	)
 
+	fs.StringVar(
+		&serialConsoleLogCapture,
+		"serial-console-log-capture",
+		string(controllers.SerialConsoleLogCaptureNone),
+		fmt.Sprintf("Where to capture the tail of the serial console log of a VM when bootstrapping fails. One of %s, %s or %s.",
+			controllers.SerialConsoleLogCaptureNone, controllers.SerialConsoleLogCaptureEvent, controllers.SerialConsoleLogCaptureConfigMap),
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
 
@@ -255,6 +264,13 @@ func main() {
 		setupLog.Info("Watching cluster-api objects only in namespace for reconciliation", "namespace", watchNamespace)
 	}
 
+	switch controllers.SerialConsoleLogCapture(serialConsoleLogCapture) {
+	case controllers.SerialConsoleLogCaptureNone, controllers.SerialConsoleLogCaptureEvent, controllers.SerialConsoleLogCaptureConfigMap:
+	default:
+		setupLog.Error(fmt.Errorf("invalid value %q", serialConsoleLogCapture), "invalid serial-console-log-capture flag")
+		os.Exit(1)
+	}
+
 	if profilerAddress != "" {
 		setupLog.Info("Profiler listening for requests", "profiler-address", profilerAddress)
 		go func() {
@@ -338,6 +354,7 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 		mgr.GetEventRecorderFor("azuremachine-reconciler"),
 		reconcileTimeout,
 		watchFilterValue,
+		controllers.SerialConsoleLogCapture(serialConsoleLogCapture),
 	).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}, Cache: machineCache}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AzureMachine")
 		os.Exit(1)
@@ -367,6 +384,16 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 		os.Exit(1)
 	}
 
+	if err := (&controllers.AzureMachineTemplateReconciler{
+		Client:           mgr.GetClient(),
+		Recorder:         mgr.GetEventRecorderFor("azuremachinetemplate-reconciler"),
+		ReconcileTimeout: reconcileTimeout,
+		WatchFilterValue: watchFilterValue,
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: azureMachineConcurrency}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AzureMachineTemplate")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.AzureJSONMachineReconciler{
 		Client:           mgr.GetClient(),
 		Recorder:         mgr.GetEventRecorderFor("azurejsonmachine-reconciler"),
@@ -415,6 +442,7 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 			mgr.GetEventRecorderFor("azuremachinepoolmachine-reconciler"),
 			reconcileTimeout,
 			watchFilterValue,
+			controllers.SerialConsoleLogCapture(serialConsoleLogCapture),
 		).SetupWithManager(ctx, mgr, controllers.Options{Options: controller.Options{MaxConcurrentReconciles: azureMachinePoolMachineConcurrency}, Cache: mpmCache}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AzureMachinePoolMachine")
 			os.Exit(1)
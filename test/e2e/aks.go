@@ -258,6 +258,8 @@ func GetWorkingAKSKubernetesVersion(ctx context.Context, subscriptionID, locatio
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create an Authorizer")
 	}
+	// The available Kubernetes versions are listed with the container service orchestrators API, which is not part of
+	// the AKS API version used for managed clusters and agent pools.
 	containerServiceClient := containerservice.NewContainerServicesClient(subscriptionID)
 	containerServiceClient.Authorizer = authorizer
 	result, err := containerServiceClient.ListOrchestrators(ctx, location, ManagedClustersResourceType)